@@ -12,6 +12,9 @@ import (
 	"slices"
 	"strings"
 	"syscall"
+	"time"
+	// time zones of profiles are validated against embedded database, so server does not depend on one of host
+	_ "time/tzdata"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-playground/validator/v10"
@@ -22,19 +25,39 @@ import (
 
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/config"
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler"
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/pkg/fixtures"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/pkg/blob"
 	"github.com/ew0s/ewos-to-go-hw/chat-server/pkg/router"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/pkg/ws"
 
 	middlewares "github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/middleware"
 
+	adminhandler "github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/admin"
+	attachmenthandler "github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/attachment"
 	authhandler "github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/auth"
+	channelhandler "github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/channel"
+	conversationhandler "github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/conversation"
 	privatemessagehandler "github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/message/private"
 	publicmessagehandler "github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/message/public"
+	realtimehandler "github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/realtime"
+	searchhandler "github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/search"
+	sessionhandler "github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/session"
 	userhandler "github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/user"
 
+	attachmentservice "github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/attachment"
 	authservice "github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/auth"
+	blockservice "github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/block"
+	channelservice "github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/channel"
+	conversationservice "github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/conversation"
+	mentionservice "github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/mention"
 	privatemessageservice "github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message/private"
 	publicmessageservice "github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message/public"
+	presenceservice "github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/presence"
+	profileservice "github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/profile"
+	searchservice "github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/search"
+	sessionservice "github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/session"
+	typingservice "github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/typing"
 	userservice "github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/user"
 
 	inmemoryrepository "github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository/in-memory"
@@ -61,10 +84,19 @@ import (
 
 const ( // todo: config file
 	dbSavePath = "chat-server/internal/db/db_state.json"
+	dbWALPath  = "chat-server/internal/db/db_state.wal"
 	configPath = "chat-server/config"
 
 	port         = 5000
 	loadFixtures = true
+
+	defaultAccessTTL  = 15 * time.Minute
+	defaultRefreshTTL = 30 * 24 * time.Hour
+
+	defaultIdleTimeout = 5 * time.Minute
+
+	defaultFsyncInterval    = time.Second
+	defaultSnapshotInterval = 5 * time.Minute
 )
 
 type UserRepo interface {
@@ -76,18 +108,135 @@ type UserRepo interface {
 	DeleteUser(ctx context.Context, id int) (*entity.User, error)
 	UpdateUser(ctx context.Context, id int, updateModel entity.User) (*entity.User, error)
 	CheckUniqueConstraints(ctx context.Context, email, username string) error
+	UpdateUserRole(ctx context.Context, id int, role entity.Role) (*entity.User, error)
+	CountUsersWithRole(ctx context.Context, role entity.Role) (int, error)
+	LockUsersWithRole(ctx context.Context, role entity.Role) (int, error)
+	UpdateLastSeen(ctx context.Context, username string, lastSeenAt time.Time) error
 }
 
 type PublicMessageRepo interface {
 	AddPublicMessage(ctx context.Context, msg entity.PublicMessage) (*entity.PublicMessage, error)
 	GetAllPublicMessages(ctx context.Context, offset, limit int) []*entity.PublicMessage
+	GetChannelMessages(ctx context.Context, channelID, offset, limit int) []*entity.PublicMessage
+	GetChannelMessagesExcludingAuthors(ctx context.Context, channelID int, authors []string, offset, limit int) []*entity.PublicMessage
 	GetPublicMessage(ctx context.Context, id int) (*entity.PublicMessage, error)
+	GetPublicMessageReplies(ctx context.Context, parentID, offset, limit int) []*entity.PublicMessage
+	CountPublicMessageReplies(ctx context.Context, parentIDs []int) (map[int]int, error)
+	UpdatePublicMessage(ctx context.Context, id int, updated entity.PublicMessage) (*entity.PublicMessage, error)
+	GetPublicMessageRevisions(ctx context.Context, messageID int) ([]*entity.MessageRevision, error)
+	DeletePublicMessage(ctx context.Context, id int) (*entity.PublicMessage, error)
+	PurgePublicMessage(ctx context.Context, id int) error
+	SearchPublicMessages(ctx context.Context, query entity.MessageSearchQuery, channelIDs []int, limit int) ([]*entity.SearchHit, error)
 }
 
 type PrivateMessageRepo interface {
 	AddPrivateMessage(ctx context.Context, msg entity.PrivateMessage) (*entity.PrivateMessage, error)
 	GetAllPrivateMessages(ctx context.Context, offset, limit int) []*entity.PrivateMessage
 	GetPrivateMessage(ctx context.Context, id int) (*entity.PrivateMessage, error)
+	GetPrivateMessageReplies(ctx context.Context, parentID, offset, limit int) []*entity.PrivateMessage
+	CountPrivateMessageReplies(ctx context.Context, parentIDs []int) (map[int]int, error)
+	MarkPrivateMessagesRead(ctx context.Context, username, counterpart string, upToID int) (*entity.ReadMarker, error)
+	CountUnreadPrivateMessages(ctx context.Context, username string) (map[string]int, error)
+	UpdatePrivateMessage(ctx context.Context, id int, updated entity.PrivateMessage) (*entity.PrivateMessage, error)
+	GetPrivateMessageRevisions(ctx context.Context, messageID int) ([]*entity.MessageRevision, error)
+	DeletePrivateMessage(ctx context.Context, id int) (*entity.PrivateMessage, error)
+	PurgePrivateMessage(ctx context.Context, id int) error
+	SearchPrivateMessages(ctx context.Context, username string, query entity.MessageSearchQuery, limit int) ([]*entity.SearchHit, error)
+}
+
+type ChannelRepo interface {
+	AddChannel(ctx context.Context, channel entity.Channel) (*entity.Channel, error)
+	GetChannel(ctx context.Context, id int) (*entity.Channel, error)
+	GetAllChannels(ctx context.Context, offset, limit int) []*entity.Channel
+	AddChannelMember(ctx context.Context, channelID int, username string) error
+	RemoveChannelMember(ctx context.Context, channelID int, username string) error
+	GetChannelMembers(ctx context.Context, channelID int) ([]*entity.ChannelMember, error)
+	IsChannelMember(ctx context.Context, channelID int, username string) (bool, error)
+	GetMemberChannelIDs(ctx context.Context, username string) ([]int, error)
+}
+
+type ConversationRepo interface {
+	AddConversation(ctx context.Context, conversation entity.Conversation) (*entity.Conversation, error)
+	GetConversation(ctx context.Context, id int) (*entity.Conversation, error)
+	GetUserConversations(ctx context.Context, username string) ([]*entity.Conversation, error)
+	AddConversationParticipant(ctx context.Context, conversationID int, username string) error
+	RemoveConversationParticipant(ctx context.Context, conversationID int, username string) error
+	GetConversationParticipants(ctx context.Context, conversationID int) ([]*entity.ConversationParticipant, error)
+	IsConversationParticipant(ctx context.Context, conversationID int, username string) (bool, error)
+	AddConversationMessage(ctx context.Context, msg entity.ConversationMessage) (*entity.ConversationMessage, error)
+	GetConversationMessages(ctx context.Context, conversationID, offset, limit int) []*entity.ConversationMessage
+	GetLastConversationMessage(ctx context.Context, conversationID int) (*entity.ConversationMessage, error)
+}
+
+type ReactionRepo interface {
+	AddReaction(ctx context.Context, kind entity.MessageKind, reaction entity.Reaction) (*entity.Reaction, error)
+	RemoveReaction(ctx context.Context, kind entity.MessageKind, messageID int, username, emoji string) error
+	GetReactions(ctx context.Context, kind entity.MessageKind, messageIDs []int) ([]*entity.Reaction, error)
+}
+
+type AttachmentRepo interface {
+	AddAttachment(ctx context.Context, attachment entity.Attachment) (*entity.Attachment, error)
+	GetAttachment(ctx context.Context, id int) (*entity.Attachment, error)
+	GetAttachments(ctx context.Context, ids []int) ([]*entity.Attachment, error)
+	LinkAttachments(ctx context.Context, kind entity.MessageKind, messageID int, ids []int) error
+	GetMessageAttachments(ctx context.Context, kind entity.MessageKind, messageIDs []int) ([]*entity.Attachment, error)
+	DeleteMessageAttachments(ctx context.Context, kind entity.MessageKind, messageID int) ([]*entity.Attachment, error)
+}
+
+type ProfileRepo interface {
+	GetProfile(ctx context.Context, userID int) (*entity.Profile, error)
+	GetProfilesByUsernames(ctx context.Context, usernames []string) ([]*entity.Profile, error)
+	SaveProfile(ctx context.Context, profile entity.Profile) (*entity.Profile, error)
+}
+
+type BlockRepo interface {
+	AddBlock(ctx context.Context, block entity.Block) (*entity.Block, error)
+	RemoveBlock(ctx context.Context, username, blockedUsername string) error
+	GetBlocks(ctx context.Context, username string) ([]*entity.Block, error)
+	IsBlocked(ctx context.Context, username, blockedUsername string) (bool, error)
+}
+
+type MentionRepo interface {
+	AddMentions(ctx context.Context, mentions []entity.Mention) ([]*entity.Mention, error)
+	GetMessageMentions(ctx context.Context, kind entity.MessageKind, messageIDs []int) ([]*entity.Mention, error)
+	GetUserMentions(ctx context.Context, username string, unreadOnly bool, offset, limit int) ([]*entity.Mention, error)
+	MarkMentionsRead(ctx context.Context, username string, upToID int, readAt time.Time) (int, error)
+}
+
+type RefreshTokenRepo interface {
+	AddRefreshToken(ctx context.Context, token entity.RefreshToken) (*entity.RefreshToken, error)
+	GetRefreshToken(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+	UseRefreshToken(ctx context.Context, tokenHash string, usedAt time.Time) error
+	RevokeTokenFamily(ctx context.Context, familyID string, revokedAt time.Time) error
+}
+
+type SessionRepo interface {
+	AddSession(ctx context.Context, session entity.Session) (*entity.Session, error)
+	GetSession(ctx context.Context, id string) (*entity.Session, error)
+	GetUserSessions(ctx context.Context, userID int) ([]*entity.Session, error)
+	TouchSession(ctx context.Context, id string, lastSeenAt time.Time) error
+	ExtendSession(ctx context.Context, id string, expiresAt, lastSeenAt time.Time) error
+	RevokeSession(ctx context.Context, id string, revokedAt time.Time) error
+}
+
+type repositories struct {
+	User           UserRepo
+	RefreshToken   RefreshTokenRepo
+	Session        SessionRepo
+	PublicMessage  PublicMessageRepo
+	PrivateMessage PrivateMessageRepo
+	Reaction       ReactionRepo
+	Attachment     AttachmentRepo
+	Channel        ChannelRepo
+	Conversation   ConversationRepo
+	Profile        ProfileRepo
+	Block          BlockRepo
+	Mention        MentionRepo
+
+	UnitOfWork userservice.UnitOfWork
+
+	// UsernameRenamers keep denormalized usernames up to date, postgres does it itself with `on update cascade`
+	UsernameRenamers []userservice.UsernameRenamer
 }
 
 type Hasher struct {
@@ -101,43 +250,69 @@ func (h *Hasher) CompareHashAndPassword(hashedPassword []byte, password []byte)
 	return bcrypt.CompareHashAndPassword(hashedPassword, password)
 }
 
-func initDB(ctx context.Context) (*inmemory.InMemDB, <-chan any) {
-	var inMemDB *inmemory.InMemDB
-
-	var savedChan <-chan any
-
-	dbStateRestored := true
-
-	jsonDb, err := os.ReadFile(dbSavePath)
+// initDB restores in-memory db from saved state and write-ahead log. Db is not started if saved state
+// can not be restored, as it would be overwritten by fresh state otherwise.
+func initDB(ctx context.Context, conf config.InMemoryDB, logger *logrus.Logger) (*inmemory.InMemDB, <-chan any) {
+	inMemDB, savedChan, err := inmemory.OpenInMemDB(ctx, inmemory.PersistenceOptions{
+		SnapshotPath:     conf.StatePath,
+		WALPath:          conf.WALPath,
+		Fsync:            inmemory.FsyncPolicy(conf.Fsync),
+		FsyncInterval:    conf.FsyncInterval,
+		SnapshotInterval: conf.SnapshotInterval,
+	})
 	if err != nil {
-		dbStateRestored = false
-	} else {
-		inMemDB, savedChan, err = inmemory.NewInMemDBFromJSON(ctx, string(jsonDb), dbSavePath)
-		if err != nil {
-			dbStateRestored = false
-		}
-	}
-
-	if !dbStateRestored {
-		inMemDB, savedChan = inmemory.NewInMemDB(ctx, dbSavePath)
+		logger.Fatalf("cannot restore in-memory db state from %s and %s: %v", conf.StatePath, conf.WALPath, err)
 	}
 
 	return inMemDB, savedChan
 }
 
-func initInMemRepos(ctx context.Context, conf *config.Config, savedChan *<-chan any) (*inmemoryrepository.UserRepo, *inmemoryrepository.PublicMessageRepo, *inmemoryrepository.PrivateMessageRepo) {
-	db, ch := initDB(ctx)
+func initInMemRepos(ctx context.Context, conf *config.Config, logger *logrus.Logger, savedChan *<-chan any) *repositories {
+	db, ch := initDB(ctx, conf.InMemoryDB, logger)
 
-	savedChan = &ch
+	*savedChan = ch
 
 	if conf.InMemoryDB.LoadFixtures {
 		fixtures.LoadFixtures(db)
 	}
 
-	return inmemoryrepository.NewUserRepo(db), inmemoryrepository.NewPublicMessageRepo(db), inmemoryrepository.NewPrivateMessageRepo(db)
+	publicMessageRepo := inmemoryrepository.NewPublicMessageRepo(db)
+	privateMessageRepo := inmemoryrepository.NewPrivateMessageRepo(db)
+	reactionRepo := inmemoryrepository.NewReactionRepo(db)
+	attachmentRepo := inmemoryrepository.NewAttachmentRepo(db)
+	channelRepo := inmemoryrepository.NewChannelRepo(db)
+	conversationRepo := inmemoryrepository.NewConversationRepo(db)
+	blockRepo := inmemoryrepository.NewBlockRepo(db)
+	mentionRepo := inmemoryrepository.NewMentionRepo(db)
+
+	return &repositories{
+		User:           inmemoryrepository.NewUserRepo(db),
+		RefreshToken:   inmemoryrepository.NewRefreshTokenRepo(db),
+		Session:        inmemoryrepository.NewSessionRepo(db),
+		PublicMessage:  publicMessageRepo,
+		PrivateMessage: privateMessageRepo,
+		Reaction:       reactionRepo,
+		Attachment:     attachmentRepo,
+		Channel:        channelRepo,
+		Conversation:   conversationRepo,
+		Profile:        inmemoryrepository.NewProfileRepo(db),
+		Block:          blockRepo,
+		Mention:        mentionRepo,
+		UnitOfWork:     inmemoryrepository.NewUnitOfWork(db),
+		UsernameRenamers: []userservice.UsernameRenamer{
+			publicMessageRepo,
+			privateMessageRepo,
+			reactionRepo,
+			attachmentRepo,
+			channelRepo,
+			conversationRepo,
+			blockRepo,
+			mentionRepo,
+		},
+	}
 }
 
-func initPostgresRepos(conf *config.Config, logger *logrus.Logger) (*postgresrepo.UserRepo, *postgresrepo.PublicMessageRepo, *postgresrepo.PrivateMessageRepo) {
+func initPostgresRepos(conf *config.Config, logger *logrus.Logger) *repositories {
 	connStr := conf.Postgres.ConnectionURL()
 
 	conn, err := sql.Open("pgx", connStr)
@@ -147,7 +322,21 @@ func initPostgresRepos(conf *config.Config, logger *logrus.Logger) (*postgresrep
 
 	db := sqlx.NewDb(conn, "postgres")
 
-	return postgresrepo.NewUserRepo(db), postgresrepo.NewPublicMessageRepo(db), postgresrepo.NewPrivateMessageRepo(db)
+	return &repositories{
+		User:           postgresrepo.NewUserRepo(db),
+		RefreshToken:   postgresrepo.NewRefreshTokenRepo(db),
+		Session:        postgresrepo.NewSessionRepo(db),
+		PublicMessage:  postgresrepo.NewPublicMessageRepo(db),
+		PrivateMessage: postgresrepo.NewPrivateMessageRepo(db),
+		Reaction:       postgresrepo.NewReactionRepo(db),
+		Attachment:     postgresrepo.NewAttachmentRepo(db),
+		Channel:        postgresrepo.NewChannelRepo(db),
+		Conversation:   postgresrepo.NewConversationRepo(db),
+		Profile:        postgresrepo.NewProfileRepo(db),
+		Block:          postgresrepo.NewBlockRepo(db),
+		Mention:        postgresrepo.NewMentionRepo(db),
+		UnitOfWork:     postgresrepo.NewUnitOfWork(db),
+	}
 }
 
 func initConfig() (*config.Config, error) { // todo: to internals utils?
@@ -180,23 +369,74 @@ func initConfig() (*config.Config, error) { // todo: to internals utils?
 		return nil, errors.New("CHAT_JWT_SECRET env variable not set")
 	}
 
+	if conf.Jwt.AccessTTL <= 0 {
+		conf.Jwt.AccessTTL = defaultAccessTTL
+	}
+
+	if conf.Jwt.RefreshTTL <= 0 {
+		conf.Jwt.RefreshTTL = defaultRefreshTTL
+	}
+
+	if conf.Presence.IdleTimeout <= 0 {
+		conf.Presence.IdleTimeout = defaultIdleTimeout
+	}
+
+	if conf.InMemoryDB.StatePath == "" {
+		conf.InMemoryDB.StatePath = dbSavePath
+	}
+
+	if conf.InMemoryDB.WALPath == "" {
+		conf.InMemoryDB.WALPath = dbWALPath
+	}
+
+	if conf.InMemoryDB.Fsync == "" {
+		conf.InMemoryDB.Fsync = string(inmemory.FsyncInterval)
+	}
+
+	if conf.InMemoryDB.FsyncInterval <= 0 {
+		conf.InMemoryDB.FsyncInterval = defaultFsyncInterval
+	}
+
+	if conf.InMemoryDB.SnapshotInterval <= 0 {
+		conf.InMemoryDB.SnapshotInterval = defaultSnapshotInterval
+	}
+
+	if !inmemory.FsyncPolicy(conf.InMemoryDB.Fsync).Valid() {
+		return nil, errors.New("invalid inmem.fsync provided")
+	}
+
 	if !slices.Contains([]string{"jwt", "basic"}, strings.ToLower(conf.Auth)) {
 		return nil, errors.New("invalid server.auth provided")
 	}
 
+	if conf.Attachments.Dir == "" || conf.Attachments.MaxSize <= 0 {
+		return nil, errors.New("invalid attachments config provided")
+	}
+
+	if conf.Avatars.Dir == "" || conf.Avatars.MaxSize <= 0 {
+		return nil, errors.New("invalid avatars config provided")
+	}
+
 	return &conf, nil
 }
 
-func initAuthMiddleware(typ string, secret string, authService authhandler.AuthService, logger *logrus.Logger, valid *validator.Validate) middlewares.Handler {
+func initAuthMiddleware(typ string, secret string, authService authhandler.AuthService, sessionService *sessionservice.Service, logger *logrus.Logger, valid *validator.Validate) middlewares.Handler {
 	switch typ {
 	case "jwt":
-		return middlewares.JWTAuthMiddleware(secret, logger)
+		return middlewares.JWTAuthMiddleware(secret, sessionService, logger)
 
 	case "basic":
 		return middlewares.BasicAuthMiddleware(authService, logger, valid)
 
 	default: // jwt auth by default
-		return middlewares.JWTAuthMiddleware(secret, logger)
+		return middlewares.JWTAuthMiddleware(secret, sessionService, logger)
+	}
+}
+
+// withPresence records activity of every request that passed authentication.
+func withPresence(auth middlewares.Handler, presence middlewares.Handler) middlewares.Handler {
+	return func(next http.Handler) http.Handler {
+		return auth(presence(next))
 	}
 }
 
@@ -211,42 +451,95 @@ func main() {
 
 	logger.Infof("CONFIG: %+v", conf)
 
-	var (
-		userRepo           UserRepo
-		publicMessageRepo  PublicMessageRepo
-		privateMessageRepo PrivateMessageRepo
-	)
+	var repos *repositories
 
 	var savedChan <-chan any
 
 	switch conf.DB {
 	case "postgres":
-		userRepo, publicMessageRepo, privateMessageRepo = initPostgresRepos(conf, logger)
+		repos = initPostgresRepos(conf, logger)
 
 	case "inmem":
-		userRepo, publicMessageRepo, privateMessageRepo = initInMemRepos(ctx, conf, &savedChan)
+		repos = initInMemRepos(ctx, conf, logger, &savedChan)
 
 	default:
-		userRepo, publicMessageRepo, privateMessageRepo = initPostgresRepos(conf, logger)
+		repos = initPostgresRepos(conf, logger)
 	}
 
 	hasher := &Hasher{}
 
-	userService := userservice.New(userRepo, hasher)
-	publicMessageService := publicmessageservice.New(publicMessageRepo, userRepo)
-	privateMessageService := privatemessageservice.New(privateMessageRepo, userRepo)
-	authService := authservice.New(userRepo, hasher)
+	hub := ws.NewHub()
+	notifier := realtimehandler.NewNotifier(hub, logger)
+
+	attachmentStorage, err := blob.NewLocalStorage(conf.Attachments.Dir)
+	if err != nil {
+		logger.Fatalf("init attachment storage error: %v", err)
+	}
+
+	avatarStorage, err := blob.NewLocalStorage(conf.Avatars.Dir)
+	if err != nil {
+		logger.Fatalf("init avatar storage error: %v", err)
+	}
+
+	userService := userservice.New(repos.User, repos.Session, repos.RefreshToken, hasher, repos.UnitOfWork, notifier,
+		repos.UsernameRenamers...)
+	publicMessageService := publicmessageservice.New(repos.PublicMessage, repos.Reaction, repos.Attachment, repos.Profile, repos.Block,
+		repos.Mention, repos.User, repos.Channel, repos.UnitOfWork, notifier)
+	privateMessageService := privatemessageservice.New(repos.PrivateMessage, repos.Reaction, repos.Attachment, repos.Block, repos.Mention,
+		repos.User, repos.UnitOfWork, notifier)
+	channelService := channelservice.New(repos.Channel, repos.PublicMessage, repos.Reaction, repos.Attachment, repos.Profile,
+		repos.Mention, repos.Block, repos.User, repos.UnitOfWork, notifier)
+	conversationService := conversationservice.New(repos.Conversation, repos.PrivateMessage, repos.User, notifier)
+	searchService := searchservice.New(repos.PublicMessage, repos.PrivateMessage, repos.Channel)
+	attachmentService := attachmentservice.New(repos.Attachment, repos.PublicMessage, repos.PrivateMessage, repos.Channel,
+		attachmentStorage, attachmentservice.Limits{
+			MaxSize:      conf.Attachments.MaxSize,
+			AllowedTypes: conf.Attachments.AllowedTypes,
+		})
+	profileService := profileservice.New(repos.Profile, repos.User, avatarStorage, profileservice.AvatarLimits{
+		MaxSize:      conf.Avatars.MaxSize,
+		AllowedTypes: conf.Avatars.AllowedTypes,
+	})
+	authService := authservice.New(repos.User, repos.RefreshToken, repos.Session, hasher, notifier, conf.Jwt.RefreshTTL)
+	sessionService := sessionservice.New(repos.Session, repos.RefreshToken, notifier)
+	presenceService := presenceservice.New(repos.User, hub, conf.Presence.IdleTimeout)
+	blockService := blockservice.New(repos.Block, repos.User)
+	mentionService := mentionservice.New(repos.Mention, repos.PublicMessage, repos.PrivateMessage)
+	typingService := typingservice.New(repos.Channel, repos.Conversation, repos.User, repos.Block, notifier)
+
+	go typingService.PruneLimits(ctx)
+
+	missingAdmins, err := userService.BootstrapAdmins(ctx, conf.Admin.Usernames)
+	if err != nil {
+		logger.Fatalf("bootstrap admins error: %v", err)
+	}
+
+	if len(missingAdmins) > 0 {
+		logger.Warnf("admins %v are not registered, restart server after their registration to grant admin role", missingAdmins)
+	}
 
 	valid := validator.New(validator.WithRequiredStructEnabled())
 
-	authMiddleware := initAuthMiddleware(conf.Server.Auth, conf.Jwt.Secret, authService, logger, valid)
+	authMiddleware := withPresence(
+		initAuthMiddleware(conf.Server.Auth, conf.Jwt.Secret, authService, sessionService, logger, valid),
+		middlewares.PresenceMiddleware(presenceService, logger),
+	)
 	loggingMiddleware := middlewares.LoggingMiddleware(logger, logrus.InfoLevel)
 	recoveryMiddleware := middlewares.RecoveryMiddleware()
 
 	authHandler := authhandler.New(userService, authService, conf.Jwt, logger, valid)
-	userHandler := userhandler.New(userService, privateMessageService, logger, valid, authMiddleware)
+	userHandler := userhandler.New(userService, privateMessageService, profileService, presenceService, blockService,
+		mentionService, conf.Avatars, logger, valid, authMiddleware)
 	publicMessageHandler := publicmessagehandler.New(publicMessageService, userService, logger, valid, authMiddleware)
 	privateMessageHandler := privatemessagehandler.New(privateMessageService, userService, logger, valid, authMiddleware)
+	channelHandler := channelhandler.New(channelService, logger, valid, authMiddleware)
+	conversationHandler := conversationhandler.New(conversationService, logger, valid, authMiddleware)
+	searchHandler := searchhandler.New(searchService, logger, valid, authMiddleware)
+	sessionHandler := sessionhandler.New(sessionService, logger, valid, authMiddleware)
+	attachmentHandler := attachmenthandler.New(attachmentService, conf.Attachments, logger, valid, authMiddleware)
+	adminHandler := adminhandler.New(publicMessageService, privateMessageService, attachmentService, userService, logger,
+		valid, authMiddleware)
+	realtimeHandler := realtimehandler.New(hub, publicMessageService, privateMessageService, typingService, presenceService, logger, valid, authMiddleware)
 
 	routers := make(map[string]chi.Router)
 
@@ -254,13 +547,20 @@ func main() {
 	routers["/users"] = userHandler.Routes()
 	routers["/messages/public"] = publicMessageHandler.Routes()
 	routers["/messages/private"] = privateMessageHandler.Routes()
+	routers["/channels"] = channelHandler.Routes()
+	routers["/conversations"] = conversationHandler.Routes()
+	routers["/search"] = searchHandler.Routes()
+	routers["/sessions"] = sessionHandler.Routes()
+	routers["/attachments"] = attachmentHandler.Routes()
+	routers["/admin"] = adminHandler.Routes()
+	routers["/ws"] = realtimeHandler.Routes()
 
 	middlewars := []router.Middleware{
 		recoveryMiddleware,
 		loggingMiddleware,
 	}
 
-	r := router.MakeRoutes("/chat/api/v1", routers, middlewars)
+	r := router.MakeRoutes(handler.APIPrefix, routers, middlewars)
 
 	server := http.Server{
 		Addr:    fmt.Sprintf(":%v", port),
@@ -287,7 +587,11 @@ func main() {
 	signal.Ignore(syscall.SIGHUP, syscall.SIGPIPE)
 	signal.Notify(interrupt, syscall.SIGINT)
 
+	shutdownDone := make(chan struct{})
+
 	go func() {
+		defer close(shutdownDone)
+
 		<-interrupt
 
 		logger.Info("interrupt signal caught")
@@ -300,6 +604,12 @@ func main() {
 		cancel()
 	}()
 
+	<-shutdownDone
+
 	// wait for inmem db being saved
-	<-savedChan
+	if conf.DB == "inmem" {
+		if res := <-savedChan; res != "ok" {
+			logger.Errorf("in-memory db state was not saved: %v", res)
+		}
+	}
 }
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE public_message_revision
+(
+    id         bigserial primary key                                        not null,
+    message_id bigint references public_message (id) on delete cascade      not null,
+    content    text                                                         not null,
+    edited_at  timestamp                                                    not null
+);
+
+CREATE TABLE private_message_revision
+(
+    id         bigserial primary key                                        not null,
+    message_id bigint references private_message (id) on delete cascade     not null,
+    content    text                                                         not null,
+    edited_at  timestamp                                                    not null
+);
+
+CREATE INDEX public_message_revision_message_id_idx ON public_message_revision (message_id);
+CREATE INDEX private_message_revision_message_id_idx ON private_message_revision (message_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE private_message_revision;
+DROP TABLE public_message_revision;
+-- +goose StatementEnd
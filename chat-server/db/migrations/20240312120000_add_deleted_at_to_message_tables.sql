@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE public_message
+    ADD COLUMN deleted_at timestamp null;
+
+ALTER TABLE private_message
+    ADD COLUMN deleted_at timestamp null;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE private_message
+    DROP COLUMN deleted_at;
+
+ALTER TABLE public_message
+    DROP COLUMN deleted_at;
+-- +goose StatementEnd
@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE channel
+(
+    id          bigserial primary key                                                          not null,
+    name        varchar(64) unique                                                             not null,
+    description text                                                                           not null default '',
+    created_by  varchar(128) references users (username) on update cascade on delete set null null,
+    created_at  timestamp                                                                      not null
+);
+
+CREATE TABLE channel_member
+(
+    channel_id bigint references channel (id) on delete cascade                                not null,
+    username   varchar(128) references users (username) on update cascade on delete cascade not null,
+    joined_at  timestamp                                                                       not null,
+    primary key (channel_id, username)
+);
+
+CREATE INDEX channel_member_username_idx ON channel_member (username);
+
+-- former global public chat becomes default general channel
+INSERT INTO channel (id, name, description, created_at)
+VALUES (1, 'general', 'default channel for everyone', now());
+
+SELECT setval(pg_get_serial_sequence('channel', 'id'), (SELECT max(id) FROM channel));
+
+ALTER TABLE public_message
+    ADD COLUMN channel_id bigint not null default 1 references channel (id) on delete cascade;
+
+CREATE INDEX public_message_channel_id_sent_at_idx ON public_message (channel_id, sent_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX public_message_channel_id_sent_at_idx;
+
+ALTER TABLE public_message
+    DROP COLUMN channel_id;
+
+DROP TABLE channel_member;
+
+DROP TABLE channel;
+-- +goose StatementEnd
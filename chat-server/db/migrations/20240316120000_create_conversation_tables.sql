@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE conversation
+(
+    id         bigserial primary key                                                          not null,
+    title      varchar(128)                                                                   not null default '',
+    created_by varchar(128) references users (username) on update cascade on delete set null null,
+    created_at timestamp                                                                      not null
+);
+
+CREATE TABLE conversation_participant
+(
+    conversation_id bigint references conversation (id) on delete cascade                        not null,
+    username        varchar(128) references users (username) on update cascade on delete cascade not null,
+    joined_at       timestamp                                                                    not null,
+    primary key (conversation_id, username)
+);
+
+CREATE INDEX conversation_participant_username_idx ON conversation_participant (username);
+
+CREATE TABLE conversation_message
+(
+    id              bigserial primary key                                      not null,
+    conversation_id bigint references conversation (id) on delete cascade      not null,
+    from_username   varchar(128) references users (username) on update cascade not null,
+    content         text                                                       not null,
+    sent_at         timestamp                                                  not null,
+    edited_at       timestamp                                                  not null
+);
+
+CREATE INDEX conversation_message_conversation_id_sent_at_idx ON conversation_message (conversation_id, sent_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE conversation_message;
+DROP TABLE conversation_participant;
+DROP TABLE conversation;
+-- +goose StatementEnd
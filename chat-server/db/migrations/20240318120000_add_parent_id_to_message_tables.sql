@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE public_message
+    ADD COLUMN parent_id bigint null references public_message (id) on delete cascade;
+
+ALTER TABLE private_message
+    ADD COLUMN parent_id bigint null references private_message (id) on delete cascade;
+
+CREATE INDEX public_message_parent_id_idx ON public_message (parent_id) WHERE parent_id IS NOT NULL;
+CREATE INDEX private_message_parent_id_idx ON private_message (parent_id) WHERE parent_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX private_message_parent_id_idx;
+DROP INDEX public_message_parent_id_idx;
+
+ALTER TABLE private_message
+    DROP COLUMN parent_id;
+
+ALTER TABLE public_message
+    DROP COLUMN parent_id;
+-- +goose StatementEnd
@@ -0,0 +1,28 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE public_message_reaction
+(
+    id         bigserial primary key                                                          not null,
+    message_id bigint references public_message (id) on delete cascade                        not null,
+    username   varchar(128) references users (username) on update cascade on delete cascade not null,
+    emoji      varchar(32)                                                                    not null,
+    created_at timestamp                                                                      not null,
+    unique (message_id, username, emoji)
+);
+
+CREATE TABLE private_message_reaction
+(
+    id         bigserial primary key                                                          not null,
+    message_id bigint references private_message (id) on delete cascade                       not null,
+    username   varchar(128) references users (username) on update cascade on delete cascade not null,
+    emoji      varchar(32)                                                                    not null,
+    created_at timestamp                                                                      not null,
+    unique (message_id, username, emoji)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE private_message_reaction;
+DROP TABLE public_message_reaction;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE private_message_read_marker
+(
+    username     varchar(128) references users (username) on update cascade on delete cascade not null,
+    counterpart  varchar(128) references users (username) on update cascade on delete cascade not null,
+    last_read_id bigint                                                                        not null,
+    read_at      timestamp                                                                     not null,
+    primary key (username, counterpart)
+);
+
+ALTER TABLE private_message
+    ADD COLUMN seen_at timestamp null;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE private_message
+    DROP COLUMN seen_at;
+
+DROP TABLE private_message_read_marker;
+-- +goose StatementEnd
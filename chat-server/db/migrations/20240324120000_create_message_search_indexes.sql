@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- 'simple' configuration does not stem words, so search finds the same messages as in-memory index does
+CREATE INDEX public_message_content_tsv_idx ON public_message USING gin (to_tsvector('simple', content));
+CREATE INDEX private_message_content_tsv_idx ON private_message USING gin (to_tsvector('simple', content));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX private_message_content_tsv_idx;
+DROP INDEX public_message_content_tsv_idx;
+-- +goose StatementEnd
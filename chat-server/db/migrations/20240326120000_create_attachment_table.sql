@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE attachment
+(
+    id           bigserial primary key                                                          not null,
+    uploaded_by  varchar(128) references users (username) on update cascade on delete cascade not null,
+    message_kind varchar(16)                                                                    null,
+    message_id   bigint                                                                         null,
+    file_name    varchar(255)                                                                   not null,
+    content_type varchar(128)                                                                   not null,
+    size         bigint                                                                         not null,
+    storage_key  varchar(64) unique                                                             not null,
+    created_at   timestamp                                                                      not null,
+    check ((message_kind is null) = (message_id is null))
+);
+
+CREATE INDEX attachment_message_idx ON attachment (message_kind, message_id) WHERE message_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE attachment;
+-- +goose StatementEnd
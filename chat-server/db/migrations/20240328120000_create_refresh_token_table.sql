@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE refresh_token
+(
+    id         bigserial primary key                                    not null,
+    family_id  varchar(32)                                              not null,
+    user_id    bigint references users (id) on delete cascade           not null,
+    token_hash varchar(64) unique                                       not null,
+    expires_at timestamp                                                not null,
+    created_at timestamp                                                not null,
+    used_at    timestamp                                                null,
+    revoked_at timestamp                                                null
+);
+
+CREATE INDEX refresh_token_family_idx ON refresh_token (family_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE refresh_token;
+-- +goose StatementEnd
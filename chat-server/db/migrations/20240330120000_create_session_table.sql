@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE session
+(
+    id           varchar(32) primary key                           not null,
+    user_id      bigint references users (id) on delete cascade    not null,
+    device_name  varchar(128)                                      not null,
+    user_agent   varchar(512)                                      not null,
+    ip           varchar(45)                                       not null,
+    created_at   timestamp                                         not null,
+    last_seen_at timestamp                                         not null,
+    expires_at   timestamp                                         not null,
+    revoked_at   timestamp                                         null
+);
+
+CREATE INDEX session_user_idx ON session (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE session;
+-- +goose StatementEnd
@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE user_profile
+(
+    user_id             bigint primary key references users (id) on delete cascade not null,
+    display_name        varchar(64)                                             not null default '',
+    bio                 varchar(512)                                            not null default '',
+    avatar_key          varchar(64)                                             not null default '',
+    avatar_content_type varchar(128)                                            not null default '',
+    time_zone           varchar(64)                                             not null default '',
+    status_text         varchar(128)                                            not null default '',
+    status_expires_at   timestamp                                               null,
+    updated_at          timestamp                                               not null
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE user_profile;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE user_block
+(
+    username         varchar(128) references users (username) on update cascade on delete cascade not null,
+    blocked_username varchar(128) references users (username) on update cascade on delete cascade not null,
+    created_at       timestamp                                                                    not null,
+    primary key (username, blocked_username)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE user_block;
+-- +goose StatementEnd
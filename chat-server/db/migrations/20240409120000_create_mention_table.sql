@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE mention
+(
+    id            bigserial primary key                                                          not null,
+    message_kind  varchar(16)                                                                    not null,
+    message_id    bigint                                                                         not null,
+    username      varchar(128) references users (username) on update cascade on delete cascade not null,
+    from_username varchar(128) references users (username) on update cascade                   not null,
+    created_at    timestamp                                                                      not null,
+    read_at       timestamp                                                                      null,
+    unique (message_kind, message_id, username)
+);
+
+CREATE INDEX mention_username_id_idx ON mention (username, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE mention;
+-- +goose StatementEnd
@@ -0,0 +1,6 @@
+package config
+
+// Admin lists users that are granted admin role on startup, other roles are managed by admins via API.
+type Admin struct {
+	Usernames []string
+}
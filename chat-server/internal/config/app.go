@@ -3,7 +3,11 @@ package config
 type Config struct {
 	Server
 	Jwt
-	DB string
-	InMemoryDB
+	DB         string
+	InMemoryDB `mapstructure:"inmem"`
 	Postgres
+	Admin
+	Attachments
+	Avatars
+	Presence
 }
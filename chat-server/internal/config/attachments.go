@@ -0,0 +1,7 @@
+package config
+
+type Attachments struct {
+	Dir          string
+	MaxSize      int64    `mapstructure:"max_size"`
+	AllowedTypes []string `mapstructure:"allowed_types"`
+}
@@ -0,0 +1,8 @@
+package config
+
+// Avatars configure storage of profile images, only image types should be allowed.
+type Avatars struct {
+	Dir          string
+	MaxSize      int64    `mapstructure:"max_size"`
+	AllowedTypes []string `mapstructure:"allowed_types"`
+}
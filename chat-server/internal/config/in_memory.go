@@ -1,5 +1,14 @@
 package config
 
+import "time"
+
+// InMemoryDB configures in-memory db. Changes are written to log before they are applied and log is
+// folded into state file every snapshot interval, fsync tells how often log is flushed to disk.
 type InMemoryDB struct {
-	LoadFixtures bool
+	LoadFixtures     bool          `mapstructure:"load_fixtures"`
+	StatePath        string        `mapstructure:"state_path"`
+	WALPath          string        `mapstructure:"wal_path"`
+	Fsync            string        `mapstructure:"fsync"`
+	FsyncInterval    time.Duration `mapstructure:"fsync_interval"`
+	SnapshotInterval time.Duration `mapstructure:"snapshot_interval"`
 }
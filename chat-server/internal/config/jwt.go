@@ -1,5 +1,9 @@
 package config
 
+import "time"
+
 type Jwt struct {
-	Secret string
+	Secret     string
+	AccessTTL  time.Duration `mapstructure:"access_ttl"`
+	RefreshTTL time.Duration `mapstructure:"refresh_ttl"`
 }
@@ -0,0 +1,8 @@
+package config
+
+import "time"
+
+// Presence configures when inactive user stops being shown online.
+type Presence struct {
+	IdleTimeout time.Duration `mapstructure:"idle_timeout"`
+}
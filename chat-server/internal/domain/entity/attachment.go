@@ -0,0 +1,29 @@
+package entity
+
+import (
+	"io"
+	"time"
+)
+
+// Attachment is metadata of uploaded file, content itself is kept in blob storage under StorageKey.
+// Attachment is pending until it is sent with a message, only uploader can see it till then.
+type Attachment struct {
+	ID          int          `db:"id"`
+	UploadedBy  string       `db:"uploaded_by"`
+	MessageKind *MessageKind `db:"message_kind"`
+	MessageID   *int         `db:"message_id"`
+	FileName    string       `db:"file_name"`
+	ContentType string       `db:"content_type"`
+	Size        int64        `db:"size"`
+	StorageKey  string       `db:"storage_key"`
+	CreatedAt   time.Time    `db:"created_at"`
+}
+
+func (a *Attachment) IsLinked() bool { return a.MessageID != nil }
+
+// AttachmentUpload is a file received from user.
+type AttachmentUpload struct {
+	FileName string
+	Size     int64
+	Content  io.Reader
+}
@@ -0,0 +1,10 @@
+package entity
+
+import "time"
+
+// Block means that Username does not accept private messages from BlockedUsername.
+type Block struct {
+	Username        string    `db:"username"`
+	BlockedUsername string    `db:"blocked_username"`
+	CreatedAt       time.Time `db:"created_at"`
+}
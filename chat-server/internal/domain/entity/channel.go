@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+const (
+	// GeneralChannelID is an id of default channel which replaced former global public chat.
+	GeneralChannelID   = 1
+	GeneralChannelName = "general"
+)
+
+type Channel struct {
+	ID          int       `db:"id"`
+	Name        string    `db:"name"`
+	Description string    `db:"description"`
+	CreatedBy   *string   `db:"created_by"`
+	CreatedAt   time.Time `db:"created_at"`
+}
+
+type ChannelMember struct {
+	ChannelID int       `db:"channel_id"`
+	Username  string    `db:"username"`
+	JoinedAt  time.Time `db:"joined_at"`
+}
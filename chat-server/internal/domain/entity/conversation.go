@@ -0,0 +1,41 @@
+package entity
+
+import "time"
+
+const (
+	ConversationKindDirect = "direct"
+	ConversationKindGroup  = "group"
+)
+
+type Conversation struct {
+	ID        int       `db:"id"`
+	Title     string    `db:"title"`
+	CreatedBy *string   `db:"created_by"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type ConversationParticipant struct {
+	ConversationID int       `db:"conversation_id"`
+	Username       string    `db:"username"`
+	JoinedAt       time.Time `db:"joined_at"`
+}
+
+type ConversationMessage struct {
+	ID             int       `db:"id"`
+	ConversationID int       `db:"conversation_id"`
+	FromUsername   string    `db:"from_username"`
+	Content        string    `db:"content"`
+	SentAt         time.Time `db:"sent_at"`
+	EditedAt       time.Time `db:"edited_at"`
+}
+
+// ConversationSummary is an entry of user's conversation list. Direct conversations are built from
+// private messages and identified by Counterpart, group conversations are identified by ConversationID.
+type ConversationSummary struct {
+	Kind           string
+	ConversationID int
+	Counterpart    string
+	Title          string
+	Participants   []string
+	LastMessageAt  time.Time
+}
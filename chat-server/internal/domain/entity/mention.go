@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+// Mention is a record that user was mentioned as @username in message, one per user and message.
+type Mention struct {
+	ID           int         `db:"id"`
+	MessageKind  MessageKind `db:"message_kind"`
+	MessageID    int         `db:"message_id"`
+	Username     string      `db:"username"`
+	FromUsername string      `db:"from_username"`
+	CreatedAt    time.Time   `db:"created_at"`
+	ReadAt       *time.Time  `db:"read_at"`
+
+	// Content of mentioning message is not stored with mention, it is filled in for mention feed.
+	Content        string `db:"-"`
+	MessageDeleted bool   `db:"-"`
+}
+
+func (m *Mention) IsRead() bool { return m.ReadAt != nil }
+
+// MentionSpan is position of @username in message content, offset and length are counted in characters.
+type MentionSpan struct {
+	Username string
+	Offset   int
+	Length   int
+}
@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// MessageRevision is a previous version of edited message content.
+type MessageRevision struct {
+	ID        int       `db:"id"`
+	MessageID int       `db:"message_id"`
+	Content   string    `db:"content"`
+	EditedAt  time.Time `db:"edited_at"`
+}
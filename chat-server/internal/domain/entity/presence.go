@@ -0,0 +1,18 @@
+package entity
+
+import "time"
+
+type PresenceStatus string
+
+const (
+	PresenceOnline PresenceStatus = "online"
+	// PresenceAway is status of user who keeps live connection but has not been active for a while.
+	PresenceAway    PresenceStatus = "away"
+	PresenceOffline PresenceStatus = "offline"
+)
+
+type Presence struct {
+	Username   string
+	Status     PresenceStatus
+	LastSeenAt *time.Time
+}
@@ -3,10 +3,28 @@ package entity
 import "time"
 
 type PrivateMessage struct {
-	ID           int       `db:"id"`
-	FromUsername string    `db:"from_username"`
-	ToUsername   string    `db:"to_username"`
-	Content      string    `db:"content"`
-	SentAt       time.Time `db:"sent_at"`
-	EditedAt     time.Time `db:"edited_at"`
+	ID           int        `db:"id"`
+	FromUsername string     `db:"from_username"`
+	ToUsername   string     `db:"to_username"`
+	Content      string     `db:"content"`
+	SentAt       time.Time  `db:"sent_at"`
+	EditedAt     time.Time  `db:"edited_at"`
+	DeletedAt    *time.Time `db:"deleted_at"`
+	ParentID     *int       `db:"parent_id"`
+	SeenAt       *time.Time `db:"seen_at"`
+
+	// ReplyCount is not stored, it is filled in when message is returned as a thread root.
+	ReplyCount int `db:"-"`
+	// Reactions are not stored with message, they are filled in for the user who requested it.
+	Reactions []ReactionSummary `db:"-"`
+	// Attachments are stored separately and linked to message, on sending only their ids are set.
+	Attachments []Attachment `db:"-"`
+	// Mentions are stored separately, their spans are filled in when message is shown to users.
+	Mentions []MentionSpan `db:"-"`
 }
+
+func (m *PrivateMessage) IsDeleted() bool { return m.DeletedAt != nil }
+
+func (m *PrivateMessage) IsReply() bool { return m.ParentID != nil }
+
+func (m *PrivateMessage) IsSeen() bool { return m.SeenAt != nil }
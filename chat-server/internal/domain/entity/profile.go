@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"io"
+	"time"
+)
+
+// Profile is public information user tells about themselves, it is kept apart from account data of User.
+// Zero profile is returned for users who have not filled it yet.
+type Profile struct {
+	UserID      int    `db:"user_id"`
+	DisplayName string `db:"display_name"`
+	Bio         string `db:"bio"`
+	// AvatarKey is key of avatar image in blob storage, empty when user has no avatar.
+	AvatarKey         string     `db:"avatar_key"`
+	AvatarContentType string     `db:"avatar_content_type"`
+	TimeZone          string     `db:"time_zone"`
+	StatusText        string     `db:"status_text"`
+	StatusExpiresAt   *time.Time `db:"status_expires_at"`
+	UpdatedAt         time.Time  `db:"updated_at"`
+
+	// Username is not stored with profile, it is taken from user the profile belongs to.
+	Username string `db:"username"`
+}
+
+func (p *Profile) HasAvatar() bool { return p.AvatarKey != "" }
+
+// HideExpiredStatus clears status that has expired by now, expired status is not removed from storage until next update.
+func (p *Profile) HideExpiredStatus(now time.Time) {
+	if p.StatusExpiresAt != nil && !p.StatusExpiresAt.After(now) {
+		p.StatusText = ""
+		p.StatusExpiresAt = nil
+	}
+}
+
+// ProfileUpdate holds changed profile fields, nil fields are kept as is and empty strings clear them.
+type ProfileUpdate struct {
+	DisplayName *string
+	Bio         *string
+	TimeZone    *string
+	StatusText  *string
+	// StatusExpiresAt is applied together with StatusText only, status set without it never expires.
+	StatusExpiresAt *time.Time
+}
+
+// Apply sets changed fields on profile.
+func (u ProfileUpdate) Apply(profile *Profile) {
+	if u.DisplayName != nil {
+		profile.DisplayName = *u.DisplayName
+	}
+
+	if u.Bio != nil {
+		profile.Bio = *u.Bio
+	}
+
+	if u.TimeZone != nil {
+		profile.TimeZone = *u.TimeZone
+	}
+
+	if u.StatusText != nil {
+		profile.StatusText = *u.StatusText
+		profile.StatusExpiresAt = u.StatusExpiresAt
+
+		if profile.StatusText == "" {
+			profile.StatusExpiresAt = nil
+		}
+	}
+}
+
+// AvatarUpload is an avatar image received from user.
+type AvatarUpload struct {
+	Size    int64
+	Content io.Reader
+}
@@ -3,9 +3,27 @@ package entity
 import "time"
 
 type PublicMessage struct {
-	ID           int       `db:"id"`
-	FromUsername string    `db:"from_username"`
-	Content      string    `db:"content"`
-	SentAt       time.Time `db:"sent_at"`
-	EditedAt     time.Time `db:"edited_at"`
+	ID           int        `db:"id"`
+	ChannelID    int        `db:"channel_id"`
+	FromUsername string     `db:"from_username"`
+	Content      string     `db:"content"`
+	SentAt       time.Time  `db:"sent_at"`
+	EditedAt     time.Time  `db:"edited_at"`
+	DeletedAt    *time.Time `db:"deleted_at"`
+	ParentID     *int       `db:"parent_id"`
+
+	// ReplyCount is not stored, it is filled in when message is returned as a thread root.
+	ReplyCount int `db:"-"`
+	// Reactions are not stored with message, they are filled in for the user who requested it.
+	Reactions []ReactionSummary `db:"-"`
+	// Attachments are stored separately and linked to message, on sending only their ids are set.
+	Attachments []Attachment `db:"-"`
+	// AuthorProfile is profile of sender, it is filled in when message is shown to users.
+	AuthorProfile *Profile `db:"-"`
+	// Mentions are stored separately, their spans are filled in when message is shown to users.
+	Mentions []MentionSpan `db:"-"`
 }
+
+func (m *PublicMessage) IsDeleted() bool { return m.DeletedAt != nil }
+
+func (m *PublicMessage) IsReply() bool { return m.ParentID != nil }
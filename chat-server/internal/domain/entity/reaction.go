@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// MessageKind tells which of message tables message belongs to.
+type MessageKind string
+
+const (
+	MessageKindPublic  MessageKind = "public"
+	MessageKindPrivate MessageKind = "private"
+)
+
+type Reaction struct {
+	ID        int       `db:"id"`
+	MessageID int       `db:"message_id"`
+	Username  string    `db:"username"`
+	Emoji     string    `db:"emoji"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// ReactionSummary is aggregated reactions of message with one emoji, as seen by the user who requested it.
+type ReactionSummary struct {
+	Emoji   string
+	Count   int
+	Reacted bool
+}
@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// ReadMarker points to the last private message user has read in conversation with counterpart.
+type ReadMarker struct {
+	Username    string    `db:"username"`
+	Counterpart string    `db:"counterpart"`
+	LastReadID  int       `db:"last_read_id"`
+	ReadAt      time.Time `db:"read_at"`
+}
@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+// RefreshToken is stored by hash of token. Tokens rotated one from another share family,
+// family is a login session and access tokens issued in it carry its id.
+type RefreshToken struct {
+	ID        int        `db:"id"`
+	FamilyID  string     `db:"family_id"`
+	UserID    int        `db:"user_id"`
+	TokenHash string     `db:"token_hash"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	CreatedAt time.Time  `db:"created_at"`
+	UsedAt    *time.Time `db:"used_at"`
+	RevokedAt *time.Time `db:"revoked_at"`
+}
+
+func (t *RefreshToken) IsUsed() bool { return t.UsedAt != nil }
+
+func (t *RefreshToken) IsRevoked() bool { return t.RevokedAt != nil }
+
+// AuthTokens are issued on login and refresh, plain refresh token is given to client only once.
+type AuthTokens struct {
+	User             *User
+	FamilyID         string
+	RefreshToken     string
+	RefreshExpiresAt time.Time
+}
@@ -0,0 +1,42 @@
+package entity
+
+import "slices"
+
+// Role defines what user is allowed to do besides regular chatting.
+type Role string
+
+const (
+	RoleUser      Role = "user"
+	RoleModerator Role = "moderator"
+	RoleAdmin     Role = "admin"
+)
+
+// Permission is checked by handlers instead of roles, so roles can be extended without touching them.
+type Permission string
+
+const (
+	PermissionPurgeMessages Permission = "messages:purge"
+	// PermissionReadRevisions allows reading edit history of any public message, even deleted one.
+	PermissionReadRevisions Permission = "messages:revisions"
+	// PermissionReadPrivateRevisions allows reading edit history of private messages of other users.
+	// Moderators don't get it, private conversations are read by them only as participants.
+	PermissionReadPrivateRevisions Permission = "private_messages:revisions"
+	PermissionManageUsers          Permission = "users:manage"
+)
+
+var rolePermissions = map[Role][]Permission{
+	RoleUser:      {},
+	RoleModerator: {PermissionReadRevisions},
+	RoleAdmin: {
+		PermissionPurgeMessages, PermissionReadRevisions, PermissionReadPrivateRevisions, PermissionManageUsers,
+	},
+}
+
+func (r Role) IsValid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+func (r Role) HasPermission(permission Permission) bool {
+	return slices.Contains(rolePermissions[r], permission)
+}
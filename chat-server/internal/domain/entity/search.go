@@ -0,0 +1,50 @@
+package entity
+
+import "time"
+
+// SnippetMaxWords is a size of highlighted fragment of message returned by search.
+const SnippetMaxWords = 20
+
+// MessageSearchQuery is a full-text query over messages, empty filters are not applied.
+// Kind limits search to one of message tables, counterpart filter applies to private messages only.
+type MessageSearchQuery struct {
+	Text        string
+	Kind        MessageKind
+	Author      string
+	Counterpart string
+	SentAfter   *time.Time
+	SentBefore  *time.Time
+}
+
+// SearchHit is a message found by search, exactly one of Public and Private is set according to Kind.
+type SearchHit struct {
+	Kind    MessageKind
+	Public  *PublicMessage
+	Private *PrivateMessage
+	Snippet string
+}
+
+func (h *SearchHit) SentAt() time.Time {
+	if h.Kind == MessageKindPublic {
+		return h.Public.SentAt
+	}
+
+	return h.Private.SentAt
+}
+
+func (h *SearchHit) MessageID() int {
+	if h.Kind == MessageKindPublic {
+		return h.Public.ID
+	}
+
+	return h.Private.ID
+}
+
+// IsNewerThan orders hits newest first, hits sent at the same time are ordered by id.
+func (h *SearchHit) IsNewerThan(other *SearchHit) bool {
+	if h.SentAt().Equal(other.SentAt()) {
+		return h.MessageID() > other.MessageID()
+	}
+
+	return h.SentAt().After(other.SentAt())
+}
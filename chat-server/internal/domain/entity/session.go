@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+// Session is a login of user on some device. Its id is id of refresh token family, access tokens carry it as sid.
+type Session struct {
+	ID         string     `db:"id"`
+	UserID     int        `db:"user_id"`
+	DeviceName string     `db:"device_name"`
+	UserAgent  string     `db:"user_agent"`
+	IP         string     `db:"ip"`
+	CreatedAt  time.Time  `db:"created_at"`
+	LastSeenAt time.Time  `db:"last_seen_at"`
+	ExpiresAt  time.Time  `db:"expires_at"`
+	RevokedAt  *time.Time `db:"revoked_at"`
+}
+
+// IsActive reports whether session was neither revoked nor expired at provided time.
+func (s *Session) IsActive(at time.Time) bool {
+	return s.RevokedAt == nil && s.ExpiresAt.After(at)
+}
+
+// SessionDevice describes client that logs in.
+type SessionDevice struct {
+	Name      string
+	UserAgent string
+	IP        string
+}
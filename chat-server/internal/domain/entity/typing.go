@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+type TypingTarget string
+
+const (
+	TypingInPublic       TypingTarget = "public"
+	TypingInPrivate      TypingTarget = "private"
+	TypingInConversation TypingTarget = "conversation"
+)
+
+// Typing is an ephemeral notice that user is writing a message, it is never stored
+// and clients should hide it after ExpiresAt unless it is renewed.
+type Typing struct {
+	Username       string
+	Target         TypingTarget
+	ChannelID      int
+	ToUsername     string
+	ConversationID int
+	ExpiresAt      time.Time
+}
@@ -7,8 +7,11 @@ type User struct {
 	Email          string    `db:"email"`
 	Username       string    `db:"username"`
 	HashedPassword string    `db:"hashed_password"`
+	Role           Role      `db:"role"`
 	CreatedAt      time.Time `db:"created_at"`
 	UpdatedAt      time.Time `db:"updated_at"`
+	// LastSeenAt is time of last activity of user, nil if user was never seen.
+	LastSeenAt *time.Time `db:"last_seen_at"`
 }
 
 func (u *User) Equal(other User) bool { return u.Username == other.Username }
@@ -0,0 +1,317 @@
+// nolint
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/mapper"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/middleware"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/request"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+
+	userservice "github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/user"
+
+	handlerinternalutils "github.com/ew0s/ewos-to-go-hw/chat-server/internal/pkg/utils/handler"
+	handlerutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/handler"
+	sliceutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/slice"
+)
+
+type PublicMessageService interface {
+	PurgePublicMessage(ctx context.Context, id int) error
+}
+
+type PrivateMessageService interface {
+	PurgePrivateMessage(ctx context.Context, id int) error
+}
+
+type AttachmentService interface {
+	PurgeMessageAttachments(ctx context.Context, kind entity.MessageKind, messageID int) error
+}
+
+type UserService interface {
+	GetAllUsers(ctx context.Context, offset, limit int) []*entity.User
+	DeleteUser(ctx context.Context, id int) (*entity.User, error)
+	ChangeUserRole(ctx context.Context, id int, role entity.Role) (*entity.User, error)
+}
+
+type Middleware = func(http.Handler) http.Handler
+
+type Handler struct {
+	PublicMessageService  PublicMessageService
+	PrivateMessageService PrivateMessageService
+	AttachmentService     AttachmentService
+	UserService           UserService
+	Middlewares           []Middleware
+
+	logger    *logrus.Logger
+	validator *validator.Validate
+}
+
+func New(
+	publicMessageService PublicMessageService,
+	privateMessageService PrivateMessageService,
+	attachmentService AttachmentService,
+	userService UserService,
+	logger *logrus.Logger,
+	validator *validator.Validate,
+	middlewares ...Middleware,
+) *Handler {
+	return &Handler{
+		PublicMessageService:  publicMessageService,
+		PrivateMessageService: privateMessageService,
+		AttachmentService:     attachmentService,
+		UserService:           userService,
+		Middlewares:           middlewares,
+		logger:                logger,
+		validator:             validator,
+	}
+}
+
+func (h *Handler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+
+	router.Group(func(r chi.Router) {
+		r.Use(h.Middlewares...)
+
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.PermissionMiddleware(entity.PermissionPurgeMessages, h.logger))
+
+			r.Delete("/messages/public/{id}", h.PurgePublicMessage)
+			r.Delete("/messages/private/{id}", h.PurgePrivateMessage)
+		})
+
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.PermissionMiddleware(entity.PermissionManageUsers, h.logger))
+
+			r.Get("/users", h.GetUsers)
+			r.Delete("/users/{id}", h.DeleteUser)
+			r.Patch("/users/{id}/role", h.ChangeUserRole)
+		})
+	})
+
+	return router
+}
+
+func switchByErrorAndWriteResponse(err error, rw http.ResponseWriter, logger *logrus.Logger) {
+	switch {
+	case errors.Is(err, repository.ErrNoSuchPublicMessage),
+		errors.Is(err, repository.ErrNoSuchPrivateMessage),
+		errors.Is(err, repository.ErrNoSuchUser):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusNotFound, "", err.Error())
+
+	case errors.Is(err, userservice.ErrInvalidRole):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusBadRequest, "", err.Error())
+
+	case errors.Is(err, userservice.ErrLastAdmin), errors.Is(err, repository.ErrUserHasMessages),
+		errors.Is(err, repository.ErrConcurrentUpdate):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusConflict, "", err.Error())
+
+	default:
+		errMsg := fmt.Sprintf("error occurred processing admin request: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusInternalServerError, errMsg, "")
+	}
+}
+
+// PurgePublicMessage godoc
+//
+//	@Summary		Purge public message
+//	@Description	Remove public message with its revision history and attachments completely. Requires admin role
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Admin
+//	@Param			id	path	int	true	"message id"
+//	@Success		204
+//	@Failure		400	{string}	invalid	message	id	provided
+//	@Failure		401	{string}	Unauthorized
+//	@Failure		403	{string}	Forbidden
+//	@Failure		404	{string}	Not	Found
+//	@Router			/api/v1/admin/messages/public/{id} [delete]
+func (h *Handler) PurgePublicMessage(rw http.ResponseWriter, req *http.Request) {
+	id, err := handlerutils.GetIntParamFromURL(req, "id")
+	if err != nil {
+		msg := fmt.Sprintf("invalid message id provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, msg, msg)
+		return
+	}
+
+	if err = h.PublicMessageService.PurgePublicMessage(req.Context(), id); err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	if err = h.AttachmentService.PurgeMessageAttachments(req.Context(), entity.MessageKindPublic, id); err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// PurgePrivateMessage godoc
+//
+//	@Summary		Purge private message
+//	@Description	Remove private message with its revision history and attachments completely. Requires admin role
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Admin
+//	@Param			id	path	int	true	"message id"
+//	@Success		204
+//	@Failure		400	{string}	invalid	message	id	provided
+//	@Failure		401	{string}	Unauthorized
+//	@Failure		403	{string}	Forbidden
+//	@Failure		404	{string}	Not	Found
+//	@Router			/api/v1/admin/messages/private/{id} [delete]
+func (h *Handler) PurgePrivateMessage(rw http.ResponseWriter, req *http.Request) {
+	id, err := handlerutils.GetIntParamFromURL(req, "id")
+	if err != nil {
+		msg := fmt.Sprintf("invalid message id provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, msg, msg)
+		return
+	}
+
+	if err = h.PrivateMessageService.PurgePrivateMessage(req.Context(), id); err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	if err = h.AttachmentService.PurgeMessageAttachments(req.Context(), entity.MessageKindPrivate, id); err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// GetUsers godoc
+//
+//	@Summary		Get users with roles
+//	@Description	Get all users with their roles. Requires admin role
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Admin
+//	@Produce		json
+//	@Param			offset	query		int	false	"offset"
+//	@Param			limit	query		int	false	"limit"
+//	@Success		200		{object}	[]response.GetUserResponse
+//	@Failure		400		{string}	invalid	pagination	options
+//	@Failure		401		{string}	Unauthorized
+//	@Failure		403		{string}	Forbidden
+//	@Router			/api/v1/admin/users [get]
+func (h *Handler) GetUsers(rw http.ResponseWriter, req *http.Request) {
+	paginationOpts := handlerinternalutils.GetPaginationOptsFromQuery(req, handler.DefaultOffset, handler.DefaultLimit)
+
+	if err := paginationOpts.Validate(h.validator); err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, "", err.Error())
+		return
+	}
+
+	users := h.UserService.GetAllUsers(req.Context(), paginationOpts.Offset, paginationOpts.Limit)
+
+	render.JSON(rw, req, sliceutils.Map(users, mapper.MapUserToUserResponse))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// DeleteUser godoc
+//
+//	@Summary		Delete user
+//	@Description	Delete user and sign out all of their sessions. Last admin cannot be deleted. Requires admin role
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Admin
+//	@Produce		json
+//	@Param			id	path		int	true	"user id"
+//	@Success		200	{object}	response.GetUserResponse
+//	@Failure		400	{string}	invalid	user	id	provided
+//	@Failure		401	{string}	Unauthorized
+//	@Failure		403	{string}	Forbidden
+//	@Failure		404	{string}	Not	Found
+//	@Failure		409	{string}	last	admin	or	user	with	messages	cannot	be	removed
+//	@Router			/api/v1/admin/users/{id} [delete]
+func (h *Handler) DeleteUser(rw http.ResponseWriter, req *http.Request) {
+	id, err := handlerutils.GetIntParamFromURL(req, "id")
+	if err != nil {
+		msg := fmt.Sprintf("invalid user id provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, msg, msg)
+		return
+	}
+
+	deleted, err := h.UserService.DeleteUser(req.Context(), id)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, mapper.MapUserToUserResponse(deleted))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// ChangeUserRole godoc
+//
+//	@Summary		Change user role
+//	@Description	Change role of user, it is applied to access tokens issued after the change. Last admin cannot be demoted. Requires admin role
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int								true	"user id"
+//	@Param			input	body		request.ChangeUserRoleRequest	true	"new role"
+//	@Success		200		{object}	response.GetUserResponse
+//	@Failure		400		{string}	invalid	role	provided
+//	@Failure		401		{string}	Unauthorized
+//	@Failure		403		{string}	Forbidden
+//	@Failure		404		{string}	Not	Found
+//	@Failure		409		{string}	last	admin	cannot	be	demoted
+//	@Router			/api/v1/admin/users/{id}/role [patch]
+func (h *Handler) ChangeUserRole(rw http.ResponseWriter, req *http.Request) {
+	id, err := handlerutils.GetIntParamFromURL(req, "id")
+	if err != nil {
+		msg := fmt.Sprintf("invalid user id provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, msg, msg)
+		return
+	}
+
+	var roleReq request.ChangeUserRoleRequest
+
+	if err = render.DecodeJSON(req.Body, &roleReq); err != nil {
+		logMsg := fmt.Sprintf("error occurred decoding ChangeUserRoleRequest struct: %s", err)
+		respMsg := fmt.Sprintf("invalid role provided: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	if err = roleReq.Validate(h.validator); err != nil {
+		logMsg := fmt.Sprintf("error occurred validating ChangeUserRoleRequest struct: %s", err)
+		respMsg := fmt.Sprintf("invalid role provided: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	user, err := h.UserService.ChangeUserRole(req.Context(), id, entity.Role(roleReq.Role))
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, mapper.MapUserToUserResponse(user))
+	rw.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,228 @@
+// nolint
+package attachment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/config"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/mapper"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/pkg/blob"
+
+	attachmentservice "github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/attachment"
+	messageservice "github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message"
+
+	handlerutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/handler"
+)
+
+const (
+	fileFormField = "file"
+	// multipartOverhead is allowed on top of attachment max size for multipart boundaries and headers.
+	multipartOverhead = 1 << 20
+)
+
+type AttachmentService interface {
+	UploadAttachment(ctx context.Context, username string, upload entity.AttachmentUpload) (*entity.Attachment, error)
+	GetAttachmentContent(ctx context.Context, id int, username string) (*entity.Attachment, io.ReadCloser, error)
+}
+
+type Middleware = func(http.Handler) http.Handler
+
+type Handler struct {
+	AttachmentService AttachmentService
+	Config            config.Attachments
+	Middlewares       []Middleware
+
+	logger    *logrus.Logger
+	validator *validator.Validate
+}
+
+func New(
+	attachmentService AttachmentService,
+	conf config.Attachments,
+	logger *logrus.Logger,
+	validator *validator.Validate,
+	middlewares ...Middleware,
+) *Handler {
+	return &Handler{
+		AttachmentService: attachmentService,
+		Config:            conf,
+		Middlewares:       middlewares,
+		logger:            logger,
+		validator:         validator,
+	}
+}
+
+func (h *Handler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+
+	router.Group(func(r chi.Router) {
+		r.Use(h.Middlewares...)
+
+		r.Post("/", h.UploadAttachment)
+		r.Get("/{id}", h.GetAttachmentContent)
+	})
+
+	return router
+}
+
+func switchByErrorAndWriteResponse(err error, rw http.ResponseWriter, logger *logrus.Logger) {
+	var maxBytesErr *http.MaxBytesError
+
+	switch {
+	case errors.Is(err, attachmentservice.ErrAttachmentTooLarge), errors.As(err, &maxBytesErr):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusRequestEntityTooLarge, "",
+			attachmentservice.ErrAttachmentTooLarge.Error())
+
+	case errors.Is(err, attachmentservice.ErrAttachmentTypeNotAllowed):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusUnsupportedMediaType, "", err.Error())
+
+	case errors.Is(err, attachmentservice.ErrNotAttachmentViewer):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusForbidden, "", err.Error())
+
+	case errors.Is(err, repository.ErrNoSuchAttachment),
+		errors.Is(err, repository.ErrNoSuchPublicMessage),
+		errors.Is(err, repository.ErrNoSuchPrivateMessage),
+		errors.Is(err, messageservice.ErrMessageDeleted),
+		errors.Is(err, blob.ErrNotFound):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusNotFound, "", repository.ErrNoSuchAttachment.Error())
+
+	default:
+		errMsg := fmt.Sprintf("error occurred processing attachment: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusInternalServerError, errMsg, "")
+	}
+}
+
+func contentDisposition(attachment *entity.Attachment) string {
+	disposition := "attachment"
+	if strings.HasPrefix(attachment.ContentType, "image/") {
+		disposition = "inline"
+	}
+
+	return mime.FormatMediaType(disposition, map[string]string{"filename": attachment.FileName})
+}
+
+// UploadAttachment godoc
+//
+//	@Summary		Upload attachment
+//	@Description	Upload single file, its id can be passed in attachment_ids when sending message. File type is detected from its content and must be allowed by server
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Attachments
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			file	formData	file	true	"File to upload"
+//	@Success		201		{object}	response.GetAttachmentResponse
+//	@Failure		400		{string}	invalid	file	provided
+//	@Failure		401		{string}	Unauthorized
+//	@Failure		413		{string}	attachment	is	too	large
+//	@Failure		415		{string}	attachment	type	is	not	allowed
+//	@Failure		500		{string}	internal	error
+//	@Router			/api/v1/attachments [post]
+func (h *Handler) UploadAttachment(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	req.Body = http.MaxBytesReader(rw, req.Body, h.Config.MaxSize+multipartOverhead)
+
+	reader, err := req.MultipartReader()
+	if err != nil {
+		msg := fmt.Sprintf("invalid file provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, "", msg)
+		return
+	}
+
+	part, err := handlerutils.NextFilePart(reader, fileFormField)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			switchByErrorAndWriteResponse(err, rw, h.logger)
+			return
+		}
+
+		msg := fmt.Sprintf("invalid file provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, "", msg)
+		return
+	}
+	defer part.Close()
+
+	attachment, err := h.AttachmentService.UploadAttachment(req.Context(), username, entity.AttachmentUpload{
+		FileName: part.FileName(),
+		Content:  part,
+	})
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	rw.WriteHeader(http.StatusCreated)
+	render.JSON(rw, req, mapper.MapAttachmentToResponse(*attachment))
+}
+
+// GetAttachmentContent godoc
+//
+//	@Summary		Download attachment
+//	@Description	Download attachment content. Attachment of public message is available to those who can read its channel, attachment of private message to its participants, not sent attachment to its uploader only
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Attachments
+//	@Produce		octet-stream
+//	@Param			id	path		int	true	"attachment id"
+//	@Success		200	{file}		binary
+//	@Failure		400	{string}	invalid	attachment	id	provided
+//	@Failure		401	{string}	Unauthorized
+//	@Failure		403	{string}	Forbidden
+//	@Failure		404	{string}	Not	Found
+//	@Failure		500	{string}	internal	error
+//	@Router			/api/v1/attachments/{id} [get]
+func (h *Handler) GetAttachmentContent(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	id, err := handlerutils.GetIntParamFromURL(req, "id")
+	if err != nil {
+		msg := fmt.Sprintf("invalid attachment id provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, msg, msg)
+		return
+	}
+
+	attachment, content, err := h.AttachmentService.GetAttachmentContent(req.Context(), id, username)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+	defer content.Close()
+
+	rw.Header().Set("Content-Type", attachment.ContentType)
+	rw.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	rw.Header().Set("Content-Disposition", contentDisposition(attachment))
+	rw.Header().Set("X-Content-Type-Options", "nosniff")
+	rw.WriteHeader(http.StatusOK)
+
+	if _, err = io.Copy(rw, content); err != nil {
+		h.logger.Errorf("error occurred writing attachment content: %s", err)
+	}
+}
@@ -2,9 +2,11 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/config"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
@@ -17,6 +19,8 @@ import (
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/request"
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/response"
 
+	authservice "github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/auth"
+
 	handlerutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/handler"
 	jwtutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/jwt"
 )
@@ -33,6 +37,9 @@ type UserService interface {
 
 type AuthService interface {
 	Login(ctx context.Context, username, password string) (*entity.User, error)
+	IssueTokens(ctx context.Context, user *entity.User, device entity.SessionDevice) (*entity.AuthTokens, error)
+	RefreshTokens(ctx context.Context, refreshToken string) (*entity.AuthTokens, error)
+	Logout(ctx context.Context, refreshToken string) error
 }
 
 type Middleware = func(http.Handler) http.Handler
@@ -72,6 +79,8 @@ func (h *Handler) Routes() *chi.Mux {
 		r.Use(h.Middlewares...)
 		r.Post("/register", h.Register)
 		r.Post("/login", h.Login)
+		r.Post("/refresh", h.Refresh)
+		r.Post("/logout", h.Logout)
 	})
 
 	return router
@@ -126,10 +135,10 @@ func (h *Handler) Register(rw http.ResponseWriter, req *http.Request) {
 // Login godoc
 //
 //	@Summary		Login user
-//	@Description	login user via JWT
+//	@Description	login user via JWT, returns short-lived access token and refresh token to get new one
 //	@Tags			Auth
 //	@Accept			json
-//	@Produce		plain
+//	@Produce		json
 //	@Param			input	body		request.LoginRequest	true	"login info"
 //	@Success		200		{object}	response.LoginResponse
 //	@Failure		400		{string}	invalid		login	data	provided
@@ -162,11 +171,50 @@ func (h *Handler) Login(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	// construct jwt token
+	device := entity.SessionDevice{
+		Name:      loginReq.DeviceName,
+		UserAgent: req.UserAgent(),
+		IP:        handlerutils.GetClientIP(req),
+	}
+
+	tokens, err := h.AuthService.IssueTokens(req.Context(), user, device)
+	if err != nil {
+		msg := fmt.Sprintf("error occurred issuing refresh token: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusInternalServerError, msg, msg)
+		return
+	}
+
+	h.writeTokens(rw, req, tokens)
+}
+
+func switchByErrorAndWriteResponse(err error, rw http.ResponseWriter, logger *logrus.Logger) {
+	switch {
+	case errors.Is(err, authservice.ErrInvalidRefreshToken),
+		errors.Is(err, authservice.ErrRefreshTokenExpired),
+		errors.Is(err, authservice.ErrRefreshTokenReused),
+		errors.Is(err, authservice.ErrSessionRevoked):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusUnauthorized, "", err.Error())
+
+	default:
+		errMsg := fmt.Sprintf("error occurred processing refresh token: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusInternalServerError, errMsg, "")
+	}
+}
+
+// writeTokens signs access token of session and writes it with refresh token.
+func (h *Handler) writeTokens(rw http.ResponseWriter, req *http.Request, tokens *entity.AuthTokens) {
+	expiresAt := time.Now().Add(h.JwtConfig.AccessTTL)
+
+	// construct jwt token, sid binds it to session, so it is rejected once session is revoked
 	payload := map[string]any{
-		"id":       user.ID,
-		"username": user.Username,
-		"email":    user.Email,
+		"id":       tokens.User.ID,
+		"username": tokens.User.Username,
+		"email":    tokens.User.Email,
+		"role":     tokens.User.Role,
+		"sid":      tokens.FamilyID,
+		"exp":      expiresAt.Unix(),
 	}
 
 	token, err := jwtutils.CreateJWT(payload, jwt.SigningMethodHS256, h.JwtConfig.Secret)
@@ -177,11 +225,89 @@ func (h *Handler) Login(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	// todo: mapper?
 	resp := response.LoginResponse{
-		Token: token,
+		Token:            token,
+		ExpiresAt:        expiresAt,
+		RefreshToken:     tokens.RefreshToken,
+		RefreshExpiresAt: tokens.RefreshExpiresAt,
 	}
 
 	render.JSON(rw, req, resp)
 	rw.WriteHeader(http.StatusOK)
 }
+
+func (h *Handler) decodeRefreshTokenRequest(rw http.ResponseWriter, req *http.Request) (*request.RefreshTokenRequest, bool) {
+	var refreshReq request.RefreshTokenRequest
+
+	if err := render.DecodeJSON(req.Body, &refreshReq); err != nil {
+		logMsg := fmt.Sprintf("error occurred decoding request body to RefreshTokenRequest struct: %v", err)
+		respMsg := fmt.Sprintf("invalid refresh token provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+		return nil, false
+	}
+
+	if err := refreshReq.Validate(h.validator); err != nil {
+		logMsg := fmt.Sprintf("error occurred validating RefreshTokenRequest struct: %v", err)
+		respMsg := fmt.Sprintf("invalid refresh token provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+		return nil, false
+	}
+
+	return &refreshReq, true
+}
+
+// Refresh godoc
+//
+//	@Summary		Refresh tokens
+//	@Description	Exchange refresh token for new access and refresh tokens, each refresh token can be used once. Reuse of refresh token revokes its session
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			input	body		request.RefreshTokenRequest	true	"refresh token"
+//	@Success		200		{object}	response.LoginResponse
+//	@Failure		400		{string}	invalid	refresh	token	provided
+//	@Failure		401		{string}	Unauthorized
+//	@Failure		500		{string}	internal	error
+//	@Router			/api/v1/auth/refresh [post]
+func (h *Handler) Refresh(rw http.ResponseWriter, req *http.Request) {
+	refreshReq, ok := h.decodeRefreshTokenRequest(rw, req)
+	if !ok {
+		return
+	}
+
+	tokens, err := h.AuthService.RefreshTokens(req.Context(), refreshReq.RefreshToken)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	h.writeTokens(rw, req, tokens)
+}
+
+// Logout godoc
+//
+//	@Summary		Logout
+//	@Description	Revoke session of refresh token, its access and refresh tokens are rejected from now on
+//	@Tags			Auth
+//	@Accept			json
+//	@Param			input	body	request.RefreshTokenRequest	true	"refresh token"
+//	@Success		204
+//	@Failure		400	{string}	invalid	refresh	token	provided
+//	@Failure		401	{string}	Unauthorized
+//	@Failure		500	{string}	internal	error
+//	@Router			/api/v1/auth/logout [post]
+func (h *Handler) Logout(rw http.ResponseWriter, req *http.Request) {
+	refreshReq, ok := h.decodeRefreshTokenRequest(rw, req)
+	if !ok {
+		return
+	}
+
+	if err := h.AuthService.Logout(req.Context(), refreshReq.RefreshToken); err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,792 @@
+// nolint
+package channel
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/mapper"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/request"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+
+	channelservice "github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/channel"
+	messageservice "github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message"
+
+	handlerinternalutils "github.com/ew0s/ewos-to-go-hw/chat-server/internal/pkg/utils/handler"
+	handlerutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/handler"
+	sliceutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/slice"
+)
+
+type ChannelService interface {
+	CreateChannel(ctx context.Context, channel entity.Channel, creatorUsername string) (*entity.Channel, error)
+	GetChannel(ctx context.Context, id int) (*entity.Channel, error)
+	GetAllChannels(ctx context.Context, offset, limit int) []*entity.Channel
+	JoinChannel(ctx context.Context, id int, username string) error
+	LeaveChannel(ctx context.Context, id int, username string) error
+	GetChannelMembers(ctx context.Context, id int) ([]*entity.ChannelMember, error)
+	SendChannelMessage(ctx context.Context, channelID int, msg entity.PublicMessage) (*entity.PublicMessage, error)
+	GetChannelMessages(ctx context.Context, channelID int, username string, offset, limit int) ([]*entity.PublicMessage, error)
+	GetChannelThread(ctx context.Context, channelID, messageID int, username string, offset, limit int) (*entity.PublicMessage, []*entity.PublicMessage, error)
+	EditChannelMessage(ctx context.Context, channelID, messageID int, editorUsername, content string) (*entity.PublicMessage, error)
+	GetChannelMessageRevisions(ctx context.Context, channelID, messageID int, username string) ([]*entity.MessageRevision, error)
+	DeleteChannelMessage(ctx context.Context, channelID, messageID int, username string) (*entity.PublicMessage, error)
+	AddChannelMessageReaction(ctx context.Context, channelID, messageID int, username, emoji string) (*entity.PublicMessage, error)
+	RemoveChannelMessageReaction(ctx context.Context, channelID, messageID int, username, emoji string) (*entity.PublicMessage, error)
+}
+
+type Middleware = func(http.Handler) http.Handler
+
+type Handler struct {
+	ChannelService ChannelService
+	Middlewares    []Middleware
+
+	logger    *logrus.Logger
+	validator *validator.Validate
+}
+
+func New(
+	channelService ChannelService,
+	logger *logrus.Logger,
+	validator *validator.Validate,
+	middlewares ...Middleware,
+) *Handler {
+	return &Handler{
+		ChannelService: channelService,
+		Middlewares:    middlewares,
+		logger:         logger,
+		validator:      validator,
+	}
+}
+
+func (h *Handler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+
+	router.Group(func(r chi.Router) {
+		r.Use(h.Middlewares...)
+
+		r.Get("/", h.GetAllChannels)
+		r.Post("/", h.CreateChannel)
+
+		r.Get("/{id}", h.GetChannel)
+		r.Post("/{id}/join", h.JoinChannel)
+		r.Post("/{id}/leave", h.LeaveChannel)
+		r.Get("/{id}/members", h.GetChannelMembers)
+
+		r.Get("/{id}/messages", h.GetChannelMessages)
+		r.Post("/{id}/messages", h.SendChannelMessage)
+		r.Patch("/{id}/messages/{messageID}", h.EditChannelMessage)
+		r.Delete("/{id}/messages/{messageID}", h.DeleteChannelMessage)
+		r.Get("/{id}/messages/{messageID}/revisions", h.GetChannelMessageRevisions)
+		r.Get("/{id}/messages/{messageID}/thread", h.GetChannelThread)
+
+		r.Post("/{id}/messages/{messageID}/reactions", h.AddChannelMessageReaction)
+		r.Delete("/{id}/messages/{messageID}/reactions/{emoji}", h.RemoveChannelMessageReaction)
+	})
+
+	return router
+}
+
+func switchByErrorAndWriteResponse(err error, rw http.ResponseWriter, logger *logrus.Logger) {
+	switch {
+	case errors.Is(err, repository.ErrNoSuchChannel), errors.Is(err, channelservice.ErrNoSuchUser),
+		errors.Is(err, repository.ErrNoSuchPublicMessage), errors.Is(err, repository.ErrNoSuchReaction):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusNotFound, "", err.Error())
+
+	case errors.Is(err, repository.ErrChannelNameExists), errors.Is(err, repository.ErrReactionExists):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusConflict, "", err.Error())
+
+	case errors.Is(err, channelservice.ErrNotChannelMember), errors.Is(err, messageservice.ErrNotMessageAuthor):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusForbidden, "", err.Error())
+
+	case errors.Is(err, channelservice.ErrLeaveGeneralChannel),
+		errors.Is(err, messageservice.ErrInvalidParentMessage),
+		errors.Is(err, messageservice.ErrInvalidAttachment):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusBadRequest, "", err.Error())
+
+	case errors.Is(err, messageservice.ErrMessageDeleted):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusGone, "", err.Error())
+
+	default:
+		errMsg := fmt.Sprintf("error occurred processing channel request: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusInternalServerError, errMsg, "")
+	}
+}
+
+func (h *Handler) getChannelID(rw http.ResponseWriter, req *http.Request) (int, bool) {
+	id, err := handlerutils.GetIntParamFromURL(req, "id")
+	if err != nil {
+		msg := fmt.Sprintf("invalid channel id provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, msg, msg)
+
+		return 0, false
+	}
+
+	return id, true
+}
+
+func (h *Handler) getMessageID(rw http.ResponseWriter, req *http.Request) (int, bool) {
+	id, err := handlerutils.GetIntParamFromURL(req, "messageID")
+	if err != nil {
+		msg := fmt.Sprintf("invalid message id provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, msg, msg)
+
+		return 0, false
+	}
+
+	return id, true
+}
+
+// GetAllChannels godoc
+//
+//	@Summary		Get all channels
+//	@Description	Get all channels that users can join
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Channel
+//	@Produce		json
+//	@Param			offset	query		int	true	"Offset"
+//	@Param			limit	query		int	true	"Limit"
+//	@Success		200		{object}	[]response.GetChannelResponse
+//	@Failure		401		{string}	Unauthorized
+//	@Router			/api/v1/channels [get]
+func (h *Handler) GetAllChannels(rw http.ResponseWriter, req *http.Request) {
+	paginationOpts := handlerinternalutils.GetPaginationOptsFromQuery(req, handler.DefaultOffset, handler.DefaultLimit)
+
+	if err := paginationOpts.Validate(h.validator); err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, "", err.Error())
+
+		return
+	}
+
+	channels := h.ChannelService.GetAllChannels(req.Context(), paginationOpts.Offset, paginationOpts.Limit)
+
+	render.JSON(rw, req, sliceutils.Map(channels, mapper.MapChannelToResponse))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// CreateChannel godoc
+//
+//	@Summary		Create channel
+//	@Description	Create new named channel, creator joins it automatically
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Channel
+//	@Accept			json
+//	@Produce		json
+//	@Param			input	body		request.CreateChannelRequest	true	"channel schema"
+//	@Success		201		{object}	response.GetChannelResponse
+//	@Failure		400		{string}	invalid	channel	provided
+//	@Failure		401		{string}	Unauthorized
+//	@Failure		409		{string}	Conflict
+//	@Router			/api/v1/channels [post]
+func (h *Handler) CreateChannel(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	var createReq request.CreateChannelRequest
+
+	if err = render.DecodeJSON(req.Body, &createReq); err != nil {
+		logMsg := fmt.Sprintf("error occurred decoding CreateChannelRequest struct: %s", err)
+		respMsg := fmt.Sprintf("invalid channel provided: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	if err = createReq.Validate(h.validator); err != nil {
+		logMsg := fmt.Sprintf("error occurred validating CreateChannelRequest struct: %s", err)
+		respMsg := fmt.Sprintf("invalid channel provided: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	channel, err := h.ChannelService.CreateChannel(req.Context(), mapper.MapCreateChannelRequestToEntity(createReq), username)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	rw.WriteHeader(http.StatusCreated)
+	render.JSON(rw, req, mapper.MapChannelToResponse(channel))
+}
+
+// GetChannel godoc
+//
+//	@Summary		Get channel
+//	@Description	Get channel by id
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Channel
+//	@Produce		json
+//	@Param			id	path		int	true	"channel id"
+//	@Success		200	{object}	response.GetChannelResponse
+//	@Failure		400	{string}	invalid	channel	id	provided
+//	@Failure		401	{string}	Unauthorized
+//	@Failure		404	{string}	Not	Found
+//	@Router			/api/v1/channels/{id} [get]
+func (h *Handler) GetChannel(rw http.ResponseWriter, req *http.Request) {
+	id, ok := h.getChannelID(rw, req)
+	if !ok {
+		return
+	}
+
+	channel, err := h.ChannelService.GetChannel(req.Context(), id)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, mapper.MapChannelToResponse(channel))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// JoinChannel godoc
+//
+//	@Summary		Join channel
+//	@Description	Become a member of channel. Joining channel twice does nothing
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Channel
+//	@Param			id	path	int	true	"channel id"
+//	@Success		204
+//	@Failure		400	{string}	invalid	channel	id	provided
+//	@Failure		401	{string}	Unauthorized
+//	@Failure		404	{string}	Not	Found
+//	@Router			/api/v1/channels/{id}/join [post]
+func (h *Handler) JoinChannel(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	id, ok := h.getChannelID(rw, req)
+	if !ok {
+		return
+	}
+
+	if err = h.ChannelService.JoinChannel(req.Context(), id, username); err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// LeaveChannel godoc
+//
+//	@Summary		Leave channel
+//	@Description	Stop being a member of channel. General channel can not be left
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Channel
+//	@Param			id	path	int	true	"channel id"
+//	@Success		204
+//	@Failure		400	{string}	invalid	channel	id	provided
+//	@Failure		401	{string}	Unauthorized
+//	@Failure		403	{string}	Forbidden
+//	@Failure		404	{string}	Not	Found
+//	@Router			/api/v1/channels/{id}/leave [post]
+func (h *Handler) LeaveChannel(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	id, ok := h.getChannelID(rw, req)
+	if !ok {
+		return
+	}
+
+	if err = h.ChannelService.LeaveChannel(req.Context(), id, username); err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// GetChannelMembers godoc
+//
+//	@Summary		Get channel members
+//	@Description	Get users that joined channel
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Channel
+//	@Produce		json
+//	@Param			id	path		int	true	"channel id"
+//	@Success		200	{object}	[]response.GetChannelMemberResponse
+//	@Failure		400	{string}	invalid	channel	id	provided
+//	@Failure		401	{string}	Unauthorized
+//	@Failure		404	{string}	Not	Found
+//	@Router			/api/v1/channels/{id}/members [get]
+func (h *Handler) GetChannelMembers(rw http.ResponseWriter, req *http.Request) {
+	id, ok := h.getChannelID(rw, req)
+	if !ok {
+		return
+	}
+
+	members, err := h.ChannelService.GetChannelMembers(req.Context(), id)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, sliceutils.Map(members, mapper.MapChannelMemberToResponse))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// GetChannelMessages godoc
+//
+//	@Summary		Get channel messages
+//	@Description	Get messages that were sent to channel. Only channel members can read them
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Channel
+//	@Produce		json
+//	@Param			id		path		int	true	"channel id"
+//	@Param			offset	query		int	true	"Offset"
+//	@Param			limit	query		int	true	"Limit"
+//	@Success		200		{object}	[]response.GetPublicMessageResponse
+//	@Failure		400		{string}	invalid	channel	id	provided
+//	@Failure		401		{string}	Unauthorized
+//	@Failure		403		{string}	Forbidden
+//	@Failure		404		{string}	Not	Found
+//	@Router			/api/v1/channels/{id}/messages [get]
+func (h *Handler) GetChannelMessages(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	id, ok := h.getChannelID(rw, req)
+	if !ok {
+		return
+	}
+
+	paginationOpts := handlerinternalutils.GetPaginationOptsFromQuery(req, handler.DefaultOffset, handler.DefaultLimit)
+
+	if err = paginationOpts.Validate(h.validator); err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, "", err.Error())
+
+		return
+	}
+
+	messages, err := h.ChannelService.GetChannelMessages(req.Context(), id, username, paginationOpts.Offset, paginationOpts.Limit)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, sliceutils.Map(messages, mapper.MapPublicMessageToResponse))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// SendChannelMessage godoc
+//
+//	@Summary		Send message to channel
+//	@Description	Send message to channel. Only channel members can post
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Channel
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int									true	"channel id"
+//	@Param			input	body		request.SendPublicMessageRequest	true	"message schema"
+//	@Success		201		{object}	response.GetPublicMessageResponse
+//	@Failure		400		{string}	invalid	message	provided
+//	@Failure		401		{string}	Unauthorized
+//	@Failure		403		{string}	Forbidden
+//	@Failure		404		{string}	Not	Found
+//	@Failure		410		{string}	Gone
+//	@Router			/api/v1/channels/{id}/messages [post]
+func (h *Handler) SendChannelMessage(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	id, ok := h.getChannelID(rw, req)
+	if !ok {
+		return
+	}
+
+	var msgReq request.SendPublicMessageRequest
+
+	if err = render.DecodeJSON(req.Body, &msgReq); err != nil {
+		logMsg := fmt.Sprintf("error occurred decoding SendPublicMessageRequest struct: %s", err)
+		respMsg := fmt.Sprintf("invalid message provided: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	if err = msgReq.Validate(h.validator); err != nil {
+		logMsg := fmt.Sprintf("error occurred validating SendPublicMessageRequest struct: %s", err)
+		respMsg := fmt.Sprintf("invalid message provided: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	message, err := h.ChannelService.SendChannelMessage(req.Context(), id, mapper.MapSendPublicMessageRequestToEntity(msgReq, username))
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	rw.WriteHeader(http.StatusCreated)
+	render.JSON(rw, req, mapper.MapPublicMessageToResponse(message))
+}
+
+// GetChannelThread godoc
+//
+//	@Summary		Get channel message thread
+//	@Description	Get root of the thread that message belongs to with its paginated replies. Only channel members can read them
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Channel
+//	@Produce		json
+//	@Param			id			path		int	true	"channel id"
+//	@Param			messageID	path		int	true	"message id"
+//	@Param			offset		query		int	true	"Offset"
+//	@Param			limit		query		int	true	"Limit"
+//	@Success		200			{object}	response.GetPublicMessageThreadResponse
+//	@Failure		400			{string}	invalid	id	provided
+//	@Failure		401			{string}	Unauthorized
+//	@Failure		403			{string}	Forbidden
+//	@Failure		404			{string}	Not	Found
+//	@Router			/api/v1/channels/{id}/messages/{messageID}/thread [get]
+func (h *Handler) GetChannelThread(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	id, ok := h.getChannelID(rw, req)
+	if !ok {
+		return
+	}
+
+	messageID, ok := h.getMessageID(rw, req)
+	if !ok {
+		return
+	}
+
+	paginationOpts := handlerinternalutils.GetPaginationOptsFromQuery(req, handler.DefaultOffset, handler.DefaultLimit)
+
+	if err = paginationOpts.Validate(h.validator); err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, "", err.Error())
+
+		return
+	}
+
+	root, replies, err := h.ChannelService.GetChannelThread(req.Context(), id, messageID, username, paginationOpts.Offset, paginationOpts.Limit)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, mapper.MapPublicMessageThreadToResponse(root, replies))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// AddChannelMessageReaction godoc
+//
+//	@Summary		React to channel message
+//	@Description	Add emoji reaction to channel message. Only channel members can react, each emoji only once
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Channel
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int								true	"channel id"
+//	@Param			messageID	path		int								true	"message id"
+//	@Param			input		body		request.ReactToMessageRequest	true	"reaction schema"
+//	@Success		200			{object}	response.GetPublicMessageResponse
+//	@Failure		400			{string}	invalid	reaction	provided
+//	@Failure		401			{string}	Unauthorized
+//	@Failure		403			{string}	Forbidden
+//	@Failure		404			{string}	Not	Found
+//	@Failure		409			{string}	Conflict
+//	@Failure		410			{string}	Gone
+//	@Router			/api/v1/channels/{id}/messages/{messageID}/reactions [post]
+func (h *Handler) AddChannelMessageReaction(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	id, ok := h.getChannelID(rw, req)
+	if !ok {
+		return
+	}
+
+	messageID, ok := h.getMessageID(rw, req)
+	if !ok {
+		return
+	}
+
+	var reactReq request.ReactToMessageRequest
+
+	if err = render.DecodeJSON(req.Body, &reactReq); err != nil {
+		logMsg := fmt.Sprintf("error occurred decoding ReactToMessageRequest struct: %s", err)
+		respMsg := fmt.Sprintf("invalid reaction provided: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	if err = reactReq.Validate(h.validator); err != nil {
+		logMsg := fmt.Sprintf("error occurred validating ReactToMessageRequest struct: %s", err)
+		respMsg := fmt.Sprintf("invalid reaction provided: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	message, err := h.ChannelService.AddChannelMessageReaction(req.Context(), id, messageID, username, reactReq.Emoji)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, mapper.MapPublicMessageToResponse(message))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// RemoveChannelMessageReaction godoc
+//
+//	@Summary		Remove reaction from channel message
+//	@Description	Remove emoji reaction of user from channel message
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Channel
+//	@Produce		json
+//	@Param			id			path		int		true	"channel id"
+//	@Param			messageID	path		int		true	"message id"
+//	@Param			emoji		path		string	true	"url encoded emoji"
+//	@Success		200			{object}	response.GetPublicMessageResponse
+//	@Failure		400			{string}	invalid	id	provided
+//	@Failure		401			{string}	Unauthorized
+//	@Failure		403			{string}	Forbidden
+//	@Failure		404			{string}	Not	Found
+//	@Failure		410			{string}	Gone
+//	@Router			/api/v1/channels/{id}/messages/{messageID}/reactions/{emoji} [delete]
+func (h *Handler) RemoveChannelMessageReaction(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	id, ok := h.getChannelID(rw, req)
+	if !ok {
+		return
+	}
+
+	messageID, ok := h.getMessageID(rw, req)
+	if !ok {
+		return
+	}
+
+	emoji, err := handlerutils.GetStringParamFromURL(req, "emoji")
+	if err != nil {
+		msg := fmt.Sprintf("invalid emoji provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, msg, msg)
+		return
+	}
+
+	message, err := h.ChannelService.RemoveChannelMessageReaction(req.Context(), id, messageID, username, emoji)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, mapper.MapPublicMessageToResponse(message))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// EditChannelMessage godoc
+//
+//	@Summary		Edit channel message
+//	@Description	Edit content of channel message. Only author who is a member of channel can edit message,
+//	@Description	previous content is kept in revision history
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Channel
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		int							true	"channel id"
+//	@Param			messageID	path		int							true	"message id"
+//	@Param			input		body		request.EditMessageRequest	true	"edited message schema"
+//	@Success		200			{object}	response.GetPublicMessageResponse
+//	@Failure		400			{string}	invalid		message	provided
+//	@Failure		401			{string}	Unauthorized
+//	@Failure		403			{string}	Forbidden
+//	@Failure		404			{string}	Not			Found
+//	@Failure		410			{string}	Gone
+//	@Failure		500			{string}	internal	error
+//	@Router			/api/v1/channels/{id}/messages/{messageID} [patch]
+func (h *Handler) EditChannelMessage(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	id, ok := h.getChannelID(rw, req)
+	if !ok {
+		return
+	}
+
+	messageID, ok := h.getMessageID(rw, req)
+	if !ok {
+		return
+	}
+
+	var editReq request.EditMessageRequest
+
+	if err = render.DecodeJSON(req.Body, &editReq); err != nil {
+		logMsg := fmt.Sprintf("error occurred decoding request body to EditMessageRequest struct: %s", err)
+		respMsg := fmt.Sprintf("invalid message provided: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	if err = editReq.Validate(h.validator); err != nil {
+		logMsg := fmt.Sprintf("error occurred validating EditMessageRequest struct: %s", err)
+		respMsg := fmt.Sprintf("invalid message provided: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	message, err := h.ChannelService.EditChannelMessage(req.Context(), id, messageID, username, editReq.Content)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, mapper.MapPublicMessageToResponse(message))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// DeleteChannelMessage godoc
+//
+//	@Summary		Delete channel message
+//	@Description	Delete channel message. Only author who is a member of channel can delete message,
+//	@Description	tombstone is left in place of it so pagination stays stable
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Channel
+//	@Produce		json
+//	@Param			id			path		int	true	"channel id"
+//	@Param			messageID	path		int	true	"message id"
+//	@Success		200			{object}	response.GetPublicMessageResponse
+//	@Failure		400			{string}	invalid	id	provided
+//	@Failure		401			{string}	Unauthorized
+//	@Failure		403			{string}	Forbidden
+//	@Failure		404			{string}	Not	Found
+//	@Router			/api/v1/channels/{id}/messages/{messageID} [delete]
+func (h *Handler) DeleteChannelMessage(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	id, ok := h.getChannelID(rw, req)
+	if !ok {
+		return
+	}
+
+	messageID, ok := h.getMessageID(rw, req)
+	if !ok {
+		return
+	}
+
+	message, err := h.ChannelService.DeleteChannelMessage(req.Context(), id, messageID, username)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, mapper.MapPublicMessageToResponse(message))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// GetChannelMessageRevisions godoc
+//
+//	@Summary		Get channel message revisions
+//	@Description	Get previous versions of edited channel message, from oldest to newest. Available only to channel members
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Channel
+//	@Produce		json
+//	@Param			id			path		int	true	"channel id"
+//	@Param			messageID	path		int	true	"message id"
+//	@Success		200			{object}	[]response.GetMessageRevisionResponse
+//	@Failure		400			{string}	invalid	id	provided
+//	@Failure		401			{string}	Unauthorized
+//	@Failure		403			{string}	Forbidden
+//	@Failure		404			{string}	Not	Found
+//	@Failure		410			{string}	Gone
+//	@Router			/api/v1/channels/{id}/messages/{messageID}/revisions [get]
+func (h *Handler) GetChannelMessageRevisions(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	id, ok := h.getChannelID(rw, req)
+	if !ok {
+		return
+	}
+
+	messageID, ok := h.getMessageID(rw, req)
+	if !ok {
+		return
+	}
+
+	revisions, err := h.ChannelService.GetChannelMessageRevisions(req.Context(), id, messageID, username)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, sliceutils.Map(revisions, mapper.MapMessageRevisionToResponse))
+	rw.WriteHeader(http.StatusOK)
+}
@@ -1,6 +1,9 @@
 package handler
 
 const (
+	// APIPrefix is path all API routes are mounted at.
+	APIPrefix = "/chat/api/v1"
+
 	DefaultOffset = 0
 	DefaultLimit  = 100
 )
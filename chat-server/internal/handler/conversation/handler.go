@@ -0,0 +1,480 @@
+// nolint
+package conversation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/mapper"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/request"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+
+	conversationservice "github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/conversation"
+
+	handlerinternalutils "github.com/ew0s/ewos-to-go-hw/chat-server/internal/pkg/utils/handler"
+	handlerutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/handler"
+	sliceutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/slice"
+)
+
+type ConversationService interface {
+	CreateConversation(ctx context.Context, conversation entity.Conversation, creatorUsername string, participants []string) (*entity.Conversation, error)
+	GetConversation(ctx context.Context, id int, username string) (*entity.Conversation, []string, error)
+	AddParticipant(ctx context.Context, id int, actorUsername, username string) error
+	RemoveParticipant(ctx context.Context, id int, actorUsername, username string) error
+	LeaveConversation(ctx context.Context, id int, username string) error
+	SendConversationMessage(ctx context.Context, msg entity.ConversationMessage) (*entity.ConversationMessage, error)
+	GetConversationMessages(ctx context.Context, id int, username string, offset, limit int) ([]*entity.ConversationMessage, error)
+	GetConversationList(ctx context.Context, username string, offset, limit int) ([]*entity.ConversationSummary, error)
+}
+
+type Middleware = func(http.Handler) http.Handler
+
+type Handler struct {
+	ConversationService ConversationService
+	Middlewares         []Middleware
+
+	logger    *logrus.Logger
+	validator *validator.Validate
+}
+
+func New(
+	conversationService ConversationService,
+	logger *logrus.Logger,
+	validator *validator.Validate,
+	middlewares ...Middleware,
+) *Handler {
+	return &Handler{
+		ConversationService: conversationService,
+		Middlewares:         middlewares,
+		logger:              logger,
+		validator:           validator,
+	}
+}
+
+func (h *Handler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+
+	router.Group(func(r chi.Router) {
+		r.Use(h.Middlewares...)
+
+		r.Get("/", h.GetConversationList)
+		r.Post("/", h.CreateConversation)
+
+		r.Get("/{id}", h.GetConversation)
+		r.Post("/{id}/participants", h.AddParticipant)
+		r.Delete("/{id}/participants/{username}", h.RemoveParticipant)
+		r.Post("/{id}/leave", h.LeaveConversation)
+
+		r.Get("/{id}/messages", h.GetConversationMessages)
+		r.Post("/{id}/messages", h.SendConversationMessage)
+	})
+
+	return router
+}
+
+func switchByErrorAndWriteResponse(err error, rw http.ResponseWriter, logger *logrus.Logger) {
+	switch {
+	case errors.Is(err, repository.ErrNoSuchConversation), errors.Is(err, conversationservice.ErrNoSuchUser):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusNotFound, "", err.Error())
+
+	case errors.Is(err, conversationservice.ErrNotConversationParticipant),
+		errors.Is(err, conversationservice.ErrNotConversationCreator):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusForbidden, "", err.Error())
+
+	case errors.Is(err, conversationservice.ErrNotEnoughParticipants):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusBadRequest, "", err.Error())
+
+	default:
+		errMsg := fmt.Sprintf("error occurred processing conversation request: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusInternalServerError, errMsg, "")
+	}
+}
+
+func (h *Handler) getConversationID(rw http.ResponseWriter, req *http.Request) (int, bool) {
+	id, err := handlerutils.GetIntParamFromURL(req, "id")
+	if err != nil {
+		msg := fmt.Sprintf("invalid conversation id provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, msg, msg)
+
+		return 0, false
+	}
+
+	return id, true
+}
+
+// GetConversationList godoc
+//
+//	@Summary		Get conversation list
+//	@Description	Get direct and group conversations of user, most recently active first
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Conversation
+//	@Produce		json
+//	@Param			offset	query		int	true	"Offset"
+//	@Param			limit	query		int	true	"Limit"
+//	@Success		200		{object}	[]response.GetConversationSummaryResponse
+//	@Failure		401		{string}	Unauthorized
+//	@Router			/api/v1/conversations [get]
+func (h *Handler) GetConversationList(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	paginationOpts := handlerinternalutils.GetPaginationOptsFromQuery(req, handler.DefaultOffset, handler.DefaultLimit)
+
+	if err = paginationOpts.Validate(h.validator); err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, "", err.Error())
+
+		return
+	}
+
+	summaries, err := h.ConversationService.GetConversationList(req.Context(), username, paginationOpts.Offset, paginationOpts.Limit)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, sliceutils.Map(summaries, mapper.MapConversationSummaryToResponse))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// CreateConversation godoc
+//
+//	@Summary		Create group conversation
+//	@Description	Create conversation of caller and at least two other participants
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Conversation
+//	@Accept			json
+//	@Produce		json
+//	@Param			input	body		request.CreateConversationRequest	true	"conversation schema"
+//	@Success		201		{object}	response.GetConversationResponse
+//	@Failure		400		{string}	invalid	conversation	provided
+//	@Failure		401		{string}	Unauthorized
+//	@Failure		404		{string}	Not	Found
+//	@Router			/api/v1/conversations [post]
+func (h *Handler) CreateConversation(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	var createReq request.CreateConversationRequest
+
+	if err = render.DecodeJSON(req.Body, &createReq); err != nil {
+		logMsg := fmt.Sprintf("error occurred decoding CreateConversationRequest struct: %s", err)
+		respMsg := fmt.Sprintf("invalid conversation provided: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	if err = createReq.Validate(h.validator); err != nil {
+		logMsg := fmt.Sprintf("error occurred validating CreateConversationRequest struct: %s", err)
+		respMsg := fmt.Sprintf("invalid conversation provided: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	conversation, err := h.ConversationService.CreateConversation(req.Context(), mapper.MapCreateConversationRequestToEntity(createReq), username, createReq.Participants)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	_, participants, err := h.ConversationService.GetConversation(req.Context(), conversation.ID, username)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	rw.WriteHeader(http.StatusCreated)
+	render.JSON(rw, req, mapper.MapConversationToResponse(conversation, participants))
+}
+
+// GetConversation godoc
+//
+//	@Summary		Get conversation
+//	@Description	Get group conversation with its participants. Only participants can see it
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Conversation
+//	@Produce		json
+//	@Param			id	path		int	true	"conversation id"
+//	@Success		200	{object}	response.GetConversationResponse
+//	@Failure		400	{string}	invalid	conversation	id	provided
+//	@Failure		401	{string}	Unauthorized
+//	@Failure		403	{string}	Forbidden
+//	@Failure		404	{string}	Not	Found
+//	@Router			/api/v1/conversations/{id} [get]
+func (h *Handler) GetConversation(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	id, ok := h.getConversationID(rw, req)
+	if !ok {
+		return
+	}
+
+	conversation, participants, err := h.ConversationService.GetConversation(req.Context(), id, username)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, mapper.MapConversationToResponse(conversation, participants))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// AddParticipant godoc
+//
+//	@Summary		Add conversation participant
+//	@Description	Add user to group conversation. Any participant can add new ones
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Conversation
+//	@Accept			json
+//	@Param			id		path	int											true	"conversation id"
+//	@Param			input	body	request.AddConversationParticipantRequest	true	"participant schema"
+//	@Success		204
+//	@Failure		400	{string}	invalid	participant	provided
+//	@Failure		401	{string}	Unauthorized
+//	@Failure		403	{string}	Forbidden
+//	@Failure		404	{string}	Not	Found
+//	@Router			/api/v1/conversations/{id}/participants [post]
+func (h *Handler) AddParticipant(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	id, ok := h.getConversationID(rw, req)
+	if !ok {
+		return
+	}
+
+	var addReq request.AddConversationParticipantRequest
+
+	if err = render.DecodeJSON(req.Body, &addReq); err != nil {
+		logMsg := fmt.Sprintf("error occurred decoding AddConversationParticipantRequest struct: %s", err)
+		respMsg := fmt.Sprintf("invalid participant provided: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	if err = addReq.Validate(h.validator); err != nil {
+		logMsg := fmt.Sprintf("error occurred validating AddConversationParticipantRequest struct: %s", err)
+		respMsg := fmt.Sprintf("invalid participant provided: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	if err = h.ConversationService.AddParticipant(req.Context(), id, username, addReq.Username); err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// RemoveParticipant godoc
+//
+//	@Summary		Remove conversation participant
+//	@Description	Remove user from group conversation. Only creator of conversation can remove other participants
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Conversation
+//	@Param			id			path	int		true	"conversation id"
+//	@Param			username	path	string	true	"participant username"
+//	@Success		204
+//	@Failure		400	{string}	invalid	conversation	id	provided
+//	@Failure		401	{string}	Unauthorized
+//	@Failure		403	{string}	Forbidden
+//	@Failure		404	{string}	Not	Found
+//	@Router			/api/v1/conversations/{id}/participants/{username} [delete]
+func (h *Handler) RemoveParticipant(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	id, ok := h.getConversationID(rw, req)
+	if !ok {
+		return
+	}
+
+	participant := chi.URLParam(req, "username")
+
+	if err = h.ConversationService.RemoveParticipant(req.Context(), id, username, participant); err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// LeaveConversation godoc
+//
+//	@Summary		Leave conversation
+//	@Description	Stop being a participant of group conversation
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Conversation
+//	@Param			id	path	int	true	"conversation id"
+//	@Success		204
+//	@Failure		400	{string}	invalid	conversation	id	provided
+//	@Failure		401	{string}	Unauthorized
+//	@Failure		403	{string}	Forbidden
+//	@Failure		404	{string}	Not	Found
+//	@Router			/api/v1/conversations/{id}/leave [post]
+func (h *Handler) LeaveConversation(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	id, ok := h.getConversationID(rw, req)
+	if !ok {
+		return
+	}
+
+	if err = h.ConversationService.LeaveConversation(req.Context(), id, username); err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// GetConversationMessages godoc
+//
+//	@Summary		Get conversation messages
+//	@Description	Get messages of group conversation. Only participants can read them
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Conversation
+//	@Produce		json
+//	@Param			id		path		int	true	"conversation id"
+//	@Param			offset	query		int	true	"Offset"
+//	@Param			limit	query		int	true	"Limit"
+//	@Success		200		{object}	[]response.GetConversationMessageResponse
+//	@Failure		400		{string}	invalid	conversation	id	provided
+//	@Failure		401		{string}	Unauthorized
+//	@Failure		403		{string}	Forbidden
+//	@Failure		404		{string}	Not	Found
+//	@Router			/api/v1/conversations/{id}/messages [get]
+func (h *Handler) GetConversationMessages(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	id, ok := h.getConversationID(rw, req)
+	if !ok {
+		return
+	}
+
+	paginationOpts := handlerinternalutils.GetPaginationOptsFromQuery(req, handler.DefaultOffset, handler.DefaultLimit)
+
+	if err = paginationOpts.Validate(h.validator); err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, "", err.Error())
+
+		return
+	}
+
+	messages, err := h.ConversationService.GetConversationMessages(req.Context(), id, username, paginationOpts.Offset, paginationOpts.Limit)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, sliceutils.Map(messages, mapper.MapConversationMessageToResponse))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// SendConversationMessage godoc
+//
+//	@Summary		Send message to conversation
+//	@Description	Send message to group conversation. Only participants can post
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Conversation
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int										true	"conversation id"
+//	@Param			input	body		request.SendConversationMessageRequest	true	"message schema"
+//	@Success		201		{object}	response.GetConversationMessageResponse
+//	@Failure		400		{string}	invalid	message	provided
+//	@Failure		401		{string}	Unauthorized
+//	@Failure		403		{string}	Forbidden
+//	@Failure		404		{string}	Not	Found
+//	@Router			/api/v1/conversations/{id}/messages [post]
+func (h *Handler) SendConversationMessage(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	id, ok := h.getConversationID(rw, req)
+	if !ok {
+		return
+	}
+
+	var msgReq request.SendConversationMessageRequest
+
+	if err = render.DecodeJSON(req.Body, &msgReq); err != nil {
+		logMsg := fmt.Sprintf("error occurred decoding SendConversationMessageRequest struct: %s", err)
+		respMsg := fmt.Sprintf("invalid message provided: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	if err = msgReq.Validate(h.validator); err != nil {
+		logMsg := fmt.Sprintf("error occurred validating SendConversationMessageRequest struct: %s", err)
+		respMsg := fmt.Sprintf("invalid message provided: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	message, err := h.ConversationService.SendConversationMessage(req.Context(), mapper.MapSendConversationMessageRequestToEntity(msgReq, id, username))
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	rw.WriteHeader(http.StatusCreated)
+	render.JSON(rw, req, mapper.MapConversationMessageToResponse(message))
+}
@@ -0,0 +1,36 @@
+package mapper
+
+import (
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/request"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/response"
+)
+
+func MapChannelToResponse(channel *entity.Channel) response.GetChannelResponse {
+	resp := response.GetChannelResponse{
+		ID:          channel.ID,
+		Name:        channel.Name,
+		Description: channel.Description,
+		CreatedAt:   channel.CreatedAt,
+	}
+
+	if channel.CreatedBy != nil {
+		resp.CreatedBy = *channel.CreatedBy
+	}
+
+	return resp
+}
+
+func MapChannelMemberToResponse(member *entity.ChannelMember) response.GetChannelMemberResponse {
+	return response.GetChannelMemberResponse{
+		Username: member.Username,
+		JoinedAt: member.JoinedAt,
+	}
+}
+
+func MapCreateChannelRequestToEntity(req request.CreateChannelRequest) entity.Channel {
+	return entity.Channel{
+		Name:        req.Name,
+		Description: req.Description,
+	}
+}
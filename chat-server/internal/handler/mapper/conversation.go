@@ -0,0 +1,58 @@
+package mapper
+
+import (
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/request"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/response"
+)
+
+func MapConversationToResponse(conversation *entity.Conversation, participants []string) response.GetConversationResponse {
+	resp := response.GetConversationResponse{
+		ID:           conversation.ID,
+		Title:        conversation.Title,
+		CreatedAt:    conversation.CreatedAt,
+		Participants: participants,
+	}
+
+	if conversation.CreatedBy != nil {
+		resp.CreatedBy = *conversation.CreatedBy
+	}
+
+	return resp
+}
+
+func MapConversationMessageToResponse(msg *entity.ConversationMessage) response.GetConversationMessageResponse {
+	return response.GetConversationMessageResponse{
+		ID:             msg.ID,
+		ConversationID: msg.ConversationID,
+		FromUsername:   msg.FromUsername,
+		Content:        msg.Content,
+		SentAt:         msg.SentAt,
+		EditedAt:       msg.EditedAt,
+	}
+}
+
+func MapConversationSummaryToResponse(summary *entity.ConversationSummary) response.GetConversationSummaryResponse {
+	return response.GetConversationSummaryResponse{
+		Kind:           summary.Kind,
+		ConversationID: summary.ConversationID,
+		Counterpart:    summary.Counterpart,
+		Title:          summary.Title,
+		Participants:   summary.Participants,
+		LastMessageAt:  summary.LastMessageAt,
+	}
+}
+
+func MapCreateConversationRequestToEntity(req request.CreateConversationRequest) entity.Conversation {
+	return entity.Conversation{
+		Title: req.Title,
+	}
+}
+
+func MapSendConversationMessageRequestToEntity(req request.SendConversationMessageRequest, conversationID int, fromUsername string) entity.ConversationMessage {
+	return entity.ConversationMessage{
+		ConversationID: conversationID,
+		FromUsername:   fromUsername,
+		Content:        req.Content,
+	}
+}
@@ -0,0 +1,33 @@
+package mapper
+
+import (
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/response"
+)
+
+func MapMentionSpanToResponse(span entity.MentionSpan) response.MentionSpanResponse {
+	return response.MentionSpanResponse{
+		Username: span.Username,
+		Offset:   span.Offset,
+		Length:   span.Length,
+	}
+}
+
+func MapMentionToResponse(mention *entity.Mention) response.GetMentionResponse {
+	resp := response.GetMentionResponse{
+		ID:           mention.ID,
+		MessageKind:  string(mention.MessageKind),
+		MessageID:    mention.MessageID,
+		FromUsername: mention.FromUsername,
+		Content:      mention.Content,
+		MentionedAt:  mention.CreatedAt,
+		Read:         mention.IsRead(),
+		ReadAt:       mention.ReadAt,
+	}
+
+	if mention.MessageDeleted {
+		resp.Content = DeletedMessageContent
+	}
+
+	return resp
+}
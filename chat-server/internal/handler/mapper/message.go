@@ -4,25 +4,85 @@ import (
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/request"
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/response"
+
+	sliceutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/slice"
 )
 
+const DeletedMessageContent = "message deleted"
+
+func MapReactionSummaryToResponse(summary entity.ReactionSummary) response.GetReactionResponse {
+	return response.GetReactionResponse{
+		Emoji:   summary.Emoji,
+		Count:   summary.Count,
+		Reacted: summary.Reacted,
+	}
+}
+
+func MapAttachmentToResponse(attachment entity.Attachment) response.GetAttachmentResponse {
+	return response.GetAttachmentResponse{
+		ID:          attachment.ID,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+	}
+}
+
+func mapAttachmentIDsToEntities(ids []int) []entity.Attachment {
+	return sliceutils.Map(ids, func(id int) entity.Attachment {
+		return entity.Attachment{ID: id}
+	})
+}
+
 func MapPublicMessageToResponse(msg *entity.PublicMessage) response.GetPublicMessageResponse {
-	return response.GetPublicMessageResponse{
+	resp := response.GetPublicMessageResponse{
+		ID:           msg.ID,
+		ChannelID:    msg.ChannelID,
 		FromUsername: msg.FromUsername,
 		Content:      msg.Content,
 		SentAt:       msg.SentAt,
 		EditedAt:     msg.EditedAt,
+		Deleted:      msg.IsDeleted(),
+		ParentID:     msg.ParentID,
+		ReplyCount:   msg.ReplyCount,
+		Reactions:    sliceutils.Map(msg.Reactions, MapReactionSummaryToResponse),
+		Attachments:  sliceutils.Map(msg.Attachments, MapAttachmentToResponse),
+		Mentions:     sliceutils.Map(msg.Mentions, MapMentionSpanToResponse),
 	}
+
+	if msg.AuthorProfile != nil {
+		resp.AuthorDisplayName = msg.AuthorProfile.DisplayName
+		resp.AuthorAvatarURL = AvatarURL(msg.AuthorProfile)
+	}
+
+	if resp.Deleted {
+		resp.Content = DeletedMessageContent
+	}
+
+	return resp
 }
 
 func MapPrivateMessageToResponse(msg *entity.PrivateMessage) response.GetPrivateMessageResponse {
-	return response.GetPrivateMessageResponse{
+	resp := response.GetPrivateMessageResponse{
+		ID:           msg.ID,
 		FromUsername: msg.FromUsername,
 		ToUsername:   msg.ToUsername,
 		Content:      msg.Content,
 		SentAt:       msg.SentAt,
 		EditedAt:     msg.EditedAt,
+		Deleted:      msg.IsDeleted(),
+		ParentID:     msg.ParentID,
+		SeenAt:       msg.SeenAt,
+		ReplyCount:   msg.ReplyCount,
+		Reactions:    sliceutils.Map(msg.Reactions, MapReactionSummaryToResponse),
+		Attachments:  sliceutils.Map(msg.Attachments, MapAttachmentToResponse),
+		Mentions:     sliceutils.Map(msg.Mentions, MapMentionSpanToResponse),
+	}
+
+	if resp.Deleted {
+		resp.Content = DeletedMessageContent
 	}
+
+	return resp
 }
 
 func MapSendPrivateMessageRequestToEntity(req request.SendPrivateMessageRequest, fromUsername string) entity.PrivateMessage {
@@ -30,6 +90,8 @@ func MapSendPrivateMessageRequestToEntity(req request.SendPrivateMessageRequest,
 		FromUsername: fromUsername,
 		ToUsername:   req.ToUsername,
 		Content:      req.Content,
+		ParentID:     req.ParentID,
+		Attachments:  mapAttachmentIDsToEntities(req.AttachmentIDs),
 	}
 }
 
@@ -37,5 +99,67 @@ func MapSendPublicMessageRequestToEntity(req request.SendPublicMessageRequest, f
 	return entity.PublicMessage{
 		FromUsername: fromUsername,
 		Content:      req.Content,
+		ParentID:     req.ParentID,
+		Attachments:  mapAttachmentIDsToEntities(req.AttachmentIDs),
+	}
+}
+
+func MapReadMarkerToResponse(marker *entity.ReadMarker) response.GetReadMarkerResponse {
+	return response.GetReadMarkerResponse{
+		Username:    marker.Username,
+		Counterpart: marker.Counterpart,
+		LastReadID:  marker.LastReadID,
+		ReadAt:      marker.ReadAt,
 	}
 }
+
+func MapMessageRevisionToResponse(revision *entity.MessageRevision) response.GetMessageRevisionResponse {
+	return response.GetMessageRevisionResponse{
+		Content:  revision.Content,
+		EditedAt: revision.EditedAt,
+	}
+}
+
+func MapPublicMessageThreadToResponse(root *entity.PublicMessage, replies []*entity.PublicMessage) response.GetPublicMessageThreadResponse {
+	return response.GetPublicMessageThreadResponse{
+		Root:    MapPublicMessageToResponse(root),
+		Replies: sliceutils.Map(replies, MapPublicMessageToResponse),
+	}
+}
+
+func MapPrivateMessageThreadToResponse(root *entity.PrivateMessage, replies []*entity.PrivateMessage) response.GetPrivateMessageThreadResponse {
+	return response.GetPrivateMessageThreadResponse{
+		Root:    MapPrivateMessageToResponse(root),
+		Replies: sliceutils.Map(replies, MapPrivateMessageToResponse),
+	}
+}
+
+func MapSearchMessagesRequestToQuery(req request.SearchMessagesRequest) entity.MessageSearchQuery {
+	return entity.MessageSearchQuery{
+		Text:        req.Query,
+		Kind:        entity.MessageKind(req.Kind),
+		Author:      req.Author,
+		Counterpart: req.Counterpart,
+		SentAfter:   req.From,
+		SentBefore:  req.To,
+	}
+}
+
+func MapSearchHitToResponse(hit *entity.SearchHit) response.GetSearchHitResponse {
+	resp := response.GetSearchHitResponse{
+		Kind:    string(hit.Kind),
+		Snippet: hit.Snippet,
+	}
+
+	if hit.Public != nil {
+		msg := MapPublicMessageToResponse(hit.Public)
+		resp.PublicMessage = &msg
+	}
+
+	if hit.Private != nil {
+		msg := MapPrivateMessageToResponse(hit.Private)
+		resp.PrivateMessage = &msg
+	}
+
+	return resp
+}
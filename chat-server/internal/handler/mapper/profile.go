@@ -0,0 +1,41 @@
+package mapper
+
+import (
+	"net/url"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/request"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/response"
+)
+
+// AvatarURL returns path avatar of user is served at, it is empty if user has no avatar.
+func AvatarURL(profile *entity.Profile) string {
+	if profile == nil || !profile.HasAvatar() {
+		return ""
+	}
+
+	return handler.APIPrefix + "/users/" + url.PathEscape(profile.Username) + "/avatar"
+}
+
+func MapProfileToResponse(profile *entity.Profile) response.GetProfileResponse {
+	return response.GetProfileResponse{
+		Username:        profile.Username,
+		DisplayName:     profile.DisplayName,
+		Bio:             profile.Bio,
+		AvatarURL:       AvatarURL(profile),
+		TimeZone:        profile.TimeZone,
+		StatusText:      profile.StatusText,
+		StatusExpiresAt: profile.StatusExpiresAt,
+	}
+}
+
+func MapUpdateUserProfileRequestToEntity(updateReq *request.UpdateUserProfileRequest) entity.ProfileUpdate {
+	return entity.ProfileUpdate{
+		DisplayName:     updateReq.DisplayName,
+		Bio:             updateReq.Bio,
+		TimeZone:        updateReq.TimeZone,
+		StatusText:      updateReq.StatusText,
+		StatusExpiresAt: updateReq.StatusExpiresAt,
+	}
+}
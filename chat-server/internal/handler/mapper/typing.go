@@ -0,0 +1,28 @@
+package mapper
+
+import (
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/request"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/response"
+)
+
+func MapTypingRequestToEntity(req request.TypingRequest, username string) entity.Typing {
+	return entity.Typing{
+		Username:       username,
+		Target:         entity.TypingTarget(req.Target),
+		ChannelID:      req.ChannelID,
+		ToUsername:     req.ToUsername,
+		ConversationID: req.ConversationID,
+	}
+}
+
+func MapTypingToEvent(typing *entity.Typing) response.TypingEvent {
+	return response.TypingEvent{
+		Username:       typing.Username,
+		Target:         string(typing.Target),
+		ChannelID:      typing.ChannelID,
+		ToUsername:     typing.ToUsername,
+		ConversationID: typing.ConversationID,
+		ExpiresAt:      typing.ExpiresAt,
+	}
+}
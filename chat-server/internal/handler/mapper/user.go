@@ -11,11 +11,19 @@ func MapUserToUserResponse(user *entity.User) response.GetUserResponse {
 		ID:        user.ID,
 		Username:  user.Username,
 		Email:     user.Email,
+		Role:      string(user.Role),
 		CreatedAt: user.CreatedAt,
 		UpdatedAt: user.UpdatedAt,
 	}
 }
 
+func MapUserToMessageSenderResponse(user *entity.User, unreadCount int) response.GetMessageSenderResponse {
+	return response.GetMessageSenderResponse{
+		GetUserResponse: MapUserToUserResponse(user),
+		UnreadCount:     unreadCount,
+	}
+}
+
 func MapRegisterRequestToUserEntity(registerReq *request.RegisterRequest) entity.User {
 	return entity.User{
 		Email:          registerReq.Email,
@@ -23,3 +31,33 @@ func MapRegisterRequestToUserEntity(registerReq *request.RegisterRequest) entity
 		HashedPassword: registerReq.Password,
 	}
 }
+
+func MapUpdateProfileRequestToUserEntity(updateReq *request.UpdateProfileRequest) entity.User {
+	return entity.User{
+		Email:    updateReq.Email,
+		Username: updateReq.Username,
+	}
+}
+
+func MapPresenceToResponse(presence *entity.Presence) response.GetPresenceResponse {
+	return response.GetPresenceResponse{
+		Username:   presence.Username,
+		Status:     string(presence.Status),
+		LastSeenAt: presence.LastSeenAt,
+	}
+}
+
+func MapUserToUserWithPresenceResponse(user *entity.User, presence *entity.Presence) response.GetUserWithPresenceResponse {
+	return response.GetUserWithPresenceResponse{
+		GetUserResponse: MapUserToUserResponse(user),
+		Status:          string(presence.Status),
+		LastSeenAt:      presence.LastSeenAt,
+	}
+}
+
+func MapBlockToResponse(block *entity.Block) response.GetBlockResponse {
+	return response.GetBlockResponse{
+		Username:  block.BlockedUsername,
+		BlockedAt: block.CreatedAt,
+	}
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler"
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/mapper"
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/request"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
 
 	messageservice "github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message"
 
@@ -27,6 +28,13 @@ type MessageService interface {
 	SendPrivateMessage(ctx context.Context, msg entity.PrivateMessage) (*entity.PrivateMessage, error)
 	GetAllPrivateMessages(ctx context.Context, toUsername string, offset, limit int) []*entity.PrivateMessage
 	GetAllPrivateMessagesFromUser(ctx context.Context, toUsername, fromUsername string, offset, limit int) ([]*entity.PrivateMessage, error)
+	EditPrivateMessage(ctx context.Context, id int, editorUsername, content string) (*entity.PrivateMessage, error)
+	DeletePrivateMessage(ctx context.Context, id int, username string) (*entity.PrivateMessage, error)
+	GetPrivateMessageRevisions(ctx context.Context, id int, username string, role entity.Role) ([]*entity.MessageRevision, error)
+	GetPrivateMessageThread(ctx context.Context, id int, username string, offset, limit int) (*entity.PrivateMessage, []*entity.PrivateMessage, error)
+	AddPrivateMessageReaction(ctx context.Context, id int, username, emoji string) (*entity.PrivateMessage, error)
+	RemovePrivateMessageReaction(ctx context.Context, id int, username, emoji string) (*entity.PrivateMessage, error)
+	MarkPrivateMessagesRead(ctx context.Context, id int, username string) (*entity.ReadMarker, error)
 }
 
 type UserService interface {
@@ -75,6 +83,15 @@ func (h *Handler) Routes() *chi.Mux {
 		r.Post("/", h.SendPrivateMessage)
 
 		r.Get("/user", h.GetAllPrivateMessagesFromUser)
+
+		r.Patch("/{id}", h.EditPrivateMessage)
+		r.Delete("/{id}", h.DeletePrivateMessage)
+		r.Get("/{id}/revisions", h.GetPrivateMessageRevisions)
+		r.Get("/{id}/thread", h.GetPrivateMessageThread)
+		r.Post("/{id}/read", h.MarkPrivateMessagesRead)
+
+		r.Post("/{id}/reactions", h.AddPrivateMessageReaction)
+		r.Delete("/{id}/reactions/{emoji}", h.RemovePrivateMessageReaction)
 	})
 
 	return router
@@ -87,6 +104,22 @@ func switchByErrorAndWriteResponse(err error, rw http.ResponseWriter, logger *lo
 
 		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusBadRequest, errMsg, errMsg)
 
+	case errors.Is(err, messageservice.ErrInvalidParentMessage), errors.Is(err, messageservice.ErrInvalidAttachment):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusBadRequest, "", err.Error())
+
+	case errors.Is(err, repository.ErrNoSuchPrivateMessage), errors.Is(err, repository.ErrNoSuchReaction):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusNotFound, "", err.Error())
+
+	case errors.Is(err, messageservice.ErrNotMessageAuthor), errors.Is(err, messageservice.ErrNotMessageParticipant),
+		errors.Is(err, messageservice.ErrSenderBlocked):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusForbidden, "", err.Error())
+
+	case errors.Is(err, repository.ErrReactionExists):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusConflict, "", err.Error())
+
+	case errors.Is(err, messageservice.ErrMessageDeleted):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusGone, "", err.Error())
+
 	default:
 		errMsg := fmt.Sprintf("error occurred saving private message: %s", err)
 
@@ -97,7 +130,7 @@ func switchByErrorAndWriteResponse(err error, rw http.ResponseWriter, logger *lo
 // SendPrivateMessage godoc
 //
 //	@Summary		Send private message to user
-//	@Description	Send private message to user
+//	@Description	Send private message to user, it is forbidden if receiver has blocked current user
 //	@Security		BasicAuth
 //	@Security		JWT
 //	@Tags			Message
@@ -107,6 +140,9 @@ func switchByErrorAndWriteResponse(err error, rw http.ResponseWriter, logger *lo
 //	@Success		200		{object}	[]response.GetPrivateMessageResponse
 //	@Failure		401		{string}	Unauthorized
 //	@Failure		400		{string}	invalid		message	provided
+//	@Failure		403		{string}	Forbidden
+//	@Failure		404		{string}	Not			Found
+//	@Failure		410		{string}	Gone
 //	@Failure		500		{string}	internal	error
 //	@Router			/api/v1/messages/private [post]
 func (h *Handler) SendPrivateMessage(rw http.ResponseWriter, req *http.Request) {
@@ -139,6 +175,7 @@ func (h *Handler) SendPrivateMessage(rw http.ResponseWriter, req *http.Request)
 	message, err := h.MessageService.SendPrivateMessage(req.Context(), mapper.MapSendPrivateMessageRequestToEntity(privMsgReq, username))
 	if err != nil {
 		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
 	}
 
 	render.JSON(rw, req, mapper.MapPrivateMessageToResponse(message))
@@ -229,3 +266,358 @@ func (h *Handler) GetAllPrivateMessagesFromUser(rw http.ResponseWriter, req *htt
 	render.JSON(rw, req, sliceutils.Map(messages, mapper.MapPrivateMessageToResponse))
 	rw.WriteHeader(http.StatusOK)
 }
+
+// EditPrivateMessage godoc
+//
+//	@Summary		Edit private message
+//	@Description	Edit content of private message. Only author can edit message, previous content is kept in revision history
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Message
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int							true	"message id"
+//	@Param			input	body		request.EditMessageRequest	true	"edited message schema"
+//	@Success		200		{object}	response.GetPrivateMessageResponse
+//	@Failure		400		{string}	invalid		message	provided
+//	@Failure		401		{string}	Unauthorized
+//	@Failure		403		{string}	Forbidden
+//	@Failure		404		{string}	Not			Found
+//	@Failure		410		{string}	Gone
+//	@Failure		500		{string}	internal	error
+//	@Router			/api/v1/messages/private/{id} [patch]
+func (h *Handler) EditPrivateMessage(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	id, err := handlerutils.GetIntParamFromURL(req, "id")
+	if err != nil {
+		msg := fmt.Sprintf("invalid message id provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, msg, msg)
+		return
+	}
+
+	var editReq request.EditMessageRequest
+
+	if err = render.DecodeJSON(req.Body, &editReq); err != nil {
+		logMsg := fmt.Sprintf("error occurred decoding request body to EditMessageRequest struct: %v", err)
+		respMsg := fmt.Sprintf("invalid message provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	if err = editReq.Validate(h.validator); err != nil {
+		logMsg := fmt.Sprintf("error occurred validating EditMessageRequest struct: %v", err)
+		respMsg := fmt.Sprintf("invalid message provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	message, err := h.MessageService.EditPrivateMessage(req.Context(), id, username, editReq.Content)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, mapper.MapPrivateMessageToResponse(message))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// DeletePrivateMessage godoc
+//
+//	@Summary		Delete private message
+//	@Description	Delete private message. Only author can delete message, tombstone is left in place of it so pagination stays stable
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Message
+//	@Produce		json
+//	@Param			id	path		int	true	"message id"
+//	@Success		200	{object}	response.GetPrivateMessageResponse
+//	@Failure		400	{string}	invalid	message	id	provided
+//	@Failure		401	{string}	Unauthorized
+//	@Failure		403	{string}	Forbidden
+//	@Failure		404	{string}	Not	Found
+//	@Router			/api/v1/messages/private/{id} [delete]
+func (h *Handler) DeletePrivateMessage(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	id, err := handlerutils.GetIntParamFromURL(req, "id")
+	if err != nil {
+		msg := fmt.Sprintf("invalid message id provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, msg, msg)
+		return
+	}
+
+	message, err := h.MessageService.DeletePrivateMessage(req.Context(), id, username)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, mapper.MapPrivateMessageToResponse(message))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// GetPrivateMessageRevisions godoc
+//
+//	@Summary		Get private message revisions
+//	@Description	Get previous versions of edited private message, from oldest to newest. Available only to sender and receiver.
+//	@Description	Users with private_messages:revisions permission (admins) can read history of any message, including deleted ones
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Message
+//	@Produce		json
+//	@Param			id	path		int	true	"message id"
+//	@Success		200	{object}	[]response.GetMessageRevisionResponse
+//	@Failure		400	{string}	invalid	message	id	provided
+//	@Failure		401	{string}	Unauthorized
+//	@Failure		403	{string}	Forbidden
+//	@Failure		404	{string}	Not	Found
+//	@Failure		410	{string}	Gone
+//	@Router			/api/v1/messages/private/{id}/revisions [get]
+func (h *Handler) GetPrivateMessageRevisions(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	role, err := handlerutils.GetStringHeaderByKey(req, "role")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	id, err := handlerutils.GetIntParamFromURL(req, "id")
+	if err != nil {
+		msg := fmt.Sprintf("invalid message id provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, msg, msg)
+		return
+	}
+
+	revisions, err := h.MessageService.GetPrivateMessageRevisions(req.Context(), id, username, entity.Role(role))
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, sliceutils.Map(revisions, mapper.MapMessageRevisionToResponse))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// GetPrivateMessageThread godoc
+//
+//	@Summary		Get private message thread
+//	@Description	Get root of the thread that message belongs to with its paginated replies. Only participants of the message can view thread
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Message
+//	@Produce		json
+//	@Param			id		path		int	true	"message id"
+//	@Param			offset	query		int	true	"Offset"
+//	@Param			limit	query		int	true	"Limit"
+//	@Success		200		{object}	response.GetPrivateMessageThreadResponse
+//	@Failure		400		{string}	invalid	message	id	provided
+//	@Failure		401		{string}	Unauthorized
+//	@Failure		403		{string}	Forbidden
+//	@Failure		404		{string}	Not	Found
+//	@Router			/api/v1/messages/private/{id}/thread [get]
+func (h *Handler) GetPrivateMessageThread(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	id, err := handlerutils.GetIntParamFromURL(req, "id")
+	if err != nil {
+		msg := fmt.Sprintf("invalid message id provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, msg, msg)
+		return
+	}
+
+	paginationOpts := handlerinternalutils.GetPaginationOptsFromQuery(req, handler.DefaultOffset, handler.DefaultLimit)
+
+	if err = paginationOpts.Validate(h.validator); err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, "", err.Error())
+		return
+	}
+
+	root, replies, err := h.MessageService.GetPrivateMessageThread(req.Context(), id, username, paginationOpts.Offset, paginationOpts.Limit)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, mapper.MapPrivateMessageThreadToResponse(root, replies))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// AddPrivateMessageReaction godoc
+//
+//	@Summary		React to private message
+//	@Description	Add emoji reaction to private message. Only participants of message can react, each emoji only once
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Message
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int								true	"message id"
+//	@Param			input	body		request.ReactToMessageRequest	true	"reaction schema"
+//	@Success		200		{object}	response.GetPrivateMessageResponse
+//	@Failure		400		{string}	invalid	reaction	provided
+//	@Failure		401		{string}	Unauthorized
+//	@Failure		403		{string}	Forbidden
+//	@Failure		404		{string}	Not	Found
+//	@Failure		409		{string}	Conflict
+//	@Failure		410		{string}	Gone
+//	@Router			/api/v1/messages/private/{id}/reactions [post]
+func (h *Handler) AddPrivateMessageReaction(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	id, err := handlerutils.GetIntParamFromURL(req, "id")
+	if err != nil {
+		msg := fmt.Sprintf("invalid message id provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, msg, msg)
+		return
+	}
+
+	var reactReq request.ReactToMessageRequest
+
+	if err = render.DecodeJSON(req.Body, &reactReq); err != nil {
+		logMsg := fmt.Sprintf("error occurred decoding request body to ReactToMessageRequest struct: %v", err)
+		respMsg := fmt.Sprintf("invalid reaction provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	if err = reactReq.Validate(h.validator); err != nil {
+		logMsg := fmt.Sprintf("error occurred validating ReactToMessageRequest struct: %v", err)
+		respMsg := fmt.Sprintf("invalid reaction provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	message, err := h.MessageService.AddPrivateMessageReaction(req.Context(), id, username, reactReq.Emoji)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, mapper.MapPrivateMessageToResponse(message))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// RemovePrivateMessageReaction godoc
+//
+//	@Summary		Remove reaction from private message
+//	@Description	Remove emoji reaction of user from private message
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Message
+//	@Produce		json
+//	@Param			id		path		int		true	"message id"
+//	@Param			emoji	path		string	true	"url encoded emoji"
+//	@Success		200		{object}	response.GetPrivateMessageResponse
+//	@Failure		400		{string}	invalid	message	id	provided
+//	@Failure		401		{string}	Unauthorized
+//	@Failure		403		{string}	Forbidden
+//	@Failure		404		{string}	Not	Found
+//	@Failure		410		{string}	Gone
+//	@Router			/api/v1/messages/private/{id}/reactions/{emoji} [delete]
+func (h *Handler) RemovePrivateMessageReaction(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	id, err := handlerutils.GetIntParamFromURL(req, "id")
+	if err != nil {
+		msg := fmt.Sprintf("invalid message id provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, msg, msg)
+		return
+	}
+
+	emoji, err := handlerutils.GetStringParamFromURL(req, "emoji")
+	if err != nil {
+		msg := fmt.Sprintf("invalid emoji provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, msg, msg)
+		return
+	}
+
+	message, err := h.MessageService.RemovePrivateMessageReaction(req.Context(), id, username, emoji)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, mapper.MapPrivateMessageToResponse(message))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// MarkPrivateMessagesRead godoc
+//
+//	@Summary		Mark private messages read
+//	@Description	Mark messages of conversation that message belongs to as read up to this message. Read marker never moves backwards, counterpart sees when messages were seen
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Message
+//	@Produce		json
+//	@Param			id	path		int	true	"message id"
+//	@Success		200	{object}	response.GetReadMarkerResponse
+//	@Failure		400	{string}	invalid	message	id	provided
+//	@Failure		401	{string}	Unauthorized
+//	@Failure		403	{string}	Forbidden
+//	@Failure		404	{string}	Not	Found
+//	@Router			/api/v1/messages/private/{id}/read [post]
+func (h *Handler) MarkPrivateMessagesRead(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	id, err := handlerutils.GetIntParamFromURL(req, "id")
+	if err != nil {
+		msg := fmt.Sprintf("invalid message id provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, msg, msg)
+		return
+	}
+
+	marker, err := h.MessageService.MarkPrivateMessagesRead(req.Context(), id, username)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, mapper.MapReadMarkerToResponse(marker))
+	rw.WriteHeader(http.StatusOK)
+}
@@ -3,6 +3,7 @@ package public
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler"
@@ -10,12 +11,16 @@ import (
 	handlerinternalutils "github.com/ew0s/ewos-to-go-hw/chat-server/internal/pkg/utils/handler"
 	"github.com/go-playground/validator/v10"
 	"net/http"
+	"strconv"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 	"github.com/sirupsen/logrus"
 
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/request"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+
+	messageservice "github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message"
 
 	handlerutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/handler"
 	sliceutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/slice"
@@ -23,7 +28,14 @@ import (
 
 type MessageService interface {
 	SendPublicMessage(ctx context.Context, msg entity.PublicMessage) (*entity.PublicMessage, error)
-	GetAllPublicMessages(ctx context.Context, offset, limit int) []*entity.PublicMessage
+	GetAllPublicMessages(ctx context.Context, username string, offset, limit int) []*entity.PublicMessage
+	GetAllPublicMessagesHidingBlocked(ctx context.Context, username string, offset, limit int) ([]*entity.PublicMessage, error)
+	EditPublicMessage(ctx context.Context, id int, editorUsername, content string) (*entity.PublicMessage, error)
+	DeletePublicMessage(ctx context.Context, id int, username string) (*entity.PublicMessage, error)
+	GetPublicMessageRevisions(ctx context.Context, id int, role entity.Role) ([]*entity.MessageRevision, error)
+	GetPublicMessageThread(ctx context.Context, id int, username string, offset, limit int) (*entity.PublicMessage, []*entity.PublicMessage, error)
+	AddPublicMessageReaction(ctx context.Context, id int, username, emoji string) (*entity.PublicMessage, error)
+	RemovePublicMessageReaction(ctx context.Context, id int, username, emoji string) (*entity.PublicMessage, error)
 }
 
 type UserService interface {
@@ -71,11 +83,43 @@ func (h *Handler) Routes() *chi.Mux {
 
 		r.Get("/", h.GetAllPublicMessages)
 		r.Post("/", h.SendPublicMessage)
+
+		r.Patch("/{id}", h.EditPublicMessage)
+		r.Delete("/{id}", h.DeletePublicMessage)
+		r.Get("/{id}/revisions", h.GetPublicMessageRevisions)
+		r.Get("/{id}/thread", h.GetPublicMessageThread)
+
+		r.Post("/{id}/reactions", h.AddPublicMessageReaction)
+		r.Delete("/{id}/reactions/{emoji}", h.RemovePublicMessageReaction)
 	})
 
 	return router
 }
 
+func switchByErrorAndWriteResponse(err error, rw http.ResponseWriter, logger *logrus.Logger) {
+	switch {
+	case errors.Is(err, messageservice.ErrInvalidParentMessage), errors.Is(err, messageservice.ErrInvalidAttachment):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusBadRequest, "", err.Error())
+
+	case errors.Is(err, repository.ErrNoSuchPublicMessage), errors.Is(err, repository.ErrNoSuchReaction):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusNotFound, "", err.Error())
+
+	case errors.Is(err, messageservice.ErrNotMessageAuthor):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusForbidden, "", err.Error())
+
+	case errors.Is(err, repository.ErrReactionExists):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusConflict, "", err.Error())
+
+	case errors.Is(err, messageservice.ErrMessageDeleted):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusGone, "", err.Error())
+
+	default:
+		errMsg := fmt.Sprintf("error occurred processing public message: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusInternalServerError, errMsg, "")
+	}
+}
+
 // GetAllPublicMessages godoc
 //
 //	@Summary		Get all public messages
@@ -84,21 +128,51 @@ func (h *Handler) Routes() *chi.Mux {
 //	@Security		JWT
 //	@Tags			Message
 //	@Produce		json
-//	@Param			offset	query		int	true	"Offset"
-//	@Param			limit	query		int	true	"Limit"
-//	@Success		200		{object}	[]response.GetPublicMessageResponse
-//	@Failure		401		{string}	Unauthorized
+//	@Param			offset			query		int		true	"Offset"
+//	@Param			limit			query		int		true	"Limit"
+//	@Param			hide_blocked	query		bool	false	"Hide messages of users blocked by current user"
+//	@Success		200				{object}	[]response.GetPublicMessageResponse
+//	@Failure		400				{string}	invalid	query	provided
+//	@Failure		401				{string}	Unauthorized
+//	@Failure		500				{string}	internal	error
 //	@Router			/api/v1/messages/public [get]
 func (h *Handler) GetAllPublicMessages(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
 	paginationOpts := handlerinternalutils.GetPaginationOptsFromQuery(req, handler.DefaultOffset, handler.DefaultLimit)
 
-	if err := paginationOpts.Validate(h.validator); err != nil {
+	if err = paginationOpts.Validate(h.validator); err != nil {
 		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, "", err.Error())
 
 		return
 	}
 
-	messages := h.MessageService.GetAllPublicMessages(req.Context(), paginationOpts.Offset, paginationOpts.Limit)
+	hideBlocked := false
+
+	if param, err := handlerutils.GetStringParamFromQuery(req, "hide_blocked"); err == nil {
+		if hideBlocked, err = strconv.ParseBool(param); err != nil {
+			msg := fmt.Sprintf("invalid hide_blocked provided: %v", err)
+
+			handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, "", msg)
+			return
+		}
+	}
+
+	var messages []*entity.PublicMessage
+
+	if hideBlocked {
+		messages, err = h.MessageService.GetAllPublicMessagesHidingBlocked(req.Context(), username, paginationOpts.Offset, paginationOpts.Limit)
+		if err != nil {
+			switchByErrorAndWriteResponse(err, rw, h.logger)
+			return
+		}
+	} else {
+		messages = h.MessageService.GetAllPublicMessages(req.Context(), username, paginationOpts.Offset, paginationOpts.Limit)
+	}
 
 	render.JSON(rw, req, sliceutils.Map(messages, mapper.MapPublicMessageToResponse))
 	rw.WriteHeader(http.StatusOK)
@@ -115,7 +189,10 @@ func (h *Handler) GetAllPublicMessages(rw http.ResponseWriter, req *http.Request
 //	@Produce		json
 //	@Param			input	body		request.SendPublicMessageRequest	true	"public message schema"
 //	@Success		200		{object}	[]response.GetPublicMessageResponse
+//	@Failure		400		{string}	invalid	message	provided
 //	@Failure		401		{string}	Unauthorized
+//	@Failure		404		{string}	Not	Found
+//	@Failure		410		{string}	Gone
 //	@Failure		500		{string}	internal	error
 //	@Router			/api/v1/messages/public [post]
 func (h *Handler) SendPublicMessage(rw http.ResponseWriter, req *http.Request) {
@@ -147,13 +224,315 @@ func (h *Handler) SendPublicMessage(rw http.ResponseWriter, req *http.Request) {
 
 	message, err := h.MessageService.SendPublicMessage(req.Context(), mapper.MapSendPublicMessageRequestToEntity(pubMsgReq, username))
 	if err != nil {
-		logMsg := fmt.Sprintf("error occurred saving public message: %s", err)
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
 
-		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusInternalServerError, logMsg, "")
+	render.JSON(rw, req, mapper.MapPublicMessageToResponse(message))
+	rw.WriteHeader(http.StatusCreated)
+}
 
+// EditPublicMessage godoc
+//
+//	@Summary		Edit public message
+//	@Description	Edit content of public message. Only author can edit message, previous content is kept in revision history
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Message
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int							true	"message id"
+//	@Param			input	body		request.EditMessageRequest	true	"edited message schema"
+//	@Success		200		{object}	response.GetPublicMessageResponse
+//	@Failure		400		{string}	invalid		message	provided
+//	@Failure		401		{string}	Unauthorized
+//	@Failure		403		{string}	Forbidden
+//	@Failure		404		{string}	Not			Found
+//	@Failure		410		{string}	Gone
+//	@Failure		500		{string}	internal	error
+//	@Router			/api/v1/messages/public/{id} [patch]
+func (h *Handler) EditPublicMessage(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	id, err := handlerutils.GetIntParamFromURL(req, "id")
+	if err != nil {
+		msg := fmt.Sprintf("invalid message id provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, msg, msg)
+		return
+	}
+
+	var editReq request.EditMessageRequest
+
+	if err = render.DecodeJSON(req.Body, &editReq); err != nil {
+		logMsg := fmt.Sprintf("error occurred decoding request body to EditMessageRequest struct: %s", err)
+		respMsg := fmt.Sprintf("invalid message provided: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	if err = editReq.Validate(h.validator); err != nil {
+		logMsg := fmt.Sprintf("error occurred validating EditMessageRequest struct: %s", err)
+		respMsg := fmt.Sprintf("invalid message provided: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	message, err := h.MessageService.EditPublicMessage(req.Context(), id, username, editReq.Content)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
 		return
 	}
 
 	render.JSON(rw, req, mapper.MapPublicMessageToResponse(message))
-	rw.WriteHeader(http.StatusCreated)
+	rw.WriteHeader(http.StatusOK)
+}
+
+// DeletePublicMessage godoc
+//
+//	@Summary		Delete public message
+//	@Description	Delete public message. Only author can delete message, tombstone is left in place of it so pagination stays stable
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Message
+//	@Produce		json
+//	@Param			id	path		int	true	"message id"
+//	@Success		200	{object}	response.GetPublicMessageResponse
+//	@Failure		400	{string}	invalid	message	id	provided
+//	@Failure		401	{string}	Unauthorized
+//	@Failure		403	{string}	Forbidden
+//	@Failure		404	{string}	Not	Found
+//	@Router			/api/v1/messages/public/{id} [delete]
+func (h *Handler) DeletePublicMessage(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	id, err := handlerutils.GetIntParamFromURL(req, "id")
+	if err != nil {
+		msg := fmt.Sprintf("invalid message id provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, msg, msg)
+		return
+	}
+
+	message, err := h.MessageService.DeletePublicMessage(req.Context(), id, username)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, mapper.MapPublicMessageToResponse(message))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// GetPublicMessageRevisions godoc
+//
+//	@Summary		Get public message revisions
+//	@Description	Get previous versions of edited public message, from oldest to newest.
+//	@Description	Users with messages:revisions permission (moderators and admins) can read history of deleted messages
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Message
+//	@Produce		json
+//	@Param			id	path		int	true	"message id"
+//	@Success		200	{object}	[]response.GetMessageRevisionResponse
+//	@Failure		400	{string}	invalid	message	id	provided
+//	@Failure		401	{string}	Unauthorized
+//	@Failure		404	{string}	Not	Found
+//	@Failure		410	{string}	Gone
+//	@Router			/api/v1/messages/public/{id}/revisions [get]
+func (h *Handler) GetPublicMessageRevisions(rw http.ResponseWriter, req *http.Request) {
+	role, err := handlerutils.GetStringHeaderByKey(req, "role")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	id, err := handlerutils.GetIntParamFromURL(req, "id")
+	if err != nil {
+		msg := fmt.Sprintf("invalid message id provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, msg, msg)
+		return
+	}
+
+	revisions, err := h.MessageService.GetPublicMessageRevisions(req.Context(), id, entity.Role(role))
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, sliceutils.Map(revisions, mapper.MapMessageRevisionToResponse))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// GetPublicMessageThread godoc
+//
+//	@Summary		Get public message thread
+//	@Description	Get root of the thread that message belongs to with its paginated replies
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Message
+//	@Produce		json
+//	@Param			id		path		int	true	"message id"
+//	@Param			offset	query		int	true	"Offset"
+//	@Param			limit	query		int	true	"Limit"
+//	@Success		200		{object}	response.GetPublicMessageThreadResponse
+//	@Failure		400		{string}	invalid	message	id	provided
+//	@Failure		401		{string}	Unauthorized
+//	@Failure		404		{string}	Not	Found
+//	@Router			/api/v1/messages/public/{id}/thread [get]
+func (h *Handler) GetPublicMessageThread(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	id, err := handlerutils.GetIntParamFromURL(req, "id")
+	if err != nil {
+		msg := fmt.Sprintf("invalid message id provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, msg, msg)
+		return
+	}
+
+	paginationOpts := handlerinternalutils.GetPaginationOptsFromQuery(req, handler.DefaultOffset, handler.DefaultLimit)
+
+	if err = paginationOpts.Validate(h.validator); err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, "", err.Error())
+		return
+	}
+
+	root, replies, err := h.MessageService.GetPublicMessageThread(req.Context(), id, username, paginationOpts.Offset, paginationOpts.Limit)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, mapper.MapPublicMessageThreadToResponse(root, replies))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// AddPublicMessageReaction godoc
+//
+//	@Summary		React to public message
+//	@Description	Add emoji reaction to public message. User can add each emoji to message only once
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Message
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int								true	"message id"
+//	@Param			input	body		request.ReactToMessageRequest	true	"reaction schema"
+//	@Success		200		{object}	response.GetPublicMessageResponse
+//	@Failure		400		{string}	invalid	reaction	provided
+//	@Failure		401		{string}	Unauthorized
+//	@Failure		404		{string}	Not	Found
+//	@Failure		409		{string}	Conflict
+//	@Failure		410		{string}	Gone
+//	@Router			/api/v1/messages/public/{id}/reactions [post]
+func (h *Handler) AddPublicMessageReaction(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	id, err := handlerutils.GetIntParamFromURL(req, "id")
+	if err != nil {
+		msg := fmt.Sprintf("invalid message id provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, msg, msg)
+		return
+	}
+
+	var reactReq request.ReactToMessageRequest
+
+	if err = render.DecodeJSON(req.Body, &reactReq); err != nil {
+		logMsg := fmt.Sprintf("error occurred decoding request body to ReactToMessageRequest struct: %s", err)
+		respMsg := fmt.Sprintf("invalid reaction provided: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	if err = reactReq.Validate(h.validator); err != nil {
+		logMsg := fmt.Sprintf("error occurred validating ReactToMessageRequest struct: %s", err)
+		respMsg := fmt.Sprintf("invalid reaction provided: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	message, err := h.MessageService.AddPublicMessageReaction(req.Context(), id, username, reactReq.Emoji)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, mapper.MapPublicMessageToResponse(message))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// RemovePublicMessageReaction godoc
+//
+//	@Summary		Remove reaction from public message
+//	@Description	Remove emoji reaction of user from public message
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Message
+//	@Produce		json
+//	@Param			id		path		int		true	"message id"
+//	@Param			emoji	path		string	true	"url encoded emoji"
+//	@Success		200		{object}	response.GetPublicMessageResponse
+//	@Failure		400		{string}	invalid	message	id	provided
+//	@Failure		401		{string}	Unauthorized
+//	@Failure		404		{string}	Not	Found
+//	@Failure		410		{string}	Gone
+//	@Router			/api/v1/messages/public/{id}/reactions/{emoji} [delete]
+func (h *Handler) RemovePublicMessageReaction(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	id, err := handlerutils.GetIntParamFromURL(req, "id")
+	if err != nil {
+		msg := fmt.Sprintf("invalid message id provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, msg, msg)
+		return
+	}
+
+	emoji, err := handlerutils.GetStringParamFromURL(req, "emoji")
+	if err != nil {
+		msg := fmt.Sprintf("invalid emoji provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, msg, msg)
+		return
+	}
+
+	message, err := h.MessageService.RemovePublicMessageReaction(req.Context(), id, username, emoji)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, mapper.MapPublicMessageToResponse(message))
+	rw.WriteHeader(http.StatusOK)
 }
@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/websocket"
 	"github.com/sirupsen/logrus"
 
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
@@ -21,6 +23,10 @@ type AuthService interface {
 	Login(ctx context.Context, username, password string) (*entity.User, error)
 }
 
+type SessionChecker interface {
+	IsSessionActive(ctx context.Context, sessionID string) (bool, error)
+}
+
 func BasicAuthMiddleware(authService AuthService, logger *logrus.Logger, valid *validator.Validate) Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
@@ -49,25 +55,50 @@ func BasicAuthMiddleware(authService AuthService, logger *logrus.Logger, valid *
 
 			req.Header.Set("id", strconv.Itoa(user.ID))
 			req.Header.Set("username", user.Username)
+			req.Header.Set("role", string(userRole(user.Role)))
+			// requests authorized with password do not belong to any session
+			req.Header.Del("session_id")
 
 			next.ServeHTTP(rw, req)
 		})
 	}
 }
 
-func JWTAuthMiddleware(secret string, logger *logrus.Logger) Handler {
+// userRole falls back to regular user role, so unknown roles never grant any permissions.
+func userRole(role entity.Role) entity.Role {
+	if !role.IsValid() {
+		return entity.RoleUser
+	}
+
+	return role
+}
+
+func getTokenFromRequest(req *http.Request) string {
+	authHeader := req.Header.Get("Authorization")
+	if authHeader != "" {
+		return strings.TrimPrefix(authHeader, "Bearer ")
+	}
+
+	// browsers cannot set headers on websocket handshake, so token may be passed via query
+	if websocket.IsWebSocketUpgrade(req) {
+		return req.URL.Query().Get("access_token")
+	}
+
+	return ""
+}
+
+// JWTAuthMiddleware lets through requests with valid access token of session that was not revoked or expired.
+func JWTAuthMiddleware(secret string, sessionChecker SessionChecker, logger *logrus.Logger) Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
-			authHeader := req.Header.Get("Authorization")
-			if authHeader == "" {
+			token := getTokenFromRequest(req)
+			if token == "" {
 				msg := "authorization header is empty"
 
 				handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusUnauthorized, msg, msg)
 				return
 			}
 
-			token := authHeader[len("Bearer "):] // TODO: JWT ACCESS AND REFRESH TOKEN
-
 			payload, err := jwtutils.ValidateToken(token, secret)
 			if err != nil {
 				msg := fmt.Sprintf("error occurred validating token: %v", err)
@@ -109,8 +140,36 @@ func JWTAuthMiddleware(secret string, logger *logrus.Logger) Handler {
 				return
 			}
 
+			sessionID, ok := payload["sid"].(string)
+			if !ok {
+				msg := "invalid payload: not contains sid"
+
+				handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusUnauthorized, msg, msg)
+				return
+			}
+
+			active, err := sessionChecker.IsSessionActive(req.Context(), sessionID)
+			if err != nil {
+				msg := fmt.Sprintf("error occurred checking session: %v", err)
+
+				handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusInternalServerError, msg, "")
+				return
+			}
+
+			if !active {
+				msg := "session is revoked or expired"
+
+				handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusUnauthorized, msg, msg)
+				return
+			}
+
+			// role is optional for tokens issued before roles were introduced
+			role, _ := payload["role"].(string)
+
 			req.Header.Set("id", strconv.Itoa(int(id)))
 			req.Header.Set("username", username)
+			req.Header.Set("role", string(userRole(entity.Role(role))))
+			req.Header.Set("session_id", sessionID)
 
 			next.ServeHTTP(rw, req)
 		})
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"time"
 
 	myhttp "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/http"
@@ -14,6 +15,31 @@ var (
 	LogEntryCtxKey = "loggerEntry"
 )
 
+// secretQueryParams are query params that must never get to logs.
+var secretQueryParams = []string{"access_token"}
+
+// redactURL hides values of secret query params, like token passed on websocket handshake.
+func redactURL(u *url.URL) string {
+	query := u.Query()
+	redacted := false
+
+	for _, param := range secretQueryParams {
+		if query.Has(param) {
+			query.Set(param, "REDACTED")
+			redacted = true
+		}
+	}
+
+	if !redacted {
+		return u.String()
+	}
+
+	res := *u
+	res.RawQuery = query.Encode()
+
+	return res.String()
+}
+
 func getLoggerFromRequest(req *http.Request) log.Logger {
 	logger, _ := req.Context().Value(LogEntryCtxKey).(log.Logger)
 	return logger
@@ -38,7 +64,7 @@ func LoggingMiddleware(logger log.Logger, level log.Level) Handler {
 				// todo: colorful output?
 
 				msg := fmt.Sprintf("URL: %s\nSatus: %v\nBytes Written: %v\nResponse: %v\nElapsed time: %s\n",
-					req.URL, ww.Status(), ww.BytesWritten(), ww.Response(), time.Since(t1))
+					redactURL(req.URL), ww.Status(), ww.BytesWritten(), ww.Response(), time.Since(t1))
 
 				if ww.Status() >= 400 {
 					logger.Logf(log.ErrorLevel, msg)
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	handlerutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/handler"
+)
+
+// PermissionMiddleware lets through only users whose role grants permission. Must be used after auth middleware.
+func PermissionMiddleware(permission entity.Permission, logger *logrus.Logger) Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			role, err := handlerutils.GetStringHeaderByKey(req, "role")
+			if err != nil {
+				handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusUnauthorized, "", err.Error())
+				return
+			}
+
+			if !entity.Role(role).HasPermission(permission) {
+				msg := fmt.Sprintf("permission %s required", permission)
+
+				handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusForbidden, msg, msg)
+				return
+			}
+
+			next.ServeHTTP(rw, req)
+		})
+	}
+}
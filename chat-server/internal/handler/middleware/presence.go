@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+
+	handlerutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/handler"
+)
+
+type PresenceTracker interface {
+	Touch(ctx context.Context, username string) error
+}
+
+// PresenceMiddleware records activity of authenticated user. Must be used after auth middleware.
+// Failure to record activity never fails request, it is only logged.
+func PresenceMiddleware(tracker PresenceTracker, logger *logrus.Logger) Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			username, err := handlerutils.GetStringHeaderByKey(req, "username")
+			if err == nil {
+				if err = tracker.Touch(req.Context(), username); err != nil {
+					logger.Warnf("error occurred while recording presence of %s: %v", username, err)
+				}
+			}
+
+			next.ServeHTTP(rw, req)
+		})
+	}
+}
@@ -0,0 +1,27 @@
+package realtime
+
+const (
+	EventError          = "error"
+	EventPublicMessage  = "public_message"
+	EventPrivateMessage = "private_message"
+	EventChannelMessage = "channel_message"
+
+	EventConversationMessage = "conversation_message"
+
+	EventPublicMessageEdited  = "public_message_edited"
+	EventPrivateMessageEdited = "private_message_edited"
+	EventChannelMessageEdited = "channel_message_edited"
+
+	EventPublicMessageDeleted  = "public_message_deleted"
+	EventPrivateMessageDeleted = "private_message_deleted"
+	EventChannelMessageDeleted = "channel_message_deleted"
+	EventPublicMessagePurged   = "public_message_purged"
+	EventPrivateMessagePurged  = "private_message_purged"
+	EventChannelMessagePurged  = "channel_message_purged"
+
+	EventPrivateMessagesRead = "private_messages_read"
+
+	EventTyping = "typing"
+
+	EventMention = "mention"
+)
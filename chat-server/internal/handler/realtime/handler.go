@@ -0,0 +1,267 @@
+// nolint
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/gorilla/websocket"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/mapper"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/request"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/pkg/ws"
+
+	messageservice "github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message"
+	typingservice "github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/typing"
+
+	handlerutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/handler"
+)
+
+type PublicMessageService interface {
+	SendPublicMessage(ctx context.Context, msg entity.PublicMessage) (*entity.PublicMessage, error)
+}
+
+type PrivateMessageService interface {
+	SendPrivateMessage(ctx context.Context, msg entity.PrivateMessage) (*entity.PrivateMessage, error)
+}
+
+type TypingService interface {
+	StartTyping(ctx context.Context, typing entity.Typing) error
+}
+
+type PresenceTracker interface {
+	Touch(ctx context.Context, username string) error
+}
+
+type Middleware = func(http.Handler) http.Handler
+
+type Handler struct {
+	Hub                   *ws.Hub
+	PublicMessageService  PublicMessageService
+	PrivateMessageService PrivateMessageService
+	TypingService         TypingService
+	PresenceTracker       PresenceTracker
+	Middlewares           []Middleware
+
+	upgrader  websocket.Upgrader
+	logger    *logrus.Logger
+	validator *validator.Validate
+}
+
+func New(
+	hub *ws.Hub,
+	publicMessageService PublicMessageService,
+	privateMessageService PrivateMessageService,
+	typingService TypingService,
+	presenceTracker PresenceTracker,
+	logger *logrus.Logger,
+	validator *validator.Validate,
+	middlewares ...Middleware,
+) *Handler {
+	return &Handler{
+		Hub:                   hub,
+		PublicMessageService:  publicMessageService,
+		PrivateMessageService: privateMessageService,
+		TypingService:         typingService,
+		PresenceTracker:       presenceTracker,
+		Middlewares:           middlewares,
+		upgrader: websocket.Upgrader{
+			ReadBufferSize:  1024,
+			WriteBufferSize: 1024,
+		},
+		logger:    logger,
+		validator: validator,
+	}
+}
+
+func (h *Handler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+
+	router.Group(func(r chi.Router) {
+		r.Use(h.Middlewares...)
+
+		r.Get("/", h.Connect)
+	})
+
+	return router
+}
+
+// Connect godoc
+//
+//	@Summary		Open websocket connection
+//	@Description	Upgrades connection to websocket. Server pushes events as {"type": ..., "payload": ...} json objects.
+//	@Description	Client may send {"type": "public_message", "payload": {"content": "..."}} to post to public chat
+//	@Description	and {"type": "private_message", "payload": {"to_username": "...", "content": "..."}} to send private message.
+//	@Description	Private messages are pushed only to connections of their sender and receiver.
+//	@Description	Client may send {"type": "typing", "payload": {"target": "public|private|conversation", "channel_id": ..., "to_username": "...", "conversation_id": ...}}
+//	@Description	while user is writing. It is pushed as typing event with expires_at only to users who can see that place and is never stored.
+//	@Description	User stays online while sending messages through connection and away while connection is idle.
+//	@Description	If Authorization header cannot be set (e.g. browser), JWT may be passed via access_token query param.
+//	@Description	Connection is closed once its session is revoked, and once user changes password, username or is deleted.
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Realtime
+//	@Param			access_token	query	string	false	"JWT access token"
+//	@Success		101
+//	@Failure		401	{string}	Unauthorized
+//	@Router			/api/v1/ws [get]
+func (h *Handler) Connect(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	// connections opened with basic auth are not bound to session
+	sessionID, _ := handlerutils.GetStringHeaderByKey(req, "session_id")
+
+	// upgrader writes error response by itself
+	conn, err := h.upgrader.Upgrade(rw, req, nil)
+	if err != nil {
+		h.logger.Errorf("error occurred upgrading connection to websocket: %v", err)
+		return
+	}
+
+	client := ws.NewClient(h.Hub, conn, username, sessionID)
+
+	h.Hub.Register(client)
+
+	go client.WritePump()
+
+	ctx := req.Context()
+
+	client.ReadPump(func(msg []byte) {
+		h.touch(ctx, username)
+		h.handleMessage(ctx, client, msg)
+	})
+
+	// request context may be already canceled when connection is closed
+	h.touch(context.WithoutCancel(ctx), username)
+}
+
+func (h *Handler) touch(ctx context.Context, username string) {
+	if err := h.PresenceTracker.Touch(ctx, username); err != nil {
+		h.logger.Warnf("error occurred while recording presence of %s: %v", username, err)
+	}
+}
+
+func (h *Handler) sendError(client *ws.Client, errMsg string) {
+	msg, err := marshalEvent(EventError, errMsg)
+	if err != nil {
+		h.logger.Errorf("error occurred marshalling error event: %v", err)
+		return
+	}
+
+	client.Send(msg)
+}
+
+func (h *Handler) handleMessage(ctx context.Context, client *ws.Client, raw []byte) {
+	var socketMsg request.SocketMessage
+
+	if err := json.Unmarshal(raw, &socketMsg); err != nil {
+		h.sendError(client, fmt.Sprintf("invalid message provided: %v", err))
+		return
+	}
+
+	if err := socketMsg.Validate(h.validator); err != nil {
+		h.sendError(client, fmt.Sprintf("invalid message provided: %v", err))
+		return
+	}
+
+	switch socketMsg.Type {
+	case EventPublicMessage:
+		h.handlePublicMessage(ctx, client, socketMsg.Payload)
+
+	case EventPrivateMessage:
+		h.handlePrivateMessage(ctx, client, socketMsg.Payload)
+
+	case EventTyping:
+		h.handleTyping(ctx, client, socketMsg.Payload)
+
+	default:
+		h.sendError(client, fmt.Sprintf("unknown message type: %v", socketMsg.Type))
+	}
+}
+
+func (h *Handler) handlePublicMessage(ctx context.Context, client *ws.Client, payload json.RawMessage) {
+	var pubMsgReq request.SendPublicMessageRequest
+
+	if err := json.Unmarshal(payload, &pubMsgReq); err != nil {
+		h.sendError(client, fmt.Sprintf("invalid message provided: %v", err))
+		return
+	}
+
+	if err := pubMsgReq.Validate(h.validator); err != nil {
+		h.sendError(client, fmt.Sprintf("invalid message provided: %v", err))
+		return
+	}
+
+	// created message is delivered back to all connections by notifier
+	_, err := h.PublicMessageService.SendPublicMessage(ctx, mapper.MapSendPublicMessageRequestToEntity(pubMsgReq, client.Key))
+	if err != nil {
+		h.logger.Errorf("error occurred saving public message: %v", err)
+		h.sendError(client, "error occurred saving public message")
+	}
+}
+
+func (h *Handler) handlePrivateMessage(ctx context.Context, client *ws.Client, payload json.RawMessage) {
+	var privMsgReq request.SendPrivateMessageRequest
+
+	if err := json.Unmarshal(payload, &privMsgReq); err != nil {
+		h.sendError(client, fmt.Sprintf("invalid message provided: %v", err))
+		return
+	}
+
+	if err := privMsgReq.Validate(h.validator); err != nil {
+		h.sendError(client, fmt.Sprintf("invalid message provided: %v", err))
+		return
+	}
+
+	// created message is delivered to receiver and sender's connections by notifier
+	_, err := h.PrivateMessageService.SendPrivateMessage(ctx, mapper.MapSendPrivateMessageRequestToEntity(privMsgReq, client.Key))
+	if err != nil {
+		if errors.Is(err, messageservice.ErrNoSuchReceiver) || errors.Is(err, messageservice.ErrSenderBlocked) {
+			h.sendError(client, fmt.Sprintf("error occurred sending private message: %v", err))
+			return
+		}
+
+		h.logger.Errorf("error occurred saving private message: %v", err)
+		h.sendError(client, "error occurred saving private message")
+	}
+}
+
+func (h *Handler) handleTyping(ctx context.Context, client *ws.Client, payload json.RawMessage) {
+	var typingReq request.TypingRequest
+
+	if err := json.Unmarshal(payload, &typingReq); err != nil {
+		h.sendError(client, fmt.Sprintf("invalid message provided: %v", err))
+		return
+	}
+
+	if err := typingReq.Validate(h.validator); err != nil {
+		h.sendError(client, fmt.Sprintf("invalid message provided: %v", err))
+		return
+	}
+
+	err := h.TypingService.StartTyping(ctx, mapper.MapTypingRequestToEntity(typingReq, client.Key))
+	if err != nil {
+		if errors.Is(err, typingservice.ErrNoSuchReceiver) ||
+			errors.Is(err, typingservice.ErrNotChannelMember) ||
+			errors.Is(err, typingservice.ErrNotConversationParticipant) ||
+			errors.Is(err, repository.ErrNoSuchChannel) {
+			h.sendError(client, fmt.Sprintf("error occurred sending typing notice: %v", err))
+			return
+		}
+
+		h.logger.Errorf("error occurred sending typing notice: %v", err)
+		h.sendError(client, "error occurred sending typing notice")
+	}
+}
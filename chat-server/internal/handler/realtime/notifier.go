@@ -0,0 +1,148 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/mapper"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/response"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/pkg/ws"
+)
+
+// Notifier pushes domain events to live websocket connections.
+type Notifier struct {
+	Hub *ws.Hub
+
+	logger *logrus.Logger
+}
+
+func NewNotifier(hub *ws.Hub, logger *logrus.Logger) *Notifier {
+	return &Notifier{
+		Hub:    hub,
+		logger: logger,
+	}
+}
+
+func marshalEvent(typ string, payload any) ([]byte, error) {
+	return json.Marshal(response.Event{
+		Type:    typ,
+		Payload: payload,
+	})
+}
+
+func (n *Notifier) broadcast(typ string, payload any) {
+	msg, err := marshalEvent(typ, payload)
+	if err != nil {
+		n.logger.Errorf("error occurred marshalling %v event: %v", typ, err)
+		return
+	}
+
+	n.Hub.Broadcast(msg)
+}
+
+func (n *Notifier) sendTo(typ string, payload any, usernames ...string) {
+	msg, err := marshalEvent(typ, payload)
+	if err != nil {
+		n.logger.Errorf("error occurred marshalling %v event: %v", typ, err)
+		return
+	}
+
+	n.Hub.SendTo(msg, usernames...)
+}
+
+func (n *Notifier) NotifyPublicMessage(_ context.Context, msg *entity.PublicMessage) {
+	n.broadcast(EventPublicMessage, mapper.MapPublicMessageToResponse(msg))
+}
+
+func (n *Notifier) NotifyPublicMessageEdited(_ context.Context, msg *entity.PublicMessage) {
+	n.broadcast(EventPublicMessageEdited, mapper.MapPublicMessageToResponse(msg))
+}
+
+func (n *Notifier) NotifyPublicMessageDeleted(_ context.Context, msg *entity.PublicMessage) {
+	n.broadcast(EventPublicMessageDeleted, mapper.MapPublicMessageToResponse(msg))
+}
+
+func (n *Notifier) NotifyPublicMessagePurged(_ context.Context, msg *entity.PublicMessage) {
+	n.broadcast(EventPublicMessagePurged, response.MessagePurgedEvent{ID: msg.ID})
+}
+
+// NotifyChannelMessage delivers message only to connections of channel members.
+func (n *Notifier) NotifyChannelMessage(_ context.Context, msg *entity.PublicMessage, members []string) {
+	n.sendTo(EventChannelMessage, mapper.MapPublicMessageToResponse(msg), members...)
+}
+
+func (n *Notifier) NotifyChannelMessageEdited(_ context.Context, msg *entity.PublicMessage, members []string) {
+	n.sendTo(EventChannelMessageEdited, mapper.MapPublicMessageToResponse(msg), members...)
+}
+
+func (n *Notifier) NotifyChannelMessageDeleted(_ context.Context, msg *entity.PublicMessage, members []string) {
+	n.sendTo(EventChannelMessageDeleted, mapper.MapPublicMessageToResponse(msg), members...)
+}
+
+func (n *Notifier) NotifyChannelMessagePurged(_ context.Context, msg *entity.PublicMessage, members []string) {
+	n.sendTo(EventChannelMessagePurged, response.MessagePurgedEvent{ID: msg.ID}, members...)
+}
+
+// NotifyConversationMessage delivers message only to connections of conversation participants.
+func (n *Notifier) NotifyConversationMessage(_ context.Context, msg *entity.ConversationMessage, participants []string) {
+	n.sendTo(EventConversationMessage, mapper.MapConversationMessageToResponse(msg), participants...)
+}
+
+func privateMessageParticipants(msg *entity.PrivateMessage) []string {
+	if msg.FromUsername == msg.ToUsername {
+		return []string{msg.ToUsername}
+	}
+
+	return []string{msg.FromUsername, msg.ToUsername}
+}
+
+// NotifyPrivateMessage delivers message only to connections of its participants,
+// so sender's other devices are kept in sync too.
+func (n *Notifier) NotifyPrivateMessage(_ context.Context, msg *entity.PrivateMessage) {
+	n.sendTo(EventPrivateMessage, mapper.MapPrivateMessageToResponse(msg), privateMessageParticipants(msg)...)
+}
+
+func (n *Notifier) NotifyPrivateMessageEdited(_ context.Context, msg *entity.PrivateMessage) {
+	n.sendTo(EventPrivateMessageEdited, mapper.MapPrivateMessageToResponse(msg), privateMessageParticipants(msg)...)
+}
+
+func (n *Notifier) NotifyPrivateMessageDeleted(_ context.Context, msg *entity.PrivateMessage) {
+	n.sendTo(EventPrivateMessageDeleted, mapper.MapPrivateMessageToResponse(msg), privateMessageParticipants(msg)...)
+}
+
+// NotifyPrivateMessagesRead tells counterpart that messages were seen and keeps reader's other devices in sync.
+func (n *Notifier) NotifyPrivateMessagesRead(_ context.Context, marker *entity.ReadMarker) {
+	n.sendTo(EventPrivateMessagesRead, mapper.MapReadMarkerToResponse(marker), marker.Username, marker.Counterpart)
+}
+
+func (n *Notifier) NotifyPrivateMessagePurged(_ context.Context, msg *entity.PrivateMessage) {
+	n.sendTo(EventPrivateMessagePurged, response.MessagePurgedEvent{ID: msg.ID}, privateMessageParticipants(msg)...)
+}
+
+// NotifyPublicTyping tells everyone that user is writing in public chat.
+func (n *Notifier) NotifyPublicTyping(_ context.Context, typing *entity.Typing) {
+	n.broadcast(EventTyping, mapper.MapTypingToEvent(typing))
+}
+
+// NotifyTyping tells only users who can see the place that user is writing there.
+func (n *Notifier) NotifyTyping(_ context.Context, typing *entity.Typing, recipients []string) {
+	n.sendTo(EventTyping, mapper.MapTypingToEvent(typing), recipients...)
+}
+
+// NotifyMention tells mentioned user that they were mentioned in message.
+func (n *Notifier) NotifyMention(_ context.Context, mention *entity.Mention) {
+	n.sendTo(EventMention, mapper.MapMentionToResponse(mention), mention.Username)
+}
+
+// NotifySessionRevoked closes connections opened within revoked session.
+func (n *Notifier) NotifySessionRevoked(_ context.Context, sessionID string) {
+	n.Hub.CloseSession(sessionID)
+}
+
+// NotifyUserSignedOut closes every connection of user, including ones opened with basic auth outside of session.
+func (n *Notifier) NotifyUserSignedOut(_ context.Context, username string) {
+	n.Hub.Close(username)
+}
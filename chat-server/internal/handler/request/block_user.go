@@ -0,0 +1,11 @@
+package request
+
+import "github.com/go-playground/validator/v10"
+
+type BlockUserRequest struct {
+	Username string `json:"username" validate:"required,min=1"`
+}
+
+func (br *BlockUserRequest) Validate(valid *validator.Validate) error {
+	return valid.Struct(br)
+}
@@ -0,0 +1,13 @@
+package request
+
+import "github.com/go-playground/validator/v10"
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required,min=8,max=128"`
+	ConfirmPassword string `json:"confirm_password" validate:"required,eqfield=NewPassword"`
+}
+
+func (cr *ChangePasswordRequest) Validate(valid *validator.Validate) error {
+	return valid.Struct(cr)
+}
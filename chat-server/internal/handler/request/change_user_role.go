@@ -0,0 +1,11 @@
+package request
+
+import "github.com/go-playground/validator/v10"
+
+type ChangeUserRoleRequest struct {
+	Role string `json:"role" validate:"required,oneof=user moderator admin"`
+}
+
+func (cr *ChangeUserRoleRequest) Validate(valid *validator.Validate) error {
+	return valid.Struct(cr)
+}
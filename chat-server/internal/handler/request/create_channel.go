@@ -0,0 +1,12 @@
+package request
+
+import "github.com/go-playground/validator/v10"
+
+type CreateChannelRequest struct {
+	Name        string `json:"name" validate:"required,min=1,max=64"`
+	Description string `json:"description" validate:"max=512"`
+}
+
+func (cc *CreateChannelRequest) Validate(valid *validator.Validate) error {
+	return valid.Struct(cc)
+}
@@ -0,0 +1,28 @@
+package request
+
+import "github.com/go-playground/validator/v10"
+
+type CreateConversationRequest struct {
+	Title        string   `json:"title" validate:"max=128"`
+	Participants []string `json:"participants" validate:"required,min=2,dive,required"`
+}
+
+func (cc *CreateConversationRequest) Validate(valid *validator.Validate) error {
+	return valid.Struct(cc)
+}
+
+type AddConversationParticipantRequest struct {
+	Username string `json:"username" validate:"required"`
+}
+
+func (ap *AddConversationParticipantRequest) Validate(valid *validator.Validate) error {
+	return valid.Struct(ap)
+}
+
+type SendConversationMessageRequest struct {
+	Content string `json:"content" validate:"required,min=1,max=2000"`
+}
+
+func (sm *SendConversationMessageRequest) Validate(valid *validator.Validate) error {
+	return valid.Struct(sm)
+}
@@ -0,0 +1,11 @@
+package request
+
+import "github.com/go-playground/validator/v10"
+
+type DeleteAccountRequest struct {
+	Password string `json:"password" validate:"required"`
+}
+
+func (dr *DeleteAccountRequest) Validate(valid *validator.Validate) error {
+	return valid.Struct(dr)
+}
@@ -0,0 +1,11 @@
+package request
+
+import "github.com/go-playground/validator/v10"
+
+type EditMessageRequest struct {
+	Content string `json:"content" validate:"required,min=1,max=2000"`
+}
+
+func (em *EditMessageRequest) Validate(valid *validator.Validate) error {
+	return valid.Struct(em)
+}
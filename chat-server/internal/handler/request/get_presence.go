@@ -0,0 +1,13 @@
+package request
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+type GetPresenceRequest struct {
+	Usernames []string `json:"usernames" validate:"required,min=1,max=100,unique,dive,required"`
+}
+
+func (gp *GetPresenceRequest) Validate(valid *validator.Validate) error {
+	return valid.Struct(gp)
+}
@@ -3,8 +3,9 @@ package request
 import "github.com/go-playground/validator/v10"
 
 type LoginRequest struct {
-	Username string `json:"username" validate:"required,min=1"`
-	Password string `json:"password" validate:"required,min=1"`
+	Username   string `json:"username" validate:"required,min=1"`
+	Password   string `json:"password" validate:"required,min=1"`
+	DeviceName string `json:"device_name,omitempty" validate:"omitempty,max=128"`
 }
 
 func (lr *LoginRequest) Validate(valid *validator.Validate) error {
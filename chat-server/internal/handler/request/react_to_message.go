@@ -0,0 +1,11 @@
+package request
+
+import "github.com/go-playground/validator/v10"
+
+type ReactToMessageRequest struct {
+	Emoji string `json:"emoji" validate:"required,max=32,excludesall=/"`
+}
+
+func (rm *ReactToMessageRequest) Validate(valid *validator.Validate) error {
+	return valid.Struct(rm)
+}
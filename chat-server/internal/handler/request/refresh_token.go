@@ -0,0 +1,11 @@
+package request
+
+import "github.com/go-playground/validator/v10"
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token" validate:"required,min=1"`
+}
+
+func (rr *RefreshTokenRequest) Validate(valid *validator.Validate) error {
+	return valid.Struct(rr)
+}
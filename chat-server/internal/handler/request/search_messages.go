@@ -0,0 +1,20 @@
+package request
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type SearchMessagesRequest struct {
+	Query       string     `json:"q" validate:"required,max=256"`
+	Kind        string     `json:"kind" validate:"omitempty,oneof=public private"`
+	Author      string     `json:"author"`
+	Counterpart string     `json:"counterpart"`
+	From        *time.Time `json:"from"`
+	To          *time.Time `json:"to"`
+}
+
+func (sm *SearchMessagesRequest) Validate(valid *validator.Validate) error {
+	return valid.Struct(sm)
+}
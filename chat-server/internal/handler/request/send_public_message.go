@@ -3,7 +3,9 @@ package request
 import "github.com/go-playground/validator/v10"
 
 type SendPublicMessageRequest struct {
-	Content string `json:"content" validate:"required,min=1,max=2000"`
+	Content       string `json:"content" validate:"required_without=AttachmentIDs,max=2000"`
+	ParentID      *int   `json:"parent_id,omitempty" validate:"omitempty,min=1"`
+	AttachmentIDs []int  `json:"attachment_ids,omitempty" validate:"omitempty,min=1,max=10,unique,dive,min=1"`
 }
 
 func (sm *SendPublicMessageRequest) Validate(valid *validator.Validate) error {
@@ -0,0 +1,16 @@
+package request
+
+import (
+	"encoding/json"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type SocketMessage struct {
+	Type    string          `json:"type" validate:"required"`
+	Payload json.RawMessage `json:"payload" validate:"required"`
+}
+
+func (sm *SocketMessage) Validate(valid *validator.Validate) error {
+	return valid.Struct(sm)
+}
@@ -0,0 +1,15 @@
+package request
+
+import "github.com/go-playground/validator/v10"
+
+// TypingRequest tells where user is writing, public chat without channel_id means general channel.
+type TypingRequest struct {
+	Target         string `json:"target" validate:"required,oneof=public private conversation"`
+	ChannelID      int    `json:"channel_id,omitempty" validate:"omitempty,min=1"`
+	ToUsername     string `json:"to_username,omitempty" validate:"required_if=Target private"`
+	ConversationID int    `json:"conversation_id,omitempty" validate:"required_if=Target conversation,omitempty,min=1"`
+}
+
+func (tr *TypingRequest) Validate(valid *validator.Validate) error {
+	return valid.Struct(tr)
+}
@@ -0,0 +1,13 @@
+package request
+
+import "github.com/go-playground/validator/v10"
+
+// UpdateProfileRequest changes only provided fields.
+type UpdateProfileRequest struct {
+	Email    string `json:"email" validate:"required_without=Username,omitempty,email"`
+	Username string `json:"username" validate:"required_without=Email,omitempty,max=128"`
+}
+
+func (ur *UpdateProfileRequest) Validate(valid *validator.Validate) error {
+	return valid.Struct(ur)
+}
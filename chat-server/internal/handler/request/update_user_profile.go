@@ -0,0 +1,22 @@
+package request
+
+import (
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// UpdateUserProfileRequest changes only provided fields of public profile, empty string clears field.
+type UpdateUserProfileRequest struct {
+	DisplayName *string `json:"display_name,omitempty" validate:"omitnil,max=64"`
+	Bio         *string `json:"bio,omitempty" validate:"omitnil,max=512"`
+	// TimeZone is IANA time zone name, e.g. Europe/Berlin.
+	TimeZone   *string `json:"time_zone,omitempty" validate:"omitnil,max=64"`
+	StatusText *string `json:"status_text,omitempty" validate:"omitnil,max=128"`
+	// StatusExpiresAt can be set only together with StatusText, status without it never expires.
+	StatusExpiresAt *time.Time `json:"status_expires_at,omitempty" validate:"excluded_without=StatusText"`
+}
+
+func (ur *UpdateUserProfileRequest) Validate(valid *validator.Validate) error {
+	return valid.Struct(ur)
+}
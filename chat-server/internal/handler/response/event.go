@@ -0,0 +1,10 @@
+package response
+
+type Event struct {
+	Type    string `json:"type"`
+	Payload any    `json:"payload"`
+}
+
+type MessagePurgedEvent struct {
+	ID int `json:"id"`
+}
@@ -0,0 +1,8 @@
+package response
+
+import "time"
+
+type GetBlockResponse struct {
+	Username  string    `json:"username"`
+	BlockedAt time.Time `json:"blocked_at"`
+}
@@ -0,0 +1,16 @@
+package response
+
+import "time"
+
+type GetChannelResponse struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedBy   string    `json:"created_by,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+type GetChannelMemberResponse struct {
+	Username string    `json:"username"`
+	JoinedAt time.Time `json:"joined_at"`
+}
@@ -0,0 +1,29 @@
+package response
+
+import "time"
+
+type GetConversationResponse struct {
+	ID           int       `json:"id"`
+	Title        string    `json:"title"`
+	CreatedBy    string    `json:"created_by,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	Participants []string  `json:"participants"`
+}
+
+type GetConversationMessageResponse struct {
+	ID             int       `json:"id"`
+	ConversationID int       `json:"conversation_id"`
+	FromUsername   string    `json:"from_username"`
+	Content        string    `json:"content"`
+	SentAt         time.Time `json:"sent_at"`
+	EditedAt       time.Time `json:"edited_at"`
+}
+
+type GetConversationSummaryResponse struct {
+	Kind           string    `json:"kind"`
+	ConversationID int       `json:"conversation_id,omitempty"`
+	Counterpart    string    `json:"counterpart,omitempty"`
+	Title          string    `json:"title,omitempty"`
+	Participants   []string  `json:"participants"`
+	LastMessageAt  time.Time `json:"last_message_at"`
+}
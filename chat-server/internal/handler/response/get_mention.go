@@ -0,0 +1,25 @@
+package response
+
+import "time"
+
+type GetMentionResponse struct {
+	ID           int        `json:"id"`
+	MessageKind  string     `json:"message_kind"`
+	MessageID    int        `json:"message_id"`
+	FromUsername string     `json:"from_username"`
+	Content      string     `json:"content"`
+	MentionedAt  time.Time  `json:"mentioned_at"`
+	Read         bool       `json:"read"`
+	ReadAt       *time.Time `json:"read_at,omitempty"`
+}
+
+// MentionSpanResponse is position of @username in message content, offset and length are counted in characters.
+type MentionSpanResponse struct {
+	Username string `json:"username"`
+	Offset   int    `json:"offset"`
+	Length   int    `json:"length"`
+}
+
+type MarkMentionsReadResponse struct {
+	Marked int `json:"marked"`
+}
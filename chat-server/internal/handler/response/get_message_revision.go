@@ -0,0 +1,8 @@
+package response
+
+import "time"
+
+type GetMessageRevisionResponse struct {
+	Content  string    `json:"content"`
+	EditedAt time.Time `json:"edited_at"`
+}
@@ -0,0 +1,17 @@
+package response
+
+import (
+	"time"
+)
+
+type GetPresenceResponse struct {
+	Username   string     `json:"username"`
+	Status     string     `json:"status"`
+	LastSeenAt *time.Time `json:"last_seen_at"`
+}
+
+type GetUserWithPresenceResponse struct {
+	GetUserResponse
+	Status     string     `json:"status"`
+	LastSeenAt *time.Time `json:"last_seen_at"`
+}
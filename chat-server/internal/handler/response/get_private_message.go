@@ -3,9 +3,22 @@ package response
 import "time"
 
 type GetPrivateMessageResponse struct {
-	FromUsername string    `json:"from_username"`
-	ToUsername   string    `json:"to_username"`
-	Content      string    `json:"content"`
-	SentAt       time.Time `json:"sent_at"`
-	EditedAt     time.Time `json:"edited_at"`
+	ID           int                     `json:"id"`
+	FromUsername string                  `json:"from_username"`
+	ToUsername   string                  `json:"to_username"`
+	Content      string                  `json:"content"`
+	SentAt       time.Time               `json:"sent_at"`
+	EditedAt     time.Time               `json:"edited_at"`
+	Deleted      bool                    `json:"deleted"`
+	ParentID     *int                    `json:"parent_id,omitempty"`
+	SeenAt       *time.Time              `json:"seen_at,omitempty"`
+	ReplyCount   int                     `json:"reply_count"`
+	Reactions    []GetReactionResponse   `json:"reactions"`
+	Attachments  []GetAttachmentResponse `json:"attachments"`
+	Mentions     []MentionSpanResponse   `json:"mentions"`
+}
+
+type GetPrivateMessageThreadResponse struct {
+	Root    GetPrivateMessageResponse   `json:"root"`
+	Replies []GetPrivateMessageResponse `json:"replies"`
 }
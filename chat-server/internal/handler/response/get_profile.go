@@ -0,0 +1,13 @@
+package response
+
+import "time"
+
+type GetProfileResponse struct {
+	Username        string     `json:"username"`
+	DisplayName     string     `json:"display_name"`
+	Bio             string     `json:"bio"`
+	AvatarURL       string     `json:"avatar_url,omitempty"`
+	TimeZone        string     `json:"time_zone"`
+	StatusText      string     `json:"status_text"`
+	StatusExpiresAt *time.Time `json:"status_expires_at,omitempty"`
+}
@@ -3,8 +3,23 @@ package response
 import "time"
 
 type GetPublicMessageResponse struct {
-	FromUsername string    `json:"from_username"`
-	Content      string    `json:"content"`
-	SentAt       time.Time `json:"sent_at"`
-	EditedAt     time.Time `json:"edited_at"`
+	ID                int                     `json:"id"`
+	ChannelID         int                     `json:"channel_id"`
+	FromUsername      string                  `json:"from_username"`
+	AuthorDisplayName string                  `json:"author_display_name,omitempty"`
+	AuthorAvatarURL   string                  `json:"author_avatar_url,omitempty"`
+	Content           string                  `json:"content"`
+	SentAt            time.Time               `json:"sent_at"`
+	EditedAt          time.Time               `json:"edited_at"`
+	Deleted           bool                    `json:"deleted"`
+	ParentID          *int                    `json:"parent_id,omitempty"`
+	ReplyCount        int                     `json:"reply_count"`
+	Reactions         []GetReactionResponse   `json:"reactions"`
+	Attachments       []GetAttachmentResponse `json:"attachments"`
+	Mentions          []MentionSpanResponse   `json:"mentions"`
+}
+
+type GetPublicMessageThreadResponse struct {
+	Root    GetPublicMessageResponse   `json:"root"`
+	Replies []GetPublicMessageResponse `json:"replies"`
 }
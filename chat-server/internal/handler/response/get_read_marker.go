@@ -0,0 +1,10 @@
+package response
+
+import "time"
+
+type GetReadMarkerResponse struct {
+	Username    string    `json:"username"`
+	Counterpart string    `json:"counterpart"`
+	LastReadID  int       `json:"last_read_id"`
+	ReadAt      time.Time `json:"read_at"`
+}
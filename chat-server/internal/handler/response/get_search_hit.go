@@ -0,0 +1,8 @@
+package response
+
+type GetSearchHitResponse struct {
+	Kind           string                     `json:"kind"`
+	Snippet        string                     `json:"snippet"`
+	PublicMessage  *GetPublicMessageResponse  `json:"public_message,omitempty"`
+	PrivateMessage *GetPrivateMessageResponse `json:"private_message,omitempty"`
+}
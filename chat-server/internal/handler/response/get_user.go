@@ -8,6 +8,12 @@ type GetUserResponse struct {
 	ID        int       `json:"id"`
 	Username  string    `json:"username"`
 	Email     string    `json:"email"`
+	Role      string    `json:"role"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
+
+type GetMessageSenderResponse struct {
+	GetUserResponse
+	UnreadCount int `json:"unread_count"`
+}
@@ -0,0 +1,12 @@
+package response
+
+import "time"
+
+type TypingEvent struct {
+	Username       string    `json:"username"`
+	Target         string    `json:"target"`
+	ChannelID      int       `json:"channel_id,omitempty"`
+	ToUsername     string    `json:"to_username,omitempty"`
+	ConversationID int       `json:"conversation_id,omitempty"`
+	ExpiresAt      time.Time `json:"expires_at"`
+}
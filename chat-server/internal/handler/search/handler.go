@@ -0,0 +1,172 @@
+// nolint
+package search
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/mapper"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/request"
+
+	searchservice "github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/search"
+
+	handlerinternalutils "github.com/ew0s/ewos-to-go-hw/chat-server/internal/pkg/utils/handler"
+	handlerutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/handler"
+	sliceutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/slice"
+)
+
+type SearchService interface {
+	SearchMessages(ctx context.Context, username string, query entity.MessageSearchQuery, offset, limit int) ([]*entity.SearchHit, error)
+}
+
+type Middleware = func(http.Handler) http.Handler
+
+type Handler struct {
+	SearchService SearchService
+	Middlewares   []Middleware
+
+	logger    *logrus.Logger
+	validator *validator.Validate
+}
+
+func New(
+	searchService SearchService,
+	logger *logrus.Logger,
+	validator *validator.Validate,
+	middlewares ...Middleware,
+) *Handler {
+	return &Handler{
+		SearchService: searchService,
+		Middlewares:   middlewares,
+		logger:        logger,
+		validator:     validator,
+	}
+}
+
+func (h *Handler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+
+	router.Group(func(r chi.Router) {
+		r.Use(h.Middlewares...)
+
+		r.Get("/", h.SearchMessages)
+	})
+
+	return router
+}
+
+func switchByErrorAndWriteResponse(err error, rw http.ResponseWriter, logger *logrus.Logger) {
+	switch {
+	case errors.Is(err, searchservice.ErrEmptySearchQuery), errors.Is(err, searchservice.ErrInvalidDateRange):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusBadRequest, "", err.Error())
+
+	default:
+		errMsg := fmt.Sprintf("error occurred searching messages: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusInternalServerError, errMsg, "")
+	}
+}
+
+func getTimeParamFromQuery(req *http.Request, key string) (*time.Time, error) {
+	str, err := handlerutils.GetStringParamFromQuery(req, key)
+	if err != nil {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, str)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s provided: %w", key, err)
+	}
+
+	return &t, nil
+}
+
+func parseSearchMessagesRequest(req *http.Request) (request.SearchMessagesRequest, error) {
+	query := req.URL.Query()
+
+	searchReq := request.SearchMessagesRequest{
+		Query:       query.Get("q"),
+		Kind:        query.Get("kind"),
+		Author:      query.Get("author"),
+		Counterpart: query.Get("counterpart"),
+	}
+
+	var err error
+
+	if searchReq.From, err = getTimeParamFromQuery(req, "from"); err != nil {
+		return searchReq, err
+	}
+
+	if searchReq.To, err = getTimeParamFromQuery(req, "to"); err != nil {
+		return searchReq, err
+	}
+
+	return searchReq, nil
+}
+
+// SearchMessages godoc
+//
+//	@Summary		Search messages
+//	@Description	Full-text search over public messages of channels that user can read and private messages of user, newest first. All words of query must be present in message, matched words are wrapped into <mark></mark> in snippet
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Search
+//	@Produce		json
+//	@Param			q			query		string	true	"Words to search"
+//	@Param			kind		query		string	false	"Search only public or only private messages"	Enums(public, private)
+//	@Param			author		query		string	false	"Username of message author"
+//	@Param			counterpart	query		string	false	"Username of other participant of private conversation, searches private messages only"
+//	@Param			from		query		string	false	"Messages sent at or after this time, RFC3339"
+//	@Param			to			query		string	false	"Messages sent at or before this time, RFC3339"
+//	@Param			offset		query		int		false	"Offset"
+//	@Param			limit		query		int		false	"Limit"
+//	@Success		200			{object}	[]response.GetSearchHitResponse
+//	@Failure		400			{string}	invalid	search	query	provided
+//	@Failure		401			{string}	Unauthorized
+//	@Failure		500			{string}	internal	error
+//	@Router			/api/v1/search [get]
+func (h *Handler) SearchMessages(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	searchReq, err := parseSearchMessagesRequest(req)
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, "", err.Error())
+		return
+	}
+
+	if err = searchReq.Validate(h.validator); err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, "", err.Error())
+		return
+	}
+
+	paginationOpts := handlerinternalutils.GetPaginationOptsFromQuery(req, handler.DefaultOffset, handler.DefaultLimit)
+
+	if err = paginationOpts.Validate(h.validator); err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, "", err.Error())
+		return
+	}
+
+	hits, err := h.SearchService.SearchMessages(req.Context(), username, mapper.MapSearchMessagesRequestToQuery(searchReq),
+		paginationOpts.Offset, paginationOpts.Limit)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, sliceutils.Map(hits, mapper.MapSearchHitToResponse))
+	rw.WriteHeader(http.StatusOK)
+}
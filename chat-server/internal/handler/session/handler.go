@@ -0,0 +1,175 @@
+// nolint
+package session
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/mapper"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/response"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+
+	sessionservice "github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/session"
+
+	handlerutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/handler"
+)
+
+type SessionService interface {
+	GetUserSessions(ctx context.Context, userID int) ([]*entity.Session, error)
+	RevokeSession(ctx context.Context, userID int, sessionID string) error
+	RevokeAllSessions(ctx context.Context, userID int) error
+}
+
+type Middleware = func(http.Handler) http.Handler
+
+type Handler struct {
+	SessionService SessionService
+	Middlewares    []Middleware
+
+	logger    *logrus.Logger
+	validator *validator.Validate
+}
+
+func New(
+	sessionService SessionService,
+	logger *logrus.Logger,
+	validator *validator.Validate,
+	middlewares ...Middleware,
+) *Handler {
+	return &Handler{
+		SessionService: sessionService,
+		Middlewares:    middlewares,
+		logger:         logger,
+		validator:      validator,
+	}
+}
+
+func (h *Handler) Routes() *chi.Mux {
+	router := chi.NewRouter()
+
+	router.Group(func(r chi.Router) {
+		r.Use(h.Middlewares...)
+
+		r.Get("/", h.GetSessions)
+		r.Delete("/", h.RevokeAllSessions)
+		r.Delete("/{id}", h.RevokeSession)
+	})
+
+	return router
+}
+
+func switchByErrorAndWriteResponse(err error, rw http.ResponseWriter, logger *logrus.Logger) {
+	switch {
+	case errors.Is(err, repository.ErrNoSuchSession):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusNotFound, "", err.Error())
+
+	case errors.Is(err, sessionservice.ErrSessionNotActive):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusConflict, "", err.Error())
+
+	default:
+		errMsg := fmt.Sprintf("error occurred processing sessions: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusInternalServerError, errMsg, "")
+	}
+}
+
+// GetSessions godoc
+//
+//	@Summary		Get sessions
+//	@Description	Get active sessions of user, recently seen first. Session of request is marked as current
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Sessions
+//	@Produce		json
+//	@Success		200	{object}	[]response.GetSessionResponse
+//	@Failure		401	{string}	Unauthorized
+//	@Failure		500	{string}	internal	error
+//	@Router			/api/v1/sessions [get]
+func (h *Handler) GetSessions(rw http.ResponseWriter, req *http.Request) {
+	userID, err := handlerutils.GetIntHeaderByKey(req, "id")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	sessions, err := h.SessionService.GetUserSessions(req.Context(), userID)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	currentSessionID := req.Header.Get("session_id")
+
+	resp := make([]response.GetSessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		resp = append(resp, mapper.MapSessionToResponse(session, currentSessionID))
+	}
+
+	render.JSON(rw, req, resp)
+	rw.WriteHeader(http.StatusOK)
+}
+
+// RevokeSession godoc
+//
+//	@Summary		Revoke session
+//	@Description	Sign out session of user, its access and refresh tokens are rejected from now on
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Sessions
+//	@Param			id	path	string	true	"session id"
+//	@Success		204
+//	@Failure		401	{string}	Unauthorized
+//	@Failure		404	{string}	Not	Found
+//	@Failure		409	{string}	session	is	revoked	or	expired
+//	@Failure		500	{string}	internal	error
+//	@Router			/api/v1/sessions/{id} [delete]
+func (h *Handler) RevokeSession(rw http.ResponseWriter, req *http.Request) {
+	userID, err := handlerutils.GetIntHeaderByKey(req, "id")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	sessionID := chi.URLParam(req, "id")
+
+	if err = h.SessionService.RevokeSession(req.Context(), userID, sessionID); err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// RevokeAllSessions godoc
+//
+//	@Summary		Revoke all sessions
+//	@Description	Sign out all sessions of user, including the one of request
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			Sessions
+//	@Success		204
+//	@Failure		401	{string}	Unauthorized
+//	@Failure		500	{string}	internal	error
+//	@Router			/api/v1/sessions [delete]
+func (h *Handler) RevokeAllSessions(rw http.ResponseWriter, req *http.Request) {
+	userID, err := handlerutils.GetIntHeaderByKey(req, "id")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	if err = h.SessionService.RevokeAllSessions(req.Context(), userID); err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
@@ -3,17 +3,30 @@ package user
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/render"
 	"github.com/go-playground/validator/v10"
 	"github.com/sirupsen/logrus"
 
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/config"
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler"
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/mapper"
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/request"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/handler/response"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/pkg/blob"
+
+	blockservice "github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/block"
+	profileservice "github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/profile"
+	userservice "github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/user"
 
 	handlerinternalutils "github.com/ew0s/ewos-to-go-hw/chat-server/internal/pkg/utils/handler"
 	handlerutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/handler"
@@ -28,19 +41,58 @@ type UserService interface {
 	GetAllUsers(ctx context.Context, offset, limit int) []*entity.User
 	UpdateUser(ctx context.Context, id int, updateModel entity.User) (*entity.User, error)
 	DeleteUser(ctx context.Context, id int) (*entity.User, error)
+	ChangePassword(ctx context.Context, id int, currentPassword, newPassword string) error
+	DeleteAccount(ctx context.Context, id int, password string) (*entity.User, error)
 }
 
 type MessageService interface {
 	GetAllPrivateMessages(ctx context.Context, toUsername string, offset, limit int) []*entity.PrivateMessage
 	GetAllUsersThatSentMessage(ctx context.Context, toUsername string, offset, limit int) []*entity.User
+	GetUnreadPrivateMessageCounts(ctx context.Context, username string) (map[string]int, error)
+}
+
+type ProfileService interface {
+	GetProfile(ctx context.Context, userID int) (*entity.Profile, error)
+	GetProfileByUsername(ctx context.Context, username string) (*entity.Profile, error)
+	UpdateProfile(ctx context.Context, userID int, update entity.ProfileUpdate) (*entity.Profile, error)
+	SetAvatar(ctx context.Context, userID int, upload entity.AvatarUpload) (*entity.Profile, error)
+	DeleteAvatar(ctx context.Context, userID int) (*entity.Profile, error)
+	GetAvatar(ctx context.Context, username string) (*entity.Profile, io.ReadCloser, error)
+}
+
+type BlockService interface {
+	BlockUser(ctx context.Context, username, blockedUsername string) (*entity.Block, error)
+	UnblockUser(ctx context.Context, username, blockedUsername string) error
+	GetBlockedUsers(ctx context.Context, username string) ([]*entity.Block, error)
+}
+
+type MentionService interface {
+	GetMentions(ctx context.Context, username string, unreadOnly bool, offset, limit int) ([]*entity.Mention, error)
+	MarkMentionsRead(ctx context.Context, username string, upToID int) (int, error)
+}
+
+type PresenceService interface {
+	GetUsersPresence(ctx context.Context, users []*entity.User) []*entity.Presence
+	GetPresenceByUsernames(ctx context.Context, usernames []string) ([]*entity.Presence, error)
 }
 
+const (
+	avatarFormField = "avatar"
+	// multipartOverhead is allowed on top of avatar max size for multipart boundaries and headers.
+	multipartOverhead = 1 << 20
+)
+
 type Middleware = func(http.Handler) http.Handler
 
 type Handler struct {
-	UserService    UserService
-	MessageService MessageService
-	Middlewares    []Middleware
+	UserService     UserService
+	MessageService  MessageService
+	ProfileService  ProfileService
+	PresenceService PresenceService
+	BlockService    BlockService
+	MentionService  MentionService
+	AvatarsConfig   config.Avatars
+	Middlewares     []Middleware
 
 	logger    *logrus.Logger
 	validator *validator.Validate
@@ -48,16 +100,26 @@ type Handler struct {
 
 func New(userService UserService,
 	messageService MessageService,
+	profileService ProfileService,
+	presenceService PresenceService,
+	blockService BlockService,
+	mentionService MentionService,
+	avatarsConf config.Avatars,
 	logger *logrus.Logger,
 	validator *validator.Validate,
 	middlewares ...Middleware,
 ) *Handler {
 	return &Handler{
-		UserService:    userService,
-		MessageService: messageService,
-		Middlewares:    middlewares,
-		logger:         logger,
-		validator:      validator,
+		UserService:     userService,
+		MessageService:  messageService,
+		ProfileService:  profileService,
+		PresenceService: presenceService,
+		BlockService:    blockService,
+		MentionService:  mentionService,
+		AvatarsConfig:   avatarsConf,
+		Middlewares:     middlewares,
+		logger:          logger,
+		validator:       validator,
 	}
 }
 
@@ -67,21 +129,79 @@ func (h *Handler) Routes() *chi.Mux {
 	router.Group(func(r chi.Router) {
 		r.Use(h.Middlewares...)
 		r.Get("/all", h.GetAll)
+		r.Get("/presence", h.GetPresence)
 		r.Get("/messages", h.GetAllUsersThatSentMessage)
+		r.Patch("/me", h.UpdateProfile)
+		r.Put("/me/password", h.ChangePassword)
+		r.Delete("/me", h.DeleteAccount)
+		r.Get("/me/profile", h.GetMyProfile)
+		r.Patch("/me/profile", h.UpdateMyProfile)
+		r.Put("/me/profile/avatar", h.SetAvatar)
+		r.Delete("/me/profile/avatar", h.DeleteAvatar)
+		r.Get("/me/blocks", h.GetBlockedUsers)
+		r.Post("/me/blocks", h.BlockUser)
+		r.Delete("/me/blocks/{username}", h.UnblockUser)
+		r.Get("/me/mentions", h.GetMentions)
+		r.Post("/me/mentions/{id}/read", h.MarkMentionsRead)
+		r.Get("/{username}/profile", h.GetUserProfile)
+		r.Get("/{username}/avatar", h.GetAvatar)
 	})
 
 	return router
 }
 
+func switchByErrorAndWriteResponse(err error, rw http.ResponseWriter, logger *logrus.Logger) {
+	var maxBytesErr *http.MaxBytesError
+
+	switch {
+	case errors.Is(err, repository.ErrNoSuchUser),
+		errors.Is(err, blockservice.ErrNoSuchUser),
+		errors.Is(err, repository.ErrNoSuchBlock),
+		errors.Is(err, profileservice.ErrNoAvatar):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusNotFound, "", err.Error())
+
+	case errors.Is(err, profileservice.ErrInvalidTimeZone),
+		errors.Is(err, profileservice.ErrStatusExpiryInPast),
+		errors.Is(err, blockservice.ErrBlockSelf):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusBadRequest, "", err.Error())
+
+	case errors.Is(err, profileservice.ErrAvatarTooLarge), errors.As(err, &maxBytesErr):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusRequestEntityTooLarge, "",
+			profileservice.ErrAvatarTooLarge.Error())
+
+	case errors.Is(err, profileservice.ErrAvatarTypeNotAllowed):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusUnsupportedMediaType, "", err.Error())
+
+	case errors.Is(err, blob.ErrNotFound):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusNotFound, "", profileservice.ErrNoAvatar.Error())
+
+	case errors.Is(err, userservice.ErrWrongPassword):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusForbidden, "", err.Error())
+
+	case errors.Is(err, repository.ErrEmailExists),
+		errors.Is(err, repository.ErrUsernameExists),
+		errors.Is(err, repository.ErrUserHasMessages),
+		errors.Is(err, repository.ErrBlockExists),
+		errors.Is(err, repository.ErrConcurrentUpdate),
+		errors.Is(err, userservice.ErrLastAdmin):
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusConflict, "", err.Error())
+
+	default:
+		errMsg := fmt.Sprintf("error occurred processing user: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, logger, http.StatusInternalServerError, errMsg, "")
+	}
+}
+
 // GetAll godoc
 //
 //	@Summary		Get all users
-//	@Description	Get all users
+//	@Description	Get all users with their presence. User is online while active recently, away while only keeping websocket connection and offline otherwise
 //	@Security		BasicAuth
 //	@Security		JWT
 //	@Tags			User
 //	@Produce		json
-//	@Success		200	{object}	[]response.GetUserResponse
+//	@Success		200	{object}	[]response.GetUserWithPresenceResponse
 //	@Failure		401	{string}	Unauthorized
 //	@Router			/api/v1/users/all [get]
 func (h *Handler) GetAll(rw http.ResponseWriter, req *http.Request) {
@@ -94,21 +214,65 @@ func (h *Handler) GetAll(rw http.ResponseWriter, req *http.Request) {
 	}
 
 	users := h.UserService.GetAllUsers(req.Context(), paginationOpts.Offset, paginationOpts.Limit)
+	presences := h.PresenceService.GetUsersPresence(req.Context(), users)
+
+	resp := make([]response.GetUserWithPresenceResponse, 0, len(users))
+	for i, user := range users {
+		resp = append(resp, mapper.MapUserToUserWithPresenceResponse(user, presences[i]))
+	}
+
+	render.JSON(rw, req, resp)
+	rw.WriteHeader(http.StatusOK)
+}
+
+// GetPresence godoc
+//
+//	@Summary		Get presence of users
+//	@Description	Get presence and last seen time of users with provided usernames. Unknown usernames are skipped
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			User
+//	@Produce		json
+//	@Param			usernames	query		string	true	"Comma separated usernames, at most 100"
+//	@Success		200			{object}	[]response.GetPresenceResponse
+//	@Failure		400			{string}	invalid	usernames	provided
+//	@Failure		401			{string}	Unauthorized
+//	@Failure		500			{string}	internal	error
+//	@Router			/api/v1/users/presence [get]
+func (h *Handler) GetPresence(rw http.ResponseWriter, req *http.Request) {
+	var presenceReq request.GetPresenceRequest
+
+	if usernames := req.URL.Query().Get("usernames"); usernames != "" {
+		presenceReq.Usernames = strings.Split(usernames, ",")
+	}
 
-	render.JSON(rw, req, sliceutils.Map(users, mapper.MapUserToUserResponse))
+	if err := presenceReq.Validate(h.validator); err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, "", err.Error())
+		return
+	}
+
+	presences, err := h.PresenceService.GetPresenceByUsernames(req.Context(), presenceReq.Usernames)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, sliceutils.Map(presences, mapper.MapPresenceToResponse))
 	rw.WriteHeader(http.StatusOK)
 }
 
 // GetAllUsersThatSentMessage godoc
 //
 //	@Summary		Get all users that sent message to current user
-//	@Description	Get all users that sent message to current user
+//	@Description	Get all users that sent message to current user with number of unread messages from each of them. Superseded by conversation list at /api/v1/conversations
+//	@Deprecated
 //	@Security		BasicAuth
 //	@Security		JWT
 //	@Tags			User
 //	@Produce		json
-//	@Success		200	{object}	[]response.GetUserResponse
+//	@Success		200	{object}	[]response.GetMessageSenderResponse
 //	@Failure		401	{string}	Unauthorized
+//	@Failure		500	{string}	internal	error
 //	@Router			/api/v1/users/messages [get]
 func (h *Handler) GetAllUsersThatSentMessage(rw http.ResponseWriter, req *http.Request) {
 	username, err := handlerutils.GetStringHeaderByKey(req, "username")
@@ -121,6 +285,628 @@ func (h *Handler) GetAllUsersThatSentMessage(rw http.ResponseWriter, req *http.R
 
 	users := h.MessageService.GetAllUsersThatSentMessage(req.Context(), username, paginateOpts.Offset, paginateOpts.Limit)
 
-	render.JSON(rw, req, sliceutils.Map(users, mapper.MapUserToUserResponse))
+	unreadCounts, err := h.MessageService.GetUnreadPrivateMessageCounts(req.Context(), username)
+	if err != nil {
+		logMsg := fmt.Sprintf("error occurred counting unread private messages: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusInternalServerError, logMsg, "")
+		return
+	}
+
+	render.JSON(rw, req, sliceutils.Map(users, func(user *entity.User) response.GetMessageSenderResponse {
+		return mapper.MapUserToMessageSenderResponse(user, unreadCounts[user.Username])
+	}))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// UpdateProfile godoc
+//
+//	@Summary		Update profile
+//	@Description	Change email or username of current user, only provided fields are changed. After username change all sessions are signed out, as their tokens carry former username
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Param			input	body		request.UpdateProfileRequest	true	"new email or username"
+//	@Success		200		{object}	response.GetUserResponse
+//	@Failure		400		{string}	invalid	profile	provided
+//	@Failure		401		{string}	Unauthorized
+//	@Failure		409		{string}	email	or	username	is	taken
+//	@Failure		500		{string}	internal	error
+//	@Router			/api/v1/users/me [patch]
+func (h *Handler) UpdateProfile(rw http.ResponseWriter, req *http.Request) {
+	userID, err := handlerutils.GetIntHeaderByKey(req, "id")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	var updateReq request.UpdateProfileRequest
+
+	if err = render.DecodeJSON(req.Body, &updateReq); err != nil {
+		logMsg := fmt.Sprintf("error occurred decoding UpdateProfileRequest struct: %s", err)
+		respMsg := fmt.Sprintf("invalid profile provided: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	if err = updateReq.Validate(h.validator); err != nil {
+		logMsg := fmt.Sprintf("error occurred validating UpdateProfileRequest struct: %s", err)
+		respMsg := fmt.Sprintf("invalid profile provided: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	user, err := h.UserService.UpdateUser(req.Context(), userID, mapper.MapUpdateProfileRequestToUserEntity(&updateReq))
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, mapper.MapUserToUserResponse(user))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// ChangePassword godoc
+//
+//	@Summary		Change password
+//	@Description	Change password of current user, current password is required. All sessions are signed out after change
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			User
+//	@Accept			json
+//	@Param			input	body	request.ChangePasswordRequest	true	"current and new password"
+//	@Success		204
+//	@Failure		400	{string}	invalid	password	provided
+//	@Failure		401	{string}	Unauthorized
+//	@Failure		403	{string}	wrong	password
+//	@Failure		500	{string}	internal	error
+//	@Router			/api/v1/users/me/password [put]
+func (h *Handler) ChangePassword(rw http.ResponseWriter, req *http.Request) {
+	userID, err := handlerutils.GetIntHeaderByKey(req, "id")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	var passwordReq request.ChangePasswordRequest
+
+	if err = render.DecodeJSON(req.Body, &passwordReq); err != nil {
+		logMsg := fmt.Sprintf("error occurred decoding ChangePasswordRequest struct: %s", err)
+		respMsg := fmt.Sprintf("invalid password provided: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	if err = passwordReq.Validate(h.validator); err != nil {
+		logMsg := fmt.Sprintf("error occurred validating ChangePasswordRequest struct: %s", err)
+		respMsg := fmt.Sprintf("invalid password provided: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	err = h.UserService.ChangePassword(req.Context(), userID, passwordReq.CurrentPassword, passwordReq.NewPassword)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteAccount godoc
+//
+//	@Summary		Delete account
+//	@Description	Delete account of current user, password is required. All sessions are signed out
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			User
+//	@Accept			json
+//	@Param			input	body	request.DeleteAccountRequest	true	"password"
+//	@Success		204
+//	@Failure		400	{string}	invalid	password	provided
+//	@Failure		401	{string}	Unauthorized
+//	@Failure		403	{string}	wrong	password
+//	@Failure		409	{string}	account	cannot	be	deleted
+//	@Failure		500	{string}	internal	error
+//	@Router			/api/v1/users/me [delete]
+func (h *Handler) DeleteAccount(rw http.ResponseWriter, req *http.Request) {
+	userID, err := handlerutils.GetIntHeaderByKey(req, "id")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	var deleteReq request.DeleteAccountRequest
+
+	if err = render.DecodeJSON(req.Body, &deleteReq); err != nil {
+		logMsg := fmt.Sprintf("error occurred decoding DeleteAccountRequest struct: %s", err)
+		respMsg := fmt.Sprintf("invalid password provided: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	if err = deleteReq.Validate(h.validator); err != nil {
+		logMsg := fmt.Sprintf("error occurred validating DeleteAccountRequest struct: %s", err)
+		respMsg := fmt.Sprintf("invalid password provided: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	if _, err = h.UserService.DeleteAccount(req.Context(), userID, deleteReq.Password); err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// GetMyProfile godoc
+//
+//	@Summary		Get my profile
+//	@Description	Get public profile of current user
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			User
+//	@Produce		json
+//	@Success		200	{object}	response.GetProfileResponse
+//	@Failure		401	{string}	Unauthorized
+//	@Failure		500	{string}	internal	error
+//	@Router			/api/v1/users/me/profile [get]
+func (h *Handler) GetMyProfile(rw http.ResponseWriter, req *http.Request) {
+	userID, err := handlerutils.GetIntHeaderByKey(req, "id")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	profile, err := h.ProfileService.GetProfile(req.Context(), userID)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, mapper.MapProfileToResponse(profile))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// UpdateMyProfile godoc
+//
+//	@Summary		Update my profile
+//	@Description	Change public profile of current user, only provided fields are changed and empty string clears field. Status expiry can be set only together with status text
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Param			input	body		request.UpdateUserProfileRequest	true	"changed profile fields"
+//	@Success		200		{object}	response.GetProfileResponse
+//	@Failure		400		{string}	invalid	profile	provided
+//	@Failure		401		{string}	Unauthorized
+//	@Failure		500		{string}	internal	error
+//	@Router			/api/v1/users/me/profile [patch]
+func (h *Handler) UpdateMyProfile(rw http.ResponseWriter, req *http.Request) {
+	userID, err := handlerutils.GetIntHeaderByKey(req, "id")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	var updateReq request.UpdateUserProfileRequest
+
+	if err = render.DecodeJSON(req.Body, &updateReq); err != nil {
+		logMsg := fmt.Sprintf("error occurred decoding UpdateUserProfileRequest struct: %s", err)
+		respMsg := fmt.Sprintf("invalid profile provided: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	if err = updateReq.Validate(h.validator); err != nil {
+		logMsg := fmt.Sprintf("error occurred validating UpdateUserProfileRequest struct: %s", err)
+		respMsg := fmt.Sprintf("invalid profile provided: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	profile, err := h.ProfileService.UpdateProfile(req.Context(), userID, mapper.MapUpdateUserProfileRequestToEntity(&updateReq))
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, mapper.MapProfileToResponse(profile))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// SetAvatar godoc
+//
+//	@Summary		Set avatar
+//	@Description	Upload image as avatar of current user, previous avatar is replaced. Image type is detected from its content and must be allowed by server
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			User
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			avatar	formData	file	true	"Avatar image"
+//	@Success		200		{object}	response.GetProfileResponse
+//	@Failure		400		{string}	invalid	avatar	provided
+//	@Failure		401		{string}	Unauthorized
+//	@Failure		413		{string}	avatar	is	too	large
+//	@Failure		415		{string}	avatar	type	is	not	allowed
+//	@Failure		500		{string}	internal	error
+//	@Router			/api/v1/users/me/profile/avatar [put]
+func (h *Handler) SetAvatar(rw http.ResponseWriter, req *http.Request) {
+	userID, err := handlerutils.GetIntHeaderByKey(req, "id")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	req.Body = http.MaxBytesReader(rw, req.Body, h.AvatarsConfig.MaxSize+multipartOverhead)
+
+	reader, err := req.MultipartReader()
+	if err != nil {
+		msg := fmt.Sprintf("invalid avatar provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, "", msg)
+		return
+	}
+
+	part, err := handlerutils.NextFilePart(reader, avatarFormField)
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			switchByErrorAndWriteResponse(err, rw, h.logger)
+			return
+		}
+
+		msg := fmt.Sprintf("invalid avatar provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, "", msg)
+		return
+	}
+	defer part.Close()
+
+	profile, err := h.ProfileService.SetAvatar(req.Context(), userID, entity.AvatarUpload{Content: part})
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, mapper.MapProfileToResponse(profile))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// DeleteAvatar godoc
+//
+//	@Summary		Delete avatar
+//	@Description	Remove avatar of current user
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			User
+//	@Produce		json
+//	@Success		200	{object}	response.GetProfileResponse
+//	@Failure		401	{string}	Unauthorized
+//	@Failure		500	{string}	internal	error
+//	@Router			/api/v1/users/me/profile/avatar [delete]
+func (h *Handler) DeleteAvatar(rw http.ResponseWriter, req *http.Request) {
+	userID, err := handlerutils.GetIntHeaderByKey(req, "id")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	profile, err := h.ProfileService.DeleteAvatar(req.Context(), userID)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, mapper.MapProfileToResponse(profile))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// GetBlockedUsers godoc
+//
+//	@Summary		Get blocked users
+//	@Description	Get users blocked by current user, most recently blocked first
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			User
+//	@Produce		json
+//	@Success		200	{object}	[]response.GetBlockResponse
+//	@Failure		401	{string}	Unauthorized
+//	@Failure		500	{string}	internal	error
+//	@Router			/api/v1/users/me/blocks [get]
+func (h *Handler) GetBlockedUsers(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	blocks, err := h.BlockService.GetBlockedUsers(req.Context(), username)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, sliceutils.Map(blocks, mapper.MapBlockToResponse))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// BlockUser godoc
+//
+//	@Summary		Block user
+//	@Description	Block user, blocked user can not send private messages to current user anymore. Messages of blocked users can be hidden from public feed with hide_blocked query param
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			User
+//	@Accept			json
+//	@Produce		json
+//	@Param			input	body		request.BlockUserRequest	true	"user to block"
+//	@Success		200		{object}	response.GetBlockResponse
+//	@Failure		400		{string}	invalid	user	provided
+//	@Failure		401		{string}	Unauthorized
+//	@Failure		404		{string}	no	such	user
+//	@Failure		409		{string}	user	is	already	blocked
+//	@Failure		500		{string}	internal	error
+//	@Router			/api/v1/users/me/blocks [post]
+func (h *Handler) BlockUser(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	var blockReq request.BlockUserRequest
+
+	if err = render.DecodeJSON(req.Body, &blockReq); err != nil {
+		logMsg := fmt.Sprintf("error occurred decoding BlockUserRequest struct: %s", err)
+		respMsg := fmt.Sprintf("invalid user provided: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	if err = blockReq.Validate(h.validator); err != nil {
+		logMsg := fmt.Sprintf("error occurred validating BlockUserRequest struct: %s", err)
+		respMsg := fmt.Sprintf("invalid user provided: %s", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, logMsg, respMsg)
+
+		return
+	}
+
+	block, err := h.BlockService.BlockUser(req.Context(), username, blockReq.Username)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, mapper.MapBlockToResponse(block))
 	rw.WriteHeader(http.StatusOK)
 }
+
+// UnblockUser godoc
+//
+//	@Summary		Unblock user
+//	@Description	Unblock user blocked by current user
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			User
+//	@Param			username	path	string	true	"username of blocked user"
+//	@Success		204
+//	@Failure		401	{string}	Unauthorized
+//	@Failure		404	{string}	user	is	not	blocked
+//	@Failure		500	{string}	internal	error
+//	@Router			/api/v1/users/me/blocks/{username} [delete]
+func (h *Handler) UnblockUser(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	blockedUsername, err := handlerutils.GetStringParamFromURL(req, "username")
+	if err != nil {
+		msg := fmt.Sprintf("invalid username provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, msg, msg)
+		return
+	}
+
+	if err = h.BlockService.UnblockUser(req.Context(), username, blockedUsername); err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// GetMentions godoc
+//
+//	@Summary		Get mentions
+//	@Description	Get messages where current user was mentioned as @username, newest first. Mentions of deleted messages are shown with tombstone content
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			User
+//	@Produce		json
+//	@Param			offset		query		int		true	"Offset"
+//	@Param			limit		query		int		true	"Limit"
+//	@Param			unread_only	query		bool	false	"Return only unread mentions"
+//	@Success		200			{object}	[]response.GetMentionResponse
+//	@Failure		400			{string}	invalid	query	provided
+//	@Failure		401			{string}	Unauthorized
+//	@Failure		500			{string}	internal	error
+//	@Router			/api/v1/users/me/mentions [get]
+func (h *Handler) GetMentions(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	paginationOpts := handlerinternalutils.GetPaginationOptsFromQuery(req, handler.DefaultOffset, handler.DefaultLimit)
+
+	if err = paginationOpts.Validate(h.validator); err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, "", err.Error())
+
+		return
+	}
+
+	unreadOnly := false
+
+	if param, err := handlerutils.GetStringParamFromQuery(req, "unread_only"); err == nil {
+		if unreadOnly, err = strconv.ParseBool(param); err != nil {
+			msg := fmt.Sprintf("invalid unread_only provided: %v", err)
+
+			handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, "", msg)
+			return
+		}
+	}
+
+	mentions, err := h.MentionService.GetMentions(req.Context(), username, unreadOnly, paginationOpts.Offset, paginationOpts.Limit)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, sliceutils.Map(mentions, mapper.MapMentionToResponse))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// MarkMentionsRead godoc
+//
+//	@Summary		Mark mentions read
+//	@Description	Mark mentions of current user up to this mention as read
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			User
+//	@Produce		json
+//	@Param			id	path		int	true	"mention id"
+//	@Success		200	{object}	response.MarkMentionsReadResponse
+//	@Failure		400	{string}	invalid	mention	id	provided
+//	@Failure		401	{string}	Unauthorized
+//	@Failure		500	{string}	internal	error
+//	@Router			/api/v1/users/me/mentions/{id}/read [post]
+func (h *Handler) MarkMentionsRead(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringHeaderByKey(req, "username")
+	if err != nil {
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusUnauthorized, "", err.Error())
+		return
+	}
+
+	id, err := handlerutils.GetIntParamFromURL(req, "id")
+	if err != nil {
+		msg := fmt.Sprintf("invalid mention id provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, msg, msg)
+		return
+	}
+
+	marked, err := h.MentionService.MarkMentionsRead(req.Context(), username, id)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, response.MarkMentionsReadResponse{Marked: marked})
+	rw.WriteHeader(http.StatusOK)
+}
+
+// GetUserProfile godoc
+//
+//	@Summary		Get user profile
+//	@Description	Get public profile of user by username
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			User
+//	@Produce		json
+//	@Param			username	path		string	true	"username"
+//	@Success		200			{object}	response.GetProfileResponse
+//	@Failure		401			{string}	Unauthorized
+//	@Failure		404			{string}	no	such	user
+//	@Failure		500			{string}	internal	error
+//	@Router			/api/v1/users/{username}/profile [get]
+func (h *Handler) GetUserProfile(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringParamFromURL(req, "username")
+	if err != nil {
+		msg := fmt.Sprintf("invalid username provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, msg, msg)
+		return
+	}
+
+	profile, err := h.ProfileService.GetProfileByUsername(req.Context(), username)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+
+	render.JSON(rw, req, mapper.MapProfileToResponse(profile))
+	rw.WriteHeader(http.StatusOK)
+}
+
+// GetAvatar godoc
+//
+//	@Summary		Get avatar
+//	@Description	Download avatar image of user, its url is returned in profile and with public messages of user
+//	@Security		BasicAuth
+//	@Security		JWT
+//	@Tags			User
+//	@Produce		image/png,image/jpeg,image/gif,image/webp
+//	@Param			username	path		string	true	"username"
+//	@Success		200			{file}		binary
+//	@Failure		401			{string}	Unauthorized
+//	@Failure		404			{string}	Not	Found
+//	@Failure		500			{string}	internal	error
+//	@Router			/api/v1/users/{username}/avatar [get]
+func (h *Handler) GetAvatar(rw http.ResponseWriter, req *http.Request) {
+	username, err := handlerutils.GetStringParamFromURL(req, "username")
+	if err != nil {
+		msg := fmt.Sprintf("invalid username provided: %v", err)
+
+		handlerutils.WriteErrResponseAndLog(rw, h.logger, http.StatusBadRequest, msg, msg)
+		return
+	}
+
+	profile, content, err := h.ProfileService.GetAvatar(req.Context(), username)
+	if err != nil {
+		switchByErrorAndWriteResponse(err, rw, h.logger)
+		return
+	}
+	defer content.Close()
+
+	// avatars are images only, anything else is not rendered by browser
+	contentType := profile.AvatarContentType
+	if !strings.HasPrefix(contentType, "image/") {
+		contentType = "application/octet-stream"
+	}
+
+	rw.Header().Set("Content-Type", contentType)
+	rw.Header().Set("X-Content-Type-Options", "nosniff")
+	rw.WriteHeader(http.StatusOK)
+
+	if _, err = io.Copy(rw, content); err != nil {
+		h.logger.Errorf("error occurred writing avatar content: %s", err)
+	}
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/attachment (interfaces: ChannelRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAttachmentChannelRepo is a mock of ChannelRepo interface.
+type MockAttachmentChannelRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentChannelRepoMockRecorder
+}
+
+// MockAttachmentChannelRepoMockRecorder is the mock recorder for MockAttachmentChannelRepo.
+type MockAttachmentChannelRepoMockRecorder struct {
+	mock *MockAttachmentChannelRepo
+}
+
+// NewMockAttachmentChannelRepo creates a new mock instance.
+func NewMockAttachmentChannelRepo(ctrl *gomock.Controller) *MockAttachmentChannelRepo {
+	mock := &MockAttachmentChannelRepo{ctrl: ctrl}
+	mock.recorder = &MockAttachmentChannelRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentChannelRepo) EXPECT() *MockAttachmentChannelRepoMockRecorder {
+	return m.recorder
+}
+
+// IsChannelMember mocks base method.
+func (m *MockAttachmentChannelRepo) IsChannelMember(arg0 context.Context, arg1 int, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsChannelMember", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsChannelMember indicates an expected call of IsChannelMember.
+func (mr *MockAttachmentChannelRepoMockRecorder) IsChannelMember(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsChannelMember", reflect.TypeOf((*MockAttachmentChannelRepo)(nil).IsChannelMember), arg0, arg1, arg2)
+}
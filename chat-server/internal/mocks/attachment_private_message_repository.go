@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/attachment (interfaces: PrivateMessageRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAttachmentPrivateMessageRepo is a mock of PrivateMessageRepo interface.
+type MockAttachmentPrivateMessageRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentPrivateMessageRepoMockRecorder
+}
+
+// MockAttachmentPrivateMessageRepoMockRecorder is the mock recorder for MockAttachmentPrivateMessageRepo.
+type MockAttachmentPrivateMessageRepoMockRecorder struct {
+	mock *MockAttachmentPrivateMessageRepo
+}
+
+// NewMockAttachmentPrivateMessageRepo creates a new mock instance.
+func NewMockAttachmentPrivateMessageRepo(ctrl *gomock.Controller) *MockAttachmentPrivateMessageRepo {
+	mock := &MockAttachmentPrivateMessageRepo{ctrl: ctrl}
+	mock.recorder = &MockAttachmentPrivateMessageRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentPrivateMessageRepo) EXPECT() *MockAttachmentPrivateMessageRepoMockRecorder {
+	return m.recorder
+}
+
+// GetPrivateMessage mocks base method.
+func (m *MockAttachmentPrivateMessageRepo) GetPrivateMessage(arg0 context.Context, arg1 int) (*entity.PrivateMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivateMessage", arg0, arg1)
+	ret0, _ := ret[0].(*entity.PrivateMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrivateMessage indicates an expected call of GetPrivateMessage.
+func (mr *MockAttachmentPrivateMessageRepoMockRecorder) GetPrivateMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivateMessage", reflect.TypeOf((*MockAttachmentPrivateMessageRepo)(nil).GetPrivateMessage), arg0, arg1)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/attachment (interfaces: PublicMessageRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAttachmentPublicMessageRepo is a mock of PublicMessageRepo interface.
+type MockAttachmentPublicMessageRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentPublicMessageRepoMockRecorder
+}
+
+// MockAttachmentPublicMessageRepoMockRecorder is the mock recorder for MockAttachmentPublicMessageRepo.
+type MockAttachmentPublicMessageRepoMockRecorder struct {
+	mock *MockAttachmentPublicMessageRepo
+}
+
+// NewMockAttachmentPublicMessageRepo creates a new mock instance.
+func NewMockAttachmentPublicMessageRepo(ctrl *gomock.Controller) *MockAttachmentPublicMessageRepo {
+	mock := &MockAttachmentPublicMessageRepo{ctrl: ctrl}
+	mock.recorder = &MockAttachmentPublicMessageRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentPublicMessageRepo) EXPECT() *MockAttachmentPublicMessageRepoMockRecorder {
+	return m.recorder
+}
+
+// GetPublicMessage mocks base method.
+func (m *MockAttachmentPublicMessageRepo) GetPublicMessage(arg0 context.Context, arg1 int) (*entity.PublicMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicMessage", arg0, arg1)
+	ret0, _ := ret[0].(*entity.PublicMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicMessage indicates an expected call of GetPublicMessage.
+func (mr *MockAttachmentPublicMessageRepoMockRecorder) GetPublicMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicMessage", reflect.TypeOf((*MockAttachmentPublicMessageRepo)(nil).GetPublicMessage), arg0, arg1)
+}
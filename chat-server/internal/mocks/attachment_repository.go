@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/attachment (interfaces: AttachmentRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAttachmentRepo is a mock of AttachmentRepo interface.
+type MockAttachmentRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentRepoMockRecorder
+}
+
+// MockAttachmentRepoMockRecorder is the mock recorder for MockAttachmentRepo.
+type MockAttachmentRepoMockRecorder struct {
+	mock *MockAttachmentRepo
+}
+
+// NewMockAttachmentRepo creates a new mock instance.
+func NewMockAttachmentRepo(ctrl *gomock.Controller) *MockAttachmentRepo {
+	mock := &MockAttachmentRepo{ctrl: ctrl}
+	mock.recorder = &MockAttachmentRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentRepo) EXPECT() *MockAttachmentRepoMockRecorder {
+	return m.recorder
+}
+
+// AddAttachment mocks base method.
+func (m *MockAttachmentRepo) AddAttachment(arg0 context.Context, arg1 entity.Attachment) (*entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddAttachment", arg0, arg1)
+	ret0, _ := ret[0].(*entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddAttachment indicates an expected call of AddAttachment.
+func (mr *MockAttachmentRepoMockRecorder) AddAttachment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddAttachment", reflect.TypeOf((*MockAttachmentRepo)(nil).AddAttachment), arg0, arg1)
+}
+
+// DeleteMessageAttachments mocks base method.
+func (m *MockAttachmentRepo) DeleteMessageAttachments(arg0 context.Context, arg1 entity.MessageKind, arg2 int) ([]*entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteMessageAttachments", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteMessageAttachments indicates an expected call of DeleteMessageAttachments.
+func (mr *MockAttachmentRepoMockRecorder) DeleteMessageAttachments(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteMessageAttachments", reflect.TypeOf((*MockAttachmentRepo)(nil).DeleteMessageAttachments), arg0, arg1, arg2)
+}
+
+// GetAttachment mocks base method.
+func (m *MockAttachmentRepo) GetAttachment(arg0 context.Context, arg1 int) (*entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachment", arg0, arg1)
+	ret0, _ := ret[0].(*entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachment indicates an expected call of GetAttachment.
+func (mr *MockAttachmentRepoMockRecorder) GetAttachment(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachment", reflect.TypeOf((*MockAttachmentRepo)(nil).GetAttachment), arg0, arg1)
+}
@@ -0,0 +1,47 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/auth (interfaces: Notifier)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAuthNotifier is a mock of Notifier interface.
+type MockAuthNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthNotifierMockRecorder
+}
+
+// MockAuthNotifierMockRecorder is the mock recorder for MockAuthNotifier.
+type MockAuthNotifierMockRecorder struct {
+	mock *MockAuthNotifier
+}
+
+// NewMockAuthNotifier creates a new mock instance.
+func NewMockAuthNotifier(ctrl *gomock.Controller) *MockAuthNotifier {
+	mock := &MockAuthNotifier{ctrl: ctrl}
+	mock.recorder = &MockAuthNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthNotifier) EXPECT() *MockAuthNotifierMockRecorder {
+	return m.recorder
+}
+
+// NotifySessionRevoked mocks base method.
+func (m *MockAuthNotifier) NotifySessionRevoked(arg0 context.Context, arg1 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifySessionRevoked", arg0, arg1)
+}
+
+// NotifySessionRevoked indicates an expected call of NotifySessionRevoked.
+func (mr *MockAuthNotifierMockRecorder) NotifySessionRevoked(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifySessionRevoked", reflect.TypeOf((*MockAuthNotifier)(nil).NotifySessionRevoked), arg0, arg1)
+}
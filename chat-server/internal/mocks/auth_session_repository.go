@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/auth (interfaces: SessionRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAuthSessionRepo is a mock of SessionRepo interface.
+type MockAuthSessionRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuthSessionRepoMockRecorder
+}
+
+// MockAuthSessionRepoMockRecorder is the mock recorder for MockAuthSessionRepo.
+type MockAuthSessionRepoMockRecorder struct {
+	mock *MockAuthSessionRepo
+}
+
+// NewMockAuthSessionRepo creates a new mock instance.
+func NewMockAuthSessionRepo(ctrl *gomock.Controller) *MockAuthSessionRepo {
+	mock := &MockAuthSessionRepo{ctrl: ctrl}
+	mock.recorder = &MockAuthSessionRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuthSessionRepo) EXPECT() *MockAuthSessionRepoMockRecorder {
+	return m.recorder
+}
+
+// AddSession mocks base method.
+func (m *MockAuthSessionRepo) AddSession(arg0 context.Context, arg1 entity.Session) (*entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSession", arg0, arg1)
+	ret0, _ := ret[0].(*entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddSession indicates an expected call of AddSession.
+func (mr *MockAuthSessionRepoMockRecorder) AddSession(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSession", reflect.TypeOf((*MockAuthSessionRepo)(nil).AddSession), arg0, arg1)
+}
+
+// ExtendSession mocks base method.
+func (m *MockAuthSessionRepo) ExtendSession(arg0 context.Context, arg1 string, arg2, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExtendSession", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExtendSession indicates an expected call of ExtendSession.
+func (mr *MockAuthSessionRepoMockRecorder) ExtendSession(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExtendSession", reflect.TypeOf((*MockAuthSessionRepo)(nil).ExtendSession), arg0, arg1, arg2, arg3)
+}
+
+// RevokeSession mocks base method.
+func (m *MockAuthSessionRepo) RevokeSession(arg0 context.Context, arg1 string, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockAuthSessionRepoMockRecorder) RevokeSession(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockAuthSessionRepo)(nil).RevokeSession), arg0, arg1, arg2)
+}
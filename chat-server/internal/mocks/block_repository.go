@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/block (interfaces: BlockRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockBlockRepo is a mock of BlockRepo interface.
+type MockBlockRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlockRepoMockRecorder
+}
+
+// MockBlockRepoMockRecorder is the mock recorder for MockBlockRepo.
+type MockBlockRepoMockRecorder struct {
+	mock *MockBlockRepo
+}
+
+// NewMockBlockRepo creates a new mock instance.
+func NewMockBlockRepo(ctrl *gomock.Controller) *MockBlockRepo {
+	mock := &MockBlockRepo{ctrl: ctrl}
+	mock.recorder = &MockBlockRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlockRepo) EXPECT() *MockBlockRepoMockRecorder {
+	return m.recorder
+}
+
+// AddBlock mocks base method.
+func (m *MockBlockRepo) AddBlock(arg0 context.Context, arg1 entity.Block) (*entity.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddBlock", arg0, arg1)
+	ret0, _ := ret[0].(*entity.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddBlock indicates an expected call of AddBlock.
+func (mr *MockBlockRepoMockRecorder) AddBlock(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddBlock", reflect.TypeOf((*MockBlockRepo)(nil).AddBlock), arg0, arg1)
+}
+
+// GetBlocks mocks base method.
+func (m *MockBlockRepo) GetBlocks(arg0 context.Context, arg1 string) ([]*entity.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocks", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocks indicates an expected call of GetBlocks.
+func (mr *MockBlockRepoMockRecorder) GetBlocks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocks", reflect.TypeOf((*MockBlockRepo)(nil).GetBlocks), arg0, arg1)
+}
+
+// RemoveBlock mocks base method.
+func (m *MockBlockRepo) RemoveBlock(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveBlock", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveBlock indicates an expected call of RemoveBlock.
+func (mr *MockBlockRepoMockRecorder) RemoveBlock(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveBlock", reflect.TypeOf((*MockBlockRepo)(nil).RemoveBlock), arg0, arg1, arg2)
+}
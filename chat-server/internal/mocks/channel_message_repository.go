@@ -0,0 +1,154 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/channel (interfaces: PublicMessageRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockChannelMessageRepo is a mock of PublicMessageRepo interface.
+type MockChannelMessageRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockChannelMessageRepoMockRecorder
+}
+
+// MockChannelMessageRepoMockRecorder is the mock recorder for MockChannelMessageRepo.
+type MockChannelMessageRepoMockRecorder struct {
+	mock *MockChannelMessageRepo
+}
+
+// NewMockChannelMessageRepo creates a new mock instance.
+func NewMockChannelMessageRepo(ctrl *gomock.Controller) *MockChannelMessageRepo {
+	mock := &MockChannelMessageRepo{ctrl: ctrl}
+	mock.recorder = &MockChannelMessageRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChannelMessageRepo) EXPECT() *MockChannelMessageRepoMockRecorder {
+	return m.recorder
+}
+
+// AddPublicMessage mocks base method.
+func (m *MockChannelMessageRepo) AddPublicMessage(arg0 context.Context, arg1 entity.PublicMessage) (*entity.PublicMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddPublicMessage", arg0, arg1)
+	ret0, _ := ret[0].(*entity.PublicMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddPublicMessage indicates an expected call of AddPublicMessage.
+func (mr *MockChannelMessageRepoMockRecorder) AddPublicMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPublicMessage", reflect.TypeOf((*MockChannelMessageRepo)(nil).AddPublicMessage), arg0, arg1)
+}
+
+// CountPublicMessageReplies mocks base method.
+func (m *MockChannelMessageRepo) CountPublicMessageReplies(arg0 context.Context, arg1 []int) (map[int]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPublicMessageReplies", arg0, arg1)
+	ret0, _ := ret[0].(map[int]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPublicMessageReplies indicates an expected call of CountPublicMessageReplies.
+func (mr *MockChannelMessageRepoMockRecorder) CountPublicMessageReplies(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPublicMessageReplies", reflect.TypeOf((*MockChannelMessageRepo)(nil).CountPublicMessageReplies), arg0, arg1)
+}
+
+// DeletePublicMessage mocks base method.
+func (m *MockChannelMessageRepo) DeletePublicMessage(arg0 context.Context, arg1 int) (*entity.PublicMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePublicMessage", arg0, arg1)
+	ret0, _ := ret[0].(*entity.PublicMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePublicMessage indicates an expected call of DeletePublicMessage.
+func (mr *MockChannelMessageRepoMockRecorder) DeletePublicMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePublicMessage", reflect.TypeOf((*MockChannelMessageRepo)(nil).DeletePublicMessage), arg0, arg1)
+}
+
+// GetChannelMessages mocks base method.
+func (m *MockChannelMessageRepo) GetChannelMessages(arg0 context.Context, arg1, arg2, arg3 int) []*entity.PublicMessage {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelMessages", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*entity.PublicMessage)
+	return ret0
+}
+
+// GetChannelMessages indicates an expected call of GetChannelMessages.
+func (mr *MockChannelMessageRepoMockRecorder) GetChannelMessages(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelMessages", reflect.TypeOf((*MockChannelMessageRepo)(nil).GetChannelMessages), arg0, arg1, arg2, arg3)
+}
+
+// GetPublicMessage mocks base method.
+func (m *MockChannelMessageRepo) GetPublicMessage(arg0 context.Context, arg1 int) (*entity.PublicMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicMessage", arg0, arg1)
+	ret0, _ := ret[0].(*entity.PublicMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicMessage indicates an expected call of GetPublicMessage.
+func (mr *MockChannelMessageRepoMockRecorder) GetPublicMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicMessage", reflect.TypeOf((*MockChannelMessageRepo)(nil).GetPublicMessage), arg0, arg1)
+}
+
+// GetPublicMessageReplies mocks base method.
+func (m *MockChannelMessageRepo) GetPublicMessageReplies(arg0 context.Context, arg1, arg2, arg3 int) []*entity.PublicMessage {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicMessageReplies", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*entity.PublicMessage)
+	return ret0
+}
+
+// GetPublicMessageReplies indicates an expected call of GetPublicMessageReplies.
+func (mr *MockChannelMessageRepoMockRecorder) GetPublicMessageReplies(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicMessageReplies", reflect.TypeOf((*MockChannelMessageRepo)(nil).GetPublicMessageReplies), arg0, arg1, arg2, arg3)
+}
+
+// GetPublicMessageRevisions mocks base method.
+func (m *MockChannelMessageRepo) GetPublicMessageRevisions(arg0 context.Context, arg1 int) ([]*entity.MessageRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicMessageRevisions", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.MessageRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicMessageRevisions indicates an expected call of GetPublicMessageRevisions.
+func (mr *MockChannelMessageRepoMockRecorder) GetPublicMessageRevisions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicMessageRevisions", reflect.TypeOf((*MockChannelMessageRepo)(nil).GetPublicMessageRevisions), arg0, arg1)
+}
+
+// UpdatePublicMessage mocks base method.
+func (m *MockChannelMessageRepo) UpdatePublicMessage(arg0 context.Context, arg1 int, arg2 entity.PublicMessage) (*entity.PublicMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePublicMessage", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.PublicMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePublicMessage indicates an expected call of UpdatePublicMessage.
+func (mr *MockChannelMessageRepoMockRecorder) UpdatePublicMessage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePublicMessage", reflect.TypeOf((*MockChannelMessageRepo)(nil).UpdatePublicMessage), arg0, arg1, arg2)
+}
@@ -0,0 +1,120 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/channel (interfaces: Notifier)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockChannelNotifier is a mock of Notifier interface.
+type MockChannelNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockChannelNotifierMockRecorder
+}
+
+// MockChannelNotifierMockRecorder is the mock recorder for MockChannelNotifier.
+type MockChannelNotifierMockRecorder struct {
+	mock *MockChannelNotifier
+}
+
+// NewMockChannelNotifier creates a new mock instance.
+func NewMockChannelNotifier(ctrl *gomock.Controller) *MockChannelNotifier {
+	mock := &MockChannelNotifier{ctrl: ctrl}
+	mock.recorder = &MockChannelNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChannelNotifier) EXPECT() *MockChannelNotifierMockRecorder {
+	return m.recorder
+}
+
+// NotifyChannelMessage mocks base method.
+func (m *MockChannelNotifier) NotifyChannelMessage(arg0 context.Context, arg1 *entity.PublicMessage, arg2 []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyChannelMessage", arg0, arg1, arg2)
+}
+
+// NotifyChannelMessage indicates an expected call of NotifyChannelMessage.
+func (mr *MockChannelNotifierMockRecorder) NotifyChannelMessage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyChannelMessage", reflect.TypeOf((*MockChannelNotifier)(nil).NotifyChannelMessage), arg0, arg1, arg2)
+}
+
+// NotifyChannelMessageDeleted mocks base method.
+func (m *MockChannelNotifier) NotifyChannelMessageDeleted(arg0 context.Context, arg1 *entity.PublicMessage, arg2 []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyChannelMessageDeleted", arg0, arg1, arg2)
+}
+
+// NotifyChannelMessageDeleted indicates an expected call of NotifyChannelMessageDeleted.
+func (mr *MockChannelNotifierMockRecorder) NotifyChannelMessageDeleted(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyChannelMessageDeleted", reflect.TypeOf((*MockChannelNotifier)(nil).NotifyChannelMessageDeleted), arg0, arg1, arg2)
+}
+
+// NotifyChannelMessageEdited mocks base method.
+func (m *MockChannelNotifier) NotifyChannelMessageEdited(arg0 context.Context, arg1 *entity.PublicMessage, arg2 []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyChannelMessageEdited", arg0, arg1, arg2)
+}
+
+// NotifyChannelMessageEdited indicates an expected call of NotifyChannelMessageEdited.
+func (mr *MockChannelNotifierMockRecorder) NotifyChannelMessageEdited(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyChannelMessageEdited", reflect.TypeOf((*MockChannelNotifier)(nil).NotifyChannelMessageEdited), arg0, arg1, arg2)
+}
+
+// NotifyMention mocks base method.
+func (m *MockChannelNotifier) NotifyMention(arg0 context.Context, arg1 *entity.Mention) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyMention", arg0, arg1)
+}
+
+// NotifyMention indicates an expected call of NotifyMention.
+func (mr *MockChannelNotifierMockRecorder) NotifyMention(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyMention", reflect.TypeOf((*MockChannelNotifier)(nil).NotifyMention), arg0, arg1)
+}
+
+// NotifyPublicMessage mocks base method.
+func (m *MockChannelNotifier) NotifyPublicMessage(arg0 context.Context, arg1 *entity.PublicMessage) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyPublicMessage", arg0, arg1)
+}
+
+// NotifyPublicMessage indicates an expected call of NotifyPublicMessage.
+func (mr *MockChannelNotifierMockRecorder) NotifyPublicMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyPublicMessage", reflect.TypeOf((*MockChannelNotifier)(nil).NotifyPublicMessage), arg0, arg1)
+}
+
+// NotifyPublicMessageDeleted mocks base method.
+func (m *MockChannelNotifier) NotifyPublicMessageDeleted(arg0 context.Context, arg1 *entity.PublicMessage) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyPublicMessageDeleted", arg0, arg1)
+}
+
+// NotifyPublicMessageDeleted indicates an expected call of NotifyPublicMessageDeleted.
+func (mr *MockChannelNotifierMockRecorder) NotifyPublicMessageDeleted(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyPublicMessageDeleted", reflect.TypeOf((*MockChannelNotifier)(nil).NotifyPublicMessageDeleted), arg0, arg1)
+}
+
+// NotifyPublicMessageEdited mocks base method.
+func (m *MockChannelNotifier) NotifyPublicMessageEdited(arg0 context.Context, arg1 *entity.PublicMessage) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyPublicMessageEdited", arg0, arg1)
+}
+
+// NotifyPublicMessageEdited indicates an expected call of NotifyPublicMessageEdited.
+func (mr *MockChannelNotifierMockRecorder) NotifyPublicMessageEdited(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyPublicMessageEdited", reflect.TypeOf((*MockChannelNotifier)(nil).NotifyPublicMessageEdited), arg0, arg1)
+}
@@ -0,0 +1,138 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/channel (interfaces: ChannelRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockChannelRepo is a mock of ChannelRepo interface.
+type MockChannelRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockChannelRepoMockRecorder
+}
+
+// MockChannelRepoMockRecorder is the mock recorder for MockChannelRepo.
+type MockChannelRepoMockRecorder struct {
+	mock *MockChannelRepo
+}
+
+// NewMockChannelRepo creates a new mock instance.
+func NewMockChannelRepo(ctrl *gomock.Controller) *MockChannelRepo {
+	mock := &MockChannelRepo{ctrl: ctrl}
+	mock.recorder = &MockChannelRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockChannelRepo) EXPECT() *MockChannelRepoMockRecorder {
+	return m.recorder
+}
+
+// AddChannel mocks base method.
+func (m *MockChannelRepo) AddChannel(arg0 context.Context, arg1 entity.Channel) (*entity.Channel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddChannel", arg0, arg1)
+	ret0, _ := ret[0].(*entity.Channel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddChannel indicates an expected call of AddChannel.
+func (mr *MockChannelRepoMockRecorder) AddChannel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddChannel", reflect.TypeOf((*MockChannelRepo)(nil).AddChannel), arg0, arg1)
+}
+
+// AddChannelMember mocks base method.
+func (m *MockChannelRepo) AddChannelMember(arg0 context.Context, arg1 int, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddChannelMember", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddChannelMember indicates an expected call of AddChannelMember.
+func (mr *MockChannelRepoMockRecorder) AddChannelMember(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddChannelMember", reflect.TypeOf((*MockChannelRepo)(nil).AddChannelMember), arg0, arg1, arg2)
+}
+
+// GetAllChannels mocks base method.
+func (m *MockChannelRepo) GetAllChannels(arg0 context.Context, arg1, arg2 int) []*entity.Channel {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllChannels", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*entity.Channel)
+	return ret0
+}
+
+// GetAllChannels indicates an expected call of GetAllChannels.
+func (mr *MockChannelRepoMockRecorder) GetAllChannels(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllChannels", reflect.TypeOf((*MockChannelRepo)(nil).GetAllChannels), arg0, arg1, arg2)
+}
+
+// GetChannel mocks base method.
+func (m *MockChannelRepo) GetChannel(arg0 context.Context, arg1 int) (*entity.Channel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannel", arg0, arg1)
+	ret0, _ := ret[0].(*entity.Channel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannel indicates an expected call of GetChannel.
+func (mr *MockChannelRepoMockRecorder) GetChannel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannel", reflect.TypeOf((*MockChannelRepo)(nil).GetChannel), arg0, arg1)
+}
+
+// GetChannelMembers mocks base method.
+func (m *MockChannelRepo) GetChannelMembers(arg0 context.Context, arg1 int) ([]*entity.ChannelMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelMembers", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.ChannelMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannelMembers indicates an expected call of GetChannelMembers.
+func (mr *MockChannelRepoMockRecorder) GetChannelMembers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelMembers", reflect.TypeOf((*MockChannelRepo)(nil).GetChannelMembers), arg0, arg1)
+}
+
+// IsChannelMember mocks base method.
+func (m *MockChannelRepo) IsChannelMember(arg0 context.Context, arg1 int, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsChannelMember", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsChannelMember indicates an expected call of IsChannelMember.
+func (mr *MockChannelRepoMockRecorder) IsChannelMember(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsChannelMember", reflect.TypeOf((*MockChannelRepo)(nil).IsChannelMember), arg0, arg1, arg2)
+}
+
+// RemoveChannelMember mocks base method.
+func (m *MockChannelRepo) RemoveChannelMember(arg0 context.Context, arg1 int, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveChannelMember", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveChannelMember indicates an expected call of RemoveChannelMember.
+func (mr *MockChannelRepoMockRecorder) RemoveChannelMember(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveChannelMember", reflect.TypeOf((*MockChannelRepo)(nil).RemoveChannelMember), arg0, arg1, arg2)
+}
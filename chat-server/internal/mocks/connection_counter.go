@@ -0,0 +1,48 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/presence (interfaces: ConnectionCounter)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockConnectionCounter is a mock of ConnectionCounter interface.
+type MockConnectionCounter struct {
+	ctrl     *gomock.Controller
+	recorder *MockConnectionCounterMockRecorder
+}
+
+// MockConnectionCounterMockRecorder is the mock recorder for MockConnectionCounter.
+type MockConnectionCounterMockRecorder struct {
+	mock *MockConnectionCounter
+}
+
+// NewMockConnectionCounter creates a new mock instance.
+func NewMockConnectionCounter(ctrl *gomock.Controller) *MockConnectionCounter {
+	mock := &MockConnectionCounter{ctrl: ctrl}
+	mock.recorder = &MockConnectionCounterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConnectionCounter) EXPECT() *MockConnectionCounterMockRecorder {
+	return m.recorder
+}
+
+// ConnectionsCount mocks base method.
+func (m *MockConnectionCounter) ConnectionsCount(arg0 string) int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConnectionsCount", arg0)
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// ConnectionsCount indicates an expected call of ConnectionsCount.
+func (mr *MockConnectionCounterMockRecorder) ConnectionsCount(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConnectionsCount", reflect.TypeOf((*MockConnectionCounter)(nil).ConnectionsCount), arg0)
+}
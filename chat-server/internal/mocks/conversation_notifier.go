@@ -0,0 +1,48 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/conversation (interfaces: Notifier)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockConversationNotifier is a mock of Notifier interface.
+type MockConversationNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockConversationNotifierMockRecorder
+}
+
+// MockConversationNotifierMockRecorder is the mock recorder for MockConversationNotifier.
+type MockConversationNotifierMockRecorder struct {
+	mock *MockConversationNotifier
+}
+
+// NewMockConversationNotifier creates a new mock instance.
+func NewMockConversationNotifier(ctrl *gomock.Controller) *MockConversationNotifier {
+	mock := &MockConversationNotifier{ctrl: ctrl}
+	mock.recorder = &MockConversationNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConversationNotifier) EXPECT() *MockConversationNotifierMockRecorder {
+	return m.recorder
+}
+
+// NotifyConversationMessage mocks base method.
+func (m *MockConversationNotifier) NotifyConversationMessage(arg0 context.Context, arg1 *entity.ConversationMessage, arg2 []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyConversationMessage", arg0, arg1, arg2)
+}
+
+// NotifyConversationMessage indicates an expected call of NotifyConversationMessage.
+func (mr *MockConversationNotifierMockRecorder) NotifyConversationMessage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyConversationMessage", reflect.TypeOf((*MockConversationNotifier)(nil).NotifyConversationMessage), arg0, arg1, arg2)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/conversation (interfaces: PrivateMessageRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockConversationPrivateMessageRepo is a mock of PrivateMessageRepo interface.
+type MockConversationPrivateMessageRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockConversationPrivateMessageRepoMockRecorder
+}
+
+// MockConversationPrivateMessageRepoMockRecorder is the mock recorder for MockConversationPrivateMessageRepo.
+type MockConversationPrivateMessageRepoMockRecorder struct {
+	mock *MockConversationPrivateMessageRepo
+}
+
+// NewMockConversationPrivateMessageRepo creates a new mock instance.
+func NewMockConversationPrivateMessageRepo(ctrl *gomock.Controller) *MockConversationPrivateMessageRepo {
+	mock := &MockConversationPrivateMessageRepo{ctrl: ctrl}
+	mock.recorder = &MockConversationPrivateMessageRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConversationPrivateMessageRepo) EXPECT() *MockConversationPrivateMessageRepoMockRecorder {
+	return m.recorder
+}
+
+// GetAllPrivateMessages mocks base method.
+func (m *MockConversationPrivateMessageRepo) GetAllPrivateMessages(arg0 context.Context, arg1, arg2 int) []*entity.PrivateMessage {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAllPrivateMessages", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*entity.PrivateMessage)
+	return ret0
+}
+
+// GetAllPrivateMessages indicates an expected call of GetAllPrivateMessages.
+func (mr *MockConversationPrivateMessageRepoMockRecorder) GetAllPrivateMessages(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPrivateMessages", reflect.TypeOf((*MockConversationPrivateMessageRepo)(nil).GetAllPrivateMessages), arg0, arg1, arg2)
+}
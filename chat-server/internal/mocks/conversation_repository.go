@@ -0,0 +1,183 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/conversation (interfaces: ConversationRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockConversationRepo is a mock of ConversationRepo interface.
+type MockConversationRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockConversationRepoMockRecorder
+}
+
+// MockConversationRepoMockRecorder is the mock recorder for MockConversationRepo.
+type MockConversationRepoMockRecorder struct {
+	mock *MockConversationRepo
+}
+
+// NewMockConversationRepo creates a new mock instance.
+func NewMockConversationRepo(ctrl *gomock.Controller) *MockConversationRepo {
+	mock := &MockConversationRepo{ctrl: ctrl}
+	mock.recorder = &MockConversationRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConversationRepo) EXPECT() *MockConversationRepoMockRecorder {
+	return m.recorder
+}
+
+// AddConversation mocks base method.
+func (m *MockConversationRepo) AddConversation(arg0 context.Context, arg1 entity.Conversation) (*entity.Conversation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddConversation", arg0, arg1)
+	ret0, _ := ret[0].(*entity.Conversation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddConversation indicates an expected call of AddConversation.
+func (mr *MockConversationRepoMockRecorder) AddConversation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddConversation", reflect.TypeOf((*MockConversationRepo)(nil).AddConversation), arg0, arg1)
+}
+
+// AddConversationMessage mocks base method.
+func (m *MockConversationRepo) AddConversationMessage(arg0 context.Context, arg1 entity.ConversationMessage) (*entity.ConversationMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddConversationMessage", arg0, arg1)
+	ret0, _ := ret[0].(*entity.ConversationMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddConversationMessage indicates an expected call of AddConversationMessage.
+func (mr *MockConversationRepoMockRecorder) AddConversationMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddConversationMessage", reflect.TypeOf((*MockConversationRepo)(nil).AddConversationMessage), arg0, arg1)
+}
+
+// AddConversationParticipant mocks base method.
+func (m *MockConversationRepo) AddConversationParticipant(arg0 context.Context, arg1 int, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddConversationParticipant", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddConversationParticipant indicates an expected call of AddConversationParticipant.
+func (mr *MockConversationRepoMockRecorder) AddConversationParticipant(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddConversationParticipant", reflect.TypeOf((*MockConversationRepo)(nil).AddConversationParticipant), arg0, arg1, arg2)
+}
+
+// GetConversation mocks base method.
+func (m *MockConversationRepo) GetConversation(arg0 context.Context, arg1 int) (*entity.Conversation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConversation", arg0, arg1)
+	ret0, _ := ret[0].(*entity.Conversation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConversation indicates an expected call of GetConversation.
+func (mr *MockConversationRepoMockRecorder) GetConversation(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConversation", reflect.TypeOf((*MockConversationRepo)(nil).GetConversation), arg0, arg1)
+}
+
+// GetConversationMessages mocks base method.
+func (m *MockConversationRepo) GetConversationMessages(arg0 context.Context, arg1, arg2, arg3 int) []*entity.ConversationMessage {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConversationMessages", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*entity.ConversationMessage)
+	return ret0
+}
+
+// GetConversationMessages indicates an expected call of GetConversationMessages.
+func (mr *MockConversationRepoMockRecorder) GetConversationMessages(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConversationMessages", reflect.TypeOf((*MockConversationRepo)(nil).GetConversationMessages), arg0, arg1, arg2, arg3)
+}
+
+// GetConversationParticipants mocks base method.
+func (m *MockConversationRepo) GetConversationParticipants(arg0 context.Context, arg1 int) ([]*entity.ConversationParticipant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConversationParticipants", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.ConversationParticipant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConversationParticipants indicates an expected call of GetConversationParticipants.
+func (mr *MockConversationRepoMockRecorder) GetConversationParticipants(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConversationParticipants", reflect.TypeOf((*MockConversationRepo)(nil).GetConversationParticipants), arg0, arg1)
+}
+
+// GetLastConversationMessage mocks base method.
+func (m *MockConversationRepo) GetLastConversationMessage(arg0 context.Context, arg1 int) (*entity.ConversationMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastConversationMessage", arg0, arg1)
+	ret0, _ := ret[0].(*entity.ConversationMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastConversationMessage indicates an expected call of GetLastConversationMessage.
+func (mr *MockConversationRepoMockRecorder) GetLastConversationMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastConversationMessage", reflect.TypeOf((*MockConversationRepo)(nil).GetLastConversationMessage), arg0, arg1)
+}
+
+// GetUserConversations mocks base method.
+func (m *MockConversationRepo) GetUserConversations(arg0 context.Context, arg1 string) ([]*entity.Conversation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserConversations", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.Conversation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserConversations indicates an expected call of GetUserConversations.
+func (mr *MockConversationRepoMockRecorder) GetUserConversations(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserConversations", reflect.TypeOf((*MockConversationRepo)(nil).GetUserConversations), arg0, arg1)
+}
+
+// IsConversationParticipant mocks base method.
+func (m *MockConversationRepo) IsConversationParticipant(arg0 context.Context, arg1 int, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsConversationParticipant", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsConversationParticipant indicates an expected call of IsConversationParticipant.
+func (mr *MockConversationRepoMockRecorder) IsConversationParticipant(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsConversationParticipant", reflect.TypeOf((*MockConversationRepo)(nil).IsConversationParticipant), arg0, arg1, arg2)
+}
+
+// RemoveConversationParticipant mocks base method.
+func (m *MockConversationRepo) RemoveConversationParticipant(arg0 context.Context, arg1 int, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveConversationParticipant", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveConversationParticipant indicates an expected call of RemoveConversationParticipant.
+func (mr *MockConversationRepoMockRecorder) RemoveConversationParticipant(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveConversationParticipant", reflect.TypeOf((*MockConversationRepo)(nil).RemoveConversationParticipant), arg0, arg1, arg2)
+}
@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/ew0s/ewos-to-go-hw/http5/homework/chat-server/internal/service/user (interfaces: Hasher)
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/user (interfaces: Hasher)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -10,18 +10,18 @@ import (
 	gomock "github.com/golang/mock/gomock"
 )
 
-// MockAuthHasher is a mock of Hasher interface.
+// MockUserHasher is a mock of Hasher interface.
 type MockUserHasher struct {
 	ctrl     *gomock.Controller
 	recorder *MockUserHasherMockRecorder
 }
 
-// MockAuthHasherMockRecorder is the mock recorder for MockAuthHasher.
+// MockUserHasherMockRecorder is the mock recorder for MockUserHasher.
 type MockUserHasherMockRecorder struct {
 	mock *MockUserHasher
 }
 
-// NewMockAuthHasher creates a new mock instance.
+// NewMockUserHasher creates a new mock instance.
 func NewMockUserHasher(ctrl *gomock.Controller) *MockUserHasher {
 	mock := &MockUserHasher{ctrl: ctrl}
 	mock.recorder = &MockUserHasherMockRecorder{mock}
@@ -33,6 +33,20 @@ func (m *MockUserHasher) EXPECT() *MockUserHasherMockRecorder {
 	return m.recorder
 }
 
+// CompareHashAndPassword mocks base method.
+func (m *MockUserHasher) CompareHashAndPassword(arg0, arg1 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompareHashAndPassword", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompareHashAndPassword indicates an expected call of CompareHashAndPassword.
+func (mr *MockUserHasherMockRecorder) CompareHashAndPassword(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareHashAndPassword", reflect.TypeOf((*MockUserHasher)(nil).CompareHashAndPassword), arg0, arg1)
+}
+
 // GenerateFromPassword mocks base method.
 func (m *MockUserHasher) GenerateFromPassword(arg0 []byte, arg1 int) ([]byte, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/mention (interfaces: PublicMessageRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMentionPublicMessageRepo is a mock of PublicMessageRepo interface.
+type MockMentionPublicMessageRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockMentionPublicMessageRepoMockRecorder
+}
+
+// MockMentionPublicMessageRepoMockRecorder is the mock recorder for MockMentionPublicMessageRepo.
+type MockMentionPublicMessageRepoMockRecorder struct {
+	mock *MockMentionPublicMessageRepo
+}
+
+// NewMockMentionPublicMessageRepo creates a new mock instance.
+func NewMockMentionPublicMessageRepo(ctrl *gomock.Controller) *MockMentionPublicMessageRepo {
+	mock := &MockMentionPublicMessageRepo{ctrl: ctrl}
+	mock.recorder = &MockMentionPublicMessageRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMentionPublicMessageRepo) EXPECT() *MockMentionPublicMessageRepoMockRecorder {
+	return m.recorder
+}
+
+// GetPublicMessage mocks base method.
+func (m *MockMentionPublicMessageRepo) GetPublicMessage(arg0 context.Context, arg1 int) (*entity.PublicMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicMessage", arg0, arg1)
+	ret0, _ := ret[0].(*entity.PublicMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicMessage indicates an expected call of GetPublicMessage.
+func (mr *MockMentionPublicMessageRepoMockRecorder) GetPublicMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicMessage", reflect.TypeOf((*MockMentionPublicMessageRepo)(nil).GetPublicMessage), arg0, arg1)
+}
@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/mention (interfaces: MentionRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMentionRepo is a mock of MentionRepo interface.
+type MockMentionRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockMentionRepoMockRecorder
+}
+
+// MockMentionRepoMockRecorder is the mock recorder for MockMentionRepo.
+type MockMentionRepoMockRecorder struct {
+	mock *MockMentionRepo
+}
+
+// NewMockMentionRepo creates a new mock instance.
+func NewMockMentionRepo(ctrl *gomock.Controller) *MockMentionRepo {
+	mock := &MockMentionRepo{ctrl: ctrl}
+	mock.recorder = &MockMentionRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMentionRepo) EXPECT() *MockMentionRepoMockRecorder {
+	return m.recorder
+}
+
+// GetUserMentions mocks base method.
+func (m *MockMentionRepo) GetUserMentions(arg0 context.Context, arg1 string, arg2 bool, arg3, arg4 int) ([]*entity.Mention, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserMentions", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*entity.Mention)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserMentions indicates an expected call of GetUserMentions.
+func (mr *MockMentionRepoMockRecorder) GetUserMentions(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserMentions", reflect.TypeOf((*MockMentionRepo)(nil).GetUserMentions), arg0, arg1, arg2, arg3, arg4)
+}
+
+// MarkMentionsRead mocks base method.
+func (m *MockMentionRepo) MarkMentionsRead(arg0 context.Context, arg1 string, arg2 int, arg3 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkMentionsRead", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkMentionsRead indicates an expected call of MarkMentionsRead.
+func (mr *MockMentionRepoMockRecorder) MarkMentionsRead(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkMentionsRead", reflect.TypeOf((*MockMentionRepo)(nil).MarkMentionsRead), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message (interfaces: AttachmentRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMessageAttachmentRepo is a mock of AttachmentRepo interface.
+type MockMessageAttachmentRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageAttachmentRepoMockRecorder
+}
+
+// MockMessageAttachmentRepoMockRecorder is the mock recorder for MockMessageAttachmentRepo.
+type MockMessageAttachmentRepoMockRecorder struct {
+	mock *MockMessageAttachmentRepo
+}
+
+// NewMockMessageAttachmentRepo creates a new mock instance.
+func NewMockMessageAttachmentRepo(ctrl *gomock.Controller) *MockMessageAttachmentRepo {
+	mock := &MockMessageAttachmentRepo{ctrl: ctrl}
+	mock.recorder = &MockMessageAttachmentRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageAttachmentRepo) EXPECT() *MockMessageAttachmentRepoMockRecorder {
+	return m.recorder
+}
+
+// GetAttachments mocks base method.
+func (m *MockMessageAttachmentRepo) GetAttachments(arg0 context.Context, arg1 []int) ([]*entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAttachments", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAttachments indicates an expected call of GetAttachments.
+func (mr *MockMessageAttachmentRepoMockRecorder) GetAttachments(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAttachments", reflect.TypeOf((*MockMessageAttachmentRepo)(nil).GetAttachments), arg0, arg1)
+}
+
+// GetMessageAttachments mocks base method.
+func (m *MockMessageAttachmentRepo) GetMessageAttachments(arg0 context.Context, arg1 entity.MessageKind, arg2 []int) ([]*entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessageAttachments", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessageAttachments indicates an expected call of GetMessageAttachments.
+func (mr *MockMessageAttachmentRepoMockRecorder) GetMessageAttachments(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageAttachments", reflect.TypeOf((*MockMessageAttachmentRepo)(nil).GetMessageAttachments), arg0, arg1, arg2)
+}
+
+// LinkAttachments mocks base method.
+func (m *MockMessageAttachmentRepo) LinkAttachments(arg0 context.Context, arg1 entity.MessageKind, arg2 int, arg3 []int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LinkAttachments", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LinkAttachments indicates an expected call of LinkAttachments.
+func (mr *MockMessageAttachmentRepoMockRecorder) LinkAttachments(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LinkAttachments", reflect.TypeOf((*MockMessageAttachmentRepo)(nil).LinkAttachments), arg0, arg1, arg2, arg3)
+}
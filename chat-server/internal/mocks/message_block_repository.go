@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message (interfaces: BlockRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMessageBlockRepo is a mock of BlockRepo interface.
+type MockMessageBlockRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageBlockRepoMockRecorder
+}
+
+// MockMessageBlockRepoMockRecorder is the mock recorder for MockMessageBlockRepo.
+type MockMessageBlockRepoMockRecorder struct {
+	mock *MockMessageBlockRepo
+}
+
+// NewMockMessageBlockRepo creates a new mock instance.
+func NewMockMessageBlockRepo(ctrl *gomock.Controller) *MockMessageBlockRepo {
+	mock := &MockMessageBlockRepo{ctrl: ctrl}
+	mock.recorder = &MockMessageBlockRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageBlockRepo) EXPECT() *MockMessageBlockRepoMockRecorder {
+	return m.recorder
+}
+
+// GetBlocks mocks base method.
+func (m *MockMessageBlockRepo) GetBlocks(arg0 context.Context, arg1 string) ([]*entity.Block, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlocks", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.Block)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBlocks indicates an expected call of GetBlocks.
+func (mr *MockMessageBlockRepoMockRecorder) GetBlocks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlocks", reflect.TypeOf((*MockMessageBlockRepo)(nil).GetBlocks), arg0, arg1)
+}
+
+// IsBlocked mocks base method.
+func (m *MockMessageBlockRepo) IsBlocked(arg0 context.Context, arg1, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsBlocked", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsBlocked indicates an expected call of IsBlocked.
+func (mr *MockMessageBlockRepoMockRecorder) IsBlocked(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBlocked", reflect.TypeOf((*MockMessageBlockRepo)(nil).IsBlocked), arg0, arg1, arg2)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message (interfaces: ChannelRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMessageChannelRepo is a mock of ChannelRepo interface.
+type MockMessageChannelRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageChannelRepoMockRecorder
+}
+
+// MockMessageChannelRepoMockRecorder is the mock recorder for MockMessageChannelRepo.
+type MockMessageChannelRepoMockRecorder struct {
+	mock *MockMessageChannelRepo
+}
+
+// NewMockMessageChannelRepo creates a new mock instance.
+func NewMockMessageChannelRepo(ctrl *gomock.Controller) *MockMessageChannelRepo {
+	mock := &MockMessageChannelRepo{ctrl: ctrl}
+	mock.recorder = &MockMessageChannelRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageChannelRepo) EXPECT() *MockMessageChannelRepoMockRecorder {
+	return m.recorder
+}
+
+// GetChannelMembers mocks base method.
+func (m *MockMessageChannelRepo) GetChannelMembers(arg0 context.Context, arg1 int) ([]*entity.ChannelMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelMembers", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.ChannelMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannelMembers indicates an expected call of GetChannelMembers.
+func (mr *MockMessageChannelRepoMockRecorder) GetChannelMembers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelMembers", reflect.TypeOf((*MockMessageChannelRepo)(nil).GetChannelMembers), arg0, arg1)
+}
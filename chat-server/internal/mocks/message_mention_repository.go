@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message (interfaces: MentionRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMessageMentionRepo is a mock of MentionRepo interface.
+type MockMessageMentionRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageMentionRepoMockRecorder
+}
+
+// MockMessageMentionRepoMockRecorder is the mock recorder for MockMessageMentionRepo.
+type MockMessageMentionRepoMockRecorder struct {
+	mock *MockMessageMentionRepo
+}
+
+// NewMockMessageMentionRepo creates a new mock instance.
+func NewMockMessageMentionRepo(ctrl *gomock.Controller) *MockMessageMentionRepo {
+	mock := &MockMessageMentionRepo{ctrl: ctrl}
+	mock.recorder = &MockMessageMentionRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageMentionRepo) EXPECT() *MockMessageMentionRepoMockRecorder {
+	return m.recorder
+}
+
+// AddMentions mocks base method.
+func (m *MockMessageMentionRepo) AddMentions(arg0 context.Context, arg1 []entity.Mention) ([]*entity.Mention, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddMentions", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.Mention)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddMentions indicates an expected call of AddMentions.
+func (mr *MockMessageMentionRepoMockRecorder) AddMentions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddMentions", reflect.TypeOf((*MockMessageMentionRepo)(nil).AddMentions), arg0, arg1)
+}
+
+// GetMessageMentions mocks base method.
+func (m *MockMessageMentionRepo) GetMessageMentions(arg0 context.Context, arg1 entity.MessageKind, arg2 []int) ([]*entity.Mention, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMessageMentions", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*entity.Mention)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMessageMentions indicates an expected call of GetMessageMentions.
+func (mr *MockMessageMentionRepoMockRecorder) GetMessageMentions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMessageMentions", reflect.TypeOf((*MockMessageMentionRepo)(nil).GetMessageMentions), arg0, arg1, arg2)
+}
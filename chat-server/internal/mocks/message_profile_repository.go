@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message (interfaces: ProfileRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockMessageProfileRepo is a mock of ProfileRepo interface.
+type MockMessageProfileRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockMessageProfileRepoMockRecorder
+}
+
+// MockMessageProfileRepoMockRecorder is the mock recorder for MockMessageProfileRepo.
+type MockMessageProfileRepoMockRecorder struct {
+	mock *MockMessageProfileRepo
+}
+
+// NewMockMessageProfileRepo creates a new mock instance.
+func NewMockMessageProfileRepo(ctrl *gomock.Controller) *MockMessageProfileRepo {
+	mock := &MockMessageProfileRepo{ctrl: ctrl}
+	mock.recorder = &MockMessageProfileRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockMessageProfileRepo) EXPECT() *MockMessageProfileRepoMockRecorder {
+	return m.recorder
+}
+
+// GetProfilesByUsernames mocks base method.
+func (m *MockMessageProfileRepo) GetProfilesByUsernames(arg0 context.Context, arg1 []string) ([]*entity.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfilesByUsernames", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfilesByUsernames indicates an expected call of GetProfilesByUsernames.
+func (mr *MockMessageProfileRepoMockRecorder) GetProfilesByUsernames(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfilesByUsernames", reflect.TypeOf((*MockMessageProfileRepo)(nil).GetProfilesByUsernames), arg0, arg1)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/presence (interfaces: UserRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPresenceUserRepo is a mock of UserRepo interface.
+type MockPresenceUserRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPresenceUserRepoMockRecorder
+}
+
+// MockPresenceUserRepoMockRecorder is the mock recorder for MockPresenceUserRepo.
+type MockPresenceUserRepoMockRecorder struct {
+	mock *MockPresenceUserRepo
+}
+
+// NewMockPresenceUserRepo creates a new mock instance.
+func NewMockPresenceUserRepo(ctrl *gomock.Controller) *MockPresenceUserRepo {
+	mock := &MockPresenceUserRepo{ctrl: ctrl}
+	mock.recorder = &MockPresenceUserRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPresenceUserRepo) EXPECT() *MockPresenceUserRepoMockRecorder {
+	return m.recorder
+}
+
+// GetUserByUsername mocks base method.
+func (m *MockPresenceUserRepo) GetUserByUsername(arg0 context.Context, arg1 string) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByUsername", arg0, arg1)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByUsername indicates an expected call of GetUserByUsername.
+func (mr *MockPresenceUserRepoMockRecorder) GetUserByUsername(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockPresenceUserRepo)(nil).GetUserByUsername), arg0, arg1)
+}
+
+// UpdateLastSeen mocks base method.
+func (m *MockPresenceUserRepo) UpdateLastSeen(arg0 context.Context, arg1 string, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateLastSeen", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateLastSeen indicates an expected call of UpdateLastSeen.
+func (mr *MockPresenceUserRepoMockRecorder) UpdateLastSeen(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateLastSeen", reflect.TypeOf((*MockPresenceUserRepo)(nil).UpdateLastSeen), arg0, arg1, arg2)
+}
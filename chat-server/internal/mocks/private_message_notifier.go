@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message/private (interfaces: Notifier)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPrivateMessageNotifier is a mock of Notifier interface.
+type MockPrivateMessageNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockPrivateMessageNotifierMockRecorder
+}
+
+// MockPrivateMessageNotifierMockRecorder is the mock recorder for MockPrivateMessageNotifier.
+type MockPrivateMessageNotifierMockRecorder struct {
+	mock *MockPrivateMessageNotifier
+}
+
+// NewMockPrivateMessageNotifier creates a new mock instance.
+func NewMockPrivateMessageNotifier(ctrl *gomock.Controller) *MockPrivateMessageNotifier {
+	mock := &MockPrivateMessageNotifier{ctrl: ctrl}
+	mock.recorder = &MockPrivateMessageNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPrivateMessageNotifier) EXPECT() *MockPrivateMessageNotifierMockRecorder {
+	return m.recorder
+}
+
+// NotifyMention mocks base method.
+func (m *MockPrivateMessageNotifier) NotifyMention(arg0 context.Context, arg1 *entity.Mention) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyMention", arg0, arg1)
+}
+
+// NotifyMention indicates an expected call of NotifyMention.
+func (mr *MockPrivateMessageNotifierMockRecorder) NotifyMention(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyMention", reflect.TypeOf((*MockPrivateMessageNotifier)(nil).NotifyMention), arg0, arg1)
+}
+
+// NotifyPrivateMessage mocks base method.
+func (m *MockPrivateMessageNotifier) NotifyPrivateMessage(arg0 context.Context, arg1 *entity.PrivateMessage) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyPrivateMessage", arg0, arg1)
+}
+
+// NotifyPrivateMessage indicates an expected call of NotifyPrivateMessage.
+func (mr *MockPrivateMessageNotifierMockRecorder) NotifyPrivateMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyPrivateMessage", reflect.TypeOf((*MockPrivateMessageNotifier)(nil).NotifyPrivateMessage), arg0, arg1)
+}
+
+// NotifyPrivateMessageDeleted mocks base method.
+func (m *MockPrivateMessageNotifier) NotifyPrivateMessageDeleted(arg0 context.Context, arg1 *entity.PrivateMessage) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyPrivateMessageDeleted", arg0, arg1)
+}
+
+// NotifyPrivateMessageDeleted indicates an expected call of NotifyPrivateMessageDeleted.
+func (mr *MockPrivateMessageNotifierMockRecorder) NotifyPrivateMessageDeleted(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyPrivateMessageDeleted", reflect.TypeOf((*MockPrivateMessageNotifier)(nil).NotifyPrivateMessageDeleted), arg0, arg1)
+}
+
+// NotifyPrivateMessageEdited mocks base method.
+func (m *MockPrivateMessageNotifier) NotifyPrivateMessageEdited(arg0 context.Context, arg1 *entity.PrivateMessage) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyPrivateMessageEdited", arg0, arg1)
+}
+
+// NotifyPrivateMessageEdited indicates an expected call of NotifyPrivateMessageEdited.
+func (mr *MockPrivateMessageNotifierMockRecorder) NotifyPrivateMessageEdited(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyPrivateMessageEdited", reflect.TypeOf((*MockPrivateMessageNotifier)(nil).NotifyPrivateMessageEdited), arg0, arg1)
+}
+
+// NotifyPrivateMessagePurged mocks base method.
+func (m *MockPrivateMessageNotifier) NotifyPrivateMessagePurged(arg0 context.Context, arg1 *entity.PrivateMessage) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyPrivateMessagePurged", arg0, arg1)
+}
+
+// NotifyPrivateMessagePurged indicates an expected call of NotifyPrivateMessagePurged.
+func (mr *MockPrivateMessageNotifierMockRecorder) NotifyPrivateMessagePurged(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyPrivateMessagePurged", reflect.TypeOf((*MockPrivateMessageNotifier)(nil).NotifyPrivateMessagePurged), arg0, arg1)
+}
+
+// NotifyPrivateMessagesRead mocks base method.
+func (m *MockPrivateMessageNotifier) NotifyPrivateMessagesRead(arg0 context.Context, arg1 *entity.ReadMarker) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyPrivateMessagesRead", arg0, arg1)
+}
+
+// NotifyPrivateMessagesRead indicates an expected call of NotifyPrivateMessagesRead.
+func (mr *MockPrivateMessageNotifierMockRecorder) NotifyPrivateMessagesRead(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyPrivateMessagesRead", reflect.TypeOf((*MockPrivateMessageNotifier)(nil).NotifyPrivateMessagesRead), arg0, arg1)
+}
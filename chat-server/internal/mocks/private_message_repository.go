@@ -50,6 +50,51 @@ func (mr *MockPrivateMessageRepoMockRecorder) AddPrivateMessage(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPrivateMessage", reflect.TypeOf((*MockPrivateMessageRepo)(nil).AddPrivateMessage), arg0, arg1)
 }
 
+// CountPrivateMessageReplies mocks base method.
+func (m *MockPrivateMessageRepo) CountPrivateMessageReplies(arg0 context.Context, arg1 []int) (map[int]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountPrivateMessageReplies", arg0, arg1)
+	ret0, _ := ret[0].(map[int]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPrivateMessageReplies indicates an expected call of CountPrivateMessageReplies.
+func (mr *MockPrivateMessageRepoMockRecorder) CountPrivateMessageReplies(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPrivateMessageReplies", reflect.TypeOf((*MockPrivateMessageRepo)(nil).CountPrivateMessageReplies), arg0, arg1)
+}
+
+// CountUnreadPrivateMessages mocks base method.
+func (m *MockPrivateMessageRepo) CountUnreadPrivateMessages(arg0 context.Context, arg1 string) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnreadPrivateMessages", arg0, arg1)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnreadPrivateMessages indicates an expected call of CountUnreadPrivateMessages.
+func (mr *MockPrivateMessageRepoMockRecorder) CountUnreadPrivateMessages(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnreadPrivateMessages", reflect.TypeOf((*MockPrivateMessageRepo)(nil).CountUnreadPrivateMessages), arg0, arg1)
+}
+
+// DeletePrivateMessage mocks base method.
+func (m *MockPrivateMessageRepo) DeletePrivateMessage(arg0 context.Context, arg1 int) (*entity.PrivateMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePrivateMessage", arg0, arg1)
+	ret0, _ := ret[0].(*entity.PrivateMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePrivateMessage indicates an expected call of DeletePrivateMessage.
+func (mr *MockPrivateMessageRepoMockRecorder) DeletePrivateMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePrivateMessage", reflect.TypeOf((*MockPrivateMessageRepo)(nil).DeletePrivateMessage), arg0, arg1)
+}
+
 // GetAllPrivateMessages mocks base method.
 func (m *MockPrivateMessageRepo) GetAllPrivateMessages(arg0 context.Context, arg1, arg2 int) []*entity.PrivateMessage {
 	m.ctrl.T.Helper()
@@ -78,3 +123,76 @@ func (mr *MockPrivateMessageRepoMockRecorder) GetPrivateMessage(arg0, arg1 inter
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivateMessage", reflect.TypeOf((*MockPrivateMessageRepo)(nil).GetPrivateMessage), arg0, arg1)
 }
+
+// GetPrivateMessageReplies mocks base method.
+func (m *MockPrivateMessageRepo) GetPrivateMessageReplies(arg0 context.Context, arg1, arg2, arg3 int) []*entity.PrivateMessage {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivateMessageReplies", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*entity.PrivateMessage)
+	return ret0
+}
+
+// GetPrivateMessageReplies indicates an expected call of GetPrivateMessageReplies.
+func (mr *MockPrivateMessageRepoMockRecorder) GetPrivateMessageReplies(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivateMessageReplies", reflect.TypeOf((*MockPrivateMessageRepo)(nil).GetPrivateMessageReplies), arg0, arg1, arg2, arg3)
+}
+
+// GetPrivateMessageRevisions mocks base method.
+func (m *MockPrivateMessageRepo) GetPrivateMessageRevisions(arg0 context.Context, arg1 int) ([]*entity.MessageRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrivateMessageRevisions", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.MessageRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrivateMessageRevisions indicates an expected call of GetPrivateMessageRevisions.
+func (mr *MockPrivateMessageRepoMockRecorder) GetPrivateMessageRevisions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrivateMessageRevisions", reflect.TypeOf((*MockPrivateMessageRepo)(nil).GetPrivateMessageRevisions), arg0, arg1)
+}
+
+// MarkPrivateMessagesRead mocks base method.
+func (m *MockPrivateMessageRepo) MarkPrivateMessagesRead(arg0 context.Context, arg1, arg2 string, arg3 int) (*entity.ReadMarker, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPrivateMessagesRead", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*entity.ReadMarker)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MarkPrivateMessagesRead indicates an expected call of MarkPrivateMessagesRead.
+func (mr *MockPrivateMessageRepoMockRecorder) MarkPrivateMessagesRead(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPrivateMessagesRead", reflect.TypeOf((*MockPrivateMessageRepo)(nil).MarkPrivateMessagesRead), arg0, arg1, arg2, arg3)
+}
+
+// PurgePrivateMessage mocks base method.
+func (m *MockPrivateMessageRepo) PurgePrivateMessage(arg0 context.Context, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgePrivateMessage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgePrivateMessage indicates an expected call of PurgePrivateMessage.
+func (mr *MockPrivateMessageRepoMockRecorder) PurgePrivateMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgePrivateMessage", reflect.TypeOf((*MockPrivateMessageRepo)(nil).PurgePrivateMessage), arg0, arg1)
+}
+
+// UpdatePrivateMessage mocks base method.
+func (m *MockPrivateMessageRepo) UpdatePrivateMessage(arg0 context.Context, arg1 int, arg2 entity.PrivateMessage) (*entity.PrivateMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePrivateMessage", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.PrivateMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePrivateMessage indicates an expected call of UpdatePrivateMessage.
+func (mr *MockPrivateMessageRepoMockRecorder) UpdatePrivateMessage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePrivateMessage", reflect.TypeOf((*MockPrivateMessageRepo)(nil).UpdatePrivateMessage), arg0, arg1, arg2)
+}
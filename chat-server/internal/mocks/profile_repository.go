@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/profile (interfaces: ProfileRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockProfileRepo is a mock of ProfileRepo interface.
+type MockProfileRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfileRepoMockRecorder
+}
+
+// MockProfileRepoMockRecorder is the mock recorder for MockProfileRepo.
+type MockProfileRepoMockRecorder struct {
+	mock *MockProfileRepo
+}
+
+// NewMockProfileRepo creates a new mock instance.
+func NewMockProfileRepo(ctrl *gomock.Controller) *MockProfileRepo {
+	mock := &MockProfileRepo{ctrl: ctrl}
+	mock.recorder = &MockProfileRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfileRepo) EXPECT() *MockProfileRepoMockRecorder {
+	return m.recorder
+}
+
+// GetProfile mocks base method.
+func (m *MockProfileRepo) GetProfile(arg0 context.Context, arg1 int) (*entity.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfile", arg0, arg1)
+	ret0, _ := ret[0].(*entity.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfile indicates an expected call of GetProfile.
+func (mr *MockProfileRepoMockRecorder) GetProfile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfile", reflect.TypeOf((*MockProfileRepo)(nil).GetProfile), arg0, arg1)
+}
+
+// SaveProfile mocks base method.
+func (m *MockProfileRepo) SaveProfile(arg0 context.Context, arg1 entity.Profile) (*entity.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveProfile", arg0, arg1)
+	ret0, _ := ret[0].(*entity.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SaveProfile indicates an expected call of SaveProfile.
+func (mr *MockProfileRepoMockRecorder) SaveProfile(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveProfile", reflect.TypeOf((*MockProfileRepo)(nil).SaveProfile), arg0, arg1)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/profile (interfaces: UserRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockProfileUserRepo is a mock of UserRepo interface.
+type MockProfileUserRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockProfileUserRepoMockRecorder
+}
+
+// MockProfileUserRepoMockRecorder is the mock recorder for MockProfileUserRepo.
+type MockProfileUserRepoMockRecorder struct {
+	mock *MockProfileUserRepo
+}
+
+// NewMockProfileUserRepo creates a new mock instance.
+func NewMockProfileUserRepo(ctrl *gomock.Controller) *MockProfileUserRepo {
+	mock := &MockProfileUserRepo{ctrl: ctrl}
+	mock.recorder = &MockProfileUserRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockProfileUserRepo) EXPECT() *MockProfileUserRepoMockRecorder {
+	return m.recorder
+}
+
+// GetUserByID mocks base method.
+func (m *MockProfileUserRepo) GetUserByID(arg0 context.Context, arg1 int) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", arg0, arg1)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockProfileUserRepoMockRecorder) GetUserByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockProfileUserRepo)(nil).GetUserByID), arg0, arg1)
+}
+
+// GetUserByUsername mocks base method.
+func (m *MockProfileUserRepo) GetUserByUsername(arg0 context.Context, arg1 string) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByUsername", arg0, arg1)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByUsername indicates an expected call of GetUserByUsername.
+func (mr *MockProfileUserRepoMockRecorder) GetUserByUsername(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockProfileUserRepo)(nil).GetUserByUsername), arg0, arg1)
+}
@@ -0,0 +1,108 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message/public (interfaces: Notifier)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPublicMessageNotifier is a mock of Notifier interface.
+type MockPublicMessageNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublicMessageNotifierMockRecorder
+}
+
+// MockPublicMessageNotifierMockRecorder is the mock recorder for MockPublicMessageNotifier.
+type MockPublicMessageNotifierMockRecorder struct {
+	mock *MockPublicMessageNotifier
+}
+
+// NewMockPublicMessageNotifier creates a new mock instance.
+func NewMockPublicMessageNotifier(ctrl *gomock.Controller) *MockPublicMessageNotifier {
+	mock := &MockPublicMessageNotifier{ctrl: ctrl}
+	mock.recorder = &MockPublicMessageNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublicMessageNotifier) EXPECT() *MockPublicMessageNotifierMockRecorder {
+	return m.recorder
+}
+
+// NotifyChannelMessagePurged mocks base method.
+func (m *MockPublicMessageNotifier) NotifyChannelMessagePurged(arg0 context.Context, arg1 *entity.PublicMessage, arg2 []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyChannelMessagePurged", arg0, arg1, arg2)
+}
+
+// NotifyChannelMessagePurged indicates an expected call of NotifyChannelMessagePurged.
+func (mr *MockPublicMessageNotifierMockRecorder) NotifyChannelMessagePurged(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyChannelMessagePurged", reflect.TypeOf((*MockPublicMessageNotifier)(nil).NotifyChannelMessagePurged), arg0, arg1, arg2)
+}
+
+// NotifyMention mocks base method.
+func (m *MockPublicMessageNotifier) NotifyMention(arg0 context.Context, arg1 *entity.Mention) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyMention", arg0, arg1)
+}
+
+// NotifyMention indicates an expected call of NotifyMention.
+func (mr *MockPublicMessageNotifierMockRecorder) NotifyMention(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyMention", reflect.TypeOf((*MockPublicMessageNotifier)(nil).NotifyMention), arg0, arg1)
+}
+
+// NotifyPublicMessage mocks base method.
+func (m *MockPublicMessageNotifier) NotifyPublicMessage(arg0 context.Context, arg1 *entity.PublicMessage) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyPublicMessage", arg0, arg1)
+}
+
+// NotifyPublicMessage indicates an expected call of NotifyPublicMessage.
+func (mr *MockPublicMessageNotifierMockRecorder) NotifyPublicMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyPublicMessage", reflect.TypeOf((*MockPublicMessageNotifier)(nil).NotifyPublicMessage), arg0, arg1)
+}
+
+// NotifyPublicMessageDeleted mocks base method.
+func (m *MockPublicMessageNotifier) NotifyPublicMessageDeleted(arg0 context.Context, arg1 *entity.PublicMessage) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyPublicMessageDeleted", arg0, arg1)
+}
+
+// NotifyPublicMessageDeleted indicates an expected call of NotifyPublicMessageDeleted.
+func (mr *MockPublicMessageNotifierMockRecorder) NotifyPublicMessageDeleted(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyPublicMessageDeleted", reflect.TypeOf((*MockPublicMessageNotifier)(nil).NotifyPublicMessageDeleted), arg0, arg1)
+}
+
+// NotifyPublicMessageEdited mocks base method.
+func (m *MockPublicMessageNotifier) NotifyPublicMessageEdited(arg0 context.Context, arg1 *entity.PublicMessage) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyPublicMessageEdited", arg0, arg1)
+}
+
+// NotifyPublicMessageEdited indicates an expected call of NotifyPublicMessageEdited.
+func (mr *MockPublicMessageNotifierMockRecorder) NotifyPublicMessageEdited(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyPublicMessageEdited", reflect.TypeOf((*MockPublicMessageNotifier)(nil).NotifyPublicMessageEdited), arg0, arg1)
+}
+
+// NotifyPublicMessagePurged mocks base method.
+func (m *MockPublicMessageNotifier) NotifyPublicMessagePurged(arg0 context.Context, arg1 *entity.PublicMessage) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyPublicMessagePurged", arg0, arg1)
+}
+
+// NotifyPublicMessagePurged indicates an expected call of NotifyPublicMessagePurged.
+func (mr *MockPublicMessageNotifierMockRecorder) NotifyPublicMessagePurged(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyPublicMessagePurged", reflect.TypeOf((*MockPublicMessageNotifier)(nil).NotifyPublicMessagePurged), arg0, arg1)
+}
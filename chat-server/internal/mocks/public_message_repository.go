@@ -50,18 +50,62 @@ func (mr *MockPublicMessageRepoMockRecorder) AddPublicMessage(arg0, arg1 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddPublicMessage", reflect.TypeOf((*MockPublicMessageRepo)(nil).AddPublicMessage), arg0, arg1)
 }
 
-// GetAllPublicMessages mocks base method.
-func (m *MockPublicMessageRepo) GetAllPublicMessages(arg0 context.Context, arg1, arg2 int) []*entity.PublicMessage {
+// CountPublicMessageReplies mocks base method.
+func (m *MockPublicMessageRepo) CountPublicMessageReplies(arg0 context.Context, arg1 []int) (map[int]int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllPublicMessages", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "CountPublicMessageReplies", arg0, arg1)
+	ret0, _ := ret[0].(map[int]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountPublicMessageReplies indicates an expected call of CountPublicMessageReplies.
+func (mr *MockPublicMessageRepoMockRecorder) CountPublicMessageReplies(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountPublicMessageReplies", reflect.TypeOf((*MockPublicMessageRepo)(nil).CountPublicMessageReplies), arg0, arg1)
+}
+
+// DeletePublicMessage mocks base method.
+func (m *MockPublicMessageRepo) DeletePublicMessage(arg0 context.Context, arg1 int) (*entity.PublicMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePublicMessage", arg0, arg1)
+	ret0, _ := ret[0].(*entity.PublicMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePublicMessage indicates an expected call of DeletePublicMessage.
+func (mr *MockPublicMessageRepoMockRecorder) DeletePublicMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePublicMessage", reflect.TypeOf((*MockPublicMessageRepo)(nil).DeletePublicMessage), arg0, arg1)
+}
+
+// GetChannelMessages mocks base method.
+func (m *MockPublicMessageRepo) GetChannelMessages(arg0 context.Context, arg1, arg2, arg3 int) []*entity.PublicMessage {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelMessages", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*entity.PublicMessage)
+	return ret0
+}
+
+// GetChannelMessages indicates an expected call of GetChannelMessages.
+func (mr *MockPublicMessageRepoMockRecorder) GetChannelMessages(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelMessages", reflect.TypeOf((*MockPublicMessageRepo)(nil).GetChannelMessages), arg0, arg1, arg2, arg3)
+}
+
+// GetChannelMessagesExcludingAuthors mocks base method.
+func (m *MockPublicMessageRepo) GetChannelMessagesExcludingAuthors(arg0 context.Context, arg1 int, arg2 []string, arg3, arg4 int) []*entity.PublicMessage {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelMessagesExcludingAuthors", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].([]*entity.PublicMessage)
 	return ret0
 }
 
-// GetAllPublicMessages indicates an expected call of GetAllPublicMessages.
-func (mr *MockPublicMessageRepoMockRecorder) GetAllPublicMessages(arg0, arg1, arg2 interface{}) *gomock.Call {
+// GetChannelMessagesExcludingAuthors indicates an expected call of GetChannelMessagesExcludingAuthors.
+func (mr *MockPublicMessageRepoMockRecorder) GetChannelMessagesExcludingAuthors(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPublicMessages", reflect.TypeOf((*MockPublicMessageRepo)(nil).GetAllPublicMessages), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelMessagesExcludingAuthors", reflect.TypeOf((*MockPublicMessageRepo)(nil).GetChannelMessagesExcludingAuthors), arg0, arg1, arg2, arg3, arg4)
 }
 
 // GetPublicMessage mocks base method.
@@ -78,3 +122,61 @@ func (mr *MockPublicMessageRepoMockRecorder) GetPublicMessage(arg0, arg1 interfa
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicMessage", reflect.TypeOf((*MockPublicMessageRepo)(nil).GetPublicMessage), arg0, arg1)
 }
+
+// GetPublicMessageReplies mocks base method.
+func (m *MockPublicMessageRepo) GetPublicMessageReplies(arg0 context.Context, arg1, arg2, arg3 int) []*entity.PublicMessage {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicMessageReplies", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*entity.PublicMessage)
+	return ret0
+}
+
+// GetPublicMessageReplies indicates an expected call of GetPublicMessageReplies.
+func (mr *MockPublicMessageRepoMockRecorder) GetPublicMessageReplies(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicMessageReplies", reflect.TypeOf((*MockPublicMessageRepo)(nil).GetPublicMessageReplies), arg0, arg1, arg2, arg3)
+}
+
+// GetPublicMessageRevisions mocks base method.
+func (m *MockPublicMessageRepo) GetPublicMessageRevisions(arg0 context.Context, arg1 int) ([]*entity.MessageRevision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPublicMessageRevisions", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.MessageRevision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPublicMessageRevisions indicates an expected call of GetPublicMessageRevisions.
+func (mr *MockPublicMessageRepoMockRecorder) GetPublicMessageRevisions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPublicMessageRevisions", reflect.TypeOf((*MockPublicMessageRepo)(nil).GetPublicMessageRevisions), arg0, arg1)
+}
+
+// PurgePublicMessage mocks base method.
+func (m *MockPublicMessageRepo) PurgePublicMessage(arg0 context.Context, arg1 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgePublicMessage", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PurgePublicMessage indicates an expected call of PurgePublicMessage.
+func (mr *MockPublicMessageRepoMockRecorder) PurgePublicMessage(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgePublicMessage", reflect.TypeOf((*MockPublicMessageRepo)(nil).PurgePublicMessage), arg0, arg1)
+}
+
+// UpdatePublicMessage mocks base method.
+func (m *MockPublicMessageRepo) UpdatePublicMessage(arg0 context.Context, arg1 int, arg2 entity.PublicMessage) (*entity.PublicMessage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePublicMessage", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.PublicMessage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePublicMessage indicates an expected call of UpdatePublicMessage.
+func (mr *MockPublicMessageRepoMockRecorder) UpdatePublicMessage(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePublicMessage", reflect.TypeOf((*MockPublicMessageRepo)(nil).UpdatePublicMessage), arg0, arg1, arg2)
+}
@@ -0,0 +1,80 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message (interfaces: ReactionRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockReactionRepo is a mock of ReactionRepo interface.
+type MockReactionRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockReactionRepoMockRecorder
+}
+
+// MockReactionRepoMockRecorder is the mock recorder for MockReactionRepo.
+type MockReactionRepoMockRecorder struct {
+	mock *MockReactionRepo
+}
+
+// NewMockReactionRepo creates a new mock instance.
+func NewMockReactionRepo(ctrl *gomock.Controller) *MockReactionRepo {
+	mock := &MockReactionRepo{ctrl: ctrl}
+	mock.recorder = &MockReactionRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReactionRepo) EXPECT() *MockReactionRepoMockRecorder {
+	return m.recorder
+}
+
+// AddReaction mocks base method.
+func (m *MockReactionRepo) AddReaction(arg0 context.Context, arg1 entity.MessageKind, arg2 entity.Reaction) (*entity.Reaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddReaction", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.Reaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddReaction indicates an expected call of AddReaction.
+func (mr *MockReactionRepoMockRecorder) AddReaction(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddReaction", reflect.TypeOf((*MockReactionRepo)(nil).AddReaction), arg0, arg1, arg2)
+}
+
+// GetReactions mocks base method.
+func (m *MockReactionRepo) GetReactions(arg0 context.Context, arg1 entity.MessageKind, arg2 []int) ([]*entity.Reaction, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReactions", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*entity.Reaction)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReactions indicates an expected call of GetReactions.
+func (mr *MockReactionRepoMockRecorder) GetReactions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReactions", reflect.TypeOf((*MockReactionRepo)(nil).GetReactions), arg0, arg1, arg2)
+}
+
+// RemoveReaction mocks base method.
+func (m *MockReactionRepo) RemoveReaction(arg0 context.Context, arg1 entity.MessageKind, arg2 int, arg3, arg4 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveReaction", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveReaction indicates an expected call of RemoveReaction.
+func (mr *MockReactionRepoMockRecorder) RemoveReaction(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveReaction", reflect.TypeOf((*MockReactionRepo)(nil).RemoveReaction), arg0, arg1, arg2, arg3, arg4)
+}
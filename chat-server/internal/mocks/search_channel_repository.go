@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/search (interfaces: ChannelRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSearchChannelRepo is a mock of ChannelRepo interface.
+type MockSearchChannelRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchChannelRepoMockRecorder
+}
+
+// MockSearchChannelRepoMockRecorder is the mock recorder for MockSearchChannelRepo.
+type MockSearchChannelRepoMockRecorder struct {
+	mock *MockSearchChannelRepo
+}
+
+// NewMockSearchChannelRepo creates a new mock instance.
+func NewMockSearchChannelRepo(ctrl *gomock.Controller) *MockSearchChannelRepo {
+	mock := &MockSearchChannelRepo{ctrl: ctrl}
+	mock.recorder = &MockSearchChannelRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchChannelRepo) EXPECT() *MockSearchChannelRepoMockRecorder {
+	return m.recorder
+}
+
+// GetMemberChannelIDs mocks base method.
+func (m *MockSearchChannelRepo) GetMemberChannelIDs(arg0 context.Context, arg1 string) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMemberChannelIDs", arg0, arg1)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMemberChannelIDs indicates an expected call of GetMemberChannelIDs.
+func (mr *MockSearchChannelRepoMockRecorder) GetMemberChannelIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMemberChannelIDs", reflect.TypeOf((*MockSearchChannelRepo)(nil).GetMemberChannelIDs), arg0, arg1)
+}
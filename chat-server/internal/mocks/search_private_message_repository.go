@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/search (interfaces: PrivateMessageRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSearchPrivateMessageRepo is a mock of PrivateMessageRepo interface.
+type MockSearchPrivateMessageRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchPrivateMessageRepoMockRecorder
+}
+
+// MockSearchPrivateMessageRepoMockRecorder is the mock recorder for MockSearchPrivateMessageRepo.
+type MockSearchPrivateMessageRepoMockRecorder struct {
+	mock *MockSearchPrivateMessageRepo
+}
+
+// NewMockSearchPrivateMessageRepo creates a new mock instance.
+func NewMockSearchPrivateMessageRepo(ctrl *gomock.Controller) *MockSearchPrivateMessageRepo {
+	mock := &MockSearchPrivateMessageRepo{ctrl: ctrl}
+	mock.recorder = &MockSearchPrivateMessageRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchPrivateMessageRepo) EXPECT() *MockSearchPrivateMessageRepoMockRecorder {
+	return m.recorder
+}
+
+// SearchPrivateMessages mocks base method.
+func (m *MockSearchPrivateMessageRepo) SearchPrivateMessages(arg0 context.Context, arg1 string, arg2 entity.MessageSearchQuery, arg3 int) ([]*entity.SearchHit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchPrivateMessages", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*entity.SearchHit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchPrivateMessages indicates an expected call of SearchPrivateMessages.
+func (mr *MockSearchPrivateMessageRepoMockRecorder) SearchPrivateMessages(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchPrivateMessages", reflect.TypeOf((*MockSearchPrivateMessageRepo)(nil).SearchPrivateMessages), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/search (interfaces: PublicMessageRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSearchPublicMessageRepo is a mock of PublicMessageRepo interface.
+type MockSearchPublicMessageRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchPublicMessageRepoMockRecorder
+}
+
+// MockSearchPublicMessageRepoMockRecorder is the mock recorder for MockSearchPublicMessageRepo.
+type MockSearchPublicMessageRepoMockRecorder struct {
+	mock *MockSearchPublicMessageRepo
+}
+
+// NewMockSearchPublicMessageRepo creates a new mock instance.
+func NewMockSearchPublicMessageRepo(ctrl *gomock.Controller) *MockSearchPublicMessageRepo {
+	mock := &MockSearchPublicMessageRepo{ctrl: ctrl}
+	mock.recorder = &MockSearchPublicMessageRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchPublicMessageRepo) EXPECT() *MockSearchPublicMessageRepoMockRecorder {
+	return m.recorder
+}
+
+// SearchPublicMessages mocks base method.
+func (m *MockSearchPublicMessageRepo) SearchPublicMessages(arg0 context.Context, arg1 entity.MessageSearchQuery, arg2 []int, arg3 int) ([]*entity.SearchHit, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchPublicMessages", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*entity.SearchHit)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchPublicMessages indicates an expected call of SearchPublicMessages.
+func (mr *MockSearchPublicMessageRepoMockRecorder) SearchPublicMessages(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchPublicMessages", reflect.TypeOf((*MockSearchPublicMessageRepo)(nil).SearchPublicMessages), arg0, arg1, arg2, arg3)
+}
@@ -0,0 +1,47 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/session (interfaces: Notifier)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSessionNotifier is a mock of Notifier interface.
+type MockSessionNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionNotifierMockRecorder
+}
+
+// MockSessionNotifierMockRecorder is the mock recorder for MockSessionNotifier.
+type MockSessionNotifierMockRecorder struct {
+	mock *MockSessionNotifier
+}
+
+// NewMockSessionNotifier creates a new mock instance.
+func NewMockSessionNotifier(ctrl *gomock.Controller) *MockSessionNotifier {
+	mock := &MockSessionNotifier{ctrl: ctrl}
+	mock.recorder = &MockSessionNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionNotifier) EXPECT() *MockSessionNotifierMockRecorder {
+	return m.recorder
+}
+
+// NotifySessionRevoked mocks base method.
+func (m *MockSessionNotifier) NotifySessionRevoked(arg0 context.Context, arg1 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifySessionRevoked", arg0, arg1)
+}
+
+// NotifySessionRevoked indicates an expected call of NotifySessionRevoked.
+func (mr *MockSessionNotifierMockRecorder) NotifySessionRevoked(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifySessionRevoked", reflect.TypeOf((*MockSessionNotifier)(nil).NotifySessionRevoked), arg0, arg1)
+}
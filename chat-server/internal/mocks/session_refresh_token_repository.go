@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/session (interfaces: RefreshTokenRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSessionRefreshTokenRepo is a mock of RefreshTokenRepo interface.
+type MockSessionRefreshTokenRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionRefreshTokenRepoMockRecorder
+}
+
+// MockSessionRefreshTokenRepoMockRecorder is the mock recorder for MockSessionRefreshTokenRepo.
+type MockSessionRefreshTokenRepoMockRecorder struct {
+	mock *MockSessionRefreshTokenRepo
+}
+
+// NewMockSessionRefreshTokenRepo creates a new mock instance.
+func NewMockSessionRefreshTokenRepo(ctrl *gomock.Controller) *MockSessionRefreshTokenRepo {
+	mock := &MockSessionRefreshTokenRepo{ctrl: ctrl}
+	mock.recorder = &MockSessionRefreshTokenRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSessionRefreshTokenRepo) EXPECT() *MockSessionRefreshTokenRepoMockRecorder {
+	return m.recorder
+}
+
+// RevokeTokenFamily mocks base method.
+func (m *MockSessionRefreshTokenRepo) RevokeTokenFamily(arg0 context.Context, arg1 string, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeTokenFamily", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeTokenFamily indicates an expected call of RevokeTokenFamily.
+func (mr *MockSessionRefreshTokenRepoMockRecorder) RevokeTokenFamily(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeTokenFamily", reflect.TypeOf((*MockSessionRefreshTokenRepo)(nil).RevokeTokenFamily), arg0, arg1, arg2)
+}
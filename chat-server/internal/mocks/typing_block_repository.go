@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/typing (interfaces: BlockRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTypingBlockRepo is a mock of BlockRepo interface.
+type MockTypingBlockRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockTypingBlockRepoMockRecorder
+}
+
+// MockTypingBlockRepoMockRecorder is the mock recorder for MockTypingBlockRepo.
+type MockTypingBlockRepoMockRecorder struct {
+	mock *MockTypingBlockRepo
+}
+
+// NewMockTypingBlockRepo creates a new mock instance.
+func NewMockTypingBlockRepo(ctrl *gomock.Controller) *MockTypingBlockRepo {
+	mock := &MockTypingBlockRepo{ctrl: ctrl}
+	mock.recorder = &MockTypingBlockRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTypingBlockRepo) EXPECT() *MockTypingBlockRepoMockRecorder {
+	return m.recorder
+}
+
+// IsBlocked mocks base method.
+func (m *MockTypingBlockRepo) IsBlocked(arg0 context.Context, arg1, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsBlocked", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsBlocked indicates an expected call of IsBlocked.
+func (mr *MockTypingBlockRepoMockRecorder) IsBlocked(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBlocked", reflect.TypeOf((*MockTypingBlockRepo)(nil).IsBlocked), arg0, arg1, arg2)
+}
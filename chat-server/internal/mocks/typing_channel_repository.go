@@ -0,0 +1,81 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/typing (interfaces: ChannelRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTypingChannelRepo is a mock of ChannelRepo interface.
+type MockTypingChannelRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockTypingChannelRepoMockRecorder
+}
+
+// MockTypingChannelRepoMockRecorder is the mock recorder for MockTypingChannelRepo.
+type MockTypingChannelRepoMockRecorder struct {
+	mock *MockTypingChannelRepo
+}
+
+// NewMockTypingChannelRepo creates a new mock instance.
+func NewMockTypingChannelRepo(ctrl *gomock.Controller) *MockTypingChannelRepo {
+	mock := &MockTypingChannelRepo{ctrl: ctrl}
+	mock.recorder = &MockTypingChannelRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTypingChannelRepo) EXPECT() *MockTypingChannelRepoMockRecorder {
+	return m.recorder
+}
+
+// GetChannel mocks base method.
+func (m *MockTypingChannelRepo) GetChannel(arg0 context.Context, arg1 int) (*entity.Channel, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannel", arg0, arg1)
+	ret0, _ := ret[0].(*entity.Channel)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannel indicates an expected call of GetChannel.
+func (mr *MockTypingChannelRepoMockRecorder) GetChannel(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannel", reflect.TypeOf((*MockTypingChannelRepo)(nil).GetChannel), arg0, arg1)
+}
+
+// GetChannelMembers mocks base method.
+func (m *MockTypingChannelRepo) GetChannelMembers(arg0 context.Context, arg1 int) ([]*entity.ChannelMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChannelMembers", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.ChannelMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChannelMembers indicates an expected call of GetChannelMembers.
+func (mr *MockTypingChannelRepoMockRecorder) GetChannelMembers(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChannelMembers", reflect.TypeOf((*MockTypingChannelRepo)(nil).GetChannelMembers), arg0, arg1)
+}
+
+// IsChannelMember mocks base method.
+func (m *MockTypingChannelRepo) IsChannelMember(arg0 context.Context, arg1 int, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsChannelMember", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsChannelMember indicates an expected call of IsChannelMember.
+func (mr *MockTypingChannelRepoMockRecorder) IsChannelMember(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsChannelMember", reflect.TypeOf((*MockTypingChannelRepo)(nil).IsChannelMember), arg0, arg1, arg2)
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/typing (interfaces: ConversationRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTypingConversationRepo is a mock of ConversationRepo interface.
+type MockTypingConversationRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockTypingConversationRepoMockRecorder
+}
+
+// MockTypingConversationRepoMockRecorder is the mock recorder for MockTypingConversationRepo.
+type MockTypingConversationRepoMockRecorder struct {
+	mock *MockTypingConversationRepo
+}
+
+// NewMockTypingConversationRepo creates a new mock instance.
+func NewMockTypingConversationRepo(ctrl *gomock.Controller) *MockTypingConversationRepo {
+	mock := &MockTypingConversationRepo{ctrl: ctrl}
+	mock.recorder = &MockTypingConversationRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTypingConversationRepo) EXPECT() *MockTypingConversationRepoMockRecorder {
+	return m.recorder
+}
+
+// GetConversationParticipants mocks base method.
+func (m *MockTypingConversationRepo) GetConversationParticipants(arg0 context.Context, arg1 int) ([]*entity.ConversationParticipant, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConversationParticipants", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.ConversationParticipant)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConversationParticipants indicates an expected call of GetConversationParticipants.
+func (mr *MockTypingConversationRepoMockRecorder) GetConversationParticipants(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConversationParticipants", reflect.TypeOf((*MockTypingConversationRepo)(nil).GetConversationParticipants), arg0, arg1)
+}
+
+// IsConversationParticipant mocks base method.
+func (m *MockTypingConversationRepo) IsConversationParticipant(arg0 context.Context, arg1 int, arg2 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsConversationParticipant", arg0, arg1, arg2)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsConversationParticipant indicates an expected call of IsConversationParticipant.
+func (mr *MockTypingConversationRepoMockRecorder) IsConversationParticipant(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsConversationParticipant", reflect.TypeOf((*MockTypingConversationRepo)(nil).IsConversationParticipant), arg0, arg1, arg2)
+}
@@ -0,0 +1,60 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/typing (interfaces: Notifier)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTypingNotifier is a mock of Notifier interface.
+type MockTypingNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockTypingNotifierMockRecorder
+}
+
+// MockTypingNotifierMockRecorder is the mock recorder for MockTypingNotifier.
+type MockTypingNotifierMockRecorder struct {
+	mock *MockTypingNotifier
+}
+
+// NewMockTypingNotifier creates a new mock instance.
+func NewMockTypingNotifier(ctrl *gomock.Controller) *MockTypingNotifier {
+	mock := &MockTypingNotifier{ctrl: ctrl}
+	mock.recorder = &MockTypingNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTypingNotifier) EXPECT() *MockTypingNotifierMockRecorder {
+	return m.recorder
+}
+
+// NotifyPublicTyping mocks base method.
+func (m *MockTypingNotifier) NotifyPublicTyping(arg0 context.Context, arg1 *entity.Typing) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyPublicTyping", arg0, arg1)
+}
+
+// NotifyPublicTyping indicates an expected call of NotifyPublicTyping.
+func (mr *MockTypingNotifierMockRecorder) NotifyPublicTyping(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyPublicTyping", reflect.TypeOf((*MockTypingNotifier)(nil).NotifyPublicTyping), arg0, arg1)
+}
+
+// NotifyTyping mocks base method.
+func (m *MockTypingNotifier) NotifyTyping(arg0 context.Context, arg1 *entity.Typing, arg2 []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyTyping", arg0, arg1, arg2)
+}
+
+// NotifyTyping indicates an expected call of NotifyTyping.
+func (mr *MockTypingNotifierMockRecorder) NotifyTyping(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyTyping", reflect.TypeOf((*MockTypingNotifier)(nil).NotifyTyping), arg0, arg1, arg2)
+}
@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/typing (interfaces: UserRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	entity "github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTypingUserRepo is a mock of UserRepo interface.
+type MockTypingUserRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockTypingUserRepoMockRecorder
+}
+
+// MockTypingUserRepoMockRecorder is the mock recorder for MockTypingUserRepo.
+type MockTypingUserRepoMockRecorder struct {
+	mock *MockTypingUserRepo
+}
+
+// NewMockTypingUserRepo creates a new mock instance.
+func NewMockTypingUserRepo(ctrl *gomock.Controller) *MockTypingUserRepo {
+	mock := &MockTypingUserRepo{ctrl: ctrl}
+	mock.recorder = &MockTypingUserRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTypingUserRepo) EXPECT() *MockTypingUserRepoMockRecorder {
+	return m.recorder
+}
+
+// GetUserByUsername mocks base method.
+func (m *MockTypingUserRepo) GetUserByUsername(arg0 context.Context, arg1 string) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByUsername", arg0, arg1)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByUsername indicates an expected call of GetUserByUsername.
+func (mr *MockTypingUserRepoMockRecorder) GetUserByUsername(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockTypingUserRepo)(nil).GetUserByUsername), arg0, arg1)
+}
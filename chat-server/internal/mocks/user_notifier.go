@@ -0,0 +1,47 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/user (interfaces: Notifier)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserNotifier is a mock of Notifier interface.
+type MockUserNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserNotifierMockRecorder
+}
+
+// MockUserNotifierMockRecorder is the mock recorder for MockUserNotifier.
+type MockUserNotifierMockRecorder struct {
+	mock *MockUserNotifier
+}
+
+// NewMockUserNotifier creates a new mock instance.
+func NewMockUserNotifier(ctrl *gomock.Controller) *MockUserNotifier {
+	mock := &MockUserNotifier{ctrl: ctrl}
+	mock.recorder = &MockUserNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserNotifier) EXPECT() *MockUserNotifierMockRecorder {
+	return m.recorder
+}
+
+// NotifyUserSignedOut mocks base method.
+func (m *MockUserNotifier) NotifyUserSignedOut(arg0 context.Context, arg1 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyUserSignedOut", arg0, arg1)
+}
+
+// NotifyUserSignedOut indicates an expected call of NotifyUserSignedOut.
+func (mr *MockUserNotifierMockRecorder) NotifyUserSignedOut(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyUserSignedOut", reflect.TypeOf((*MockUserNotifier)(nil).NotifyUserSignedOut), arg0, arg1)
+}
@@ -0,0 +1,50 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/user (interfaces: RefreshTokenRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserRefreshTokenRepo is a mock of RefreshTokenRepo interface.
+type MockUserRefreshTokenRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserRefreshTokenRepoMockRecorder
+}
+
+// MockUserRefreshTokenRepoMockRecorder is the mock recorder for MockUserRefreshTokenRepo.
+type MockUserRefreshTokenRepoMockRecorder struct {
+	mock *MockUserRefreshTokenRepo
+}
+
+// NewMockUserRefreshTokenRepo creates a new mock instance.
+func NewMockUserRefreshTokenRepo(ctrl *gomock.Controller) *MockUserRefreshTokenRepo {
+	mock := &MockUserRefreshTokenRepo{ctrl: ctrl}
+	mock.recorder = &MockUserRefreshTokenRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserRefreshTokenRepo) EXPECT() *MockUserRefreshTokenRepoMockRecorder {
+	return m.recorder
+}
+
+// RevokeTokenFamily mocks base method.
+func (m *MockUserRefreshTokenRepo) RevokeTokenFamily(arg0 context.Context, arg1 string, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeTokenFamily", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeTokenFamily indicates an expected call of RevokeTokenFamily.
+func (mr *MockUserRefreshTokenRepoMockRecorder) RevokeTokenFamily(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeTokenFamily", reflect.TypeOf((*MockUserRefreshTokenRepo)(nil).RevokeTokenFamily), arg0, arg1, arg2)
+}
@@ -138,6 +138,21 @@ func (mr *MockUserRepoMockRecorder) GetUserByUsername(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByUsername", reflect.TypeOf((*MockUserRepo)(nil).GetUserByUsername), arg0, arg1)
 }
 
+// LockUsersWithRole mocks base method.
+func (m *MockUserRepo) LockUsersWithRole(arg0 context.Context, arg1 entity.Role) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockUsersWithRole", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LockUsersWithRole indicates an expected call of LockUsersWithRole.
+func (mr *MockUserRepoMockRecorder) LockUsersWithRole(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockUsersWithRole", reflect.TypeOf((*MockUserRepo)(nil).LockUsersWithRole), arg0, arg1)
+}
+
 // UpdateUser mocks base method.
 func (m *MockUserRepo) UpdateUser(arg0 context.Context, arg1 int, arg2 entity.User) (*entity.User, error) {
 	m.ctrl.T.Helper()
@@ -152,3 +167,18 @@ func (mr *MockUserRepoMockRecorder) UpdateUser(arg0, arg1, arg2 interface{}) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockUserRepo)(nil).UpdateUser), arg0, arg1, arg2)
 }
+
+// UpdateUserRole mocks base method.
+func (m *MockUserRepo) UpdateUserRole(arg0 context.Context, arg1 int, arg2 entity.Role) (*entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserRole", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateUserRole indicates an expected call of UpdateUserRole.
+func (mr *MockUserRepoMockRecorder) UpdateUserRole(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserRole", reflect.TypeOf((*MockUserRepo)(nil).UpdateUserRole), arg0, arg1, arg2)
+}
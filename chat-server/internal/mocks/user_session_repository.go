@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/user (interfaces: SessionRepo)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUserSessionRepo is a mock of SessionRepo interface.
+type MockUserSessionRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserSessionRepoMockRecorder
+}
+
+// MockUserSessionRepoMockRecorder is the mock recorder for MockUserSessionRepo.
+type MockUserSessionRepoMockRecorder struct {
+	mock *MockUserSessionRepo
+}
+
+// NewMockUserSessionRepo creates a new mock instance.
+func NewMockUserSessionRepo(ctrl *gomock.Controller) *MockUserSessionRepo {
+	mock := &MockUserSessionRepo{ctrl: ctrl}
+	mock.recorder = &MockUserSessionRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserSessionRepo) EXPECT() *MockUserSessionRepoMockRecorder {
+	return m.recorder
+}
+
+// GetUserSessions mocks base method.
+func (m *MockUserSessionRepo) GetUserSessions(arg0 context.Context, arg1 int) ([]*entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserSessions", arg0, arg1)
+	ret0, _ := ret[0].([]*entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserSessions indicates an expected call of GetUserSessions.
+func (mr *MockUserSessionRepoMockRecorder) GetUserSessions(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserSessions", reflect.TypeOf((*MockUserSessionRepo)(nil).GetUserSessions), arg0, arg1)
+}
+
+// RevokeSession mocks base method.
+func (m *MockUserSessionRepo) RevokeSession(arg0 context.Context, arg1 string, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockUserSessionRepoMockRecorder) RevokeSession(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockUserSessionRepo)(nil).RevokeSession), arg0, arg1, arg2)
+}
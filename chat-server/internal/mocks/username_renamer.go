@@ -0,0 +1,49 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/user (interfaces: UsernameRenamer)
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockUsernameRenamer is a mock of UsernameRenamer interface.
+type MockUsernameRenamer struct {
+	ctrl     *gomock.Controller
+	recorder *MockUsernameRenamerMockRecorder
+}
+
+// MockUsernameRenamerMockRecorder is the mock recorder for MockUsernameRenamer.
+type MockUsernameRenamerMockRecorder struct {
+	mock *MockUsernameRenamer
+}
+
+// NewMockUsernameRenamer creates a new mock instance.
+func NewMockUsernameRenamer(ctrl *gomock.Controller) *MockUsernameRenamer {
+	mock := &MockUsernameRenamer{ctrl: ctrl}
+	mock.recorder = &MockUsernameRenamerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUsernameRenamer) EXPECT() *MockUsernameRenamerMockRecorder {
+	return m.recorder
+}
+
+// RenameUsername mocks base method.
+func (m *MockUsernameRenamer) RenameUsername(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameUsername", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameUsername indicates an expected call of RenameUsername.
+func (mr *MockUsernameRenamerMockRecorder) RenameUsername(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameUsername", reflect.TypeOf((*MockUsernameRenamer)(nil).RenameUsername), arg0, arg1, arg2)
+}
@@ -19,6 +19,7 @@ func LoadFixtures(db inmemory.InMemoryDB) {
 			Username:       "test",
 			Email:          "test@mail.ru",
 			HashedPassword: "$2a$10$n1ZupQQL9NBnIDHShSIfwut3wf2cUMtsmzBo/7r29oRo4tYRrmoLS",
+			Role:           entity.RoleUser,
 			CreatedAt:      now,
 			UpdatedAt:      now,
 		},
@@ -27,6 +28,7 @@ func LoadFixtures(db inmemory.InMemoryDB) {
 			Username:       "test2",
 			Email:          "test2@mail.ru",
 			HashedPassword: "$2a$10$O3bRPhNaWgVibnpkUFL.K.xXwmYnDKKMJ1Ak4iavFrSnn8wAsgYPW",
+			Role:           entity.RoleUser,
 			CreatedAt:      now,
 			UpdatedAt:      now,
 		},
@@ -35,6 +37,7 @@ func LoadFixtures(db inmemory.InMemoryDB) {
 			Username:       "test3",
 			Email:          "test3@mail.ru",
 			HashedPassword: "$2a$10$lgQ9a71CwJQkAF1yUcKKl..RGDT4OaGRjyBAVFgGupkdMclmS7wMS",
+			Role:           entity.RoleUser,
 			CreatedAt:      now,
 			UpdatedAt:      now,
 		},
@@ -52,6 +55,7 @@ func LoadFixtures(db inmemory.InMemoryDB) {
 	pubMessages := []entity.PublicMessage{
 		{
 			ID:           1,
+			ChannelID:    entity.GeneralChannelID,
 			FromUsername: "test",
 			Content:      "Hello everyone, I'm Test!",
 			SentAt:       now,
@@ -59,6 +63,7 @@ func LoadFixtures(db inmemory.InMemoryDB) {
 		},
 		{
 			ID:           2,
+			ChannelID:    entity.GeneralChannelID,
 			FromUsername: "test2",
 			Content:      "Hello everyone, I'm Test2 ;)",
 			SentAt:       now,
@@ -66,6 +71,7 @@ func LoadFixtures(db inmemory.InMemoryDB) {
 		},
 		{
 			ID:           3,
+			ChannelID:    entity.GeneralChannelID,
 			FromUsername: "test3",
 			Content:      "What's up! I'm Test3",
 			SentAt:       now,
@@ -1,8 +1,11 @@
 package testing
 
 import (
+	"context"
 	"database/sql/driver"
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/mocks"
+	"github.com/golang/mock/gomock"
 	"time"
 )
 
@@ -42,3 +45,14 @@ func PrivateMessagesEquals(msg1, msg2 entity.PrivateMessage) bool {
 		timesAlmostEquals(msg1.SentAt, msg2.SentAt) &&
 		timesAlmostEquals(msg1.EditedAt, msg2.EditedAt)
 }
+
+// NewUnitOfWorkMock runs units of work in place, as if they always commit.
+func NewUnitOfWorkMock(ctrl *gomock.Controller) *mocks.MockUnitOfWork {
+	uowMock := mocks.NewMockUnitOfWork(ctrl)
+
+	uowMock.EXPECT().Do(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error { return fn(ctx) }).
+		AnyTimes()
+
+	return uowMock
+}
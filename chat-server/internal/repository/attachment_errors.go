@@ -0,0 +1,5 @@
+package repository
+
+import "errors"
+
+var ErrNoSuchAttachment = errors.New("no such attachment")
@@ -0,0 +1,8 @@
+package repository
+
+import "errors"
+
+var (
+	ErrNoSuchBlock = errors.New("user is not blocked")
+	ErrBlockExists = errors.New("user is already blocked")
+)
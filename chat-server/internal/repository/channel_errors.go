@@ -0,0 +1,8 @@
+package repository
+
+import "errors"
+
+var (
+	ErrNoSuchChannel     = errors.New("no such channel")
+	ErrChannelNameExists = errors.New("channel with this name already exists")
+)
@@ -0,0 +1,7 @@
+package repository
+
+import "errors"
+
+var (
+	ErrNoSuchConversation = errors.New("no such conversation")
+)
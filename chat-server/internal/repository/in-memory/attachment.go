@@ -0,0 +1,185 @@
+// nolint
+package in_memory
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+
+	inmemory "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/db/in-memory"
+)
+
+type AttachmentRepo struct {
+	DB inmemory.InMemoryDB
+}
+
+func NewAttachmentRepo(db inmemory.InMemoryDB) *AttachmentRepo {
+	repo := AttachmentRepo{
+		DB: db,
+	}
+
+	_, err := repo.DB.GetTable(AttachmentTableName)
+	if errors.Is(err, inmemory.ErrNotExistedTable) {
+		repo.DB.CreateTable(AttachmentTableName)
+	}
+
+	return &repo
+}
+
+func (ar *AttachmentRepo) AddAttachment(ctx context.Context, attachment entity.Attachment) (*entity.Attachment, error) {
+	exec := inmemory.ExecutorFromContext(ctx, ar.DB)
+
+	idOffset, err := exec.GetTableCounter(AttachmentTableName)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment.ID = idOffset + 1
+	attachment.CreatedAt = time.Now()
+
+	if err = exec.AddRow(AttachmentTableName, strconv.Itoa(attachment.ID), attachment); err != nil {
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+func (ar *AttachmentRepo) getAttachment(exec inmemory.Executor, id int) (*entity.Attachment, error) {
+	row, err := exec.GetRow(AttachmentTableName, strconv.Itoa(id))
+	if err != nil {
+		return nil, repository.ErrNoSuchAttachment
+	}
+
+	attachment, ok := row.(entity.Attachment)
+	if !ok {
+		return nil, repository.ErrNoSuchAttachment
+	}
+
+	return &attachment, nil
+}
+
+func (ar *AttachmentRepo) GetAttachment(ctx context.Context, id int) (*entity.Attachment, error) {
+	return ar.getAttachment(inmemory.ExecutorFromContext(ctx, ar.DB), id)
+}
+
+// GetAttachments returns attachments with provided ids, missing ones are skipped.
+func (ar *AttachmentRepo) GetAttachments(ctx context.Context, ids []int) ([]*entity.Attachment, error) {
+	exec := inmemory.ExecutorFromContext(ctx, ar.DB)
+
+	attachments := make([]*entity.Attachment, 0, len(ids))
+
+	for _, id := range ids {
+		attachment, err := ar.getAttachment(exec, id)
+		if err != nil {
+			continue
+		}
+
+		attachments = append(attachments, attachment)
+	}
+
+	return attachments, nil
+}
+
+func (ar *AttachmentRepo) getAllAttachments(exec inmemory.Executor) ([]*entity.Attachment, error) {
+	rows, err := exec.GetAllRows(AttachmentTableName, 0, math.MaxInt64)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments := make([]*entity.Attachment, 0, len(rows))
+
+	for _, row := range rows {
+		attachment, ok := row.(entity.Attachment)
+		if ok {
+			attachments = append(attachments, &attachment)
+		}
+	}
+
+	return attachments, nil
+}
+
+// LinkAttachments links pending attachments to message, all of them must exist and be pending.
+func (ar *AttachmentRepo) LinkAttachments(ctx context.Context, kind entity.MessageKind, messageID int, ids []int) error {
+	exec := inmemory.ExecutorFromContext(ctx, ar.DB)
+
+	attachments := make([]*entity.Attachment, 0, len(ids))
+
+	for _, id := range ids {
+		attachment, err := ar.getAttachment(exec, id)
+		if err != nil || attachment.IsLinked() {
+			return repository.ErrNoSuchAttachment
+		}
+
+		attachments = append(attachments, attachment)
+	}
+
+	for _, attachment := range attachments {
+		attachment.MessageKind = &kind
+		attachment.MessageID = &messageID
+
+		if err := exec.AlterRow(AttachmentTableName, strconv.Itoa(attachment.ID), *attachment); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetMessageAttachments returns attachments of provided messages in order of uploading.
+func (ar *AttachmentRepo) GetMessageAttachments(ctx context.Context, kind entity.MessageKind, messageIDs []int) ([]*entity.Attachment, error) {
+	attachments, err := ar.getAllAttachments(inmemory.ExecutorFromContext(ctx, ar.DB))
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*entity.Attachment, 0)
+
+	for _, attachment := range attachments {
+		if attachment.IsLinked() && *attachment.MessageKind == kind && slices.Contains(messageIDs, *attachment.MessageID) {
+			res = append(res, attachment)
+		}
+	}
+
+	return res, nil
+}
+
+// DeleteMessageAttachments removes attachments of message and returns them, so their content can be removed too.
+func (ar *AttachmentRepo) DeleteMessageAttachments(ctx context.Context, kind entity.MessageKind, messageID int) ([]*entity.Attachment, error) {
+	exec := inmemory.ExecutorFromContext(ctx, ar.DB)
+
+	attachments, err := ar.getAllAttachments(exec)
+	if err != nil {
+		return nil, err
+	}
+
+	deleted := make([]*entity.Attachment, 0)
+
+	for _, attachment := range attachments {
+		if !attachment.IsLinked() || *attachment.MessageKind != kind || *attachment.MessageID != messageID {
+			continue
+		}
+
+		if err = exec.DropRow(AttachmentTableName, strconv.Itoa(attachment.ID)); err != nil {
+			return nil, err
+		}
+
+		deleted = append(deleted, attachment)
+	}
+
+	return deleted, nil
+}
+
+// RenameUsername points attachments uploaded by user to new username.
+func (ar *AttachmentRepo) RenameUsername(ctx context.Context, oldUsername, newUsername string) error {
+	return renameUsernameInTable(inmemory.ExecutorFromContext(ctx, ar.DB), AttachmentTableName,
+		func(attachment entity.Attachment) string { return strconv.Itoa(attachment.ID) },
+		func(attachment *entity.Attachment) bool {
+			return renameUsername(&attachment.UploadedBy, oldUsername, newUsername)
+		})
+}
@@ -0,0 +1,112 @@
+// nolint
+package in_memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+
+	inmemory "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/db/in-memory"
+)
+
+type BlockRepo struct {
+	DB inmemory.InMemoryDB
+}
+
+func NewBlockRepo(db inmemory.InMemoryDB) *BlockRepo {
+	repo := BlockRepo{
+		DB: db,
+	}
+
+	_, err := repo.DB.GetTable(BlockTableName)
+	if errors.Is(err, inmemory.ErrNotExistedTable) {
+		repo.DB.CreateTable(BlockTableName)
+	}
+
+	return &repo
+}
+
+// blockKey makes row key unique for pair of users, which keeps one block per them.
+func blockKey(username, blockedUsername string) string {
+	return fmt.Sprintf("%q:%q", username, blockedUsername)
+}
+
+func (br *BlockRepo) AddBlock(ctx context.Context, block entity.Block) (*entity.Block, error) {
+	block.CreatedAt = time.Now()
+
+	err := inmemory.ExecutorFromContext(ctx, br.DB).
+		AddRow(BlockTableName, blockKey(block.Username, block.BlockedUsername), block)
+	if errors.Is(err, inmemory.ErrExistingKey) {
+		return nil, repository.ErrBlockExists
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &block, nil
+}
+
+func (br *BlockRepo) RemoveBlock(ctx context.Context, username, blockedUsername string) error {
+	exec := inmemory.ExecutorFromContext(ctx, br.DB)
+
+	key := blockKey(username, blockedUsername)
+
+	if _, err := exec.GetRow(BlockTableName, key); err != nil {
+		return repository.ErrNoSuchBlock
+	}
+
+	return exec.DropRow(BlockTableName, key)
+}
+
+// GetBlocks returns users blocked by user, most recently blocked first.
+func (br *BlockRepo) GetBlocks(ctx context.Context, username string) ([]*entity.Block, error) {
+	rows, err := inmemory.ExecutorFromContext(ctx, br.DB).GetAllRows(BlockTableName, 0, math.MaxInt64)
+	if err != nil {
+		return nil, err
+	}
+
+	blocks := make([]*entity.Block, 0)
+
+	for _, row := range rows {
+		block, ok := row.(entity.Block)
+		if ok && block.Username == username {
+			blocks = append(blocks, &block)
+		}
+	}
+
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i].CreatedAt.After(blocks[j].CreatedAt) })
+
+	return blocks, nil
+}
+
+func (br *BlockRepo) IsBlocked(ctx context.Context, username, blockedUsername string) (bool, error) {
+	_, err := inmemory.ExecutorFromContext(ctx, br.DB).GetRow(BlockTableName, blockKey(username, blockedUsername))
+	if errors.Is(err, inmemory.ErrNotExistedRow) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// RenameUsername points blocks of user and blocks of other users on them to new username.
+func (br *BlockRepo) RenameUsername(ctx context.Context, oldUsername, newUsername string) error {
+	return renameUsernameInTable(inmemory.ExecutorFromContext(ctx, br.DB), BlockTableName,
+		func(block entity.Block) string {
+			return blockKey(block.Username, block.BlockedUsername)
+		},
+		func(block *entity.Block) bool {
+			renamed := renameUsername(&block.Username, oldUsername, newUsername)
+			return renameUsername(&block.BlockedUsername, oldUsername, newUsername) || renamed
+		})
+}
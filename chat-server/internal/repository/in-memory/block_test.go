@@ -0,0 +1,58 @@
+package in_memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+
+	inmemory "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/db/in-memory"
+)
+
+func TestBlockRepo(t *testing.T) {
+	ctx := context.Background()
+	db, _ := inmemory.NewInMemDB(ctx, "")
+
+	repo := NewBlockRepo(db)
+
+	_, err := repo.AddBlock(ctx, entity.Block{Username: "alice", BlockedUsername: "bob"})
+	require.NoError(t, err)
+
+	_, err = repo.AddBlock(ctx, entity.Block{Username: "alice", BlockedUsername: "bob"})
+	assert.ErrorIs(t, err, repository.ErrBlockExists)
+
+	_, err = repo.AddBlock(ctx, entity.Block{Username: "bob", BlockedUsername: "alice"})
+	require.NoError(t, err)
+
+	blocked, err := repo.IsBlocked(ctx, "alice", "bob")
+	require.NoError(t, err)
+	assert.True(t, blocked)
+
+	blocked, err = repo.IsBlocked(ctx, "alice", "carol")
+	require.NoError(t, err)
+	assert.False(t, blocked)
+
+	// both sides of block follow renamed user
+	require.NoError(t, repo.RenameUsername(ctx, "bob", "robert"))
+
+	blocks, err := repo.GetBlocks(ctx, "alice")
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	assert.Equal(t, "robert", blocks[0].BlockedUsername)
+
+	blocks, err = repo.GetBlocks(ctx, "robert")
+	require.NoError(t, err)
+	require.Len(t, blocks, 1)
+	assert.Equal(t, "alice", blocks[0].BlockedUsername)
+
+	require.NoError(t, repo.RemoveBlock(ctx, "alice", "robert"))
+	assert.ErrorIs(t, repo.RemoveBlock(ctx, "alice", "robert"), repository.ErrNoSuchBlock)
+
+	blocked, err = repo.IsBlocked(ctx, "alice", "robert")
+	require.NoError(t, err)
+	assert.False(t, blocked)
+}
@@ -0,0 +1,207 @@
+// nolint
+package in_memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+
+	inmemory "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/db/in-memory"
+	sliceutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/slice"
+)
+
+type ChannelRepo struct {
+	DB inmemory.InMemoryDB
+}
+
+func NewChannelRepo(db inmemory.InMemoryDB) *ChannelRepo {
+	repo := ChannelRepo{
+		DB: db,
+	}
+
+	for _, table := range []string{ChannelTableName, ChannelMemberTableName} {
+		_, err := repo.DB.GetTable(table)
+		if errors.Is(err, inmemory.ErrNotExistedTable) {
+			repo.DB.CreateTable(table)
+		}
+	}
+
+	// former global public chat lives in general channel, so it must always exist
+	if _, err := repo.getChannel(context.Background(), entity.GeneralChannelID); err != nil {
+		_, _ = repo.AddChannel(context.Background(), entity.Channel{
+			Name:        entity.GeneralChannelName,
+			Description: "default channel for everyone",
+		})
+	}
+
+	return &repo
+}
+
+func channelMemberKey(channelID int, username string) string {
+	return fmt.Sprintf("%d:%s", channelID, username)
+}
+
+func (cr *ChannelRepo) getAllChannels(ctx context.Context, offset, limit int) []*entity.Channel {
+	rows, err := inmemory.ExecutorFromContext(ctx, cr.DB).GetAllRows(ChannelTableName, offset, limit)
+	if err != nil {
+		return nil
+	}
+
+	res := make([]*entity.Channel, 0, len(rows))
+
+	for _, row := range rows {
+		channel, ok := row.(entity.Channel)
+		if ok {
+			res = append(res, &channel)
+		}
+	}
+
+	return res
+}
+
+func (cr *ChannelRepo) GetAllChannels(ctx context.Context, offset, limit int) []*entity.Channel {
+	return cr.getAllChannels(ctx, offset, limit)
+}
+
+func (cr *ChannelRepo) AddChannel(ctx context.Context, channel entity.Channel) (*entity.Channel, error) {
+	exec := inmemory.ExecutorFromContext(ctx, cr.DB)
+
+	channels := cr.getAllChannels(ctx, 0, math.MaxInt64)
+
+	if len(sliceutils.Filter(channels, func(c *entity.Channel) bool { return c.Name == channel.Name })) != 0 {
+		return nil, repository.ErrChannelNameExists
+	}
+
+	idOffset, err := exec.GetTableCounter(ChannelTableName)
+	if err != nil {
+		return nil, err
+	}
+
+	channel.ID = idOffset + 1
+	channel.CreatedAt = time.Now()
+
+	if err = exec.AddRow(ChannelTableName, strconv.Itoa(channel.ID), channel); err != nil {
+		return nil, err
+	}
+
+	return &channel, nil
+}
+
+func (cr *ChannelRepo) getChannel(ctx context.Context, id int) (*entity.Channel, error) {
+	row, err := inmemory.ExecutorFromContext(ctx, cr.DB).GetRow(ChannelTableName, strconv.Itoa(id))
+	if err != nil {
+		return nil, repository.ErrNoSuchChannel
+	}
+
+	channel, ok := row.(entity.Channel)
+	if !ok {
+		return nil, repository.ErrNoSuchChannel
+	}
+
+	return &channel, nil
+}
+
+func (cr *ChannelRepo) GetChannel(ctx context.Context, id int) (*entity.Channel, error) {
+	return cr.getChannel(ctx, id)
+}
+
+// AddChannelMember adds user to channel members. Adding existing member does nothing.
+func (cr *ChannelRepo) AddChannelMember(ctx context.Context, channelID int, username string) error {
+	if _, err := cr.getChannel(ctx, channelID); err != nil {
+		return err
+	}
+
+	member := entity.ChannelMember{
+		ChannelID: channelID,
+		Username:  username,
+		JoinedAt:  time.Now(),
+	}
+
+	err := inmemory.ExecutorFromContext(ctx, cr.DB).
+		AddRow(ChannelMemberTableName, channelMemberKey(channelID, username), member)
+	if err != nil && !errors.Is(err, inmemory.ErrExistingKey) {
+		return err
+	}
+
+	return nil
+}
+
+func (cr *ChannelRepo) RemoveChannelMember(ctx context.Context, channelID int, username string) error {
+	return inmemory.ExecutorFromContext(ctx, cr.DB).DropRow(ChannelMemberTableName, channelMemberKey(channelID, username))
+}
+
+func (cr *ChannelRepo) GetChannelMembers(ctx context.Context, channelID int) ([]*entity.ChannelMember, error) {
+	if _, err := cr.getChannel(ctx, channelID); err != nil {
+		return nil, err
+	}
+
+	rows, err := inmemory.ExecutorFromContext(ctx, cr.DB).GetAllRows(ChannelMemberTableName, 0, math.MaxInt64)
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]*entity.ChannelMember, 0)
+
+	for _, row := range rows {
+		member, ok := row.(entity.ChannelMember)
+		if ok && member.ChannelID == channelID {
+			members = append(members, &member)
+		}
+	}
+
+	return members, nil
+}
+
+func (cr *ChannelRepo) IsChannelMember(ctx context.Context, channelID int, username string) (bool, error) {
+	_, err := inmemory.ExecutorFromContext(ctx, cr.DB).GetRow(ChannelMemberTableName, channelMemberKey(channelID, username))
+	if errors.Is(err, inmemory.ErrNotExistedRow) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GetMemberChannelIDs returns ids of channels that user is a member of.
+func (cr *ChannelRepo) GetMemberChannelIDs(ctx context.Context, username string) ([]int, error) {
+	rows, err := inmemory.ExecutorFromContext(ctx, cr.DB).GetAllRows(ChannelMemberTableName, 0, math.MaxInt64)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, 0)
+
+	for _, row := range rows {
+		member, ok := row.(entity.ChannelMember)
+		if ok && member.Username == username {
+			ids = append(ids, member.ChannelID)
+		}
+	}
+
+	return ids, nil
+}
+
+// RenameUsername points channels created by user and memberships of user to new username.
+func (cr *ChannelRepo) RenameUsername(ctx context.Context, oldUsername, newUsername string) error {
+	err := renameUsernameInTable(inmemory.ExecutorFromContext(ctx, cr.DB), ChannelTableName,
+		func(channel entity.Channel) string { return strconv.Itoa(channel.ID) },
+		func(channel *entity.Channel) bool { return renameUsername(channel.CreatedBy, oldUsername, newUsername) })
+	if err != nil {
+		return err
+	}
+
+	return renameUsernameInTable(inmemory.ExecutorFromContext(ctx, cr.DB), ChannelMemberTableName,
+		func(member entity.ChannelMember) string { return channelMemberKey(member.ChannelID, member.Username) },
+		func(member *entity.ChannelMember) bool {
+			return renameUsername(&member.Username, oldUsername, newUsername)
+		})
+}
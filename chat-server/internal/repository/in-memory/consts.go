@@ -1,7 +1,30 @@
 package in_memory
 
 const (
-	PrivateMessageTableName = "private_messages"
-	PublicMessageTableName  = "public_messages"
-	UserTableName           = "users"
+	AttachmentTableName               = "attachments"
+	BlockTableName                    = "user_blocks"
+	ChannelTableName                  = "channels"
+	ChannelMemberTableName            = "channel_members"
+	ConversationTableName             = "conversations"
+	ConversationParticipantTableName  = "conversation_participants"
+	ConversationMessageTableName      = "conversation_messages"
+	MentionTableName                  = "mentions"
+	PrivateMessageTableName           = "private_messages"
+	PrivateMessageRevisionTableName   = "private_message_revisions"
+	PrivateMessageReactionTableName   = "private_message_reactions"
+	PrivateMessageReadMarkerTableName = "private_message_read_markers"
+	ProfileTableName                  = "profiles"
+	PublicMessageTableName            = "public_messages"
+	PublicMessageRevisionTableName    = "public_message_revisions"
+	PublicMessageReactionTableName    = "public_message_reactions"
+	RefreshTokenTableName             = "refresh_tokens"
+	SessionTableName                  = "sessions"
+	UserTableName                     = "users"
+	UserRoleGuardTableName            = "user_role_guards"
+)
+
+const (
+	RefreshTokenFamilyIndex = "family_id"
+	UserEmailIndex          = "email"
+	UserUsernameIndex       = "username"
 )
@@ -0,0 +1,268 @@
+// nolint
+package in_memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+
+	inmemory "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/db/in-memory"
+	sliceutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/slice"
+)
+
+type ConversationRepo struct {
+	DB inmemory.InMemoryDB
+}
+
+func NewConversationRepo(db inmemory.InMemoryDB) *ConversationRepo {
+	repo := ConversationRepo{
+		DB: db,
+	}
+
+	for _, table := range []string{ConversationTableName, ConversationParticipantTableName, ConversationMessageTableName} {
+		_, err := repo.DB.GetTable(table)
+		if errors.Is(err, inmemory.ErrNotExistedTable) {
+			repo.DB.CreateTable(table)
+		}
+	}
+
+	return &repo
+}
+
+func conversationParticipantKey(conversationID int, username string) string {
+	return fmt.Sprintf("%d:%s", conversationID, username)
+}
+
+func (cr *ConversationRepo) AddConversation(ctx context.Context, conversation entity.Conversation) (*entity.Conversation, error) {
+	exec := inmemory.ExecutorFromContext(ctx, cr.DB)
+
+	idOffset, err := exec.GetTableCounter(ConversationTableName)
+	if err != nil {
+		return nil, err
+	}
+
+	conversation.ID = idOffset + 1
+	conversation.CreatedAt = time.Now()
+
+	if err = exec.AddRow(ConversationTableName, strconv.Itoa(conversation.ID), conversation); err != nil {
+		return nil, err
+	}
+
+	return &conversation, nil
+}
+
+func (cr *ConversationRepo) getConversation(ctx context.Context, id int) (*entity.Conversation, error) {
+	row, err := inmemory.ExecutorFromContext(ctx, cr.DB).GetRow(ConversationTableName, strconv.Itoa(id))
+	if err != nil {
+		return nil, repository.ErrNoSuchConversation
+	}
+
+	conversation, ok := row.(entity.Conversation)
+	if !ok {
+		return nil, repository.ErrNoSuchConversation
+	}
+
+	return &conversation, nil
+}
+
+func (cr *ConversationRepo) GetConversation(ctx context.Context, id int) (*entity.Conversation, error) {
+	return cr.getConversation(ctx, id)
+}
+
+func (cr *ConversationRepo) getAllParticipants(ctx context.Context) ([]*entity.ConversationParticipant, error) {
+	rows, err := inmemory.ExecutorFromContext(ctx, cr.DB).GetAllRows(ConversationParticipantTableName, 0, math.MaxInt64)
+	if err != nil {
+		return nil, err
+	}
+
+	participants := make([]*entity.ConversationParticipant, 0, len(rows))
+
+	for _, row := range rows {
+		participant, ok := row.(entity.ConversationParticipant)
+		if ok {
+			participants = append(participants, &participant)
+		}
+	}
+
+	return participants, nil
+}
+
+// GetUserConversations returns conversations where user is a participant.
+func (cr *ConversationRepo) GetUserConversations(ctx context.Context, username string) ([]*entity.Conversation, error) {
+	participants, err := cr.getAllParticipants(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	conversations := make([]*entity.Conversation, 0)
+
+	for _, participant := range participants {
+		if participant.Username != username {
+			continue
+		}
+
+		conversation, err := cr.getConversation(ctx, participant.ConversationID)
+		if err != nil {
+			continue
+		}
+
+		conversations = append(conversations, conversation)
+	}
+
+	return conversations, nil
+}
+
+// AddConversationParticipant adds user to conversation. Adding existing participant does nothing.
+func (cr *ConversationRepo) AddConversationParticipant(ctx context.Context, conversationID int, username string) error {
+	if _, err := cr.getConversation(ctx, conversationID); err != nil {
+		return err
+	}
+
+	participant := entity.ConversationParticipant{
+		ConversationID: conversationID,
+		Username:       username,
+		JoinedAt:       time.Now(),
+	}
+
+	err := inmemory.ExecutorFromContext(ctx, cr.DB).
+		AddRow(ConversationParticipantTableName, conversationParticipantKey(conversationID, username), participant)
+	if err != nil && !errors.Is(err, inmemory.ErrExistingKey) {
+		return err
+	}
+
+	return nil
+}
+
+func (cr *ConversationRepo) RemoveConversationParticipant(ctx context.Context, conversationID int, username string) error {
+	return inmemory.ExecutorFromContext(ctx, cr.DB).
+		DropRow(ConversationParticipantTableName, conversationParticipantKey(conversationID, username))
+}
+
+func (cr *ConversationRepo) GetConversationParticipants(ctx context.Context, conversationID int) ([]*entity.ConversationParticipant, error) {
+	if _, err := cr.getConversation(ctx, conversationID); err != nil {
+		return nil, err
+	}
+
+	participants, err := cr.getAllParticipants(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return sliceutils.Filter(participants, func(p *entity.ConversationParticipant) bool {
+		return p.ConversationID == conversationID
+	}), nil
+}
+
+func (cr *ConversationRepo) IsConversationParticipant(ctx context.Context, conversationID int, username string) (bool, error) {
+	_, err := inmemory.ExecutorFromContext(ctx, cr.DB).
+		GetRow(ConversationParticipantTableName, conversationParticipantKey(conversationID, username))
+	if errors.Is(err, inmemory.ErrNotExistedRow) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (cr *ConversationRepo) AddConversationMessage(ctx context.Context, msg entity.ConversationMessage) (*entity.ConversationMessage, error) {
+	exec := inmemory.ExecutorFromContext(ctx, cr.DB)
+
+	if _, err := cr.getConversation(ctx, msg.ConversationID); err != nil {
+		return nil, err
+	}
+
+	idOffset, err := exec.GetTableCounter(ConversationMessageTableName)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	msg.ID = idOffset + 1
+	msg.SentAt = now
+	msg.EditedAt = now
+
+	if err = exec.AddRow(ConversationMessageTableName, strconv.Itoa(msg.ID), msg); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+func (cr *ConversationRepo) getConversationMessages(ctx context.Context, conversationID int) []*entity.ConversationMessage {
+	rows, err := inmemory.ExecutorFromContext(ctx, cr.DB).GetAllRows(ConversationMessageTableName, 0, math.MaxInt64)
+	if err != nil {
+		return nil
+	}
+
+	messages := make([]*entity.ConversationMessage, 0)
+
+	for _, row := range rows {
+		msg, ok := row.(entity.ConversationMessage)
+		if ok && msg.ConversationID == conversationID {
+			messages = append(messages, &msg)
+		}
+	}
+
+	sort.Slice(messages, func(i, j int) bool { return messages[i].SentAt.Before(messages[j].SentAt) })
+
+	return messages
+}
+
+// GetConversationMessages returns messages of the conversation ordered by sending time.
+func (cr *ConversationRepo) GetConversationMessages(ctx context.Context, conversationID, offset, limit int) []*entity.ConversationMessage {
+	return sliceutils.Slice(cr.getConversationMessages(ctx, conversationID), offset, limit)
+}
+
+// GetLastConversationMessage returns the latest message of conversation or nil if nothing was sent yet.
+func (cr *ConversationRepo) GetLastConversationMessage(ctx context.Context, conversationID int) (*entity.ConversationMessage, error) {
+	if _, err := cr.getConversation(ctx, conversationID); err != nil {
+		return nil, err
+	}
+
+	messages := cr.getConversationMessages(ctx, conversationID)
+	if len(messages) == 0 {
+		return nil, nil
+	}
+
+	return messages[len(messages)-1], nil
+}
+
+// RenameUsername points conversations created by user, participations and messages of user to new username.
+func (cr *ConversationRepo) RenameUsername(ctx context.Context, oldUsername, newUsername string) error {
+	err := renameUsernameInTable(inmemory.ExecutorFromContext(ctx, cr.DB), ConversationTableName,
+		func(conversation entity.Conversation) string { return strconv.Itoa(conversation.ID) },
+		func(conversation *entity.Conversation) bool {
+			return renameUsername(conversation.CreatedBy, oldUsername, newUsername)
+		})
+	if err != nil {
+		return err
+	}
+
+	err = renameUsernameInTable(inmemory.ExecutorFromContext(ctx, cr.DB), ConversationParticipantTableName,
+		func(participant entity.ConversationParticipant) string {
+			return conversationParticipantKey(participant.ConversationID, participant.Username)
+		},
+		func(participant *entity.ConversationParticipant) bool {
+			return renameUsername(&participant.Username, oldUsername, newUsername)
+		})
+	if err != nil {
+		return err
+	}
+
+	return renameUsernameInTable(inmemory.ExecutorFromContext(ctx, cr.DB), ConversationMessageTableName,
+		func(msg entity.ConversationMessage) string { return strconv.Itoa(msg.ID) },
+		func(msg *entity.ConversationMessage) bool {
+			return renameUsername(&msg.FromUsername, oldUsername, newUsername)
+		})
+}
@@ -0,0 +1,17 @@
+// nolint
+package in_memory
+
+import (
+	"errors"
+
+	inmemory "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/db/in-memory"
+)
+
+// notFoundError maps missing row or table to the error of repository, other errors like unexpected row type are kept.
+func notFoundError(err error, notFound error) error {
+	if errors.Is(err, inmemory.ErrNotExistedRow) || errors.Is(err, inmemory.ErrNotExistedTable) {
+		return notFound
+	}
+
+	return err
+}
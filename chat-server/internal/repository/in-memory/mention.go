@@ -0,0 +1,143 @@
+// nolint
+package in_memory
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+
+	inmemory "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/db/in-memory"
+)
+
+type MentionRepo struct {
+	DB inmemory.InMemoryDB
+}
+
+func NewMentionRepo(db inmemory.InMemoryDB) *MentionRepo {
+	repo := MentionRepo{
+		DB: db,
+	}
+
+	_, err := repo.DB.GetTable(MentionTableName)
+	if errors.Is(err, inmemory.ErrNotExistedTable) {
+		repo.DB.CreateTable(MentionTableName)
+	}
+
+	return &repo
+}
+
+func (mr *MentionRepo) getMentions(exec inmemory.Executor, filter func(entity.Mention) bool) ([]*entity.Mention, error) {
+	rows, err := exec.GetAllRows(MentionTableName, 0, math.MaxInt64)
+	if err != nil {
+		return nil, err
+	}
+
+	mentions := make([]*entity.Mention, 0)
+
+	for _, row := range rows {
+		mention, ok := row.(entity.Mention)
+		if ok && filter(mention) {
+			mentions = append(mentions, &mention)
+		}
+	}
+
+	return mentions, nil
+}
+
+func (mr *MentionRepo) AddMentions(ctx context.Context, mentions []entity.Mention) ([]*entity.Mention, error) {
+	exec := inmemory.ExecutorFromContext(ctx, mr.DB)
+
+	idOffset, err := exec.GetTableCounter(MentionTableName)
+	if err != nil {
+		return nil, err
+	}
+
+	added := make([]*entity.Mention, 0, len(mentions))
+	now := time.Now()
+
+	for i := range mentions {
+		mention := mentions[i]
+		mention.ID = idOffset + i + 1
+		mention.CreatedAt = now
+
+		if err = exec.AddRow(MentionTableName, strconv.Itoa(mention.ID), mention); err != nil {
+			return nil, err
+		}
+
+		added = append(added, &mention)
+	}
+
+	return added, nil
+}
+
+func (mr *MentionRepo) GetMessageMentions(
+	ctx context.Context,
+	kind entity.MessageKind,
+	messageIDs []int,
+) ([]*entity.Mention, error) {
+	return mr.getMentions(inmemory.ExecutorFromContext(ctx, mr.DB), func(m entity.Mention) bool {
+		return m.MessageKind == kind && slices.Contains(messageIDs, m.MessageID)
+	})
+}
+
+// GetUserMentions returns mentions of user, newest first.
+func (mr *MentionRepo) GetUserMentions(
+	ctx context.Context,
+	username string,
+	unreadOnly bool,
+	offset, limit int,
+) ([]*entity.Mention, error) {
+	mentions, err := mr.getMentions(inmemory.ExecutorFromContext(ctx, mr.DB), func(m entity.Mention) bool {
+		return m.Username == username && (!unreadOnly || !m.IsRead())
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.Reverse(mentions)
+
+	if offset >= len(mentions) {
+		return []*entity.Mention{}, nil
+	}
+
+	return mentions[offset:min(offset+limit, len(mentions))], nil
+}
+
+// MarkMentionsRead marks unread mentions of user with id up to upToID as read, it returns count of marked ones.
+func (mr *MentionRepo) MarkMentionsRead(ctx context.Context, username string, upToID int, readAt time.Time) (int, error) {
+	exec := inmemory.ExecutorFromContext(ctx, mr.DB)
+
+	mentions, err := mr.getMentions(exec, func(m entity.Mention) bool {
+		return m.Username == username && m.ID <= upToID && !m.IsRead()
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, mention := range mentions {
+		mention.ReadAt = &readAt
+
+		if err = exec.AlterRow(MentionTableName, strconv.Itoa(mention.ID), *mention); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(mentions), nil
+}
+
+// RenameUsername points mentions of user and mentions made by user to new username.
+func (mr *MentionRepo) RenameUsername(ctx context.Context, oldUsername, newUsername string) error {
+	return renameUsernameInTable(inmemory.ExecutorFromContext(ctx, mr.DB), MentionTableName,
+		func(mention entity.Mention) string {
+			return strconv.Itoa(mention.ID)
+		},
+		func(mention *entity.Mention) bool {
+			renamed := renameUsername(&mention.Username, oldUsername, newUsername)
+			return renameUsername(&mention.FromUsername, oldUsername, newUsername) || renamed
+		})
+}
@@ -0,0 +1,68 @@
+package in_memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+
+	inmemory "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/db/in-memory"
+)
+
+func TestMentionRepo(t *testing.T) {
+	ctx := context.Background()
+	db, _ := inmemory.NewInMemDB(ctx, "")
+
+	repo := NewMentionRepo(db)
+
+	added, err := repo.AddMentions(ctx, []entity.Mention{
+		{MessageKind: entity.MessageKindPublic, MessageID: 1, Username: "bob", FromUsername: "alice"},
+		{MessageKind: entity.MessageKindPublic, MessageID: 1, Username: "carol", FromUsername: "alice"},
+	})
+	require.NoError(t, err)
+	require.Len(t, added, 2)
+	assert.Equal(t, []int{1, 2}, []int{added[0].ID, added[1].ID})
+
+	_, err = repo.AddMentions(ctx, []entity.Mention{
+		{MessageKind: entity.MessageKindPrivate, MessageID: 1, Username: "bob", FromUsername: "carol"},
+	})
+	require.NoError(t, err)
+
+	mentions, err := repo.GetMessageMentions(ctx, entity.MessageKindPublic, []int{1, 2})
+	require.NoError(t, err)
+	assert.Len(t, mentions, 2)
+
+	mentions, err = repo.GetUserMentions(ctx, "bob", false, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, mentions, 2)
+	assert.Equal(t, 3, mentions[0].ID)
+	assert.Equal(t, 1, mentions[1].ID)
+
+	marked, err := repo.MarkMentionsRead(ctx, "bob", 1, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, marked)
+
+	mentions, err = repo.GetUserMentions(ctx, "bob", true, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, mentions, 1)
+	assert.Equal(t, 3, mentions[0].ID)
+
+	// mentions follow renamed user on both sides
+	require.NoError(t, repo.RenameUsername(ctx, "bob", "robert"))
+
+	mentions, err = repo.GetUserMentions(ctx, "robert", false, 1, 10)
+	require.NoError(t, err)
+	require.Len(t, mentions, 1)
+	assert.True(t, mentions[0].IsRead())
+
+	require.NoError(t, repo.RenameUsername(ctx, "alice", "alicia"))
+
+	mentions, err = repo.GetUserMentions(ctx, "carol", false, 0, 10)
+	require.NoError(t, err)
+	require.Len(t, mentions, 1)
+	assert.Equal(t, "alicia", mentions[0].FromUsername)
+}
@@ -0,0 +1,49 @@
+// nolint
+package in_memory
+
+import (
+	"context"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+
+	inmemory "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/db/in-memory"
+)
+
+func newRevisionTable(db inmemory.InMemoryDB, name string) *inmemory.TypedTable[int, entity.MessageRevision] {
+	return inmemory.NewTypedTable(db, inmemory.TableSchema[int, entity.MessageRevision]{
+		Name:   name,
+		Keys:   inmemory.IntKeys,
+		KeyOf:  func(revision entity.MessageRevision) int { return revision.ID },
+		SetKey: func(revision *entity.MessageRevision, id int) { revision.ID = id },
+	})
+}
+
+func getMessageRevisions(ctx context.Context, revisions *inmemory.TypedTable[int, entity.MessageRevision], messageID int) ([]*entity.MessageRevision, error) {
+	rows, err := revisions.Filter(ctx, func(revision entity.MessageRevision) bool { return revision.MessageID == messageID })
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*entity.MessageRevision, 0, len(rows))
+
+	for i := range rows {
+		res = append(res, &rows[i])
+	}
+
+	return res, nil
+}
+
+func dropMessageRevisions(ctx context.Context, revisions *inmemory.TypedTable[int, entity.MessageRevision], messageID int) error {
+	rows, err := revisions.Filter(ctx, func(revision entity.MessageRevision) bool { return revision.MessageID == messageID })
+	if err != nil {
+		return err
+	}
+
+	for _, revision := range rows {
+		if err = revisions.Delete(ctx, revision.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
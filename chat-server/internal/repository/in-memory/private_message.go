@@ -3,72 +3,72 @@ package in_memory
 
 import (
 	"context"
-	"errors"
+	"fmt"
+	"math"
+	"slices"
 	"sort"
-	"strconv"
-	"sync"
 	"time"
 
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
 
 	inmemory "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/db/in-memory"
+	sliceutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/slice"
 )
 
 type PrivateMessageRepo struct {
-	DB    inmemory.InMemoryDB
-	mutex sync.RWMutex
+	DB          inmemory.InMemoryDB
+	messages    *inmemory.TypedTable[int, entity.PrivateMessage]
+	revisions   *inmemory.TypedTable[int, entity.MessageRevision]
+	readMarkers *inmemory.TypedTable[string, entity.ReadMarker]
 }
 
 func NewPrivateMessageRepo(db inmemory.InMemoryDB) *PrivateMessageRepo {
 	repo := PrivateMessageRepo{
-		DB:    db,
-		mutex: sync.RWMutex{},
+		DB: db,
+		messages: inmemory.NewTypedTable(db, inmemory.TableSchema[int, entity.PrivateMessage]{
+			Name:   PrivateMessageTableName,
+			Keys:   inmemory.IntKeys,
+			KeyOf:  func(msg entity.PrivateMessage) int { return msg.ID },
+			SetKey: func(msg *entity.PrivateMessage, id int) { msg.ID = id },
+		}),
+		revisions: newRevisionTable(db, PrivateMessageRevisionTableName),
+		readMarkers: inmemory.NewTypedTable(db, inmemory.TableSchema[string, entity.ReadMarker]{
+			Name:  PrivateMessageReadMarkerTableName,
+			Keys:  inmemory.StringKeys,
+			KeyOf: func(marker entity.ReadMarker) string { return readMarkerKey(marker.Username, marker.Counterpart) },
+		}),
 	}
 
-	_, err := repo.DB.GetTable(PrivateMessageTableName)
-	if errors.Is(err, inmemory.ErrNotExistedTable) {
-		repo.DB.CreateTable(PrivateMessageTableName)
-	}
+	_ = repo.DB.CreateTextIndex(PrivateMessageTableName, privateMessageText)
 
 	return &repo
 }
 
-func (pr *PrivateMessageRepo) AddPrivateMessage(_ context.Context, msg entity.PrivateMessage) (*entity.PrivateMessage, error) {
-	pr.mutex.Lock()
-	defer pr.mutex.Unlock()
-
-	idOffset, err := pr.DB.GetTableCounter(PrivateMessageTableName)
-	if err != nil {
-		return nil, err
-	}
-
+func (pr *PrivateMessageRepo) AddPrivateMessage(ctx context.Context, msg entity.PrivateMessage) (*entity.PrivateMessage, error) {
 	now := time.Now()
 
-	msg.ID = idOffset + 1
 	msg.SentAt = now
 	msg.EditedAt = now
 
-	if err = pr.DB.AddRow(PrivateMessageTableName, strconv.Itoa(msg.ID), msg); err != nil {
+	msg, err := pr.messages.Add(ctx, msg)
+	if err != nil {
 		return nil, err
 	}
 
 	return &msg, nil
 }
 
-func (pr *PrivateMessageRepo) getAllPrivateMessages(_ context.Context, offset, limit int) []*entity.PrivateMessage {
-	rows, err := pr.DB.GetAllRows(PrivateMessageTableName, offset, limit)
+func (pr *PrivateMessageRepo) getAllPrivateMessages(ctx context.Context, offset, limit int) []*entity.PrivateMessage {
+	messages, err := pr.messages.Rows(ctx, offset, limit)
 	if err != nil {
 		return nil
 	}
 
-	res := make([]*entity.PrivateMessage, 0, len(rows))
+	res := make([]*entity.PrivateMessage, 0, len(messages))
 
-	for _, row := range rows {
-		msg, ok := row.(entity.PrivateMessage)
-		if ok {
-			res = append(res, &msg)
-		}
+	for i := range messages {
+		res = append(res, &messages[i])
 	}
 
 	sort.Slice(res, func(i, j int) bool { return res[i].SentAt.Before(res[j].SentAt) })
@@ -77,29 +77,243 @@ func (pr *PrivateMessageRepo) getAllPrivateMessages(_ context.Context, offset, l
 }
 
 func (pr *PrivateMessageRepo) GetAllPrivateMessages(ctx context.Context, offset, limit int) []*entity.PrivateMessage {
-	pr.mutex.RLock()
-	defer pr.mutex.RUnlock()
-
 	return pr.getAllPrivateMessages(ctx, offset, limit)
 }
 
-func (pr *PrivateMessageRepo) getPrivateMessage(_ context.Context, id int) (*entity.PrivateMessage, error) {
-	row, err := pr.DB.GetRow(PrivateMessageTableName, strconv.Itoa(id))
-	if err != nil {
-		return nil, repository.ErrNoSuchPrivateMessage
+// GetPrivateMessageReplies returns replies in thread of the message ordered by sending time.
+func (pr *PrivateMessageRepo) GetPrivateMessageReplies(ctx context.Context, parentID, offset, limit int) []*entity.PrivateMessage {
+	replies := sliceutils.Filter(pr.getAllPrivateMessages(ctx, 0, math.MaxInt64), func(msg *entity.PrivateMessage) bool {
+		return msg.ParentID != nil && *msg.ParentID == parentID
+	})
+
+	return sliceutils.Slice(replies, offset, limit)
+}
+
+// CountPrivateMessageReplies returns number of replies for each of provided messages that has any.
+func (pr *PrivateMessageRepo) CountPrivateMessageReplies(ctx context.Context, parentIDs []int) (map[int]int, error) {
+	counts := make(map[int]int, len(parentIDs))
+
+	for _, msg := range pr.getAllPrivateMessages(ctx, 0, math.MaxInt64) {
+		if msg.ParentID != nil && slices.Contains(parentIDs, *msg.ParentID) {
+			counts[*msg.ParentID]++
+		}
 	}
 
-	msg, ok := row.(entity.PrivateMessage)
-	if !ok {
-		return nil, repository.ErrNoSuchPrivateMessage
+	return counts, nil
+}
+
+func (pr *PrivateMessageRepo) getPrivateMessage(ctx context.Context, id int) (*entity.PrivateMessage, error) {
+	msg, err := pr.messages.Get(ctx, id)
+	if err != nil {
+		return nil, notFoundError(err, repository.ErrNoSuchPrivateMessage)
 	}
 
 	return &msg, nil
 }
 
 func (pr *PrivateMessageRepo) GetPrivateMessage(ctx context.Context, id int) (*entity.PrivateMessage, error) {
-	pr.mutex.RLock()
-	defer pr.mutex.RUnlock()
-
 	return pr.getPrivateMessage(ctx, id)
 }
+
+// UpdatePrivateMessage replaces content of message and keeps previous version in revision history.
+func (pr *PrivateMessageRepo) UpdatePrivateMessage(ctx context.Context, id int, updated entity.PrivateMessage) (*entity.PrivateMessage, error) {
+	msg, err := pr.getPrivateMessage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = pr.revisions.Add(ctx, entity.MessageRevision{
+		MessageID: msg.ID,
+		Content:   msg.Content,
+		EditedAt:  msg.EditedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	msg.Content = updated.Content
+	msg.EditedAt = time.Now()
+
+	if err = pr.messages.Update(ctx, *msg); err != nil {
+		return nil, notFoundError(err, repository.ErrNoSuchPrivateMessage)
+	}
+
+	return msg, nil
+}
+
+func (pr *PrivateMessageRepo) GetPrivateMessageRevisions(ctx context.Context, messageID int) ([]*entity.MessageRevision, error) {
+	if _, err := pr.getPrivateMessage(ctx, messageID); err != nil {
+		return nil, err
+	}
+
+	return getMessageRevisions(ctx, pr.revisions, messageID)
+}
+
+// DeletePrivateMessage marks message as deleted, leaving a tombstone in place of it so pagination stays stable.
+func (pr *PrivateMessageRepo) DeletePrivateMessage(ctx context.Context, id int) (*entity.PrivateMessage, error) {
+	msg, err := pr.getPrivateMessage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	msg.Content = ""
+	msg.DeletedAt = &now
+
+	if err = pr.messages.Update(ctx, *msg); err != nil {
+		return nil, notFoundError(err, repository.ErrNoSuchPrivateMessage)
+	}
+
+	return msg, nil
+}
+
+// PurgePrivateMessage removes message, its revision history and reactions completely.
+func (pr *PrivateMessageRepo) PurgePrivateMessage(ctx context.Context, id int) error {
+	if _, err := pr.getPrivateMessage(ctx, id); err != nil {
+		return err
+	}
+
+	if err := dropMessageRevisions(ctx, pr.revisions, id); err != nil {
+		return err
+	}
+
+	if err := dropMessageReactions(inmemory.ExecutorFromContext(ctx, pr.DB), PrivateMessageReactionTableName, id); err != nil {
+		return err
+	}
+
+	return pr.messages.Delete(ctx, id)
+}
+
+func readMarkerKey(username, counterpart string) string {
+	return fmt.Sprintf("%q:%q", username, counterpart)
+}
+
+func (pr *PrivateMessageRepo) getReadMarker(ctx context.Context, username, counterpart string) (*entity.ReadMarker, error) {
+	marker, err := pr.readMarkers.Get(ctx, readMarkerKey(username, counterpart))
+	if err != nil {
+		return nil, err
+	}
+
+	return &marker, nil
+}
+
+// MarkPrivateMessagesRead moves read marker of user in conversation with counterpart up to provided message
+// and sets seen time of messages received from counterpart. Marker never moves backwards.
+func (pr *PrivateMessageRepo) MarkPrivateMessagesRead(ctx context.Context, username, counterpart string, upToID int) (*entity.ReadMarker, error) {
+	marker, err := pr.getReadMarker(ctx, username, counterpart)
+	if err == nil && marker.LastReadID >= upToID {
+		return marker, nil
+	}
+
+	now := time.Now()
+
+	marker = &entity.ReadMarker{
+		Username:    username,
+		Counterpart: counterpart,
+		LastReadID:  upToID,
+		ReadAt:      now,
+	}
+
+	if err = pr.readMarkers.Save(ctx, *marker); err != nil {
+		return nil, err
+	}
+
+	messages, err := pr.messages.Range(ctx, 1, upToID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, msg := range messages {
+		if msg.FromUsername != counterpart || msg.ToUsername != username || msg.IsSeen() {
+			continue
+		}
+
+		msg.SeenAt = &now
+
+		if err = pr.messages.Update(ctx, msg); err != nil {
+			return nil, err
+		}
+	}
+
+	return marker, nil
+}
+
+// CountUnreadPrivateMessages returns number of not deleted messages received by user after read marker, grouped by sender.
+func (pr *PrivateMessageRepo) CountUnreadPrivateMessages(ctx context.Context, username string) (map[string]int, error) {
+	counts := make(map[string]int)
+
+	for _, msg := range pr.getAllPrivateMessages(ctx, 0, math.MaxInt64) {
+		if msg.ToUsername != username || msg.FromUsername == username || msg.IsDeleted() {
+			continue
+		}
+
+		lastReadID := 0
+
+		if marker, err := pr.getReadMarker(ctx, username, msg.FromUsername); err == nil {
+			lastReadID = marker.LastReadID
+		}
+
+		if msg.ID > lastReadID {
+			counts[msg.FromUsername]++
+		}
+	}
+
+	return counts, nil
+}
+
+// SearchPrivateMessages returns not deleted messages sent or received by user matching query, newest first.
+func (pr *PrivateMessageRepo) SearchPrivateMessages(ctx context.Context, username string, query entity.MessageSearchQuery, limit int) ([]*entity.SearchHit, error) {
+	messages, err := pr.messages.Search(ctx, query.Text)
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]*entity.SearchHit, 0, len(messages))
+
+	for i := range messages {
+		msg := &messages[i]
+
+		if !matchesSearchFilters(query, msg.FromUsername, msg.SentAt) {
+			continue
+		}
+
+		if query.Counterpart != "" {
+			isWithCounterpart := (msg.FromUsername == username && msg.ToUsername == query.Counterpart) ||
+				(msg.FromUsername == query.Counterpart && msg.ToUsername == username)
+			if !isWithCounterpart {
+				continue
+			}
+		} else if msg.FromUsername != username && msg.ToUsername != username {
+			continue
+		}
+
+		hits = append(hits, &entity.SearchHit{
+			Kind:    entity.MessageKindPrivate,
+			Private: msg,
+			Snippet: inmemory.Highlight(msg.Content, query.Text, entity.SnippetMaxWords),
+		})
+	}
+
+	return sortAndLimitHits(hits, limit), nil
+}
+
+// RenameUsername points messages and read markers of user to new username.
+func (pr *PrivateMessageRepo) RenameUsername(ctx context.Context, oldUsername, newUsername string) error {
+	err := pr.messages.UpdateAll(ctx, func(msg *entity.PrivateMessage) bool {
+		from := renameUsername(&msg.FromUsername, oldUsername, newUsername)
+		to := renameUsername(&msg.ToUsername, oldUsername, newUsername)
+
+		return from || to
+	})
+	if err != nil {
+		return err
+	}
+
+	return pr.readMarkers.UpdateAll(ctx, func(marker *entity.ReadMarker) bool {
+		user := renameUsername(&marker.Username, oldUsername, newUsername)
+		counterpart := renameUsername(&marker.Counterpart, oldUsername, newUsername)
+
+		return user || counterpart
+	})
+}
@@ -0,0 +1,141 @@
+// nolint
+package in_memory
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+
+	inmemory "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/db/in-memory"
+)
+
+type ProfileRepo struct {
+	DB inmemory.InMemoryDB
+}
+
+func NewProfileRepo(db inmemory.InMemoryDB) *ProfileRepo {
+	repo := ProfileRepo{
+		DB: db,
+	}
+
+	_, err := repo.DB.GetTable(ProfileTableName)
+	if errors.Is(err, inmemory.ErrNotExistedTable) {
+		repo.DB.CreateTable(ProfileTableName)
+	}
+
+	return &repo
+}
+
+// getProfile returns stored profile of user, username is not stored with it and is set from user.
+func (pr *ProfileRepo) getProfile(exec inmemory.Executor, user entity.User) (*entity.Profile, error) {
+	row, err := exec.GetRow(ProfileTableName, strconv.Itoa(user.ID))
+	if err != nil {
+		return nil, repository.ErrNoSuchProfile
+	}
+
+	profile, ok := row.(entity.Profile)
+	if !ok {
+		return nil, repository.ErrNoSuchProfile
+	}
+
+	profile.Username = user.Username
+
+	return &profile, nil
+}
+
+func (pr *ProfileRepo) getUser(exec inmemory.Executor, id int) (*entity.User, error) {
+	row, err := exec.GetRow(UserTableName, strconv.Itoa(id))
+	if err != nil {
+		return nil, repository.ErrNoSuchUser
+	}
+
+	user, ok := row.(entity.User)
+	if !ok {
+		return nil, repository.ErrNoSuchUser
+	}
+
+	return &user, nil
+}
+
+func (pr *ProfileRepo) GetProfile(ctx context.Context, userID int) (*entity.Profile, error) {
+	exec := inmemory.ExecutorFromContext(ctx, pr.DB)
+
+	user, err := pr.getUser(exec, userID)
+	if err != nil {
+		return nil, repository.ErrNoSuchProfile
+	}
+
+	return pr.getProfile(exec, *user)
+}
+
+// GetProfilesByUsernames returns profiles of users with provided usernames, users without profile are skipped.
+func (pr *ProfileRepo) GetProfilesByUsernames(ctx context.Context, usernames []string) ([]*entity.Profile, error) {
+	exec := inmemory.ExecutorFromContext(ctx, pr.DB)
+
+	profiles := make([]*entity.Profile, 0)
+
+	if len(usernames) == 0 {
+		return profiles, nil
+	}
+
+	seen := make(map[string]struct{}, len(usernames))
+
+	for _, username := range usernames {
+		if _, ok := seen[username]; ok {
+			continue
+		}
+
+		seen[username] = struct{}{}
+
+		rows, err := exec.GetRowsByIndex(UserTableName, UserUsernameIndex, username)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			user, ok := row.(entity.User)
+			if !ok {
+				continue
+			}
+
+			if profile, err := pr.getProfile(exec, user); err == nil {
+				profiles = append(profiles, profile)
+			}
+		}
+	}
+
+	return profiles, nil
+}
+
+// SaveProfile creates profile of user or replaces existing one.
+func (pr *ProfileRepo) SaveProfile(ctx context.Context, profile entity.Profile) (*entity.Profile, error) {
+	exec := inmemory.ExecutorFromContext(ctx, pr.DB)
+
+	user, err := pr.getUser(exec, profile.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile.Username = ""
+	profile.UpdatedAt = time.Now()
+
+	key := strconv.Itoa(profile.UserID)
+
+	if _, err = exec.GetRow(ProfileTableName, key); err != nil {
+		err = exec.AddRow(ProfileTableName, key, profile)
+	} else {
+		err = exec.AlterRow(ProfileTableName, key, profile)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	profile.Username = user.Username
+
+	return &profile, nil
+}
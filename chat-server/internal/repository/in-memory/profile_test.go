@@ -0,0 +1,55 @@
+package in_memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+
+	inmemory "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/db/in-memory"
+)
+
+func TestProfileRepo_SaveProfile(t *testing.T) {
+	ctx := context.Background()
+	db, _ := inmemory.NewInMemDB(ctx, "")
+
+	userRepo := NewUserRepo(db)
+	profileRepo := NewProfileRepo(db)
+
+	user, err := userRepo.AddUser(ctx, entity.User{Email: "old@mail.com", Username: "old"})
+	require.NoError(t, err)
+
+	_, err = userRepo.AddUser(ctx, entity.User{Email: "bob@mail.com", Username: "bob"})
+	require.NoError(t, err)
+
+	_, err = profileRepo.GetProfile(ctx, user.ID)
+	assert.ErrorIs(t, err, repository.ErrNoSuchProfile)
+
+	saved, err := profileRepo.SaveProfile(ctx, entity.Profile{UserID: user.ID, DisplayName: "Old"})
+	require.NoError(t, err)
+	assert.Equal(t, "old", saved.Username)
+
+	_, err = profileRepo.SaveProfile(ctx, entity.Profile{UserID: user.ID, DisplayName: "Newer"})
+	require.NoError(t, err)
+
+	// profile is kept by user id, so it follows user through rename
+	_, err = userRepo.UpdateUser(ctx, user.ID, entity.User{Username: "new"})
+	require.NoError(t, err)
+
+	got, err := profileRepo.GetProfile(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "Newer", got.DisplayName)
+	assert.Equal(t, "new", got.Username)
+
+	profiles, err := profileRepo.GetProfilesByUsernames(ctx, []string{"new", "bob", "missing"})
+	require.NoError(t, err)
+	require.Len(t, profiles, 1)
+	assert.Equal(t, user.ID, profiles[0].UserID)
+
+	_, err = profileRepo.SaveProfile(ctx, entity.Profile{UserID: 100})
+	assert.ErrorIs(t, err, repository.ErrNoSuchUser)
+}
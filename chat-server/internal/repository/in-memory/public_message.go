@@ -3,72 +3,69 @@ package in_memory
 
 import (
 	"context"
-	"errors"
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+	"math"
+	"slices"
 	"sort"
-	"strconv"
-	"sync"
 	"time"
 
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
 
 	inmemory "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/db/in-memory"
+	sliceutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/slice"
 )
 
 type PublicMessageRepo struct {
-	DB    inmemory.InMemoryDB
-	mutex sync.RWMutex
+	DB        inmemory.InMemoryDB
+	messages  *inmemory.TypedTable[int, entity.PublicMessage]
+	revisions *inmemory.TypedTable[int, entity.MessageRevision]
 }
 
 func NewPublicMessageRepo(db inmemory.InMemoryDB) *PublicMessageRepo {
 	repo := PublicMessageRepo{
-		DB:    db,
-		mutex: sync.RWMutex{},
+		DB: db,
+		messages: inmemory.NewTypedTable(db, inmemory.TableSchema[int, entity.PublicMessage]{
+			Name:   PublicMessageTableName,
+			Keys:   inmemory.IntKeys,
+			KeyOf:  func(msg entity.PublicMessage) int { return msg.ID },
+			SetKey: func(msg *entity.PublicMessage, id int) { msg.ID = id },
+		}),
+		revisions: newRevisionTable(db, PublicMessageRevisionTableName),
 	}
 
-	_, err := repo.DB.GetTable(PublicMessageTableName)
-	if errors.Is(err, inmemory.ErrNotExistedTable) {
-		repo.DB.CreateTable(PublicMessageTableName)
-	}
+	_ = repo.DB.CreateTextIndex(PublicMessageTableName, publicMessageText)
 
 	return &repo
 }
 
-func (pr *PublicMessageRepo) AddPublicMessage(_ context.Context, msg entity.PublicMessage) (*entity.PublicMessage, error) {
-	pr.mutex.Lock()
-	defer pr.mutex.Unlock()
+func (pr *PublicMessageRepo) AddPublicMessage(ctx context.Context, msg entity.PublicMessage) (*entity.PublicMessage, error) {
+	now := time.Now()
 
-	idOffset, err := pr.DB.GetTableCounter(PublicMessageTableName)
-	if err != nil {
-		return nil, err
+	if msg.ChannelID == 0 {
+		msg.ChannelID = entity.GeneralChannelID
 	}
 
-	now := time.Now()
-
-	msg.ID = idOffset + 1
 	msg.SentAt = now
 	msg.EditedAt = now
 
-	if err = pr.DB.AddRow(PublicMessageTableName, strconv.Itoa(msg.ID), msg); err != nil {
+	msg, err := pr.messages.Add(ctx, msg)
+	if err != nil {
 		return nil, err
 	}
 
 	return &msg, nil
 }
 
-func (pr *PublicMessageRepo) getAllPublicMessages(_ context.Context, offset, limit int) []*entity.PublicMessage {
-	rows, err := pr.DB.GetAllRows(PublicMessageTableName, offset, limit)
+func (pr *PublicMessageRepo) getAllPublicMessages(ctx context.Context, offset, limit int) []*entity.PublicMessage {
+	messages, err := pr.messages.Rows(ctx, offset, limit)
 	if err != nil {
 		return nil
 	}
 
-	res := make([]*entity.PublicMessage, 0, len(rows))
+	res := make([]*entity.PublicMessage, 0, len(messages))
 
-	for _, row := range rows {
-		msg, ok := row.(entity.PublicMessage)
-		if ok {
-			res = append(res, &msg)
-		}
+	for i := range messages {
+		res = append(res, &messages[i])
 	}
 
 	sort.Slice(res, func(i, j int) bool { return res[i].SentAt.Before(res[j].SentAt) })
@@ -77,29 +74,161 @@ func (pr *PublicMessageRepo) getAllPublicMessages(_ context.Context, offset, lim
 }
 
 func (pr *PublicMessageRepo) GetAllPublicMessages(ctx context.Context, offset, limit int) []*entity.PublicMessage {
-	pr.mutex.RLock()
-	defer pr.mutex.RUnlock()
-
 	return pr.getAllPublicMessages(ctx, offset, limit)
 }
 
-func (pr *PublicMessageRepo) getPublicMessage(_ context.Context, id int) (*entity.PublicMessage, error) {
-	row, err := pr.DB.GetRow(PublicMessageTableName, strconv.Itoa(id))
-	if err != nil {
-		return nil, repository.ErrNoSuchPublicMessage
+// GetChannelMessages returns messages of the channel ordered by sending time.
+func (pr *PublicMessageRepo) GetChannelMessages(ctx context.Context, channelID, offset, limit int) []*entity.PublicMessage {
+	messages := sliceutils.Filter(pr.getAllPublicMessages(ctx, 0, math.MaxInt64), func(msg *entity.PublicMessage) bool {
+		return msg.ChannelID == channelID
+	})
+
+	return sliceutils.Slice(messages, offset, limit)
+}
+
+// GetChannelMessagesExcludingAuthors returns messages of the channel ordered by sending time, except ones sent by provided authors.
+func (pr *PublicMessageRepo) GetChannelMessagesExcludingAuthors(ctx context.Context, channelID int, authors []string, offset, limit int) []*entity.PublicMessage {
+	messages := sliceutils.Filter(pr.getAllPublicMessages(ctx, 0, math.MaxInt64), func(msg *entity.PublicMessage) bool {
+		return msg.ChannelID == channelID && !slices.Contains(authors, msg.FromUsername)
+	})
+
+	return sliceutils.Slice(messages, offset, limit)
+}
+
+// GetPublicMessageReplies returns replies in thread of the message ordered by sending time.
+func (pr *PublicMessageRepo) GetPublicMessageReplies(ctx context.Context, parentID, offset, limit int) []*entity.PublicMessage {
+	replies := sliceutils.Filter(pr.getAllPublicMessages(ctx, 0, math.MaxInt64), func(msg *entity.PublicMessage) bool {
+		return msg.ParentID != nil && *msg.ParentID == parentID
+	})
+
+	return sliceutils.Slice(replies, offset, limit)
+}
+
+// CountPublicMessageReplies returns number of replies for each of provided messages that has any.
+func (pr *PublicMessageRepo) CountPublicMessageReplies(ctx context.Context, parentIDs []int) (map[int]int, error) {
+	counts := make(map[int]int, len(parentIDs))
+
+	for _, msg := range pr.getAllPublicMessages(ctx, 0, math.MaxInt64) {
+		if msg.ParentID != nil && slices.Contains(parentIDs, *msg.ParentID) {
+			counts[*msg.ParentID]++
+		}
 	}
 
-	msg, ok := row.(entity.PublicMessage)
-	if !ok {
-		return nil, repository.ErrNoSuchPublicMessage
+	return counts, nil
+}
+
+func (pr *PublicMessageRepo) getPublicMessage(ctx context.Context, id int) (*entity.PublicMessage, error) {
+	msg, err := pr.messages.Get(ctx, id)
+	if err != nil {
+		return nil, notFoundError(err, repository.ErrNoSuchPublicMessage)
 	}
 
 	return &msg, nil
 }
 
 func (pr *PublicMessageRepo) GetPublicMessage(ctx context.Context, id int) (*entity.PublicMessage, error) {
-	pr.mutex.RLock()
-	defer pr.mutex.RUnlock()
-
 	return pr.getPublicMessage(ctx, id)
 }
+
+// UpdatePublicMessage replaces content of message and keeps previous version in revision history.
+func (pr *PublicMessageRepo) UpdatePublicMessage(ctx context.Context, id int, updated entity.PublicMessage) (*entity.PublicMessage, error) {
+	msg, err := pr.getPublicMessage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = pr.revisions.Add(ctx, entity.MessageRevision{
+		MessageID: msg.ID,
+		Content:   msg.Content,
+		EditedAt:  msg.EditedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	msg.Content = updated.Content
+	msg.EditedAt = time.Now()
+
+	if err = pr.messages.Update(ctx, *msg); err != nil {
+		return nil, notFoundError(err, repository.ErrNoSuchPublicMessage)
+	}
+
+	return msg, nil
+}
+
+func (pr *PublicMessageRepo) GetPublicMessageRevisions(ctx context.Context, messageID int) ([]*entity.MessageRevision, error) {
+	if _, err := pr.getPublicMessage(ctx, messageID); err != nil {
+		return nil, err
+	}
+
+	return getMessageRevisions(ctx, pr.revisions, messageID)
+}
+
+// DeletePublicMessage marks message as deleted, leaving a tombstone in place of it so pagination stays stable.
+func (pr *PublicMessageRepo) DeletePublicMessage(ctx context.Context, id int) (*entity.PublicMessage, error) {
+	msg, err := pr.getPublicMessage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	msg.Content = ""
+	msg.DeletedAt = &now
+
+	if err = pr.messages.Update(ctx, *msg); err != nil {
+		return nil, notFoundError(err, repository.ErrNoSuchPublicMessage)
+	}
+
+	return msg, nil
+}
+
+// PurgePublicMessage removes message, its revision history and reactions completely.
+func (pr *PublicMessageRepo) PurgePublicMessage(ctx context.Context, id int) error {
+	if _, err := pr.getPublicMessage(ctx, id); err != nil {
+		return err
+	}
+
+	if err := dropMessageRevisions(ctx, pr.revisions, id); err != nil {
+		return err
+	}
+
+	if err := dropMessageReactions(inmemory.ExecutorFromContext(ctx, pr.DB), PublicMessageReactionTableName, id); err != nil {
+		return err
+	}
+
+	return pr.messages.Delete(ctx, id)
+}
+
+// SearchPublicMessages returns not deleted messages of provided channels matching query, newest first.
+func (pr *PublicMessageRepo) SearchPublicMessages(ctx context.Context, query entity.MessageSearchQuery, channelIDs []int, limit int) ([]*entity.SearchHit, error) {
+	messages, err := pr.messages.Search(ctx, query.Text)
+	if err != nil {
+		return nil, err
+	}
+
+	hits := make([]*entity.SearchHit, 0, len(messages))
+
+	for i := range messages {
+		msg := &messages[i]
+
+		if !slices.Contains(channelIDs, msg.ChannelID) || !matchesSearchFilters(query, msg.FromUsername, msg.SentAt) {
+			continue
+		}
+
+		hits = append(hits, &entity.SearchHit{
+			Kind:    entity.MessageKindPublic,
+			Public:  msg,
+			Snippet: inmemory.Highlight(msg.Content, query.Text, entity.SnippetMaxWords),
+		})
+	}
+
+	return sortAndLimitHits(hits, limit), nil
+}
+
+// RenameUsername points messages of user to new username.
+func (pr *PublicMessageRepo) RenameUsername(ctx context.Context, oldUsername, newUsername string) error {
+	return pr.messages.UpdateAll(ctx, func(msg *entity.PublicMessage) bool {
+		return renameUsername(&msg.FromUsername, oldUsername, newUsername)
+	})
+}
@@ -0,0 +1,165 @@
+// nolint
+package in_memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+
+	inmemory "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/db/in-memory"
+)
+
+type ReactionRepo struct {
+	DB inmemory.InMemoryDB
+}
+
+func NewReactionRepo(db inmemory.InMemoryDB) *ReactionRepo {
+	repo := ReactionRepo{
+		DB: db,
+	}
+
+	for _, table := range []string{PublicMessageReactionTableName, PrivateMessageReactionTableName} {
+		_, err := repo.DB.GetTable(table)
+		if errors.Is(err, inmemory.ErrNotExistedTable) {
+			repo.DB.CreateTable(table)
+		}
+	}
+
+	return &repo
+}
+
+func reactionTableName(kind entity.MessageKind) (string, error) {
+	switch kind {
+	case entity.MessageKindPublic:
+		return PublicMessageReactionTableName, nil
+	case entity.MessageKindPrivate:
+		return PrivateMessageReactionTableName, nil
+	default:
+		return "", repository.ErrUnknownMessageKind
+	}
+}
+
+// reactionKey makes row key unique for user, emoji and message, which keeps one reaction per them.
+func reactionKey(messageID int, username, emoji string) string {
+	return fmt.Sprintf("%d:%q:%q", messageID, username, emoji)
+}
+
+// AddReaction adds reaction to message, same reaction of user can be added only once.
+func (rr *ReactionRepo) AddReaction(ctx context.Context, kind entity.MessageKind, reaction entity.Reaction) (*entity.Reaction, error) {
+	exec := inmemory.ExecutorFromContext(ctx, rr.DB)
+
+	table, err := reactionTableName(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	idOffset, err := exec.GetTableCounter(table)
+	if err != nil {
+		return nil, err
+	}
+
+	reaction.ID = idOffset + 1
+	reaction.CreatedAt = time.Now()
+
+	err = exec.AddRow(table, reactionKey(reaction.MessageID, reaction.Username, reaction.Emoji), reaction)
+	if errors.Is(err, inmemory.ErrExistingKey) {
+		return nil, repository.ErrReactionExists
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &reaction, nil
+}
+
+func (rr *ReactionRepo) RemoveReaction(ctx context.Context, kind entity.MessageKind, messageID int, username, emoji string) error {
+	exec := inmemory.ExecutorFromContext(ctx, rr.DB)
+
+	table, err := reactionTableName(kind)
+	if err != nil {
+		return err
+	}
+
+	key := reactionKey(messageID, username, emoji)
+
+	if _, err = exec.GetRow(table, key); err != nil {
+		return repository.ErrNoSuchReaction
+	}
+
+	return exec.DropRow(table, key)
+}
+
+// GetReactions returns reactions of provided messages ordered by time they were added.
+func (rr *ReactionRepo) GetReactions(ctx context.Context, kind entity.MessageKind, messageIDs []int) ([]*entity.Reaction, error) {
+	table, err := reactionTableName(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := inmemory.ExecutorFromContext(ctx, rr.DB).GetAllRows(table, 0, math.MaxInt64)
+	if err != nil {
+		return nil, err
+	}
+
+	reactions := make([]*entity.Reaction, 0)
+
+	for _, row := range rows {
+		reaction, ok := row.(entity.Reaction)
+		if ok && slices.Contains(messageIDs, reaction.MessageID) {
+			reactions = append(reactions, &reaction)
+		}
+	}
+
+	sort.Slice(reactions, func(i, j int) bool { return reactions[i].ID < reactions[j].ID })
+
+	return reactions, nil
+}
+
+// dropMessageReactions removes all reactions of purged message. Table is absent when reactions were never used.
+func dropMessageReactions(db inmemory.Executor, table string, messageID int) error {
+	rows, err := db.GetAllRows(table, 0, math.MaxInt64)
+	if errors.Is(err, inmemory.ErrNotExistedTable) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		reaction, ok := row.(entity.Reaction)
+		if ok && reaction.MessageID == messageID {
+			if err = db.DropRow(table, reactionKey(reaction.MessageID, reaction.Username, reaction.Emoji)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// RenameUsername points reactions of user to new username.
+func (rr *ReactionRepo) RenameUsername(ctx context.Context, oldUsername, newUsername string) error {
+	for _, table := range []string{PublicMessageReactionTableName, PrivateMessageReactionTableName} {
+		err := renameUsernameInTable(inmemory.ExecutorFromContext(ctx, rr.DB), table,
+			func(reaction entity.Reaction) string {
+				return reactionKey(reaction.MessageID, reaction.Username, reaction.Emoji)
+			},
+			func(reaction *entity.Reaction) bool {
+				return renameUsername(&reaction.Username, oldUsername, newUsername)
+			})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+// nolint
+package in_memory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+
+	inmemory "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/db/in-memory"
+)
+
+type RefreshTokenRepo struct {
+	DB inmemory.InMemoryDB
+}
+
+func NewRefreshTokenRepo(db inmemory.InMemoryDB) *RefreshTokenRepo {
+	repo := RefreshTokenRepo{
+		DB: db,
+	}
+
+	_, err := repo.DB.GetTable(RefreshTokenTableName)
+	if errors.Is(err, inmemory.ErrNotExistedTable) {
+		repo.DB.CreateTable(RefreshTokenTableName)
+	}
+
+	_ = repo.DB.CreateIndex(RefreshTokenTableName, inmemory.IndexSpec{
+		Name: RefreshTokenFamilyIndex,
+		Extract: func(row any) (string, bool) {
+			token, ok := row.(entity.RefreshToken)
+			return token.FamilyID, ok
+		},
+	})
+
+	return &repo
+}
+
+// AddRefreshToken stores token, rows are keyed by token hash as tokens are looked up by it.
+// Used tokens of family are kept until it is revoked, as reuse of any of them must revoke the family.
+func (rr *RefreshTokenRepo) AddRefreshToken(ctx context.Context, token entity.RefreshToken) (*entity.RefreshToken, error) {
+	exec := inmemory.ExecutorFromContext(ctx, rr.DB)
+
+	token.CreatedAt = time.Now()
+
+	idOffset, err := exec.GetTableCounter(RefreshTokenTableName)
+	if err != nil {
+		return nil, err
+	}
+
+	token.ID = idOffset + 1
+
+	if err = exec.AddRow(RefreshTokenTableName, token.TokenHash, token); err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+func (rr *RefreshTokenRepo) getRefreshToken(exec inmemory.Executor, tokenHash string) (*entity.RefreshToken, error) {
+	row, err := exec.GetRow(RefreshTokenTableName, tokenHash)
+	if err != nil {
+		return nil, repository.ErrNoSuchRefreshToken
+	}
+
+	token, ok := row.(entity.RefreshToken)
+	if !ok {
+		return nil, repository.ErrNoSuchRefreshToken
+	}
+
+	return &token, nil
+}
+
+func (rr *RefreshTokenRepo) GetRefreshToken(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	return rr.getRefreshToken(inmemory.ExecutorFromContext(ctx, rr.DB), tokenHash)
+}
+
+// UseRefreshToken marks token as used, token can be used only once.
+func (rr *RefreshTokenRepo) UseRefreshToken(ctx context.Context, tokenHash string, usedAt time.Time) error {
+	exec := inmemory.ExecutorFromContext(ctx, rr.DB)
+
+	token, err := rr.getRefreshToken(exec, tokenHash)
+	if err != nil {
+		return err
+	}
+
+	if token.IsUsed() {
+		return repository.ErrRefreshTokenUsed
+	}
+
+	token.UsedAt = &usedAt
+
+	return exec.AlterRow(RefreshTokenTableName, tokenHash, *token)
+}
+
+func (rr *RefreshTokenRepo) getFamilyTokens(exec inmemory.Executor, familyID string) ([]*entity.RefreshToken, error) {
+	rows, err := exec.GetRowsByIndex(RefreshTokenTableName, RefreshTokenFamilyIndex, familyID)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens := make([]*entity.RefreshToken, 0, len(rows))
+
+	for _, row := range rows {
+		token, ok := row.(entity.RefreshToken)
+		if ok {
+			tokens = append(tokens, &token)
+		}
+	}
+
+	return tokens, nil
+}
+
+// pruneFamily drops used and expired tokens of family.
+func (rr *RefreshTokenRepo) pruneFamily(exec inmemory.Executor, familyID string, now time.Time) error {
+	tokens, err := rr.getFamilyTokens(exec, familyID)
+	if err != nil {
+		return err
+	}
+
+	for _, token := range tokens {
+		if !token.IsUsed() && token.ExpiresAt.After(now) {
+			continue
+		}
+
+		if err = exec.DropRow(RefreshTokenTableName, token.TokenHash); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RevokeTokenFamily revokes all tokens of family, already revoked ones are kept as is.
+// Used and expired tokens are dropped as they can not be presented successfully anymore.
+func (rr *RefreshTokenRepo) RevokeTokenFamily(ctx context.Context, familyID string, revokedAt time.Time) error {
+	exec := inmemory.ExecutorFromContext(ctx, rr.DB)
+
+	if err := rr.pruneFamily(exec, familyID, revokedAt); err != nil {
+		return err
+	}
+
+	tokens, err := rr.getFamilyTokens(exec, familyID)
+	if err != nil {
+		return err
+	}
+
+	for _, token := range tokens {
+		if token.IsRevoked() {
+			continue
+		}
+
+		token.RevokedAt = &revokedAt
+
+		if err = exec.AlterRow(RefreshTokenTableName, token.TokenHash, *token); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
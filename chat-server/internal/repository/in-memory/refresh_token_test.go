@@ -0,0 +1,60 @@
+package in_memory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+
+	inmemory "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/db/in-memory"
+)
+
+func TestRefreshTokenRepo_PruneFamily(t *testing.T) {
+	ctx := context.Background()
+	db, _ := inmemory.NewInMemDB(ctx, "")
+
+	repo := NewRefreshTokenRepo(db)
+
+	expiresAt := time.Now().Add(time.Hour)
+
+	for _, hash := range []string{"first", "second"} {
+		_, err := repo.AddRefreshToken(ctx, entity.RefreshToken{FamilyID: "family", TokenHash: hash, ExpiresAt: expiresAt})
+		require.NoError(t, err)
+
+		require.NoError(t, repo.UseRefreshToken(ctx, hash, time.Now()))
+	}
+
+	_, err := repo.AddRefreshToken(ctx, entity.RefreshToken{FamilyID: "other", TokenHash: "expired", ExpiresAt: time.Now()})
+	require.NoError(t, err)
+
+	_, err = repo.AddRefreshToken(ctx, entity.RefreshToken{FamilyID: "family", TokenHash: "third", ExpiresAt: expiresAt})
+	require.NoError(t, err)
+
+	// used tokens are kept while family is alive to detect reuse of any of them
+	for _, hash := range []string{"first", "second"} {
+		token, err := repo.GetRefreshToken(ctx, hash)
+		require.NoError(t, err)
+		assert.True(t, token.IsUsed())
+	}
+
+	require.NoError(t, repo.RevokeTokenFamily(ctx, "family", time.Now()))
+
+	for _, hash := range []string{"first", "second"} {
+		_, err = repo.GetRefreshToken(ctx, hash)
+		assert.ErrorIs(t, err, repository.ErrNoSuchRefreshToken)
+	}
+
+	token, err := repo.GetRefreshToken(ctx, "third")
+	require.NoError(t, err)
+	assert.True(t, token.IsRevoked())
+
+	require.NoError(t, repo.RevokeTokenFamily(ctx, "other", time.Now()))
+
+	_, err = repo.GetRefreshToken(ctx, "expired")
+	assert.ErrorIs(t, err, repository.ErrNoSuchRefreshToken)
+}
@@ -0,0 +1,61 @@
+// nolint
+package in_memory
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	inmemory "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/db/in-memory"
+)
+
+// renameUsernameInTable applies rename to every row of table of type T. Rows whose key is built from username
+// are moved under the new key. It is what postgres does for in-memory db with `on update cascade`.
+// Row of other type is reported with ErrUnexpectedRowType, as skipping it would leave old username behind.
+func renameUsernameInTable[T any](db inmemory.Executor, table string, key func(T) string, rename func(*T) bool) error {
+	rows, err := db.GetAllRows(table, 0, math.MaxInt64)
+	if errors.Is(err, inmemory.ErrNotExistedTable) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		value, ok := row.(T)
+		if !ok {
+			return fmt.Errorf("%w: table %q holds %T instead of %T", inmemory.ErrUnexpectedRowType, table, row, value)
+		}
+
+		oldKey := key(value)
+
+		if !rename(&value) {
+			continue
+		}
+
+		newKey := key(value)
+
+		if newKey == oldKey {
+			err = db.AlterRow(table, oldKey, value)
+		} else if err = db.DropRow(table, oldKey); err == nil {
+			err = db.AddRow(table, newKey, value)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func renameUsername(username *string, oldUsername, newUsername string) bool {
+	if username == nil || *username != oldUsername {
+		return false
+	}
+
+	*username = newUsername
+
+	return true
+}
@@ -0,0 +1,85 @@
+package in_memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+
+	inmemory "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/db/in-memory"
+)
+
+func TestPrivateMessageRepo_RenameUsername(t *testing.T) {
+	ctx := context.Background()
+	db, _ := inmemory.NewInMemDB(ctx, "")
+
+	messageRepo := NewPrivateMessageRepo(db)
+	reactionRepo := NewReactionRepo(db)
+
+	msg, err := messageRepo.AddPrivateMessage(ctx, entity.PrivateMessage{FromUsername: "old", ToUsername: "bob", Content: "hi"})
+	require.NoError(t, err)
+
+	_, err = messageRepo.AddPrivateMessage(ctx, entity.PrivateMessage{FromUsername: "bob", ToUsername: "old", Content: "hey"})
+	require.NoError(t, err)
+
+	_, err = messageRepo.MarkPrivateMessagesRead(ctx, "bob", "old", msg.ID)
+	require.NoError(t, err)
+
+	_, err = reactionRepo.AddReaction(ctx, entity.MessageKindPrivate,
+		entity.Reaction{MessageID: msg.ID, Username: "old", Emoji: "👍"})
+	require.NoError(t, err)
+
+	require.NoError(t, messageRepo.RenameUsername(ctx, "old", "new"))
+	require.NoError(t, reactionRepo.RenameUsername(ctx, "old", "new"))
+
+	got, err := messageRepo.GetPrivateMessage(ctx, msg.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "new", got.FromUsername)
+
+	unread, err := messageRepo.CountUnreadPrivateMessages(ctx, "new")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"bob": 1}, unread)
+
+	unread, err = messageRepo.CountUnreadPrivateMessages(ctx, "bob")
+	require.NoError(t, err)
+	assert.Empty(t, unread)
+
+	reactions, err := reactionRepo.GetReactions(ctx, entity.MessageKindPrivate, []int{msg.ID})
+	require.NoError(t, err)
+	require.Len(t, reactions, 1)
+	assert.Equal(t, "new", reactions[0].Username)
+
+	// reaction is found under its new key, so it can be removed by new username
+	assert.NoError(t, reactionRepo.RemoveReaction(ctx, entity.MessageKindPrivate, msg.ID, "new", "👍"))
+}
+
+func TestRenameUsernameWithUnexpectedRow(t *testing.T) {
+	ctx := context.Background()
+	db, _ := inmemory.NewInMemDB(ctx, "")
+
+	uow := NewUnitOfWork(db)
+	messageRepo := NewPrivateMessageRepo(db)
+	blockRepo := NewBlockRepo(db)
+
+	msg, err := messageRepo.AddPrivateMessage(ctx, entity.PrivateMessage{FromUsername: "old", ToUsername: "bob", Content: "hi"})
+	require.NoError(t, err)
+
+	require.NoError(t, db.AddRow(BlockTableName, "broken", "not a block"))
+
+	err = uow.Do(ctx, func(ctx context.Context) error {
+		if err := messageRepo.RenameUsername(ctx, "old", "new"); err != nil {
+			return err
+		}
+
+		return blockRepo.RenameUsername(ctx, "old", "new")
+	})
+	assert.ErrorIs(t, err, inmemory.ErrUnexpectedRowType, "row of unexpected type must not be skipped")
+
+	// rename is rolled back rather than applied in part
+	got, err := messageRepo.GetPrivateMessage(ctx, msg.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "old", got.FromUsername)
+}
@@ -0,0 +1,55 @@
+// nolint
+package in_memory
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+)
+
+func publicMessageText(row any) (string, bool) {
+	msg, ok := row.(entity.PublicMessage)
+	if !ok || msg.IsDeleted() {
+		return "", false
+	}
+
+	return msg.Content, true
+}
+
+func privateMessageText(row any) (string, bool) {
+	msg, ok := row.(entity.PrivateMessage)
+	if !ok || msg.IsDeleted() {
+		return "", false
+	}
+
+	return msg.Content, true
+}
+
+// matchesSearchFilters checks filters common for both message tables, text is matched by index.
+func matchesSearchFilters(query entity.MessageSearchQuery, fromUsername string, sentAt time.Time) bool {
+	if query.Author != "" && fromUsername != query.Author {
+		return false
+	}
+
+	if query.SentAfter != nil && sentAt.Before(*query.SentAfter) {
+		return false
+	}
+
+	if query.SentBefore != nil && sentAt.After(*query.SentBefore) {
+		return false
+	}
+
+	return true
+}
+
+// sortAndLimitHits orders hits newest first as postgres search does.
+func sortAndLimitHits(hits []*entity.SearchHit, limit int) []*entity.SearchHit {
+	sort.Slice(hits, func(i, j int) bool { return hits[i].IsNewerThan(hits[j]) })
+
+	if len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	return hits
+}
@@ -0,0 +1,114 @@
+// nolint
+package in_memory
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+
+	inmemory "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/db/in-memory"
+)
+
+type SessionRepo struct {
+	DB inmemory.InMemoryDB
+}
+
+func NewSessionRepo(db inmemory.InMemoryDB) *SessionRepo {
+	repo := SessionRepo{
+		DB: db,
+	}
+
+	_, err := repo.DB.GetTable(SessionTableName)
+	if errors.Is(err, inmemory.ErrNotExistedTable) {
+		repo.DB.CreateTable(SessionTableName)
+	}
+
+	return &repo
+}
+
+func (sr *SessionRepo) AddSession(ctx context.Context, session entity.Session) (*entity.Session, error) {
+	session.CreatedAt = time.Now()
+	session.LastSeenAt = session.CreatedAt
+
+	if err := inmemory.ExecutorFromContext(ctx, sr.DB).AddRow(SessionTableName, session.ID, session); err != nil {
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (sr *SessionRepo) getSession(exec inmemory.Executor, id string) (*entity.Session, error) {
+	row, err := exec.GetRow(SessionTableName, id)
+	if err != nil {
+		return nil, repository.ErrNoSuchSession
+	}
+
+	session, ok := row.(entity.Session)
+	if !ok {
+		return nil, repository.ErrNoSuchSession
+	}
+
+	return &session, nil
+}
+
+func (sr *SessionRepo) GetSession(ctx context.Context, id string) (*entity.Session, error) {
+	return sr.getSession(inmemory.ExecutorFromContext(ctx, sr.DB), id)
+}
+
+func (sr *SessionRepo) GetUserSessions(ctx context.Context, userID int) ([]*entity.Session, error) {
+	rows, err := inmemory.ExecutorFromContext(ctx, sr.DB).GetAllRows(SessionTableName, 0, math.MaxInt64)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*entity.Session, 0)
+
+	for _, row := range rows {
+		session, ok := row.(entity.Session)
+		if ok && session.UserID == userID {
+			sessions = append(sessions, &session)
+		}
+	}
+
+	return sessions, nil
+}
+
+func (sr *SessionRepo) alterSession(ctx context.Context, id string, alter func(session *entity.Session)) error {
+	exec := inmemory.ExecutorFromContext(ctx, sr.DB)
+
+	session, err := sr.getSession(exec, id)
+	if err != nil {
+		return err
+	}
+
+	alter(session)
+
+	return exec.AlterRow(SessionTableName, id, *session)
+}
+
+func (sr *SessionRepo) TouchSession(ctx context.Context, id string, lastSeenAt time.Time) error {
+	return sr.alterSession(ctx, id, func(session *entity.Session) {
+		session.LastSeenAt = lastSeenAt
+	})
+}
+
+// ExtendSession prolongs session when its refresh token is rotated.
+func (sr *SessionRepo) ExtendSession(ctx context.Context, id string, expiresAt, lastSeenAt time.Time) error {
+	return sr.alterSession(ctx, id, func(session *entity.Session) {
+		session.ExpiresAt = expiresAt
+		session.LastSeenAt = lastSeenAt
+	})
+}
+
+// RevokeSession revokes session, time of earlier revocation is kept.
+func (sr *SessionRepo) RevokeSession(ctx context.Context, id string, revokedAt time.Time) error {
+	return sr.alterSession(ctx, id, func(session *entity.Session) {
+		if session.RevokedAt == nil {
+			session.RevokedAt = &revokedAt
+		}
+	})
+}
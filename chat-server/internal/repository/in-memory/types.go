@@ -0,0 +1,101 @@
+// nolint
+package in_memory
+
+import (
+	"strconv"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+
+	inmemory "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/db/in-memory"
+)
+
+// Names of row types stored in snapshots of in-memory db, they must not change once data is saved.
+const (
+	AttachmentRowType              = "attachment"
+	BlockRowType                   = "block"
+	ChannelRowType                 = "channel"
+	ChannelMemberRowType           = "channel_member"
+	ConversationRowType            = "conversation"
+	ConversationParticipantRowType = "conversation_participant"
+	ConversationMessageRowType     = "conversation_message"
+	MentionRowType                 = "mention"
+	MessageRevisionRowType         = "message_revision"
+	PrivateMessageRowType          = "private_message"
+	ProfileRowType                 = "profile"
+	PublicMessageRowType           = "public_message"
+	ReactionRowType                = "reaction"
+	ReadMarkerRowType              = "read_marker"
+	RefreshTokenRowType            = "refresh_token"
+	SessionRowType                 = "session"
+	UserRowType                    = "user"
+	RoleGuardRowType               = "role_guard"
+)
+
+func init() {
+	inmemory.RegisterType[entity.Attachment](AttachmentRowType)
+	inmemory.RegisterType[entity.Block](BlockRowType)
+	inmemory.RegisterType[entity.Channel](ChannelRowType)
+	inmemory.RegisterType[entity.ChannelMember](ChannelMemberRowType)
+	inmemory.RegisterType[entity.Conversation](ConversationRowType)
+	inmemory.RegisterType[entity.ConversationParticipant](ConversationParticipantRowType)
+	inmemory.RegisterType[entity.ConversationMessage](ConversationMessageRowType)
+	inmemory.RegisterType[entity.Mention](MentionRowType)
+	inmemory.RegisterType[entity.MessageRevision](MessageRevisionRowType)
+	inmemory.RegisterType[entity.PrivateMessage](PrivateMessageRowType)
+	inmemory.RegisterType[entity.Profile](ProfileRowType)
+	inmemory.RegisterType[entity.PublicMessage](PublicMessageRowType)
+	inmemory.RegisterType[entity.Reaction](ReactionRowType)
+	inmemory.RegisterType[entity.ReadMarker](ReadMarkerRowType)
+	inmemory.RegisterType[entity.RefreshToken](RefreshTokenRowType)
+	inmemory.RegisterType[entity.Session](SessionRowType)
+	inmemory.RegisterType[entity.User](UserRowType)
+	inmemory.RegisterType[roleGuard](RoleGuardRowType)
+
+	inmemory.RegisterSnapshotMigration(0, migrateLegacySnapshot)
+}
+
+// legacyTableRowTypes are row types of tables in snapshots saved before row types were recorded.
+var legacyTableRowTypes = map[string]string{
+	AttachmentTableName:               AttachmentRowType,
+	BlockTableName:                    BlockRowType,
+	ChannelTableName:                  ChannelRowType,
+	ChannelMemberTableName:            ChannelMemberRowType,
+	ConversationTableName:             ConversationRowType,
+	ConversationParticipantTableName:  ConversationParticipantRowType,
+	ConversationMessageTableName:      ConversationMessageRowType,
+	MentionTableName:                  MentionRowType,
+	PrivateMessageTableName:           PrivateMessageRowType,
+	PrivateMessageRevisionTableName:   MessageRevisionRowType,
+	PrivateMessageReactionTableName:   ReactionRowType,
+	PrivateMessageReadMarkerTableName: ReadMarkerRowType,
+	ProfileTableName:                  ProfileRowType,
+	PublicMessageTableName:            PublicMessageRowType,
+	PublicMessageRevisionTableName:    MessageRevisionRowType,
+	PublicMessageReactionTableName:    ReactionRowType,
+	RefreshTokenTableName:             RefreshTokenRowType,
+	SessionTableName:                  SessionRowType,
+	UserTableName:                     UserRowType,
+}
+
+// migrateLegacySnapshot sets row types of known tables. Legacy snapshots did not keep id counters,
+// so counters are set past the greatest numeric key, as ids of dropped rows must not be reused.
+func migrateLegacySnapshot(snapshot *inmemory.Snapshot) error {
+	for name, table := range snapshot.Tables {
+		rowType, ok := legacyTableRowTypes[name]
+		if !ok || len(table.Rows) == 0 {
+			continue
+		}
+
+		table.RowType = rowType
+
+		for _, row := range table.Rows {
+			if id, err := strconv.Atoi(row.Key); err == nil {
+				table.Counter = max(table.Counter, id)
+			}
+		}
+
+		snapshot.Tables[name] = table
+	}
+
+	return nil
+}
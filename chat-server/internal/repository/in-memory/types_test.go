@@ -0,0 +1,70 @@
+package in_memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+
+	inmemory "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/db/in-memory"
+)
+
+func TestSnapshotRestoresRepositories(t *testing.T) {
+	ctx := context.Background()
+	db, _ := inmemory.NewInMemDB(ctx, "")
+
+	userRepo := NewUserRepo(db)
+	publicMessageRepo := NewPublicMessageRepo(db)
+	privateMessageRepo := NewPrivateMessageRepo(db)
+
+	user, err := userRepo.AddUser(ctx, entity.User{Email: "alice@mail.com", Username: "alice", HashedPassword: "hash"})
+	require.NoError(t, err)
+
+	publicMsg, err := publicMessageRepo.AddPublicMessage(ctx, entity.PublicMessage{FromUsername: "alice", Content: "hi all"})
+	require.NoError(t, err)
+
+	privateMsg, err := privateMessageRepo.AddPrivateMessage(ctx, entity.PrivateMessage{FromUsername: "alice", ToUsername: "bob", Content: "hi"})
+	require.NoError(t, err)
+
+	data, err := db.MarshalSnapshot()
+	require.NoError(t, err)
+
+	restored, _, err := inmemory.NewInMemDBFromJSON(ctx, string(data), "")
+	require.NoError(t, err)
+
+	restoredUser, err := NewUserRepo(restored).GetUserByUsername(ctx, "alice")
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, restoredUser.ID)
+	assert.True(t, user.CreatedAt.Equal(restoredUser.CreatedAt))
+
+	restoredPublic, err := NewPublicMessageRepo(restored).GetPublicMessage(ctx, publicMsg.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "hi all", restoredPublic.Content)
+
+	restoredPrivate, err := NewPrivateMessageRepo(restored).GetPrivateMessage(ctx, privateMsg.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "bob", restoredPrivate.ToUsername)
+}
+
+func TestLegacySnapshotIsMigrated(t *testing.T) {
+	ctx := context.Background()
+
+	// snapshot saved before row types were recorded, user 1 was deleted
+	legacy := `{"users": {"2": {"ID": 2, "Email": "bob@mail.com", "Username": "bob", "HashedPassword": "hash"}}}`
+
+	db, _, err := inmemory.NewInMemDBFromJSON(ctx, legacy, "")
+	require.NoError(t, err)
+
+	repo := NewUserRepo(db)
+
+	user, err := repo.GetUserByUsername(ctx, "bob")
+	require.NoError(t, err)
+	assert.Equal(t, 2, user.ID)
+
+	created, err := repo.AddUser(ctx, entity.User{Email: "carol@mail.com", Username: "carol", HashedPassword: "hash"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, created.ID)
+}
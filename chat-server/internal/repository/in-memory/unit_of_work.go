@@ -0,0 +1,45 @@
+// nolint
+package in_memory
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+
+	inmemory "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/db/in-memory"
+)
+
+// UnitOfWork runs function in transaction of in-memory db. Repositories called with context of the function
+// read and write through the transaction, so its changes are applied all at once or not at all.
+type UnitOfWork struct {
+	DB inmemory.InMemoryDB
+}
+
+func NewUnitOfWork(db inmemory.InMemoryDB) *UnitOfWork {
+	return &UnitOfWork{
+		DB: db,
+	}
+}
+
+// Do commits transaction if fn succeeds and rolls it back otherwise. Nested call joins outer transaction.
+func (uow *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := inmemory.TxFromContext(ctx); ok {
+		return fn(ctx)
+	}
+
+	tx := uow.DB.Begin()
+
+	if err := fn(inmemory.ContextWithTx(ctx, tx)); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	err := tx.Commit()
+	if errors.Is(err, inmemory.ErrTxConflict) {
+		return repository.ErrConcurrentUpdate
+	}
+
+	// changes made by others since transaction began may take email or username of user
+	return uniqueViolationToError(err)
+}
@@ -0,0 +1,250 @@
+package in_memory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+
+	inmemory "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/db/in-memory"
+)
+
+func TestUnitOfWork_Do(t *testing.T) {
+	ctx := context.Background()
+	db, _ := inmemory.NewInMemDB(ctx, "")
+
+	uow := NewUnitOfWork(db)
+	userRepo := NewUserRepo(db)
+	messageRepo := NewPublicMessageRepo(db)
+
+	user, err := userRepo.AddUser(ctx, entity.User{Email: "old@mail.com", Username: "old"})
+	require.NoError(t, err)
+
+	msg, err := messageRepo.AddPublicMessage(ctx, entity.PublicMessage{FromUsername: "old", Content: "hello"})
+	require.NoError(t, err)
+
+	rename := func(ctx context.Context, username string) error {
+		if _, err := userRepo.UpdateUser(ctx, user.ID, entity.User{Email: user.Email, Username: username}); err != nil {
+			return err
+		}
+
+		return messageRepo.RenameUsername(ctx, "old", username)
+	}
+
+	errFailed := errors.New("failed")
+
+	err = uow.Do(ctx, func(ctx context.Context) error {
+		if err := rename(ctx, "new"); err != nil {
+			return err
+		}
+
+		// changes are seen inside unit of work only
+		got, err := userRepo.GetUserByUsername(ctx, "new")
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, got.ID)
+
+		_, err = userRepo.GetUserByUsername(context.Background(), "new")
+		assert.ErrorIs(t, err, repository.ErrNoSuchUser)
+
+		return errFailed
+	})
+	assert.ErrorIs(t, err, errFailed)
+
+	got, err := messageRepo.GetPublicMessage(ctx, msg.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "old", got.FromUsername, "failed unit of work must not be applied in part")
+
+	require.NoError(t, uow.Do(ctx, func(ctx context.Context) error { return rename(ctx, "new") }))
+
+	got, err = messageRepo.GetPublicMessage(ctx, msg.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "new", got.FromUsername)
+
+	_, err = userRepo.GetUserByUsername(ctx, "new")
+	assert.NoError(t, err)
+}
+
+func TestUnitOfWork_ConcurrentUpdate(t *testing.T) {
+	ctx := context.Background()
+	db, _ := inmemory.NewInMemDB(ctx, "")
+
+	uow := NewUnitOfWork(db)
+	userRepo := NewUserRepo(db)
+
+	user, err := userRepo.AddUser(ctx, entity.User{Email: "user@mail.com", Username: "user"})
+	require.NoError(t, err)
+
+	other, err := userRepo.AddUser(ctx, entity.User{Email: "other@mail.com", Username: "other"})
+	require.NoError(t, err)
+
+	err = uow.Do(ctx, func(txCtx context.Context) error {
+		if _, err := userRepo.UpdateUserRole(txCtx, user.ID, entity.RoleAdmin); err != nil {
+			return err
+		}
+
+		// the same user is changed outside meanwhile
+		_, err := userRepo.UpdateUserRole(ctx, user.ID, entity.RoleUser)
+
+		return err
+	})
+	assert.ErrorIs(t, err, repository.ErrConcurrentUpdate)
+
+	err = uow.Do(ctx, func(txCtx context.Context) error {
+		_, err := userRepo.UpdateUser(txCtx, user.ID, entity.User{Email: "user@mail.com", Username: "taken"})
+		if err != nil {
+			return err
+		}
+
+		// username is taken outside meanwhile
+		_, err = userRepo.UpdateUser(ctx, other.ID, entity.User{Email: "other@mail.com", Username: "taken"})
+
+		return err
+	})
+	assert.ErrorIs(t, err, repository.ErrUsernameExists)
+
+	got, err := userRepo.GetUserByID(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "user", got.Username)
+}
+
+func TestUnitOfWork_LockUsersWithRole(t *testing.T) {
+	ctx := context.Background()
+	db, _ := inmemory.NewInMemDB(ctx, "")
+
+	uow := NewUnitOfWork(db)
+	userRepo := NewUserRepo(db)
+
+	admins := make([]*entity.User, 0, 2)
+
+	for _, username := range []string{"first", "second"} {
+		admin, err := userRepo.AddUser(ctx, entity.User{Email: username + "@mail.com", Username: username, Role: entity.RoleAdmin})
+		require.NoError(t, err)
+
+		admins = append(admins, admin)
+	}
+
+	demote := func(ctx context.Context, id int) error {
+		count, err := userRepo.LockUsersWithRole(ctx, entity.RoleAdmin)
+		if err != nil {
+			return err
+		}
+
+		assert.Equal(t, 2, count)
+
+		_, err = userRepo.UpdateUserRole(ctx, id, entity.RoleUser)
+
+		return err
+	}
+
+	err := uow.Do(ctx, func(txCtx context.Context) error {
+		if err := demote(txCtx, admins[0].ID); err != nil {
+			return err
+		}
+
+		// the other admin is demoted meanwhile, both transactions saw two admins
+		return uow.Do(ctx, func(ctx context.Context) error { return demote(ctx, admins[1].ID) })
+	})
+	assert.ErrorIs(t, err, repository.ErrConcurrentUpdate)
+
+	count, err := userRepo.CountUsersWithRole(ctx, entity.RoleAdmin)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count, "concurrent transactions must not demote all admins")
+}
+
+func TestUnitOfWork_RevokeSessions(t *testing.T) {
+	ctx := context.Background()
+	db, _ := inmemory.NewInMemDB(ctx, "")
+
+	uow := NewUnitOfWork(db)
+	sessionRepo := NewSessionRepo(db)
+	refreshTokenRepo := NewRefreshTokenRepo(db)
+
+	now := time.Now()
+
+	_, err := sessionRepo.AddSession(ctx, entity.Session{ID: "sid", UserID: 1, ExpiresAt: now.Add(time.Hour)})
+	require.NoError(t, err)
+
+	_, err = refreshTokenRepo.AddRefreshToken(ctx, entity.RefreshToken{FamilyID: "sid", TokenHash: "hash", ExpiresAt: now.Add(time.Hour)})
+	require.NoError(t, err)
+
+	errFailed := errors.New("failed")
+
+	err = uow.Do(ctx, func(ctx context.Context) error {
+		if err := refreshTokenRepo.RevokeTokenFamily(ctx, "sid", now); err != nil {
+			return err
+		}
+
+		if err := sessionRepo.RevokeSession(ctx, "sid", now); err != nil {
+			return err
+		}
+
+		return errFailed
+	})
+	assert.ErrorIs(t, err, errFailed)
+
+	// sessions stay active if unit of work they are revoked in fails
+	session, err := sessionRepo.GetSession(ctx, "sid")
+	require.NoError(t, err)
+	assert.True(t, session.IsActive(now))
+
+	token, err := refreshTokenRepo.GetRefreshToken(ctx, "hash")
+	require.NoError(t, err)
+	assert.False(t, token.IsRevoked())
+}
+
+func TestUnitOfWork_SendMessage(t *testing.T) {
+	ctx := context.Background()
+	db, _ := inmemory.NewInMemDB(ctx, "")
+
+	uow := NewUnitOfWork(db)
+	messageRepo := NewPublicMessageRepo(db)
+	attachmentRepo := NewAttachmentRepo(db)
+	mentionRepo := NewMentionRepo(db)
+
+	attachment, err := attachmentRepo.AddAttachment(ctx, entity.Attachment{UploadedBy: "user", FileName: "a.png"})
+	require.NoError(t, err)
+
+	var msg *entity.PublicMessage
+
+	errFailed := errors.New("failed")
+
+	err = uow.Do(ctx, func(ctx context.Context) error {
+		var err error
+
+		if msg, err = messageRepo.AddPublicMessage(ctx, entity.PublicMessage{FromUsername: "user", Content: "hi @other"}); err != nil {
+			return err
+		}
+
+		if err = attachmentRepo.LinkAttachments(ctx, entity.MessageKindPublic, msg.ID, []int{attachment.ID}); err != nil {
+			return err
+		}
+
+		_, err = mentionRepo.AddMentions(ctx, []entity.Mention{
+			{MessageKind: entity.MessageKindPublic, MessageID: msg.ID, Username: "other", FromUsername: "user"},
+		})
+		if err != nil {
+			return err
+		}
+
+		return errFailed
+	})
+	assert.ErrorIs(t, err, errFailed)
+
+	// message failed to be sent is not stored in part
+	_, err = messageRepo.GetPublicMessage(ctx, msg.ID)
+	assert.ErrorIs(t, err, repository.ErrNoSuchPublicMessage)
+
+	got, err := attachmentRepo.GetAttachment(ctx, attachment.ID)
+	require.NoError(t, err)
+	assert.False(t, got.IsLinked())
+
+	mentions, err := mentionRepo.GetMessageMentions(ctx, entity.MessageKindPublic, []int{msg.ID})
+	require.NoError(t, err)
+	assert.Empty(t, mentions)
+}
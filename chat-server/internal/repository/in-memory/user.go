@@ -5,192 +5,179 @@ import (
 	"context"
 	"errors"
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
-	"math"
-	"strconv"
-	"sync"
 	"time"
 
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
 
 	inmemory "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/db/in-memory"
-	sliceutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/slice"
 )
 
 type UserRepo struct {
-	mutex sync.RWMutex
-	DB    inmemory.InMemoryDB
+	DB         inmemory.InMemoryDB
+	users      *inmemory.TypedTable[int, entity.User]
+	roleGuards *inmemory.TypedTable[string, roleGuard]
+}
+
+// roleGuard is row written by transaction that counts users with role, see LockUsersWithRole.
+type roleGuard struct {
+	Role entity.Role
 }
 
 func NewUserRepo(db inmemory.InMemoryDB) *UserRepo {
 	repo := UserRepo{
-		DB:    db,
-		mutex: sync.RWMutex{},
+		DB: db,
+		users: inmemory.NewTypedTable(db, inmemory.TableSchema[int, entity.User]{
+			Name:   UserTableName,
+			Keys:   inmemory.IntKeys,
+			KeyOf:  func(user entity.User) int { return user.ID },
+			SetKey: func(user *entity.User, id int) { user.ID = id },
+		}),
+		roleGuards: inmemory.NewTypedTable(db, inmemory.TableSchema[string, roleGuard]{
+			Name:  UserRoleGuardTableName,
+			Keys:  inmemory.StringKeys,
+			KeyOf: func(guard roleGuard) string { return string(guard.Role) },
+		}),
+	}
+
+	for _, index := range []inmemory.TypedIndex[entity.User]{
+		{Name: UserEmailIndex, Unique: true, Extract: func(user entity.User) (string, bool) { return user.Email, true }},
+		{Name: UserUsernameIndex, Unique: true, Extract: func(user entity.User) (string, bool) { return user.Username, true }},
+	} {
+		// state saved before indexes existed may have duplicates, they are still looked up by index
+		if err := repo.users.CreateIndex(index); errors.Is(err, inmemory.ErrUniqueViolation) {
+			index.Unique = false
+			_ = repo.users.CreateIndex(index)
+		}
 	}
 
-	_, err := repo.DB.GetTable(UserTableName)
-	if errors.Is(err, inmemory.ErrNotExistedTable) {
-		repo.DB.CreateTable(UserTableName)
+	return &repo
+}
+
+// uniqueViolationToError maps violated unique index of users table to the error of repository.
+func uniqueViolationToError(err error) error {
+	var violation *inmemory.UniqueViolationError
+	if !errors.As(err, &violation) || violation.Table != UserTableName {
+		return err
 	}
 
-	return &repo
+	switch violation.Index {
+	case UserEmailIndex:
+		return repository.ErrEmailExists
+	case UserUsernameIndex:
+		return repository.ErrUsernameExists
+	default:
+		return err
+	}
 }
 
-func (ur *UserRepo) getAllUsers(_ context.Context, offset, limit int) []*entity.User {
-	rows, err := ur.DB.GetAllRows(UserTableName, offset, limit)
+func (ur *UserRepo) getAllUsers(ctx context.Context, offset, limit int) []*entity.User {
+	users, err := ur.users.Rows(ctx, offset, limit)
 	if err != nil {
 		return nil
 	}
 
-	res := make([]*entity.User, 0, len(rows))
+	res := make([]*entity.User, 0, len(users))
 
-	for _, row := range rows {
-		user, ok := row.(entity.User)
-		if ok {
-			res = append(res, &user)
-		}
+	for i := range users {
+		res = append(res, &users[i])
 	}
 
 	return res
 }
 
 func (ur *UserRepo) GetAllUsers(ctx context.Context, offset, limit int) []*entity.User {
-	ur.mutex.RLock()
-	defer ur.mutex.RUnlock()
-
 	return ur.getAllUsers(ctx, offset, limit)
 }
 
-func (ur *UserRepo) AddUser(_ context.Context, user entity.User) (*entity.User, error) {
-	ur.mutex.Lock()
-	defer ur.mutex.Unlock()
+func (ur *UserRepo) AddUser(ctx context.Context, user entity.User) (*entity.User, error) {
+	now := time.Now()
 
-	idOffset, err := ur.DB.GetTableCounter(UserTableName)
-	if err != nil {
-		return nil, err
+	if user.Role == "" {
+		user.Role = entity.RoleUser
 	}
 
-	now := time.Now()
-
-	user.ID = idOffset + 1
 	user.CreatedAt = now
 	user.UpdatedAt = now
 
-	if err = ur.DB.AddRow(UserTableName, strconv.Itoa(user.ID), user); err != nil {
-		return nil, err
+	user, err := ur.users.Add(ctx, user)
+	if err != nil {
+		return nil, uniqueViolationToError(err)
 	}
 
 	return &user, nil
 }
 
-func (ur *UserRepo) getUserByID(_ context.Context, id int) (*entity.User, error) {
-	row, err := ur.DB.GetRow(UserTableName, strconv.Itoa(id))
+func (ur *UserRepo) getUserByID(ctx context.Context, id int) (*entity.User, error) {
+	user, err := ur.users.Get(ctx, id)
 	if err != nil {
-		return nil, repository.ErrNoSuchUser
-	}
-
-	user, ok := row.(entity.User)
-	if !ok {
-		return nil, repository.ErrNoSuchUser
+		return nil, notFoundError(err, repository.ErrNoSuchUser)
 	}
 
 	return &user, nil
 }
 
 func (ur *UserRepo) GetUserByID(ctx context.Context, id int) (*entity.User, error) {
-	ur.mutex.RLock()
-	defer ur.mutex.RUnlock()
-
 	return ur.getUserByID(ctx, id)
 }
 
-func (ur *UserRepo) getUserByEmail(ctx context.Context, email string) (*entity.User, error) {
-	users := ur.getAllUsers(ctx, 0, math.MaxInt64)
-	if len(users) == 0 {
-		return nil, repository.ErrNoSuchUser
-	}
-
-	filtered := sliceutils.Filter(users, func(u *entity.User) bool { return u.Email == email })
-	if len(filtered) == 0 {
-		return nil, repository.ErrNoSuchUser
+func (ur *UserRepo) getUserByIndex(ctx context.Context, index, key string) (*entity.User, error) {
+	user, err := ur.users.FindBy(ctx, index, key)
+	if err != nil {
+		return nil, notFoundError(err, repository.ErrNoSuchUser)
 	}
 
-	user := filtered[0]
+	return &user, nil
+}
 
-	return user, nil
+func (ur *UserRepo) getUserByEmail(ctx context.Context, email string) (*entity.User, error) {
+	return ur.getUserByIndex(ctx, UserEmailIndex, email)
 }
 
 func (ur *UserRepo) GetUserByEmail(ctx context.Context, email string) (*entity.User, error) {
-	ur.mutex.RLock()
-	defer ur.mutex.RUnlock()
-
 	return ur.getUserByEmail(ctx, email)
 }
 
 func (ur *UserRepo) getUserByUsername(ctx context.Context, username string) (*entity.User, error) {
-	users := ur.getAllUsers(ctx, 0, math.MaxInt64)
-	if len(users) == 0 {
-		return nil, repository.ErrNoSuchUser
-	}
-
-	filtered := sliceutils.Filter(users, func(u *entity.User) bool { return u.Username == username })
-
-	if len(filtered) == 0 {
-		return nil, repository.ErrNoSuchUser
-	}
-
-	user := filtered[0]
-
-	return user, nil
+	return ur.getUserByIndex(ctx, UserUsernameIndex, username)
 }
 
 func (ur *UserRepo) GetUserByUsername(ctx context.Context, username string) (*entity.User, error) {
-	ur.mutex.RLock()
-	defer ur.mutex.RUnlock()
-
 	return ur.getUserByUsername(ctx, username)
 }
 
 func (ur *UserRepo) DeleteUser(ctx context.Context, id int) (*entity.User, error) {
-	ur.mutex.Lock()
-	defer ur.mutex.Unlock()
-
 	user, err := ur.getUserByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	if err = ur.DB.DropRow(UserTableName, strconv.Itoa(id)); err != nil {
-		return nil, repository.ErrNoSuchUser
+	if err = ur.users.Delete(ctx, id); err != nil {
+		return nil, notFoundError(err, repository.ErrNoSuchUser)
 	}
 
 	return user, nil
 }
 
 func (ur *UserRepo) UpdateUser(ctx context.Context, id int, updated entity.User) (*entity.User, error) {
-	ur.mutex.Lock()
-	defer ur.mutex.Unlock()
-
 	user, err := ur.getUserByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
 	updated.ID = id
+	updated.Role = user.Role // role is changed only by UpdateUserRole
+	updated.LastSeenAt = user.LastSeenAt
 	updated.CreatedAt = user.CreatedAt
 	updated.UpdatedAt = time.Now()
 
-	err = ur.DB.AlterRow(UserTableName, strconv.Itoa(id), updated)
-	if err != nil {
-		return nil, repository.ErrNoSuchUser
+	if err = ur.users.Update(ctx, updated); err != nil {
+		return nil, notFoundError(uniqueViolationToError(err), repository.ErrNoSuchUser)
 	}
 
-	return user, nil
+	return &updated, nil
 }
 
 func (ur *UserRepo) CheckUniqueConstraints(ctx context.Context, email, username string) error {
-	ur.mutex.RLock()
-	defer ur.mutex.RUnlock()
-
 	got, err := ur.getUserByEmail(ctx, email)
 	if got != nil || err == nil {
 		return repository.ErrEmailExists
@@ -203,3 +190,55 @@ func (ur *UserRepo) CheckUniqueConstraints(ctx context.Context, email, username
 
 	return nil
 }
+
+func (ur *UserRepo) UpdateUserRole(ctx context.Context, id int, role entity.Role) (*entity.User, error) {
+	user, err := ur.getUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Role = role
+	user.UpdatedAt = time.Now()
+
+	if err = ur.users.Update(ctx, *user); err != nil {
+		return nil, notFoundError(err, repository.ErrNoSuchUser)
+	}
+
+	return user, nil
+}
+
+func (ur *UserRepo) CountUsersWithRole(ctx context.Context, role entity.Role) (int, error) {
+	users, err := ur.users.Filter(ctx, func(user entity.User) bool { return user.Role == role })
+	if err != nil {
+		return 0, err
+	}
+
+	return len(users), nil
+}
+
+// LockUsersWithRole counts users with role and writes guard row of the role in transaction of ctx.
+// Transactions locking the same role conflict then, so only one of them commits even if they change
+// different users.
+func (ur *UserRepo) LockUsersWithRole(ctx context.Context, role entity.Role) (int, error) {
+	if err := ur.roleGuards.Save(ctx, roleGuard{Role: role}); err != nil {
+		return 0, err
+	}
+
+	return ur.CountUsersWithRole(ctx, role)
+}
+
+// UpdateLastSeen records time of last activity of user, it is not treated as user update.
+func (ur *UserRepo) UpdateLastSeen(ctx context.Context, username string, lastSeenAt time.Time) error {
+	user, err := ur.getUserByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+
+	user.LastSeenAt = &lastSeenAt
+
+	if err = ur.users.Update(ctx, *user); err != nil {
+		return notFoundError(err, repository.ErrNoSuchUser)
+	}
+
+	return nil
+}
@@ -2,10 +2,12 @@ package in_memory
 
 import (
 	"context"
+	"errors"
 	"math"
 	"testing"
 
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
 
 	inmemory "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/db/in-memory"
 	sliceutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/slice"
@@ -183,3 +185,93 @@ func TestGetUserByUsernamePositive(t *testing.T) {
 		t.Fatal("cannot delete user")
 	}
 }
+
+func TestUserUniqueConstraints(t *testing.T) {
+	ctx := context.Background()
+	repo := initRepo(ctx)
+
+	created, err := repo.AddUser(ctx, entity.User{Email: "test@mail.com", Username: "test"})
+	if err != nil {
+		t.Fatalf("cannot add user")
+	}
+
+	other, err := repo.AddUser(ctx, entity.User{Email: "other@mail.com", Username: "other"})
+	if err != nil {
+		t.Fatalf("cannot add user")
+	}
+
+	if err = repo.CheckUniqueConstraints(ctx, "test@mail.com", "new"); !errors.Is(err, repository.ErrEmailExists) {
+		t.Fatalf("expected email exists error, got: %v", err)
+	}
+
+	if err = repo.CheckUniqueConstraints(ctx, "new@mail.com", "test"); !errors.Is(err, repository.ErrUsernameExists) {
+		t.Fatalf("expected username exists error, got: %v", err)
+	}
+
+	if _, err = repo.AddUser(ctx, entity.User{Email: "test@mail.com", Username: "new"}); !errors.Is(err, repository.ErrEmailExists) {
+		t.Fatalf("user with existing email must not be added, got: %v", err)
+	}
+
+	_, err = repo.UpdateUser(ctx, other.ID, entity.User{Email: "other@mail.com", Username: created.Username})
+	if !errors.Is(err, repository.ErrUsernameExists) {
+		t.Fatalf("user must not take existing username, got: %v", err)
+	}
+
+	if _, err = repo.UpdateUser(ctx, other.ID, entity.User{Email: "other@mail.com", Username: "renamed"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = repo.GetUserByUsername(ctx, "other"); !errors.Is(err, repository.ErrNoSuchUser) {
+		t.Fatalf("old username must not be found, got: %v", err)
+	}
+
+	got, err := repo.GetUserByUsername(ctx, "renamed")
+	if err != nil || got.ID != other.ID {
+		t.Fatalf("renamed user must be found, got: %v, err: %v", got, err)
+	}
+}
+
+func TestUserRepoWithDuplicates(t *testing.T) {
+	ctx := context.Background()
+	db, _ := inmemory.NewInMemDB(ctx, "")
+
+	// state saved before users were indexed
+	db.CreateTable(UserTableName)
+	_ = db.AddRow(UserTableName, "1", entity.User{ID: 1, Email: "test@mail.com", Username: "first"})
+	_ = db.AddRow(UserTableName, "2", entity.User{ID: 2, Email: "test@mail.com", Username: "second"})
+
+	repo := NewUserRepo(db)
+
+	got, err := repo.GetUserByEmail(ctx, "test@mail.com")
+	if err != nil || got.ID != 1 {
+		t.Fatalf("the first user with email must be found, got: %v, err: %v", got, err)
+	}
+
+	got, err = repo.GetUserByUsername(ctx, "second")
+	if err != nil || got.ID != 2 {
+		t.Fatalf("user must be found by username, got: %v, err: %v", got, err)
+	}
+
+	if _, err = repo.AddUser(ctx, entity.User{Email: "new@mail.com", Username: "first"}); !errors.Is(err, repository.ErrUsernameExists) {
+		t.Fatalf("index without duplicates must stay unique, got: %v", err)
+	}
+}
+
+func TestUserRepoWithUnexpectedRow(t *testing.T) {
+	ctx := context.Background()
+	repo := initRepo(ctx)
+
+	_ = repo.DB.AddRow(UserTableName, "1", "not a user")
+
+	if _, err := repo.GetUserByID(ctx, 1); !errors.Is(err, inmemory.ErrUnexpectedRowType) {
+		t.Fatalf("row of unexpected type must be reported, got: %v", err)
+	}
+
+	if _, err := repo.GetUserByID(ctx, 2); !errors.Is(err, repository.ErrNoSuchUser) {
+		t.Fatalf("expected no such user error, got: %v", err)
+	}
+
+	if _, err := repo.CountUsersWithRole(ctx, entity.RoleUser); !errors.Is(err, inmemory.ErrUnexpectedRowType) {
+		t.Fatalf("row of unexpected type must not be skipped, got: %v", err)
+	}
+}
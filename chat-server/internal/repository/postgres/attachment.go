@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+)
+
+type AttachmentRepo struct {
+	DB *sqlx.DB
+}
+
+func NewAttachmentRepo(db *sqlx.DB) *AttachmentRepo {
+	return &AttachmentRepo{
+		DB: db,
+	}
+}
+
+func (ar *AttachmentRepo) AddAttachment(ctx context.Context, attachment entity.Attachment) (*entity.Attachment, error) {
+	attachment.CreatedAt = time.Now()
+
+	var created entity.Attachment
+
+	err := ar.DB.GetContext(ctx, &created,
+		`INSERT INTO attachment (uploaded_by, file_name, content_type, size, storage_key, created_at)
+VALUES ($1, $2, $3, $4, $5, $6) RETURNING *`,
+		attachment.UploadedBy, attachment.FileName, attachment.ContentType, attachment.Size, attachment.StorageKey, attachment.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+func (ar *AttachmentRepo) GetAttachment(ctx context.Context, id int) (*entity.Attachment, error) {
+	var attachment entity.Attachment
+
+	err := ar.DB.GetContext(ctx, &attachment, "SELECT * FROM attachment WHERE id = $1", id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNoSuchAttachment
+		}
+
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+// GetAttachments returns attachments with provided ids, missing ones are skipped.
+func (ar *AttachmentRepo) GetAttachments(ctx context.Context, ids []int) ([]*entity.Attachment, error) {
+	attachments := make([]*entity.Attachment, 0)
+
+	if len(ids) == 0 {
+		return attachments, nil
+	}
+
+	query, args, err := sqlx.In("SELECT * FROM attachment WHERE id IN (?) ORDER BY id", ids)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ar.DB.SelectContext(ctx, &attachments, ar.DB.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+// LinkAttachments links pending attachments to message, all of them must exist and be pending.
+func (ar *AttachmentRepo) LinkAttachments(ctx context.Context, kind entity.MessageKind, messageID int, ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	query, args, err := sqlx.In(
+		"UPDATE attachment SET message_kind = ?, message_id = ? WHERE id IN (?) AND message_id IS NULL", string(kind), messageID, ids)
+	if err != nil {
+		return err
+	}
+
+	return inTx(ctx, ar.DB, func(q querier) error {
+		res, err := q.ExecContext(ctx, q.Rebind(query), args...)
+		if err != nil {
+			return err
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		// some of attachments were linked concurrently or removed
+		if affected != int64(len(ids)) {
+			return repository.ErrNoSuchAttachment
+		}
+
+		return nil
+	})
+}
+
+// GetMessageAttachments returns attachments of provided messages in order of uploading.
+func (ar *AttachmentRepo) GetMessageAttachments(ctx context.Context, kind entity.MessageKind, messageIDs []int) ([]*entity.Attachment, error) {
+	attachments := make([]*entity.Attachment, 0)
+
+	if len(messageIDs) == 0 {
+		return attachments, nil
+	}
+
+	query, args, err := sqlx.In("SELECT * FROM attachment WHERE message_kind = ? AND message_id IN (?) ORDER BY id", string(kind), messageIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ar.DB.SelectContext(ctx, &attachments, ar.DB.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+// DeleteMessageAttachments removes attachments of message and returns them, so their content can be removed too.
+func (ar *AttachmentRepo) DeleteMessageAttachments(ctx context.Context, kind entity.MessageKind, messageID int) ([]*entity.Attachment, error) {
+	deleted := make([]*entity.Attachment, 0)
+
+	err := ar.DB.SelectContext(ctx, &deleted,
+		"DELETE FROM attachment WHERE message_kind = $1 AND message_id = $2 RETURNING *", string(kind), messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	return deleted, nil
+}
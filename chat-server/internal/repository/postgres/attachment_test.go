@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+)
+
+func TestAttachmentRepo_LinkAttachments(t *testing.T) {
+	db, mock, err := sqlxmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%v' was not expected when opening a stub database connection", err)
+	}
+
+	defer db.Close()
+
+	repo := NewAttachmentRepo(db)
+
+	query := regexp.QuoteMeta(`UPDATE attachment SET message_kind = ?, message_id = ? WHERE id IN (?, ?) AND message_id IS NULL`)
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		wantErr       error
+	}{
+		{
+			name: "ok",
+			mockBehaviour: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(query).
+					WithArgs("public", 2, 3, 4).
+					WillReturnResult(sqlxmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "some of attachments already linked",
+			mockBehaviour: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(query).
+					WithArgs("public", 2, 3, 4).
+					WillReturnResult(sqlxmock.NewResult(0, 1))
+				mock.ExpectRollback()
+			},
+			wantErr: repository.ErrNoSuchAttachment,
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			err := repo.LinkAttachments(ctx, entity.MessageKindPublic, 2, []int{3, 4})
+
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
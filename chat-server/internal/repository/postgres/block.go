@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+)
+
+type BlockRepo struct {
+	DB *sqlx.DB
+}
+
+func NewBlockRepo(db *sqlx.DB) *BlockRepo {
+	return &BlockRepo{
+		DB: db,
+	}
+}
+
+func (br *BlockRepo) AddBlock(ctx context.Context, block entity.Block) (*entity.Block, error) {
+	query := "INSERT INTO user_block (username, blocked_username, created_at) VALUES ($1, $2, $3) RETURNING *"
+
+	var created entity.Block
+
+	err := br.DB.GetContext(ctx, &created, query, block.Username, block.BlockedUsername, time.Now())
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case uniqueViolationCode:
+				return nil, repository.ErrBlockExists
+			case foreignKeyViolationCode:
+				return nil, repository.ErrNoSuchUser
+			}
+		}
+
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+func (br *BlockRepo) RemoveBlock(ctx context.Context, username, blockedUsername string) error {
+	query := "DELETE FROM user_block WHERE username = $1 AND blocked_username = $2"
+
+	res, err := br.DB.ExecContext(ctx, query, username, blockedUsername)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repository.ErrNoSuchBlock
+	}
+
+	return nil
+}
+
+// GetBlocks returns users blocked by user, most recently blocked first.
+func (br *BlockRepo) GetBlocks(ctx context.Context, username string) ([]*entity.Block, error) {
+	query := "SELECT * FROM user_block WHERE username = $1 ORDER BY created_at DESC"
+
+	blocks := make([]*entity.Block, 0)
+
+	if err := br.DB.SelectContext(ctx, &blocks, query, username); err != nil {
+		return nil, err
+	}
+
+	return blocks, nil
+}
+
+func (br *BlockRepo) IsBlocked(ctx context.Context, username, blockedUsername string) (bool, error) {
+	query := "SELECT EXISTS(SELECT 1 FROM user_block WHERE username = $1 AND blocked_username = $2)"
+
+	var blocked bool
+
+	if err := br.DB.GetContext(ctx, &blocked, query, username, blockedUsername); err != nil {
+		return false, err
+	}
+
+	return blocked, nil
+}
@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+)
+
+func TestBlockRepo_AddBlock(t *testing.T) {
+	db, mock, err := sqlxmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%v' was not expected when opening a stub database connection", err)
+	}
+
+	defer db.Close()
+
+	repo := NewBlockRepo(db)
+
+	now := time.Now()
+	query := regexp.QuoteMeta(`INSERT INTO user_block (username, blocked_username, created_at) VALUES ($1, $2, $3) RETURNING *`)
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		want          *entity.Block
+		wantErr       error
+	}{
+		{
+			name: "ok",
+			mockBehaviour: func() {
+				rows := sqlxmock.NewRows([]string{"username", "blocked_username", "created_at"}).
+					AddRow("alice", "bob", now)
+
+				mock.ExpectQuery(query).WithArgs("alice", "bob", sqlxmock.AnyArg()).WillReturnRows(rows)
+			},
+			want: &entity.Block{Username: "alice", BlockedUsername: "bob", CreatedAt: now},
+		},
+		{
+			name: "already blocked",
+			mockBehaviour: func() {
+				mock.ExpectQuery(query).WithArgs("alice", "bob", sqlxmock.AnyArg()).
+					WillReturnError(&pgconn.PgError{Code: uniqueViolationCode})
+			},
+			wantErr: repository.ErrBlockExists,
+		},
+		{
+			name: "no such user",
+			mockBehaviour: func() {
+				mock.ExpectQuery(query).WithArgs("alice", "bob", sqlxmock.AnyArg()).
+					WillReturnError(&pgconn.PgError{Code: foreignKeyViolationCode})
+			},
+			wantErr: repository.ErrNoSuchUser,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			got, err := repo.AddBlock(context.Background(), entity.Block{Username: "alice", BlockedUsername: "bob"})
+
+			assert.Equal(t, test.wantErr, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestBlockRepo_RemoveBlock(t *testing.T) {
+	db, mock, err := sqlxmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%v' was not expected when opening a stub database connection", err)
+	}
+
+	defer db.Close()
+
+	repo := NewBlockRepo(db)
+
+	query := regexp.QuoteMeta(`DELETE FROM user_block WHERE username = $1 AND blocked_username = $2`)
+
+	mock.ExpectExec(query).WithArgs("alice", "bob").WillReturnResult(sqlxmock.NewResult(0, 1))
+	assert.NoError(t, repo.RemoveBlock(context.Background(), "alice", "bob"))
+
+	mock.ExpectExec(query).WithArgs("alice", "bob").WillReturnResult(sqlxmock.NewResult(0, 0))
+	assert.Equal(t, repository.ErrNoSuchBlock, repo.RemoveBlock(context.Background(), "alice", "bob"))
+}
@@ -0,0 +1,151 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+)
+
+const (
+	uniqueViolationCode     = "23505"
+	foreignKeyViolationCode = "23503"
+)
+
+type ChannelRepo struct {
+	DB *sqlx.DB
+}
+
+func NewChannelRepo(db *sqlx.DB) *ChannelRepo {
+	return &ChannelRepo{
+		DB: db,
+	}
+}
+
+func (cr *ChannelRepo) AddChannel(ctx context.Context, channel entity.Channel) (*entity.Channel, error) {
+	channel.CreatedAt = time.Now()
+
+	result, err := cr.DB.NamedQueryContext(ctx,
+		`INSERT INTO channel (name, description, created_by, created_at) 
+VALUES (:name, :description, :created_by, :created_at) 
+RETURNING *`,
+		&channel)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return nil, repository.ErrChannelNameExists
+		}
+
+		return nil, err
+	}
+
+	defer result.Close()
+
+	var created entity.Channel
+
+	if result.Next() {
+		if err = result.StructScan(&created); err != nil {
+			return nil, err
+		}
+	}
+
+	return &created, nil
+}
+
+func (cr *ChannelRepo) GetChannel(ctx context.Context, id int) (*entity.Channel, error) {
+	var channel entity.Channel
+
+	if err := cr.DB.GetContext(ctx, &channel, "SELECT * FROM channel WHERE id = $1", id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNoSuchChannel
+		}
+
+		return nil, err
+	}
+
+	return &channel, nil
+}
+
+func (cr *ChannelRepo) GetAllChannels(ctx context.Context, offset, limit int) []*entity.Channel {
+	var query string
+
+	if limit == math.MaxInt64 {
+		query = fmt.Sprintf("SELECT * FROM channel ORDER BY id OFFSET %v", offset)
+	} else {
+		query = fmt.Sprintf("SELECT * FROM channel ORDER BY id LIMIT %v OFFSET %v", limit, offset)
+	}
+
+	var channels []*entity.Channel
+
+	if err := cr.DB.SelectContext(ctx, &channels, query); err != nil {
+		return nil
+	}
+
+	return channels
+}
+
+// AddChannelMember adds user to channel members. Adding existing member does nothing.
+func (cr *ChannelRepo) AddChannelMember(ctx context.Context, channelID int, username string) error {
+	if _, err := cr.GetChannel(ctx, channelID); err != nil {
+		return err
+	}
+
+	_, err := cr.DB.ExecContext(ctx,
+		`INSERT INTO channel_member (channel_id, username, joined_at) VALUES ($1, $2, $3) 
+ON CONFLICT (channel_id, username) DO NOTHING`,
+		channelID, username, time.Now())
+
+	return err
+}
+
+func (cr *ChannelRepo) RemoveChannelMember(ctx context.Context, channelID int, username string) error {
+	_, err := cr.DB.ExecContext(ctx, "DELETE FROM channel_member WHERE channel_id = $1 AND username = $2", channelID, username)
+
+	return err
+}
+
+func (cr *ChannelRepo) GetChannelMembers(ctx context.Context, channelID int) ([]*entity.ChannelMember, error) {
+	if _, err := cr.GetChannel(ctx, channelID); err != nil {
+		return nil, err
+	}
+
+	members := make([]*entity.ChannelMember, 0)
+
+	err := cr.DB.SelectContext(ctx, &members, "SELECT * FROM channel_member WHERE channel_id = $1 ORDER BY joined_at", channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (cr *ChannelRepo) IsChannelMember(ctx context.Context, channelID int, username string) (bool, error) {
+	var exists bool
+
+	err := cr.DB.GetContext(ctx, &exists,
+		"SELECT EXISTS(SELECT 1 FROM channel_member WHERE channel_id = $1 AND username = $2)", channelID, username)
+	if err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// GetMemberChannelIDs returns ids of channels that user is a member of.
+func (cr *ChannelRepo) GetMemberChannelIDs(ctx context.Context, username string) ([]int, error) {
+	ids := make([]int, 0)
+
+	if err := cr.DB.SelectContext(ctx, &ids, "SELECT channel_id FROM channel_member WHERE username = $1", username); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
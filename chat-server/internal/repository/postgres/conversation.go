@@ -0,0 +1,194 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+)
+
+type ConversationRepo struct {
+	DB *sqlx.DB
+}
+
+func NewConversationRepo(db *sqlx.DB) *ConversationRepo {
+	return &ConversationRepo{
+		DB: db,
+	}
+}
+
+func (cr *ConversationRepo) AddConversation(ctx context.Context, conversation entity.Conversation) (*entity.Conversation, error) {
+	conversation.CreatedAt = time.Now()
+
+	result, err := cr.DB.NamedQueryContext(ctx,
+		`INSERT INTO conversation (title, created_by, created_at) 
+VALUES (:title, :created_by, :created_at) 
+RETURNING *`,
+		&conversation)
+	if err != nil {
+		return nil, err
+	}
+
+	defer result.Close()
+
+	var created entity.Conversation
+
+	if result.Next() {
+		if err = result.StructScan(&created); err != nil {
+			return nil, err
+		}
+	}
+
+	return &created, nil
+}
+
+func (cr *ConversationRepo) GetConversation(ctx context.Context, id int) (*entity.Conversation, error) {
+	var conversation entity.Conversation
+
+	if err := cr.DB.GetContext(ctx, &conversation, "SELECT * FROM conversation WHERE id = $1", id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNoSuchConversation
+		}
+
+		return nil, err
+	}
+
+	return &conversation, nil
+}
+
+// GetUserConversations returns conversations where user is a participant.
+func (cr *ConversationRepo) GetUserConversations(ctx context.Context, username string) ([]*entity.Conversation, error) {
+	conversations := make([]*entity.Conversation, 0)
+
+	err := cr.DB.SelectContext(ctx, &conversations,
+		`SELECT c.* FROM conversation c 
+JOIN conversation_participant cp ON cp.conversation_id = c.id 
+WHERE cp.username = $1 ORDER BY c.id`,
+		username)
+	if err != nil {
+		return nil, err
+	}
+
+	return conversations, nil
+}
+
+// AddConversationParticipant adds user to conversation. Adding existing participant does nothing.
+func (cr *ConversationRepo) AddConversationParticipant(ctx context.Context, conversationID int, username string) error {
+	if _, err := cr.GetConversation(ctx, conversationID); err != nil {
+		return err
+	}
+
+	_, err := cr.DB.ExecContext(ctx,
+		`INSERT INTO conversation_participant (conversation_id, username, joined_at) VALUES ($1, $2, $3) 
+ON CONFLICT (conversation_id, username) DO NOTHING`,
+		conversationID, username, time.Now())
+
+	return err
+}
+
+func (cr *ConversationRepo) RemoveConversationParticipant(ctx context.Context, conversationID int, username string) error {
+	_, err := cr.DB.ExecContext(ctx,
+		"DELETE FROM conversation_participant WHERE conversation_id = $1 AND username = $2", conversationID, username)
+
+	return err
+}
+
+func (cr *ConversationRepo) GetConversationParticipants(ctx context.Context, conversationID int) ([]*entity.ConversationParticipant, error) {
+	if _, err := cr.GetConversation(ctx, conversationID); err != nil {
+		return nil, err
+	}
+
+	participants := make([]*entity.ConversationParticipant, 0)
+
+	err := cr.DB.SelectContext(ctx, &participants,
+		"SELECT * FROM conversation_participant WHERE conversation_id = $1 ORDER BY joined_at", conversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return participants, nil
+}
+
+func (cr *ConversationRepo) IsConversationParticipant(ctx context.Context, conversationID int, username string) (bool, error) {
+	var exists bool
+
+	err := cr.DB.GetContext(ctx, &exists,
+		"SELECT EXISTS(SELECT 1 FROM conversation_participant WHERE conversation_id = $1 AND username = $2)",
+		conversationID, username)
+	if err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+func (cr *ConversationRepo) AddConversationMessage(ctx context.Context, msg entity.ConversationMessage) (*entity.ConversationMessage, error) {
+	now := time.Now()
+
+	msg.SentAt = now
+	msg.EditedAt = now
+
+	result, err := cr.DB.NamedQueryContext(ctx,
+		`INSERT INTO conversation_message (conversation_id, from_username, content, sent_at, edited_at) 
+VALUES (:conversation_id, :from_username, :content, :sent_at, :edited_at) 
+RETURNING *`,
+		&msg)
+	if err != nil {
+		return nil, err
+	}
+
+	defer result.Close()
+
+	var created entity.ConversationMessage
+
+	if result.Next() {
+		if err = result.StructScan(&created); err != nil {
+			return nil, err
+		}
+	}
+
+	return &created, nil
+}
+
+// GetConversationMessages returns messages of the conversation ordered by sending time.
+func (cr *ConversationRepo) GetConversationMessages(ctx context.Context, conversationID, offset, limit int) []*entity.ConversationMessage {
+	var query string
+
+	if limit == math.MaxInt64 {
+		query = fmt.Sprintf("SELECT * FROM conversation_message WHERE conversation_id = $1 ORDER BY sent_at OFFSET %v", offset)
+	} else {
+		query = fmt.Sprintf("SELECT * FROM conversation_message WHERE conversation_id = $1 ORDER BY sent_at LIMIT %v OFFSET %v", limit, offset)
+	}
+
+	var messages []*entity.ConversationMessage
+
+	if err := cr.DB.SelectContext(ctx, &messages, query, conversationID); err != nil {
+		return nil
+	}
+
+	return messages
+}
+
+// GetLastConversationMessage returns the latest message of conversation or nil if nothing was sent yet.
+func (cr *ConversationRepo) GetLastConversationMessage(ctx context.Context, conversationID int) (*entity.ConversationMessage, error) {
+	var msg entity.ConversationMessage
+
+	err := cr.DB.GetContext(ctx, &msg,
+		"SELECT * FROM conversation_message WHERE conversation_id = $1 ORDER BY sent_at DESC LIMIT 1", conversationID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return &msg, nil
+}
@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+)
+
+type MentionRepo struct {
+	DB *sqlx.DB
+}
+
+func NewMentionRepo(db *sqlx.DB) *MentionRepo {
+	return &MentionRepo{
+		DB: db,
+	}
+}
+
+func (mr *MentionRepo) AddMentions(ctx context.Context, mentions []entity.Mention) ([]*entity.Mention, error) {
+	query := `INSERT INTO mention (message_kind, message_id, username, from_username, created_at)
+		VALUES ($1, $2, $3, $4, $5) RETURNING *`
+
+	added := make([]*entity.Mention, 0, len(mentions))
+	now := time.Now()
+
+	err := inTx(ctx, mr.DB, func(q querier) error {
+		for _, mention := range mentions {
+			var created entity.Mention
+
+			err := q.GetContext(ctx, &created, query,
+				string(mention.MessageKind), mention.MessageID, mention.Username, mention.FromUsername, now)
+			if err != nil {
+				return err
+			}
+
+			added = append(added, &created)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return added, nil
+}
+
+func (mr *MentionRepo) GetMessageMentions(
+	ctx context.Context,
+	kind entity.MessageKind,
+	messageIDs []int,
+) ([]*entity.Mention, error) {
+	query, args, err := sqlx.In("SELECT * FROM mention WHERE message_kind = ? AND message_id IN (?) ORDER BY id", string(kind), messageIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	mentions := make([]*entity.Mention, 0)
+
+	if err = mr.DB.SelectContext(ctx, &mentions, mr.DB.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+
+	return mentions, nil
+}
+
+// GetUserMentions returns mentions of user, newest first.
+func (mr *MentionRepo) GetUserMentions(
+	ctx context.Context,
+	username string,
+	unreadOnly bool,
+	offset, limit int,
+) ([]*entity.Mention, error) {
+	query := `SELECT * FROM mention WHERE username = $1 AND ($2 = false OR read_at IS NULL)
+		ORDER BY id DESC OFFSET $3 LIMIT $4`
+
+	mentions := make([]*entity.Mention, 0)
+
+	if err := mr.DB.SelectContext(ctx, &mentions, query, username, unreadOnly, offset, limit); err != nil {
+		return nil, err
+	}
+
+	return mentions, nil
+}
+
+// MarkMentionsRead marks unread mentions of user with id up to upToID as read, it returns count of marked ones.
+func (mr *MentionRepo) MarkMentionsRead(ctx context.Context, username string, upToID int, readAt time.Time) (int, error) {
+	query := "UPDATE mention SET read_at = $1 WHERE username = $2 AND id <= $3 AND read_at IS NULL"
+
+	res, err := mr.DB.ExecContext(ctx, query, readAt, username, upToID)
+	if err != nil {
+		return 0, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(affected), nil
+}
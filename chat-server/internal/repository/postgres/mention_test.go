@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+)
+
+func TestMentionRepo_AddMentions(t *testing.T) {
+	db, mock, err := sqlxmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%v' was not expected when opening a stub database connection", err)
+	}
+
+	defer db.Close()
+
+	repo := NewMentionRepo(db)
+
+	now := time.Now()
+	query := regexp.QuoteMeta(`INSERT INTO mention (message_kind, message_id, username, from_username, created_at)
+		VALUES ($1, $2, $3, $4, $5) RETURNING *`)
+	columns := []string{"id", "message_kind", "message_id", "username", "from_username", "created_at", "read_at"}
+	mentions := []entity.Mention{
+		{MessageKind: entity.MessageKindPublic, MessageID: 1, Username: "bob", FromUsername: "alice"},
+		{MessageKind: entity.MessageKindPublic, MessageID: 1, Username: "carol", FromUsername: "alice"},
+	}
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		want          []*entity.Mention
+		wantErr       bool
+	}{
+		{
+			name: "ok",
+			mockBehaviour: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(query).WithArgs("public", 1, "bob", "alice", sqlxmock.AnyArg()).
+					WillReturnRows(sqlxmock.NewRows(columns).AddRow(1, "public", 1, "bob", "alice", now, nil))
+				mock.ExpectQuery(query).WithArgs("public", 1, "carol", "alice", sqlxmock.AnyArg()).
+					WillReturnRows(sqlxmock.NewRows(columns).AddRow(2, "public", 1, "carol", "alice", now, nil))
+				mock.ExpectCommit()
+			},
+			want: []*entity.Mention{
+				{ID: 1, MessageKind: entity.MessageKindPublic, MessageID: 1, Username: "bob", FromUsername: "alice", CreatedAt: now},
+				{ID: 2, MessageKind: entity.MessageKindPublic, MessageID: 1, Username: "carol", FromUsername: "alice", CreatedAt: now},
+			},
+		},
+		{
+			name: "insert failed",
+			mockBehaviour: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(query).WithArgs("public", 1, "bob", "alice", sqlxmock.AnyArg()).
+					WillReturnError(errors.New("some error"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			got, err := repo.AddMentions(context.Background(), mentions)
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestMentionRepo_MarkMentionsRead(t *testing.T) {
+	db, mock, err := sqlxmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%v' was not expected when opening a stub database connection", err)
+	}
+
+	defer db.Close()
+
+	repo := NewMentionRepo(db)
+
+	now := time.Now()
+	query := regexp.QuoteMeta(`UPDATE mention SET read_at = $1 WHERE username = $2 AND id <= $3 AND read_at IS NULL`)
+
+	mock.ExpectExec(query).WithArgs(now, "bob", 5).WillReturnResult(sqlxmock.NewResult(0, 3))
+
+	marked, err := repo.MarkMentionsRead(context.Background(), "bob", 5, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, marked)
+}
@@ -2,6 +2,8 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"math"
 	"time"
@@ -9,6 +11,7 @@ import (
 	"github.com/jmoiron/sqlx"
 
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
 )
 
 type PrivateMessageRepo struct {
@@ -27,15 +30,17 @@ func (pr *PrivateMessageRepo) AddPrivateMessage(ctx context.Context, msg entity.
 	msg.SentAt = now
 	msg.EditedAt = now
 
-	result, err := pr.DB.NamedQueryContext(ctx,
-		`INSERT INTO private_message (from_username, to_username, content, sent_at, edited_at) 
-VALUES (:from_username, :to_username, :content, :sent_at, :edited_at) 
-RETURNING id, from_username, to_username, content, sent_at, edited_at`,
+	result, err := sqlx.NamedQueryContext(ctx, conn(ctx, pr.DB),
+		`INSERT INTO private_message (from_username, to_username, content, sent_at, edited_at, parent_id) 
+VALUES (:from_username, :to_username, :content, :sent_at, :edited_at, :parent_id) 
+RETURNING id, from_username, to_username, content, sent_at, edited_at, parent_id`,
 		&msg)
 	if err != nil {
 		return nil, err
 	}
 
+	defer result.Close()
+
 	var resMsg entity.PrivateMessage
 
 	if result.Next() {
@@ -77,6 +82,58 @@ func (pr *PrivateMessageRepo) GetAllPrivateMessages(ctx context.Context, offset,
 	return users
 }
 
+// GetPrivateMessageReplies returns replies in thread of the message ordered by sending time.
+func (pr *PrivateMessageRepo) GetPrivateMessageReplies(ctx context.Context, parentID, offset, limit int) []*entity.PrivateMessage {
+	var query string
+
+	if limit == math.MaxInt64 {
+		query = fmt.Sprintf("SELECT * FROM private_message WHERE parent_id = $1 ORDER BY sent_at OFFSET %v", offset)
+	} else {
+		query = fmt.Sprintf("SELECT * FROM private_message WHERE parent_id = $1 ORDER BY sent_at LIMIT %v OFFSET %v", limit, offset)
+	}
+
+	var replies []*entity.PrivateMessage
+
+	if err := pr.DB.SelectContext(ctx, &replies, query, parentID); err != nil {
+		return nil
+	}
+
+	return replies
+}
+
+// CountPrivateMessageReplies returns number of replies for each of provided messages that has any.
+func (pr *PrivateMessageRepo) CountPrivateMessageReplies(ctx context.Context, parentIDs []int) (map[int]int, error) {
+	counts := make(map[int]int, len(parentIDs))
+
+	if len(parentIDs) == 0 {
+		return counts, nil
+	}
+
+	query, args, err := sqlx.In("SELECT parent_id, count(*) FROM private_message WHERE parent_id IN (?) GROUP BY parent_id", parentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := pr.DB.QueryContext(ctx, pr.DB.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var parentID, count int
+
+		if err = rows.Scan(&parentID, &count); err != nil {
+			return nil, err
+		}
+
+		counts[parentID] = count
+	}
+
+	return counts, rows.Err()
+}
+
 func (pr *PrivateMessageRepo) GetPrivateMessage(ctx context.Context, id int) (*entity.PrivateMessage, error) {
 	row := pr.DB.QueryRowxContext(ctx, "SELECT * FROM private_message WHERE id = $1", id)
 	if err := row.Err(); err != nil {
@@ -87,8 +144,232 @@ func (pr *PrivateMessageRepo) GetPrivateMessage(ctx context.Context, id int) (*e
 
 	err := row.StructScan(&msg)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNoSuchPrivateMessage
+		}
+
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+// UpdatePrivateMessage replaces content of message and keeps previous version in revision history.
+func (pr *PrivateMessageRepo) UpdatePrivateMessage(ctx context.Context, id int, updated entity.PrivateMessage) (*entity.PrivateMessage, error) {
+	tx, err := pr.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback() // nolint
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO private_message_revision (message_id, content, edited_at) 
+SELECT id, content, edited_at FROM private_message WHERE id = $1`,
+		id)
+	if err != nil {
+		return nil, err
+	}
+
+	row := tx.QueryRowxContext(ctx,
+		"UPDATE private_message SET content = $1, edited_at = $2 WHERE id = $3 RETURNING *",
+		updated.Content, time.Now(), id)
+	if err = row.Err(); err != nil {
+		return nil, err
+	}
+
+	var msg entity.PrivateMessage
+
+	if err = row.StructScan(&msg); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNoSuchPrivateMessage
+		}
+
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
 		return nil, err
 	}
 
 	return &msg, nil
 }
+
+func (pr *PrivateMessageRepo) GetPrivateMessageRevisions(ctx context.Context, messageID int) ([]*entity.MessageRevision, error) {
+	if _, err := pr.GetPrivateMessage(ctx, messageID); err != nil {
+		return nil, err
+	}
+
+	rows, err := pr.DB.QueryxContext(ctx, "SELECT * FROM private_message_revision WHERE message_id = $1 ORDER BY id", messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make([]*entity.MessageRevision, 0)
+
+	for rows.Next() {
+		var revision entity.MessageRevision
+
+		if err = rows.StructScan(&revision); err != nil {
+			return nil, err
+		}
+
+		revisions = append(revisions, &revision)
+	}
+
+	return revisions, nil
+}
+
+// DeletePrivateMessage marks message as deleted, leaving a tombstone in place of it so pagination stays stable.
+func (pr *PrivateMessageRepo) DeletePrivateMessage(ctx context.Context, id int) (*entity.PrivateMessage, error) {
+	row := pr.DB.QueryRowxContext(ctx,
+		"UPDATE private_message SET content = '', deleted_at = $1 WHERE id = $2 RETURNING *",
+		time.Now(), id)
+	if err := row.Err(); err != nil {
+		return nil, err
+	}
+
+	var msg entity.PrivateMessage
+
+	if err := row.StructScan(&msg); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNoSuchPrivateMessage
+		}
+
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+// PurgePrivateMessage removes message completely, revision history is removed by cascade.
+func (pr *PrivateMessageRepo) PurgePrivateMessage(ctx context.Context, id int) error {
+	res, err := pr.DB.ExecContext(ctx, "DELETE FROM private_message WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repository.ErrNoSuchPrivateMessage
+	}
+
+	return nil
+}
+
+// MarkPrivateMessagesRead moves read marker of user in conversation with counterpart up to provided message
+// and sets seen time of messages received from counterpart. Marker never moves backwards.
+func (pr *PrivateMessageRepo) MarkPrivateMessagesRead(ctx context.Context, username, counterpart string, upToID int) (*entity.ReadMarker, error) {
+	tx, err := pr.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback() // nolint
+
+	now := time.Now()
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO private_message_read_marker (username, counterpart, last_read_id, read_at) 
+VALUES ($1, $2, $3, $4) 
+ON CONFLICT (username, counterpart) DO UPDATE SET last_read_id = excluded.last_read_id, read_at = excluded.read_at 
+WHERE private_message_read_marker.last_read_id < excluded.last_read_id`,
+		username, counterpart, upToID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE private_message SET seen_at = $1 
+WHERE from_username = $2 AND to_username = $3 AND id <= $4 AND seen_at IS NULL`,
+		now, counterpart, username, upToID)
+	if err != nil {
+		return nil, err
+	}
+
+	var marker entity.ReadMarker
+
+	err = tx.GetContext(ctx, &marker,
+		"SELECT * FROM private_message_read_marker WHERE username = $1 AND counterpart = $2", username, counterpart)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &marker, nil
+}
+
+// CountUnreadPrivateMessages returns number of not deleted messages received by user after read marker, grouped by sender.
+func (pr *PrivateMessageRepo) CountUnreadPrivateMessages(ctx context.Context, username string) (map[string]int, error) {
+	rows, err := pr.DB.QueryContext(ctx,
+		`SELECT pm.from_username, count(*) FROM private_message pm 
+LEFT JOIN private_message_read_marker m ON m.username = pm.to_username AND m.counterpart = pm.from_username 
+WHERE pm.to_username = $1 AND pm.from_username <> $1 AND pm.deleted_at IS NULL AND pm.id > coalesce(m.last_read_id, 0) 
+GROUP BY pm.from_username`,
+		username)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	counts := make(map[string]int)
+
+	for rows.Next() {
+		var (
+			fromUsername string
+			count        int
+		)
+
+		if err = rows.Scan(&fromUsername, &count); err != nil {
+			return nil, err
+		}
+
+		counts[fromUsername] = count
+	}
+
+	return counts, rows.Err()
+}
+
+type privateMessageSearchRow struct {
+	entity.PrivateMessage
+	Snippet string `db:"snippet"`
+}
+
+// SearchPrivateMessages returns not deleted messages sent or received by user matching query, newest first.
+func (pr *PrivateMessageRepo) SearchPrivateMessages(ctx context.Context, username string, query entity.MessageSearchQuery, limit int) ([]*entity.SearchHit, error) {
+	conditions, args := searchConditions(query)
+
+	if query.Counterpart != "" {
+		conditions = append(conditions, "((from_username = ? AND to_username = ?) OR (from_username = ? AND to_username = ?))")
+		args = append(args, username, query.Counterpart, query.Counterpart, username)
+	} else {
+		conditions = append(conditions, "(from_username = ? OR to_username = ?)")
+		args = append(args, username, username)
+	}
+
+	sqlQuery := pr.DB.Rebind(searchQuery("private_message", conditions, limit))
+
+	var rows []*privateMessageSearchRow
+
+	if err := pr.DB.SelectContext(ctx, &rows, sqlQuery, append([]any{query.Text}, args...)...); err != nil {
+		return nil, err
+	}
+
+	hits := make([]*entity.SearchHit, 0, len(rows))
+
+	for _, row := range rows {
+		msg := row.PrivateMessage
+
+		hits = append(hits, &entity.SearchHit{Kind: entity.MessageKindPrivate, Private: &msg, Snippet: row.Snippet})
+	}
+
+	return hits, nil
+}
@@ -45,7 +45,7 @@ func TestPrivateMessageRepo_AddMessage(t *testing.T) {
 					AddRow(1, "from_username", "to_username", "content", now, now)
 
 				mock.ExpectQuery("INSERT INTO private_message").
-					WithArgs("from_username", "to_username", "content", testingutils.AnyTime{}, testingutils.AnyTime{}).
+					WithArgs("from_username", "to_username", "content", testingutils.AnyTime{}, testingutils.AnyTime{}, nil).
 					WillReturnRows(rows)
 			},
 
@@ -67,7 +67,7 @@ func TestPrivateMessageRepo_AddMessage(t *testing.T) {
 			name: "empty fields",
 			mockBehaviour: func() {
 				mock.ExpectQuery("INSERT INTO private_message").
-					WithArgs("", "", "", testingutils.AnyTime{}, testingutils.AnyTime{}).
+					WithArgs("", "", "", testingutils.AnyTime{}, testingutils.AnyTime{}, nil).
 					WillReturnError(errors.New("not null constraint not satisfied"))
 			},
 
@@ -341,3 +341,154 @@ func TestPrivateMessageRepo_Get(t *testing.T) {
 		})
 	}
 }
+
+func TestPrivateMessageRepo_CountUnread(t *testing.T) {
+	db, mock, err := sqlxmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%v' was not expected when opening a stub database connection", err)
+	}
+
+	defer db.Close()
+
+	repo := NewPrivateMessageRepo(db)
+
+	query := regexp.QuoteMeta(`SELECT pm.from_username, count(*) FROM private_message pm 
+LEFT JOIN private_message_read_marker m ON m.username = pm.to_username AND m.counterpart = pm.from_username 
+WHERE pm.to_username = $1 AND pm.from_username <> $1 AND pm.deleted_at IS NULL AND pm.id > coalesce(m.last_read_id, 0) 
+GROUP BY pm.from_username`)
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		want          map[string]int
+		wantErr       bool
+	}{
+		{
+			name: "ok",
+			mockBehaviour: func() {
+				rows := sqlxmock.NewRows([]string{"from_username", "count"}).
+					AddRow("first", 2).
+					AddRow("second", 1)
+
+				mock.ExpectQuery(query).WithArgs("username").WillReturnRows(rows)
+			},
+			want: map[string]int{"first": 2, "second": 1},
+		},
+		{
+			name: "ok, nothing unread",
+			mockBehaviour: func() {
+				mock.ExpectQuery(query).WithArgs("username").WillReturnRows(sqlxmock.NewRows([]string{"from_username", "count"}))
+			},
+			want: map[string]int{},
+		},
+		{
+			name: "db error",
+			mockBehaviour: func() {
+				mock.ExpectQuery(query).WithArgs("username").WillReturnError(errors.New("connection refused"))
+			},
+			wantErr: true,
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			got, err := repo.CountUnreadPrivateMessages(ctx, "username")
+
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPrivateMessageRepo_Search(t *testing.T) {
+	db, mock, err := sqlxmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%v' was not expected when opening a stub database connection", err)
+	}
+
+	defer db.Close()
+
+	repo := NewPrivateMessageRepo(db)
+
+	now := time.Now()
+
+	query := regexp.QuoteMeta(`SELECT *, ts_headline('simple', content, plainto_tsquery('simple', ?), 'StartSel=<mark>, StopSel=</mark>, MaxWords=20, MinWords=5') AS snippet FROM private_message 
+WHERE to_tsvector('simple', content) @@ plainto_tsquery('simple', ?) AND deleted_at IS NULL AND sent_at >= ? AND ((from_username = ? AND to_username = ?) OR (from_username = ? AND to_username = ?)) ORDER BY sent_at DESC, id DESC LIMIT 10`)
+
+	columns := []string{"id", "from_username", "to_username", "content", "sent_at", "edited_at", "deleted_at", "parent_id", "seen_at", "snippet"}
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		want          []*entity.SearchHit
+		wantErr       bool
+	}{
+		{
+			name: "ok",
+			mockBehaviour: func() {
+				rows := sqlxmock.NewRows(columns).
+					AddRow(2, "counterpart", "username", "hello world", now, now, nil, nil, nil, "<mark>hello</mark> world")
+
+				mock.ExpectQuery(query).
+					WithArgs("hello", "hello", now, "username", "counterpart", "counterpart", "username").
+					WillReturnRows(rows)
+			},
+			want: []*entity.SearchHit{
+				{
+					Kind: entity.MessageKindPrivate,
+					Private: &entity.PrivateMessage{
+						ID:           2,
+						FromUsername: "counterpart",
+						ToUsername:   "username",
+						Content:      "hello world",
+						SentAt:       now,
+						EditedAt:     now,
+					},
+					Snippet: "<mark>hello</mark> world",
+				},
+			},
+		},
+		{
+			name: "db error",
+			mockBehaviour: func() {
+				mock.ExpectQuery(query).
+					WithArgs("hello", "hello", now, "username", "counterpart", "counterpart", "username").
+					WillReturnError(errors.New("connection refused"))
+			},
+			wantErr: true,
+		},
+	}
+
+	ctx := context.Background()
+
+	searchQuery := entity.MessageSearchQuery{
+		Text:        "hello",
+		Counterpart: "counterpart",
+		SentAfter:   &now,
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			got, err := repo.SearchPrivateMessages(ctx, "username", searchQuery, 10)
+
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
+			}
+		})
+	}
+}
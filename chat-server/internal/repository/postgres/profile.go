@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+)
+
+// selectProfiles selects profiles with username of their users.
+const selectProfiles = "SELECT p.*, u.username FROM user_profile p JOIN users u ON u.id = p.user_id"
+
+type ProfileRepo struct {
+	DB *sqlx.DB
+}
+
+func NewProfileRepo(db *sqlx.DB) *ProfileRepo {
+	return &ProfileRepo{
+		DB: db,
+	}
+}
+
+func (pr *ProfileRepo) GetProfile(ctx context.Context, userID int) (*entity.Profile, error) {
+	var profile entity.Profile
+
+	if err := pr.DB.GetContext(ctx, &profile, selectProfiles+" WHERE p.user_id = $1", userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNoSuchProfile
+		}
+
+		return nil, err
+	}
+
+	return &profile, nil
+}
+
+// GetProfilesByUsernames returns profiles of users with provided usernames, users without profile are skipped.
+func (pr *ProfileRepo) GetProfilesByUsernames(ctx context.Context, usernames []string) ([]*entity.Profile, error) {
+	profiles := make([]*entity.Profile, 0)
+
+	if len(usernames) == 0 {
+		return profiles, nil
+	}
+
+	query, args, err := sqlx.In(selectProfiles+" WHERE u.username IN (?)", usernames)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = pr.DB.SelectContext(ctx, &profiles, pr.DB.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+
+	return profiles, nil
+}
+
+// SaveProfile creates profile of user or replaces existing one.
+func (pr *ProfileRepo) SaveProfile(ctx context.Context, profile entity.Profile) (*entity.Profile, error) {
+	profile.UpdatedAt = time.Now()
+
+	_, err := pr.DB.NamedExecContext(ctx,
+		`INSERT INTO user_profile (user_id, display_name, bio, avatar_key, avatar_content_type, time_zone, status_text, status_expires_at, updated_at)
+VALUES (:user_id, :display_name, :bio, :avatar_key, :avatar_content_type, :time_zone, :status_text, :status_expires_at, :updated_at)
+ON CONFLICT (user_id) DO UPDATE SET display_name = excluded.display_name, bio = excluded.bio, avatar_key = excluded.avatar_key,
+avatar_content_type = excluded.avatar_content_type, time_zone = excluded.time_zone, status_text = excluded.status_text,
+status_expires_at = excluded.status_expires_at, updated_at = excluded.updated_at`,
+		&profile)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolationCode {
+			return nil, repository.ErrNoSuchUser
+		}
+
+		return nil, err
+	}
+
+	return pr.GetProfile(ctx, profile.UserID)
+}
@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+)
+
+func TestProfileRepo_GetProfilesByUsernames(t *testing.T) {
+	db, mock, err := sqlxmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%v' was not expected when opening a stub database connection", err)
+	}
+
+	defer db.Close()
+
+	repo := NewProfileRepo(db)
+
+	query := regexp.QuoteMeta(`SELECT p.*, u.username FROM user_profile p JOIN users u ON u.id = p.user_id WHERE u.username IN (?, ?)`)
+
+	tests := []struct {
+		name          string
+		usernames     []string
+		mockBehaviour func()
+		want          []*entity.Profile
+	}{
+		{
+			name:      "ok",
+			usernames: []string{"first", "second"},
+			mockBehaviour: func() {
+				rows := sqlxmock.NewRows([]string{"user_id", "display_name", "avatar_key", "username"}).
+					AddRow(1, "First", "key", "first")
+
+				mock.ExpectQuery(query).WithArgs("first", "second").WillReturnRows(rows)
+			},
+			want: []*entity.Profile{{UserID: 1, DisplayName: "First", AvatarKey: "key", Username: "first"}},
+		},
+		{
+			name:          "ok, no usernames",
+			mockBehaviour: func() {},
+			want:          []*entity.Profile{},
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			got, err := repo.GetProfilesByUsernames(ctx, test.usernames)
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -2,6 +2,8 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"math"
 	"time"
@@ -9,6 +11,7 @@ import (
 	"github.com/jmoiron/sqlx"
 
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
 )
 
 type PublicMessageRepo struct {
@@ -27,15 +30,21 @@ func (pr *PublicMessageRepo) AddPublicMessage(ctx context.Context, msg entity.Pu
 	msg.SentAt = now
 	msg.EditedAt = now
 
-	result, err := pr.DB.NamedQueryContext(ctx,
-		`INSERT INTO public_message (from_username, content, sent_at, edited_at) 
-VALUES (:from_username, :content, :sent_at, :edited_at) 
-RETURNING id, from_username, content, sent_at, edited_at`,
+	if msg.ChannelID == 0 {
+		msg.ChannelID = entity.GeneralChannelID
+	}
+
+	result, err := sqlx.NamedQueryContext(ctx, conn(ctx, pr.DB),
+		`INSERT INTO public_message (channel_id, from_username, content, sent_at, edited_at, parent_id) 
+VALUES (:channel_id, :from_username, :content, :sent_at, :edited_at, :parent_id) 
+RETURNING id, channel_id, from_username, content, sent_at, edited_at, parent_id`,
 		&msg)
 	if err != nil {
 		return nil, err
 	}
 
+	defer result.Close()
+
 	var resMsg entity.PublicMessage
 
 	if result.Next() {
@@ -77,6 +86,117 @@ func (pr *PublicMessageRepo) GetAllPublicMessages(ctx context.Context, offset, l
 	return users
 }
 
+// GetChannelMessages returns messages of the channel ordered by sending time.
+func (pr *PublicMessageRepo) GetChannelMessages(ctx context.Context, channelID, offset, limit int) []*entity.PublicMessage {
+	var query string
+
+	if limit == math.MaxInt64 {
+		query = fmt.Sprintf("SELECT * FROM public_message WHERE channel_id = $1 ORDER BY sent_at OFFSET %v", offset)
+	} else {
+		query = fmt.Sprintf("SELECT * FROM public_message WHERE channel_id = $1 ORDER BY sent_at LIMIT %v OFFSET %v", limit, offset)
+	}
+
+	rows, err := pr.DB.QueryxContext(ctx, query, channelID)
+	if err != nil {
+		return nil
+	}
+
+	var messages []*entity.PublicMessage
+
+	for rows.Next() {
+		var msg entity.PublicMessage
+
+		err = rows.StructScan(&msg)
+		if err != nil {
+			return nil
+		}
+
+		messages = append(messages, &msg)
+	}
+
+	return messages
+}
+
+// GetChannelMessagesExcludingAuthors returns messages of the channel ordered by sending time, except ones sent by provided authors.
+func (pr *PublicMessageRepo) GetChannelMessagesExcludingAuthors(ctx context.Context, channelID int, authors []string, offset, limit int) []*entity.PublicMessage {
+	if len(authors) == 0 {
+		return pr.GetChannelMessages(ctx, channelID, offset, limit)
+	}
+
+	query := "SELECT * FROM public_message WHERE channel_id = ? AND from_username NOT IN (?) ORDER BY sent_at"
+
+	if limit == math.MaxInt64 {
+		query += fmt.Sprintf(" OFFSET %v", offset)
+	} else {
+		query += fmt.Sprintf(" LIMIT %v OFFSET %v", limit, offset)
+	}
+
+	query, args, err := sqlx.In(query, channelID, authors)
+	if err != nil {
+		return nil
+	}
+
+	var messages []*entity.PublicMessage
+
+	if err = pr.DB.SelectContext(ctx, &messages, pr.DB.Rebind(query), args...); err != nil {
+		return nil
+	}
+
+	return messages
+}
+
+// GetPublicMessageReplies returns replies in thread of the message ordered by sending time.
+func (pr *PublicMessageRepo) GetPublicMessageReplies(ctx context.Context, parentID, offset, limit int) []*entity.PublicMessage {
+	var query string
+
+	if limit == math.MaxInt64 {
+		query = fmt.Sprintf("SELECT * FROM public_message WHERE parent_id = $1 ORDER BY sent_at OFFSET %v", offset)
+	} else {
+		query = fmt.Sprintf("SELECT * FROM public_message WHERE parent_id = $1 ORDER BY sent_at LIMIT %v OFFSET %v", limit, offset)
+	}
+
+	var replies []*entity.PublicMessage
+
+	if err := pr.DB.SelectContext(ctx, &replies, query, parentID); err != nil {
+		return nil
+	}
+
+	return replies
+}
+
+// CountPublicMessageReplies returns number of replies for each of provided messages that has any.
+func (pr *PublicMessageRepo) CountPublicMessageReplies(ctx context.Context, parentIDs []int) (map[int]int, error) {
+	counts := make(map[int]int, len(parentIDs))
+
+	if len(parentIDs) == 0 {
+		return counts, nil
+	}
+
+	query, args, err := sqlx.In("SELECT parent_id, count(*) FROM public_message WHERE parent_id IN (?) GROUP BY parent_id", parentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := pr.DB.QueryContext(ctx, pr.DB.Rebind(query), args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var parentID, count int
+
+		if err = rows.Scan(&parentID, &count); err != nil {
+			return nil, err
+		}
+
+		counts[parentID] = count
+	}
+
+	return counts, rows.Err()
+}
+
 func (pr *PublicMessageRepo) GetPublicMessage(ctx context.Context, id int) (*entity.PublicMessage, error) {
 	row := pr.DB.QueryRowxContext(ctx, "SELECT * FROM public_message WHERE id = $1", id)
 	if err := row.Err(); err != nil {
@@ -87,8 +207,157 @@ func (pr *PublicMessageRepo) GetPublicMessage(ctx context.Context, id int) (*ent
 
 	err := row.StructScan(&msg)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNoSuchPublicMessage
+		}
+
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+// UpdatePublicMessage replaces content of message and keeps previous version in revision history.
+func (pr *PublicMessageRepo) UpdatePublicMessage(ctx context.Context, id int, updated entity.PublicMessage) (*entity.PublicMessage, error) {
+	tx, err := pr.DB.BeginTxx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	defer tx.Rollback() // nolint
+
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO public_message_revision (message_id, content, edited_at) 
+SELECT id, content, edited_at FROM public_message WHERE id = $1`,
+		id)
+	if err != nil {
+		return nil, err
+	}
+
+	row := tx.QueryRowxContext(ctx,
+		"UPDATE public_message SET content = $1, edited_at = $2 WHERE id = $3 RETURNING *",
+		updated.Content, time.Now(), id)
+	if err = row.Err(); err != nil {
+		return nil, err
+	}
+
+	var msg entity.PublicMessage
+
+	if err = row.StructScan(&msg); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNoSuchPublicMessage
+		}
+
+		return nil, err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return &msg, nil
+}
+
+func (pr *PublicMessageRepo) GetPublicMessageRevisions(ctx context.Context, messageID int) ([]*entity.MessageRevision, error) {
+	if _, err := pr.GetPublicMessage(ctx, messageID); err != nil {
+		return nil, err
+	}
+
+	rows, err := pr.DB.QueryxContext(ctx, "SELECT * FROM public_message_revision WHERE message_id = $1 ORDER BY id", messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make([]*entity.MessageRevision, 0)
+
+	for rows.Next() {
+		var revision entity.MessageRevision
+
+		if err = rows.StructScan(&revision); err != nil {
+			return nil, err
+		}
+
+		revisions = append(revisions, &revision)
+	}
+
+	return revisions, nil
+}
+
+// DeletePublicMessage marks message as deleted, leaving a tombstone in place of it so pagination stays stable.
+func (pr *PublicMessageRepo) DeletePublicMessage(ctx context.Context, id int) (*entity.PublicMessage, error) {
+	row := pr.DB.QueryRowxContext(ctx,
+		"UPDATE public_message SET content = '', deleted_at = $1 WHERE id = $2 RETURNING *",
+		time.Now(), id)
+	if err := row.Err(); err != nil {
+		return nil, err
+	}
+
+	var msg entity.PublicMessage
+
+	if err := row.StructScan(&msg); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNoSuchPublicMessage
+		}
+
 		return nil, err
 	}
 
 	return &msg, nil
 }
+
+// PurgePublicMessage removes message completely, revision history is removed by cascade.
+func (pr *PublicMessageRepo) PurgePublicMessage(ctx context.Context, id int) error {
+	res, err := pr.DB.ExecContext(ctx, "DELETE FROM public_message WHERE id = $1", id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repository.ErrNoSuchPublicMessage
+	}
+
+	return nil
+}
+
+type publicMessageSearchRow struct {
+	entity.PublicMessage
+	Snippet string `db:"snippet"`
+}
+
+// SearchPublicMessages returns not deleted messages of provided channels matching query, newest first.
+func (pr *PublicMessageRepo) SearchPublicMessages(ctx context.Context, query entity.MessageSearchQuery, channelIDs []int, limit int) ([]*entity.SearchHit, error) {
+	hits := make([]*entity.SearchHit, 0)
+
+	if len(channelIDs) == 0 {
+		return hits, nil
+	}
+
+	conditions, args := searchConditions(query)
+
+	conditions = append(conditions, "channel_id IN (?)")
+	args = append(args, channelIDs)
+
+	sqlQuery, args, err := sqlx.In(searchQuery("public_message", conditions, limit), append([]any{query.Text}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []*publicMessageSearchRow
+
+	if err = pr.DB.SelectContext(ctx, &rows, pr.DB.Rebind(sqlQuery), args...); err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		msg := row.PublicMessage
+
+		hits = append(hits, &entity.SearchHit{Kind: entity.MessageKindPublic, Public: &msg, Snippet: row.Snippet})
+	}
+
+	return hits, nil
+}
@@ -43,11 +43,11 @@ func TestPublicMessageRepo_AddMessage(t *testing.T) { // TODO: CHANGE!
 		{
 			name: "ok",
 			mockBehaviour: func() {
-				rows := sqlxmock.NewRows([]string{"id", "from_username", "content", "sent_at", "edited_at"}).
-					AddRow(1, "from_username", "content", now, now)
+				rows := sqlxmock.NewRows([]string{"id", "channel_id", "from_username", "content", "sent_at", "edited_at"}).
+					AddRow(1, entity.GeneralChannelID, "from_username", "content", now, now)
 
 				mock.ExpectQuery("INSERT INTO public_message").
-					WithArgs("from_username", "content", testingutils.AnyTime{}, testingutils.AnyTime{}).
+					WithArgs(entity.GeneralChannelID, "from_username", "content", testingutils.AnyTime{}, testingutils.AnyTime{}, nil).
 					WillReturnRows(rows)
 			},
 
@@ -57,6 +57,7 @@ func TestPublicMessageRepo_AddMessage(t *testing.T) { // TODO: CHANGE!
 			},
 			want: &inputArgs{
 				ID:           1,
+				ChannelID:    entity.GeneralChannelID,
 				FromUsername: "from_username",
 				Content:      "content",
 				SentAt:       now,
@@ -67,7 +68,7 @@ func TestPublicMessageRepo_AddMessage(t *testing.T) { // TODO: CHANGE!
 			name: "empty fields",
 			mockBehaviour: func() {
 				mock.ExpectQuery("INSERT INTO public_message").
-					WithArgs("", "", testingutils.AnyTime{}, testingutils.AnyTime{}).
+					WithArgs(entity.GeneralChannelID, "", "", testingutils.AnyTime{}, testingutils.AnyTime{}, nil).
 					WillReturnError(errors.New("not null constraint not satisfied"))
 			},
 
@@ -333,3 +334,173 @@ func TestPublicMessageRepo_Get(t *testing.T) {
 		})
 	}
 }
+
+func TestPublicMessageRepo_Update(t *testing.T) {
+	db, mock, err := sqlxmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%v' was not expected when opening a stub database connection", err)
+	}
+
+	defer db.Close()
+
+	repo := NewPublicMessageRepo(db)
+
+	type inputArgs struct {
+		id      int
+		updated entity.PublicMessage
+	}
+
+	type outputArg = entity.PublicMessage
+
+	now := time.Now()
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		input         inputArgs
+		want          outputArg
+		wantErr       bool
+	}{
+		{
+			name: "ok, revision saved and content updated",
+			mockBehaviour: func() {
+				mock.ExpectBegin()
+
+				mock.ExpectExec("INSERT INTO public_message_revision").
+					WithArgs(1).
+					WillReturnResult(sqlxmock.NewResult(1, 1))
+
+				rows := sqlxmock.NewRows([]string{"id", "from_username", "content", "sent_at", "edited_at"}).
+					AddRow(1, "username", "edited", now, now)
+
+				mock.ExpectQuery(regexp.QuoteMeta(`UPDATE public_message SET content = $1, edited_at = $2 WHERE id = $3 RETURNING *`)).
+					WithArgs("edited", testingutils.AnyTime{}, 1).
+					WillReturnRows(rows)
+
+				mock.ExpectCommit()
+			},
+			input: inputArgs{
+				id:      1,
+				updated: entity.PublicMessage{Content: "edited"},
+			},
+			want: entity.PublicMessage{
+				ID:           1,
+				FromUsername: "username",
+				Content:      "edited",
+				SentAt:       now,
+				EditedAt:     now,
+			},
+		},
+		{
+			name: "err, no such message",
+			mockBehaviour: func() {
+				mock.ExpectBegin()
+
+				mock.ExpectExec("INSERT INTO public_message_revision").
+					WithArgs(2).
+					WillReturnResult(sqlxmock.NewResult(0, 0))
+
+				rows := sqlxmock.NewRows([]string{"id", "from_username", "content", "sent_at", "edited_at"})
+
+				mock.ExpectQuery(regexp.QuoteMeta(`UPDATE public_message SET content = $1, edited_at = $2 WHERE id = $3 RETURNING *`)).
+					WithArgs("edited", testingutils.AnyTime{}, 2).
+					WillReturnRows(rows)
+
+				mock.ExpectRollback()
+			},
+			input: inputArgs{
+				id:      2,
+				updated: entity.PublicMessage{Content: "edited"},
+			},
+			wantErr: true,
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			got, err := repo.UpdatePublicMessage(ctx, test.input.id, test.input.updated)
+
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, testingutils.PublicMessagesEquals(test.want, *got))
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPublicMessageRepo_CountReplies(t *testing.T) {
+	db, mock, err := sqlxmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%v' was not expected when opening a stub database connection", err)
+	}
+
+	defer db.Close()
+
+	repo := NewPublicMessageRepo(db)
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		input         []int
+		want          map[int]int
+		wantErr       bool
+	}{
+		{
+			name: "ok",
+			mockBehaviour: func() {
+				rows := sqlxmock.NewRows([]string{"parent_id", "count"}).
+					AddRow(1, 2).
+					AddRow(3, 1)
+
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT parent_id, count(*) FROM public_message WHERE parent_id IN (?, ?, ?) GROUP BY parent_id`)).
+					WithArgs(1, 2, 3).
+					WillReturnRows(rows)
+			},
+			input: []int{1, 2, 3},
+			want:  map[int]int{1: 2, 3: 1},
+		},
+		{
+			name:          "ok, no messages provided",
+			mockBehaviour: func() {},
+			input:         nil,
+			want:          map[int]int{},
+		},
+		{
+			name: "db error",
+			mockBehaviour: func() {
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT parent_id, count(*) FROM public_message WHERE parent_id IN (?) GROUP BY parent_id`)).
+					WithArgs(1).
+					WillReturnError(errors.New("connection refused"))
+			},
+			input:   []int{1},
+			wantErr: true,
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			got, err := repo.CountPublicMessageReplies(ctx, test.input)
+
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,110 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+)
+
+type ReactionRepo struct {
+	DB *sqlx.DB
+}
+
+func NewReactionRepo(db *sqlx.DB) *ReactionRepo {
+	return &ReactionRepo{
+		DB: db,
+	}
+}
+
+func reactionTableName(kind entity.MessageKind) (string, error) {
+	switch kind {
+	case entity.MessageKindPublic:
+		return "public_message_reaction", nil
+	case entity.MessageKindPrivate:
+		return "private_message_reaction", nil
+	default:
+		return "", repository.ErrUnknownMessageKind
+	}
+}
+
+// AddReaction adds reaction to message, same reaction of user can be added only once.
+func (rr *ReactionRepo) AddReaction(ctx context.Context, kind entity.MessageKind, reaction entity.Reaction) (*entity.Reaction, error) {
+	table, err := reactionTableName(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	query := fmt.Sprintf(
+		"INSERT INTO %s (message_id, username, emoji, created_at) VALUES ($1, $2, $3, $4) RETURNING *", table)
+
+	var created entity.Reaction
+
+	err = rr.DB.GetContext(ctx, &created, query, reaction.MessageID, reaction.Username, reaction.Emoji, time.Now())
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == uniqueViolationCode {
+			return nil, repository.ErrReactionExists
+		}
+
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+func (rr *ReactionRepo) RemoveReaction(ctx context.Context, kind entity.MessageKind, messageID int, username, emoji string) error {
+	table, err := reactionTableName(kind)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE message_id = $1 AND username = $2 AND emoji = $3", table)
+
+	res, err := rr.DB.ExecContext(ctx, query, messageID, username, emoji)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repository.ErrNoSuchReaction
+	}
+
+	return nil
+}
+
+// GetReactions returns reactions of provided messages ordered by time they were added.
+func (rr *ReactionRepo) GetReactions(ctx context.Context, kind entity.MessageKind, messageIDs []int) ([]*entity.Reaction, error) {
+	reactions := make([]*entity.Reaction, 0)
+
+	if len(messageIDs) == 0 {
+		return reactions, nil
+	}
+
+	table, err := reactionTableName(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	query, args, err := sqlx.In(fmt.Sprintf("SELECT * FROM %s WHERE message_id IN (?) ORDER BY id", table), messageIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = rr.DB.SelectContext(ctx, &reactions, rr.DB.Rebind(query), args...); err != nil {
+		return nil, err
+	}
+
+	return reactions, nil
+}
@@ -0,0 +1,153 @@
+package postgres
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+)
+
+func TestReactionRepo_AddReaction(t *testing.T) {
+	db, mock, err := sqlxmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%v' was not expected when opening a stub database connection", err)
+	}
+
+	defer db.Close()
+
+	repo := NewReactionRepo(db)
+
+	now := time.Now()
+
+	type inputArgs struct {
+		kind     entity.MessageKind
+		reaction entity.Reaction
+	}
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		input         inputArgs
+		want          *entity.Reaction
+		wantErr       error
+	}{
+		{
+			name: "ok",
+			mockBehaviour: func() {
+				rows := sqlxmock.NewRows([]string{"id", "message_id", "username", "emoji", "created_at"}).
+					AddRow(1, 2, "username", "👍", now)
+
+				mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO public_message_reaction (message_id, username, emoji, created_at) VALUES ($1, $2, $3, $4) RETURNING *`)).
+					WithArgs(2, "username", "👍", sqlxmock.AnyArg()).
+					WillReturnRows(rows)
+			},
+			input: inputArgs{
+				kind:     entity.MessageKindPublic,
+				reaction: entity.Reaction{MessageID: 2, Username: "username", Emoji: "👍"},
+			},
+			want: &entity.Reaction{ID: 1, MessageID: 2, Username: "username", Emoji: "👍", CreatedAt: now},
+		},
+		{
+			name: "reaction already exists",
+			mockBehaviour: func() {
+				mock.ExpectQuery(regexp.QuoteMeta(`INSERT INTO private_message_reaction (message_id, username, emoji, created_at) VALUES ($1, $2, $3, $4) RETURNING *`)).
+					WithArgs(2, "username", "👍", sqlxmock.AnyArg()).
+					WillReturnError(&pgconn.PgError{Code: uniqueViolationCode})
+			},
+			input: inputArgs{
+				kind:     entity.MessageKindPrivate,
+				reaction: entity.Reaction{MessageID: 2, Username: "username", Emoji: "👍"},
+			},
+			wantErr: repository.ErrReactionExists,
+		},
+		{
+			name:          "unknown message kind",
+			mockBehaviour: func() {},
+			input: inputArgs{
+				kind:     "unknown",
+				reaction: entity.Reaction{MessageID: 2, Username: "username", Emoji: "👍"},
+			},
+			wantErr: repository.ErrUnknownMessageKind,
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			got, err := repo.AddReaction(ctx, test.input.kind, test.input.reaction)
+
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReactionRepo_RemoveReaction(t *testing.T) {
+	db, mock, err := sqlxmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%v' was not expected when opening a stub database connection", err)
+	}
+
+	defer db.Close()
+
+	repo := NewReactionRepo(db)
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		wantErr       error
+	}{
+		{
+			name: "ok",
+			mockBehaviour: func() {
+				mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM public_message_reaction WHERE message_id = $1 AND username = $2 AND emoji = $3`)).
+					WithArgs(2, "username", "👍").
+					WillReturnResult(sqlxmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "no such reaction",
+			mockBehaviour: func() {
+				mock.ExpectExec(regexp.QuoteMeta(`DELETE FROM public_message_reaction WHERE message_id = $1 AND username = $2 AND emoji = $3`)).
+					WithArgs(2, "username", "👍").
+					WillReturnResult(sqlxmock.NewResult(0, 0))
+			},
+			wantErr: repository.ErrNoSuchReaction,
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			err := repo.RemoveReaction(ctx, entity.MessageKindPublic, 2, "username", "👍")
+
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
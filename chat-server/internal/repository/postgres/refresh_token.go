@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+)
+
+type RefreshTokenRepo struct {
+	DB *sqlx.DB
+}
+
+func NewRefreshTokenRepo(db *sqlx.DB) *RefreshTokenRepo {
+	return &RefreshTokenRepo{
+		DB: db,
+	}
+}
+
+// AddRefreshToken stores token. Used tokens of family are kept until it is revoked, as reuse of any
+// of them must revoke the family.
+func (rr *RefreshTokenRepo) AddRefreshToken(ctx context.Context, token entity.RefreshToken) (*entity.RefreshToken, error) {
+	token.CreatedAt = time.Now()
+
+	var created entity.RefreshToken
+
+	err := conn(ctx, rr.DB).GetContext(ctx, &created,
+		`INSERT INTO refresh_token (family_id, user_id, token_hash, expires_at, created_at)
+VALUES ($1, $2, $3, $4, $5) RETURNING *`,
+		token.FamilyID, token.UserID, token.TokenHash, token.ExpiresAt, token.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+func (rr *RefreshTokenRepo) GetRefreshToken(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	var token entity.RefreshToken
+
+	err := conn(ctx, rr.DB).GetContext(ctx, &token, "SELECT * FROM refresh_token WHERE token_hash = $1", tokenHash)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNoSuchRefreshToken
+		}
+
+		return nil, err
+	}
+
+	return &token, nil
+}
+
+// UseRefreshToken marks token as used, token can be used only once even by concurrent requests.
+func (rr *RefreshTokenRepo) UseRefreshToken(ctx context.Context, tokenHash string, usedAt time.Time) error {
+	res, err := conn(ctx, rr.DB).ExecContext(ctx,
+		"UPDATE refresh_token SET used_at = $1 WHERE token_hash = $2 AND used_at IS NULL", usedAt, tokenHash)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repository.ErrRefreshTokenUsed
+	}
+
+	return nil
+}
+
+// RevokeTokenFamily revokes all tokens of family, already revoked ones are kept as is.
+// Used and expired tokens are deleted as they can not be presented successfully anymore.
+func (rr *RefreshTokenRepo) RevokeTokenFamily(ctx context.Context, familyID string, revokedAt time.Time) error {
+	_, err := conn(ctx, rr.DB).ExecContext(ctx,
+		"DELETE FROM refresh_token WHERE family_id = $1 AND (used_at IS NOT NULL OR expires_at <= $2)", familyID, revokedAt)
+	if err != nil {
+		return err
+	}
+
+	_, err = conn(ctx, rr.DB).ExecContext(ctx,
+		"UPDATE refresh_token SET revoked_at = $1 WHERE family_id = $2 AND revoked_at IS NULL", revokedAt, familyID)
+
+	return err
+}
@@ -0,0 +1,133 @@
+package postgres
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+)
+
+func TestRefreshTokenRepo_UseRefreshToken(t *testing.T) {
+	db, mock, err := sqlxmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%v' was not expected when opening a stub database connection", err)
+	}
+
+	defer db.Close()
+
+	repo := NewRefreshTokenRepo(db)
+
+	now := time.Now()
+	query := regexp.QuoteMeta(`UPDATE refresh_token SET used_at = $1 WHERE token_hash = $2 AND used_at IS NULL`)
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		wantErr       error
+	}{
+		{
+			name: "ok",
+			mockBehaviour: func() {
+				mock.ExpectExec(query).
+					WithArgs(now, "hash").
+					WillReturnResult(sqlxmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "token already used",
+			mockBehaviour: func() {
+				mock.ExpectExec(query).
+					WithArgs(now, "hash").
+					WillReturnResult(sqlxmock.NewResult(0, 0))
+			},
+			wantErr: repository.ErrRefreshTokenUsed,
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			err := repo.UseRefreshToken(ctx, "hash", now)
+
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRefreshTokenRepo_RevokeTokenFamily(t *testing.T) {
+	db, mock, err := sqlxmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%v' was not expected when opening a stub database connection", err)
+	}
+
+	defer db.Close()
+
+	repo := NewRefreshTokenRepo(db)
+
+	now := time.Now()
+	deleteQuery := regexp.QuoteMeta(
+		`DELETE FROM refresh_token WHERE family_id = $1 AND (used_at IS NOT NULL OR expires_at <= $2)`)
+	revokeQuery := regexp.QuoteMeta(
+		`UPDATE refresh_token SET revoked_at = $1 WHERE family_id = $2 AND revoked_at IS NULL`)
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		wantErr       error
+	}{
+		{
+			name: "ok",
+			mockBehaviour: func() {
+				mock.ExpectExec(deleteQuery).
+					WithArgs("family", now).
+					WillReturnResult(sqlxmock.NewResult(0, 2))
+
+				mock.ExpectExec(revokeQuery).
+					WithArgs(now, "family").
+					WillReturnResult(sqlxmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "err, delete failed",
+			mockBehaviour: func() {
+				mock.ExpectExec(deleteQuery).
+					WithArgs("family", now).
+					WillReturnError(repository.ErrNoSuchRefreshToken)
+			},
+			wantErr: repository.ErrNoSuchRefreshToken,
+		},
+	}
+
+	ctx := context.Background()
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			err := repo.RevokeTokenFamily(ctx, "family", now)
+
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
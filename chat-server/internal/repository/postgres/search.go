@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+)
+
+// headlineOptions makes ts_headline snippets look like the ones of in-memory search.
+var headlineOptions = fmt.Sprintf("StartSel=<mark>, StopSel=</mark>, MaxWords=%d, MinWords=5", entity.SnippetMaxWords)
+
+// searchConditions returns conditions common for searching in both message tables, with bind arguments for them.
+// Query uses 'simple' configuration to hit indexes created by migration.
+func searchConditions(query entity.MessageSearchQuery) ([]string, []any) {
+	conditions := []string{
+		"to_tsvector('simple', content) @@ plainto_tsquery('simple', ?)",
+		"deleted_at IS NULL",
+	}
+	args := []any{query.Text}
+
+	if query.Author != "" {
+		conditions = append(conditions, "from_username = ?")
+		args = append(args, query.Author)
+	}
+
+	if query.SentAfter != nil {
+		conditions = append(conditions, "sent_at >= ?")
+		args = append(args, *query.SentAfter)
+	}
+
+	if query.SentBefore != nil {
+		conditions = append(conditions, "sent_at <= ?")
+		args = append(args, *query.SentBefore)
+	}
+
+	return conditions, args
+}
+
+// searchQuery builds search over message table, first bind argument is a text of query used for snippet.
+func searchQuery(table string, conditions []string, limit int) string {
+	return fmt.Sprintf(
+		`SELECT *, ts_headline('simple', content, plainto_tsquery('simple', ?), '%s') AS snippet FROM %s 
+WHERE %s ORDER BY sent_at DESC, id DESC LIMIT %d`,
+		headlineOptions, table, strings.Join(conditions, " AND "), limit)
+}
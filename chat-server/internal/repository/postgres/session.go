@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+)
+
+type SessionRepo struct {
+	DB *sqlx.DB
+}
+
+func NewSessionRepo(db *sqlx.DB) *SessionRepo {
+	return &SessionRepo{
+		DB: db,
+	}
+}
+
+func (sr *SessionRepo) AddSession(ctx context.Context, session entity.Session) (*entity.Session, error) {
+	session.CreatedAt = time.Now()
+	session.LastSeenAt = session.CreatedAt
+
+	var created entity.Session
+
+	err := conn(ctx, sr.DB).GetContext(ctx, &created,
+		`INSERT INTO session (id, user_id, device_name, user_agent, ip, created_at, last_seen_at, expires_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8) RETURNING *`,
+		session.ID, session.UserID, session.DeviceName, session.UserAgent, session.IP,
+		session.CreatedAt, session.LastSeenAt, session.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return &created, nil
+}
+
+func (sr *SessionRepo) GetSession(ctx context.Context, id string) (*entity.Session, error) {
+	var session entity.Session
+
+	err := conn(ctx, sr.DB).GetContext(ctx, &session, "SELECT * FROM session WHERE id = $1", id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNoSuchSession
+		}
+
+		return nil, err
+	}
+
+	return &session, nil
+}
+
+func (sr *SessionRepo) GetUserSessions(ctx context.Context, userID int) ([]*entity.Session, error) {
+	sessions := make([]*entity.Session, 0)
+
+	if err := conn(ctx, sr.DB).SelectContext(ctx, &sessions, "SELECT * FROM session WHERE user_id = $1", userID); err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+func (sr *SessionRepo) execOnSession(ctx context.Context, query string, args ...any) error {
+	res, err := conn(ctx, sr.DB).ExecContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repository.ErrNoSuchSession
+	}
+
+	return nil
+}
+
+func (sr *SessionRepo) TouchSession(ctx context.Context, id string, lastSeenAt time.Time) error {
+	return sr.execOnSession(ctx, "UPDATE session SET last_seen_at = $1 WHERE id = $2", lastSeenAt, id)
+}
+
+// ExtendSession prolongs session when its refresh token is rotated.
+func (sr *SessionRepo) ExtendSession(ctx context.Context, id string, expiresAt, lastSeenAt time.Time) error {
+	return sr.execOnSession(ctx,
+		"UPDATE session SET expires_at = $1, last_seen_at = $2 WHERE id = $3", expiresAt, lastSeenAt, id)
+}
+
+// RevokeSession revokes session, time of earlier revocation is kept.
+func (sr *SessionRepo) RevokeSession(ctx context.Context, id string, revokedAt time.Time) error {
+	return sr.execOnSession(ctx,
+		"UPDATE session SET revoked_at = COALESCE(revoked_at, $1) WHERE id = $2", revokedAt, id)
+}
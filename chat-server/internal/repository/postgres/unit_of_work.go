@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jmoiron/sqlx"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+)
+
+const serializationFailureCode = "40001"
+
+type txContextKey struct{}
+
+// querier is implemented both by db and transaction.
+type querier interface {
+	sqlx.ExtContext
+	GetContext(ctx context.Context, dest any, query string, args ...any) error
+	SelectContext(ctx context.Context, dest any, query string, args ...any) error
+}
+
+// conn returns transaction of the context or db itself.
+func conn(ctx context.Context, db *sqlx.DB) querier {
+	if tx, ok := ctx.Value(txContextKey{}).(*sqlx.Tx); ok {
+		return tx
+	}
+
+	return db
+}
+
+// inTx runs fn in transaction of the context, or in its own transaction if there is none, so that
+// repository keeps several statements atomic both inside and outside of unit of work.
+func inTx(ctx context.Context, db *sqlx.DB, fn func(q querier) error) error {
+	if tx, ok := ctx.Value(txContextKey{}).(*sqlx.Tx); ok {
+		return fn(tx)
+	}
+
+	tx, err := db.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err = fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UnitOfWork runs function in repeatable read transaction, postgres counterpart of snapshot isolation
+// of in-memory db. Repositories called with context of the function query through the transaction.
+type UnitOfWork struct {
+	DB *sqlx.DB
+}
+
+func NewUnitOfWork(db *sqlx.DB) *UnitOfWork {
+	return &UnitOfWork{
+		DB: db,
+	}
+}
+
+// Do commits transaction if fn succeeds and rolls it back otherwise. Nested call joins outer transaction.
+func (uow *UnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txContextKey{}).(*sqlx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := uow.DB.BeginTxx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead})
+	if err != nil {
+		return err
+	}
+
+	if err = fn(context.WithValue(ctx, txContextKey{}, tx)); err != nil {
+		_ = tx.Rollback()
+		return txError(err)
+	}
+
+	return txError(tx.Commit())
+}
+
+// txError reports concurrent update that made repeatable read transaction fail.
+func txError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == serializationFailureCode {
+		return repository.ErrConcurrentUpdate
+	}
+
+	return err
+}
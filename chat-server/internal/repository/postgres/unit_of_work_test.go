@@ -0,0 +1,98 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+
+	sqlxmock "github.com/zhashkevych/go-sqlxmock"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+)
+
+func TestUnitOfWork_Do(t *testing.T) {
+	db, mock, err := sqlxmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%v' was not expected when opening a stub database connection", err)
+	}
+
+	defer db.Close()
+
+	uow := NewUnitOfWork(db)
+	userRepo := NewUserRepo(db)
+
+	query := regexp.QuoteMeta(`UPDATE users SET role = $1, updated_at = $2 WHERE id = $3 RETURNING *`)
+	errFailed := errors.New("failed")
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		fn            func(ctx context.Context) error
+		wantErr       error
+	}{
+		{
+			name: "ok, repositories query through transaction",
+			mockBehaviour: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(query).WithArgs(entity.RoleAdmin, sqlxmock.AnyArg(), 1).
+					WillReturnRows(sqlxmock.NewRows([]string{"id", "role"}).AddRow(1, entity.RoleAdmin))
+				mock.ExpectCommit()
+			},
+			fn: func(ctx context.Context) error {
+				// nested unit of work joins the outer one
+				return uow.Do(ctx, func(ctx context.Context) error {
+					_, err := userRepo.UpdateUserRole(ctx, 1, entity.RoleAdmin)
+					return err
+				})
+			},
+		},
+		{
+			name: "failed function rolls back",
+			mockBehaviour: func() {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			fn:      func(context.Context) error { return errFailed },
+			wantErr: errFailed,
+		},
+		{
+			name: "concurrent update",
+			mockBehaviour: func() {
+				mock.ExpectBegin()
+				mock.ExpectCommit().WillReturnError(&pgconn.PgError{Code: serializationFailureCode})
+			},
+			fn:      func(context.Context) error { return nil },
+			wantErr: repository.ErrConcurrentUpdate,
+		},
+		{
+			name: "users with role are locked by concurrent transaction",
+			mockBehaviour: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(`SELECT id FROM users WHERE role = $1 FOR UPDATE`)).WithArgs(entity.RoleAdmin).
+					WillReturnError(&pgconn.PgError{Code: serializationFailureCode})
+				mock.ExpectRollback()
+			},
+			fn: func(ctx context.Context) error {
+				_, err := userRepo.LockUsersWithRole(ctx, entity.RoleAdmin)
+				return err
+			},
+			wantErr: repository.ErrConcurrentUpdate,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			err := uow.Do(context.Background(), test.fn)
+
+			assert.Equal(t, test.wantErr, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -2,11 +2,14 @@ package postgres
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
 	"math"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jmoiron/sqlx"
 
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
@@ -31,7 +34,7 @@ func (ur *UserRepo) GetAllUsers(ctx context.Context, offset, limit int) []*entit
 		query = fmt.Sprintf("SELECT * FROM users ORDER BY created_at LIMIT %v OFFSET %v", limit, offset)
 	}
 
-	rows, err := ur.DB.QueryxContext(ctx, query)
+	rows, err := conn(ctx, ur.DB).QueryxContext(ctx, query)
 	if err != nil {
 		return nil // todo: return err?
 	}
@@ -58,10 +61,10 @@ func (ur *UserRepo) AddUser(ctx context.Context, user entity.User) (*entity.User
 	user.CreatedAt = now
 	user.UpdatedAt = now
 
-	result, err := ur.DB.NamedQueryContext(ctx,
+	result, err := sqlx.NamedQueryContext(ctx, conn(ctx, ur.DB),
 		`INSERT INTO users (email, username, hashed_password, created_at, updated_at) 
 VALUES (:email, :username, :hashed_password, :created_at, :updated_at) 
-RETURNING id, email, username, hashed_password, created_at, updated_at`,
+RETURNING id, email, username, hashed_password, role, created_at, updated_at, last_seen_at`,
 		&user)
 	if err != nil {
 		return nil, err
@@ -89,7 +92,7 @@ func (ur *UserRepo) getUserByArg(ctx context.Context, argName string, arg any) (
 		query = fmt.Sprintf("SELECT * FROM users WHERE %v = %v", argName, arg)
 	}
 
-	row := ur.DB.QueryRowxContext(ctx, query)
+	row := conn(ctx, ur.DB).QueryRowxContext(ctx, query)
 	if err := row.Err(); err != nil {
 		return nil, err
 	}
@@ -98,6 +101,10 @@ func (ur *UserRepo) getUserByArg(ctx context.Context, argName string, arg any) (
 
 	err := row.StructScan(&user)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNoSuchUser
+		}
+
 		return nil, err
 	}
 
@@ -117,15 +124,20 @@ func (ur *UserRepo) GetUserByUsername(ctx context.Context, username string) (*en
 }
 
 func (ur *UserRepo) DeleteUser(ctx context.Context, id int) (*entity.User, error) {
-	row := ur.DB.QueryRowxContext(ctx, "DELETE FROM users WHERE id = $1", id)
-	if err := row.Err(); err != nil {
-		return nil, err
-	}
-
 	var user entity.User
 
-	err := row.StructScan(&user)
+	err := conn(ctx, ur.DB).GetContext(ctx, &user, "DELETE FROM users WHERE id = $1 RETURNING *", id)
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNoSuchUser
+		}
+
+		// messages keep their sender, so user cannot be removed from under them
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolationCode {
+			return nil, repository.ErrUserHasMessages
+		}
+
 		return nil, err
 	}
 
@@ -133,32 +145,27 @@ func (ur *UserRepo) DeleteUser(ctx context.Context, id int) (*entity.User, error
 }
 
 func (ur *UserRepo) UpdateUser(ctx context.Context, id int, updated entity.User) (*entity.User, error) {
+	updated.ID = id
 	updated.UpdatedAt = time.Now()
 
-	tx := ur.DB.MustBegin()
-
-	query := "UPDATE users SET email=:email, username=:username, hashed_password=:hashed_password, updated_at=:updated_at" + fmt.Sprintf("WHERE :id = %v", id)
-
-	// todo: RETURNING in update state?
-
-	_, err := tx.NamedExecContext(ctx, query, &updated)
+	// role is changed only by UpdateUserRole
+	result, err := sqlx.NamedQueryContext(ctx, conn(ctx, ur.DB),
+		`UPDATE users SET email = :email, username = :username, hashed_password = :hashed_password, updated_at = :updated_at
+WHERE id = :id RETURNING *`,
+		&updated)
 	if err != nil {
 		return nil, err
 	}
 
-	row := tx.QueryRowxContext(ctx, "SELECT * FROM users WHERE id = $1", id)
-	if err = row.Err(); err != nil {
-		return nil, err
-	}
+	defer result.Close()
 
-	if err = tx.Commit(); err != nil {
-		return nil, err
-	} // todo: maybe tx.Commit() or tx.Rollback() inside defer func?
+	if !result.Next() {
+		return nil, repository.ErrNoSuchUser
+	}
 
 	var user entity.User
 
-	err = row.StructScan(&user)
-	if err != nil {
+	if err = result.StructScan(&user); err != nil {
 		return nil, err
 	}
 
@@ -178,3 +185,60 @@ func (ur *UserRepo) CheckUniqueConstraints(ctx context.Context, email, username
 
 	return nil
 }
+
+func (ur *UserRepo) UpdateUserRole(ctx context.Context, id int, role entity.Role) (*entity.User, error) {
+	var user entity.User
+
+	err := conn(ctx, ur.DB).GetContext(ctx, &user,
+		"UPDATE users SET role = $1, updated_at = $2 WHERE id = $3 RETURNING *", role, time.Now(), id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, repository.ErrNoSuchUser
+		}
+
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (ur *UserRepo) CountUsersWithRole(ctx context.Context, role entity.Role) (int, error) {
+	var count int
+
+	if err := conn(ctx, ur.DB).GetContext(ctx, &count, "SELECT count(*) FROM users WHERE role = $1", role); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+// LockUsersWithRole counts users with role and locks them until transaction of ctx ends, so concurrent
+// transactions checking users with the same role wait for each other and the later one fails.
+func (ur *UserRepo) LockUsersWithRole(ctx context.Context, role entity.Role) (int, error) {
+	ids := make([]int, 0)
+
+	if err := conn(ctx, ur.DB).SelectContext(ctx, &ids, "SELECT id FROM users WHERE role = $1 FOR UPDATE", role); err != nil {
+		return 0, err
+	}
+
+	return len(ids), nil
+}
+
+// UpdateLastSeen records time of last activity of user, it is not treated as user update.
+func (ur *UserRepo) UpdateLastSeen(ctx context.Context, username string, lastSeenAt time.Time) error {
+	result, err := conn(ctx, ur.DB).ExecContext(ctx, "UPDATE users SET last_seen_at = $1 WHERE username = $2", lastSeenAt, username)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return repository.ErrNoSuchUser
+	}
+
+	return nil
+}
@@ -9,11 +9,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
 
 	sqlxmock "github.com/zhashkevych/go-sqlxmock"
 
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
 
 	sliceutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/slice"
 )
@@ -521,7 +523,7 @@ func TestUserRepo_Delete(t *testing.T) {
 					NewRows([]string{"id", "username", "email", "hashed_password", "created_at", "updated_at"}).
 					AddRow(1, "username", "email@mail.com", "hashed_password", time.Time{}, time.Time{})
 
-				mock.ExpectQuery(regexp.QuoteMeta(`DELETE FROM users WHERE id = $1`)).
+				mock.ExpectQuery(regexp.QuoteMeta(`DELETE FROM users WHERE id = $1 RETURNING *`)).
 					WithArgs(1).
 					WillReturnRows(rows)
 			},
@@ -541,13 +543,23 @@ func TestUserRepo_Delete(t *testing.T) {
 				rows := sqlxmock.
 					NewRows([]string{"id", "username", "email", "hashed_password", "created_at", "updated_at"})
 
-				mock.ExpectQuery(regexp.QuoteMeta(`DELETE FROM users WHERE id = $1`)).
+				mock.ExpectQuery(regexp.QuoteMeta(`DELETE FROM users WHERE id = $1 RETURNING *`)).
 					WithArgs(1).
 					WillReturnRows(rows)
 			},
 			input:   1,
 			wantErr: true,
 		},
+		{
+			name: "err, user has sent messages",
+			mockBehaviour: func() {
+				mock.ExpectQuery(regexp.QuoteMeta(`DELETE FROM users WHERE id = $1 RETURNING *`)).
+					WithArgs(1).
+					WillReturnError(&pgconn.PgError{Code: foreignKeyViolationCode})
+			},
+			input:   1,
+			wantErr: true,
+		},
 	}
 
 	ctx := context.Background()
@@ -570,4 +582,40 @@ func TestUserRepo_Delete(t *testing.T) {
 	}
 }
 
-// TODO: TEST repo.UpdateUser
+func TestUserRepo_UpdateUser(t *testing.T) {
+	db, mock, err := sqlxmock.Newx()
+	if err != nil {
+		t.Fatalf("an error '%v' was not expected when opening a stub database connection", err)
+	}
+
+	defer db.Close()
+
+	repo := NewUserRepo(db)
+
+	ctx := context.Background()
+
+	updated := entity.User{Email: "new@mail.com", Username: "new", HashedPassword: "hashed_password"}
+
+	rows := sqlxmock.
+		NewRows([]string{"id", "username", "email", "hashed_password", "role", "created_at", "updated_at"}).
+		AddRow(1, "new", "new@mail.com", "hashed_password", "user", time.Time{}, time.Time{})
+
+	mock.ExpectQuery("UPDATE users SET").
+		WithArgs("new@mail.com", "new", "hashed_password", testingutils.AnyTime{}, 1).
+		WillReturnRows(rows)
+
+	got, err := repo.UpdateUser(ctx, 1, updated)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "new", got.Username)
+	assert.Equal(t, entity.RoleUser, got.Role)
+
+	mock.ExpectQuery("UPDATE users SET").
+		WithArgs("new@mail.com", "new", "hashed_password", testingutils.AnyTime{}, 2).
+		WillReturnRows(sqlxmock.NewRows([]string{"id"}))
+
+	_, err = repo.UpdateUser(ctx, 2, updated)
+
+	assert.ErrorIs(t, err, repository.ErrNoSuchUser)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
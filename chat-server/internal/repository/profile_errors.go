@@ -0,0 +1,5 @@
+package repository
+
+import "errors"
+
+var ErrNoSuchProfile = errors.New("no such profile")
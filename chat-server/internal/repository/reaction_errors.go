@@ -0,0 +1,9 @@
+package repository
+
+import "errors"
+
+var (
+	ErrNoSuchReaction     = errors.New("no such reaction")
+	ErrReactionExists     = errors.New("reaction already exists")
+	ErrUnknownMessageKind = errors.New("unknown message kind")
+)
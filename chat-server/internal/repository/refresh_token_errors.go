@@ -0,0 +1,8 @@
+package repository
+
+import "errors"
+
+var (
+	ErrNoSuchRefreshToken = errors.New("no such refresh token")
+	ErrRefreshTokenUsed   = errors.New("refresh token already used")
+)
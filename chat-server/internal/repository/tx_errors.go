@@ -0,0 +1,5 @@
+package repository
+
+import "errors"
+
+var ErrConcurrentUpdate = errors.New("data was changed concurrently, try again")
@@ -3,7 +3,8 @@ package repository
 import "errors"
 
 var (
-	ErrNoSuchUser     = errors.New("no such user")
-	ErrEmailExists    = errors.New("user with this email already exists")
-	ErrUsernameExists = errors.New("user with this username already exists")
+	ErrNoSuchUser      = errors.New("no such user")
+	ErrEmailExists     = errors.New("user with this email already exists")
+	ErrUsernameExists  = errors.New("user with this username already exists")
+	ErrUserHasMessages = errors.New("user cannot be deleted while messages sent by them exist")
 )
@@ -0,0 +1,203 @@
+package attachment
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/mocks"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n")
+
+func drain(_ context.Context, _ string, content io.Reader) (int64, error) {
+	return io.Copy(io.Discard, content)
+}
+
+func TestAttachmentService_Upload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	attachmentRepoMock := mocks.NewMockAttachmentRepo(ctrl)
+	storageMock := mocks.NewMockStorage(ctrl)
+
+	service := New(attachmentRepoMock, nil, nil, nil, storageMock, Limits{
+		MaxSize:      16,
+		AllowedTypes: []string{"image/png", "text/plain"},
+	})
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		input         entity.AttachmentUpload
+		want          *entity.Attachment
+		wantErr       error
+	}{
+		{
+			name: "ok, type is detected from content and file name is cleaned",
+			mockBehaviour: func() {
+				storageMock.EXPECT().Put(ctx, gomock.Any(), gomock.Any()).DoAndReturn(drain)
+				attachmentRepoMock.EXPECT().AddAttachment(ctx, gomock.Any()).DoAndReturn(
+					func(_ context.Context, attachment entity.Attachment) (*entity.Attachment, error) {
+						attachment.ID = 1
+						return &attachment, nil
+					})
+			},
+			input: entity.AttachmentUpload{FileName: "../../etc/cat.txt", Content: bytes.NewReader(pngHeader)},
+			want:  &entity.Attachment{ID: 1, UploadedBy: "username", FileName: "cat.txt", ContentType: "image/png", Size: int64(len(pngHeader))},
+		},
+		{
+			name:          "err, declared size is too large",
+			mockBehaviour: func() {},
+			input:         entity.AttachmentUpload{FileName: "a.txt", Size: 17, Content: strings.NewReader("text")},
+			wantErr:       ErrAttachmentTooLarge,
+		},
+		{
+			name: "err, actual size is too large",
+			mockBehaviour: func() {
+				storageMock.EXPECT().Put(ctx, gomock.Any(), gomock.Any()).DoAndReturn(drain)
+				storageMock.EXPECT().Delete(ctx, gomock.Any()).Return(nil)
+			},
+			input:   entity.AttachmentUpload{FileName: "a.txt", Content: strings.NewReader(strings.Repeat("a", 100))},
+			wantErr: ErrAttachmentTooLarge,
+		},
+		{
+			name:          "err, type not allowed",
+			mockBehaviour: func() {},
+			input:         entity.AttachmentUpload{FileName: "a.png", Content: strings.NewReader("<html></html>")},
+			wantErr:       ErrAttachmentTypeNotAllowed,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			got, err := service.UploadAttachment(ctx, "username", test.input)
+
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.NotEmpty(t, got.StorageKey)
+
+				got.StorageKey = ""
+				assert.Equal(t, test.want, got)
+			}
+		})
+	}
+}
+
+func TestAttachmentService_GetContent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	attachmentRepoMock := mocks.NewMockAttachmentRepo(ctrl)
+	publicRepoMock := mocks.NewMockAttachmentPublicMessageRepo(ctrl)
+	privateRepoMock := mocks.NewMockAttachmentPrivateMessageRepo(ctrl)
+	channelRepoMock := mocks.NewMockAttachmentChannelRepo(ctrl)
+	storageMock := mocks.NewMockStorage(ctrl)
+
+	service := New(attachmentRepoMock, publicRepoMock, privateRepoMock, channelRepoMock, storageMock, Limits{})
+
+	publicKind, privateKind := entity.MessageKindPublic, entity.MessageKindPrivate
+	msgID := 7
+
+	pending := &entity.Attachment{ID: 1, UploadedBy: "first", StorageKey: "pending"}
+	public := &entity.Attachment{ID: 2, UploadedBy: "first", MessageKind: &publicKind, MessageID: &msgID, StorageKey: "public"}
+	private := &entity.Attachment{ID: 3, UploadedBy: "first", MessageKind: &privateKind, MessageID: &msgID, StorageKey: "private"}
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		id            int
+		username      string
+		wantErr       error
+	}{
+		{
+			name: "ok, uploader sees pending attachment",
+			mockBehaviour: func() {
+				attachmentRepoMock.EXPECT().GetAttachment(ctx, 1).Return(pending, nil)
+				storageMock.EXPECT().Get(ctx, "pending").Return(io.NopCloser(strings.NewReader("")), nil)
+			},
+			id:       1,
+			username: "first",
+		},
+		{
+			name: "err, pending attachment of another user is hidden",
+			mockBehaviour: func() {
+				attachmentRepoMock.EXPECT().GetAttachment(ctx, 1).Return(pending, nil)
+			},
+			id:       1,
+			username: "second",
+			wantErr:  repository.ErrNoSuchAttachment,
+		},
+		{
+			name: "ok, channel member",
+			mockBehaviour: func() {
+				attachmentRepoMock.EXPECT().GetAttachment(ctx, 2).Return(public, nil)
+				publicRepoMock.EXPECT().GetPublicMessage(ctx, 7).Return(&entity.PublicMessage{ID: 7, ChannelID: 3, SentAt: now}, nil)
+				channelRepoMock.EXPECT().IsChannelMember(ctx, 3, "second").Return(true, nil)
+				storageMock.EXPECT().Get(ctx, "public").Return(io.NopCloser(strings.NewReader("")), nil)
+			},
+			id:       2,
+			username: "second",
+		},
+		{
+			name: "err, not a channel member",
+			mockBehaviour: func() {
+				attachmentRepoMock.EXPECT().GetAttachment(ctx, 2).Return(public, nil)
+				publicRepoMock.EXPECT().GetPublicMessage(ctx, 7).Return(&entity.PublicMessage{ID: 7, ChannelID: 3, SentAt: now}, nil)
+				channelRepoMock.EXPECT().IsChannelMember(ctx, 3, "second").Return(false, nil)
+			},
+			id:       2,
+			username: "second",
+			wantErr:  ErrNotAttachmentViewer,
+		},
+		{
+			name: "err, not a participant of private message",
+			mockBehaviour: func() {
+				attachmentRepoMock.EXPECT().GetAttachment(ctx, 3).Return(private, nil)
+				privateRepoMock.EXPECT().GetPrivateMessage(ctx, 7).Return(&entity.PrivateMessage{ID: 7, FromUsername: "first", ToUsername: "second"}, nil)
+			},
+			id:       3,
+			username: "third",
+			wantErr:  ErrNotAttachmentViewer,
+		},
+		{
+			name: "err, message deleted",
+			mockBehaviour: func() {
+				attachmentRepoMock.EXPECT().GetAttachment(ctx, 3).Return(private, nil)
+				privateRepoMock.EXPECT().GetPrivateMessage(ctx, 7).Return(&entity.PrivateMessage{ID: 7, FromUsername: "first", ToUsername: "second", DeletedAt: &now}, nil)
+			},
+			id:       3,
+			username: "second",
+			wantErr:  message.ErrMessageDeleted,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			_, content, err := service.GetAttachmentContent(ctx, test.id, test.username)
+
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.NoError(t, content.Close())
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+package attachment
+
+import "errors"
+
+var (
+	ErrAttachmentTooLarge       = errors.New("attachment is too large")
+	ErrAttachmentTypeNotAllowed = errors.New("attachment type is not allowed")
+	ErrNotAttachmentViewer      = errors.New("user can not access attachment")
+)
@@ -0,0 +1,232 @@
+package attachment
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/pkg/blob"
+)
+
+//go:generate mockgen -destination=../../mocks/attachment_repository.go -package=mocks github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/attachment AttachmentRepo
+//go:generate mockgen -destination=../../mocks/attachment_public_message_repository.go -package=mocks -mock_names=PublicMessageRepo=MockAttachmentPublicMessageRepo github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/attachment PublicMessageRepo
+//go:generate mockgen -destination=../../mocks/attachment_private_message_repository.go -package=mocks -mock_names=PrivateMessageRepo=MockAttachmentPrivateMessageRepo github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/attachment PrivateMessageRepo
+//go:generate mockgen -destination=../../mocks/attachment_channel_repository.go -package=mocks -mock_names=ChannelRepo=MockAttachmentChannelRepo github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/attachment ChannelRepo
+//go:generate mockgen -destination=../../mocks/blob_storage.go -package=mocks github.com/ew0s/ewos-to-go-hw/chat-server/pkg/blob Storage
+
+const (
+	maxFileNameLen  = 255
+	defaultFileName = "attachment"
+)
+
+type AttachmentRepo interface {
+	AddAttachment(ctx context.Context, attachment entity.Attachment) (*entity.Attachment, error)
+	GetAttachment(ctx context.Context, id int) (*entity.Attachment, error)
+	DeleteMessageAttachments(ctx context.Context, kind entity.MessageKind, messageID int) ([]*entity.Attachment, error)
+}
+
+type PublicMessageRepo interface {
+	GetPublicMessage(ctx context.Context, id int) (*entity.PublicMessage, error)
+}
+
+type PrivateMessageRepo interface {
+	GetPrivateMessage(ctx context.Context, id int) (*entity.PrivateMessage, error)
+}
+
+type ChannelRepo interface {
+	IsChannelMember(ctx context.Context, channelID int, username string) (bool, error)
+}
+
+// Limits restrict files that can be uploaded.
+type Limits struct {
+	MaxSize      int64
+	AllowedTypes []string
+}
+
+type Service struct {
+	AttachmentRepo     AttachmentRepo
+	PublicMessageRepo  PublicMessageRepo
+	PrivateMessageRepo PrivateMessageRepo
+	ChannelRepo        ChannelRepo
+	Storage            blob.Storage
+
+	limits Limits
+}
+
+func New(
+	attachmentRepo AttachmentRepo,
+	publicMessageRepo PublicMessageRepo,
+	privateMessageRepo PrivateMessageRepo,
+	channelRepo ChannelRepo,
+	storage blob.Storage,
+	limits Limits,
+) *Service {
+	return &Service{
+		AttachmentRepo:     attachmentRepo,
+		PublicMessageRepo:  publicMessageRepo,
+		PrivateMessageRepo: privateMessageRepo,
+		ChannelRepo:        channelRepo,
+		Storage:            storage,
+		limits:             limits,
+	}
+}
+
+// sanitizeFileName keeps only base name of file sent by client, names are shown to other users as is.
+func sanitizeFileName(name string) string {
+	name = filepath.Base(strings.ReplaceAll(name, `\`, "/"))
+
+	if name == "." || name == "/" {
+		return defaultFileName
+	}
+
+	if runes := []rune(name); len(runes) > maxFileNameLen {
+		name = string(runes[len(runes)-maxFileNameLen:])
+	}
+
+	return name
+}
+
+// UploadAttachment stores file of user as pending attachment, it is linked to message when message is sent with it.
+func (s *Service) UploadAttachment(ctx context.Context, username string, upload entity.AttachmentUpload) (*entity.Attachment, error) {
+	if upload.Size > s.limits.MaxSize {
+		return nil, ErrAttachmentTooLarge
+	}
+
+	content := bufio.NewReaderSize(upload.Content, blob.SniffLen)
+
+	contentType := blob.DetectContentType(content)
+	if !slices.Contains(s.limits.AllowedTypes, contentType) {
+		return nil, ErrAttachmentTypeNotAllowed
+	}
+
+	key, err := blob.NewKey()
+	if err != nil {
+		return nil, err
+	}
+
+	// declared size may lie, so no more than one byte over the limit is read
+	written, err := s.Storage.Put(ctx, key, io.LimitReader(content, s.limits.MaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if written > s.limits.MaxSize {
+		_ = s.Storage.Delete(ctx, key)
+
+		return nil, ErrAttachmentTooLarge
+	}
+
+	attachment := entity.Attachment{
+		UploadedBy:  username,
+		FileName:    sanitizeFileName(upload.FileName),
+		ContentType: contentType,
+		Size:        written,
+		StorageKey:  key,
+	}
+
+	created, err := s.AttachmentRepo.AddAttachment(ctx, attachment)
+	if err != nil {
+		_ = s.Storage.Delete(ctx, key)
+
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// checkViewer returns error if user can not see message that attachment belongs to.
+// Pending attachment is visible to uploader only, attachments of deleted messages are hidden.
+func (s *Service) checkViewer(ctx context.Context, attachment *entity.Attachment, username string) error {
+	if !attachment.IsLinked() {
+		if attachment.UploadedBy != username {
+			return repository.ErrNoSuchAttachment
+		}
+
+		return nil
+	}
+
+	switch *attachment.MessageKind {
+	case entity.MessageKindPublic:
+		msg, err := s.PublicMessageRepo.GetPublicMessage(ctx, *attachment.MessageID)
+		if err != nil {
+			return err
+		}
+
+		if msg.IsDeleted() {
+			return message.ErrMessageDeleted
+		}
+
+		if msg.ChannelID == entity.GeneralChannelID {
+			return nil
+		}
+
+		isMember, err := s.ChannelRepo.IsChannelMember(ctx, msg.ChannelID, username)
+		if err != nil {
+			return err
+		}
+
+		if !isMember {
+			return ErrNotAttachmentViewer
+		}
+
+	case entity.MessageKindPrivate:
+		msg, err := s.PrivateMessageRepo.GetPrivateMessage(ctx, *attachment.MessageID)
+		if err != nil {
+			return err
+		}
+
+		if msg.IsDeleted() {
+			return message.ErrMessageDeleted
+		}
+
+		if msg.FromUsername != username && msg.ToUsername != username {
+			return ErrNotAttachmentViewer
+		}
+
+	default:
+		return repository.ErrUnknownMessageKind
+	}
+
+	return nil
+}
+
+// GetAttachmentContent returns attachment with reader of its content if user can see it, caller must close reader.
+func (s *Service) GetAttachmentContent(ctx context.Context, id int, username string) (*entity.Attachment, io.ReadCloser, error) {
+	attachment, err := s.AttachmentRepo.GetAttachment(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err = s.checkViewer(ctx, attachment, username); err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.Storage.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return attachment, content, nil
+}
+
+// PurgeMessageAttachments removes attachments of purged message together with their content.
+func (s *Service) PurgeMessageAttachments(ctx context.Context, kind entity.MessageKind, messageID int) error {
+	attachments, err := s.AttachmentRepo.DeleteMessageAttachments(ctx, kind, messageID)
+	if err != nil {
+		return err
+	}
+
+	for _, attachment := range attachments {
+		if err = s.Storage.Delete(ctx, attachment.StorageKey); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
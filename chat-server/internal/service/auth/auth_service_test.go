@@ -8,10 +8,13 @@ import (
 	testingutils "github.com/ew0s/ewos-to-go-hw/chat-server/internal/pkg/utils/testing"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"testing"
 	"time"
 
 	repoerrors "github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+	inmemoryrepository "github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository/in-memory"
+	inmemory "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/db/in-memory"
 )
 
 func TestAuthService_Login(t *testing.T) {
@@ -20,9 +23,12 @@ func TestAuthService_Login(t *testing.T) {
 	now := time.Now()
 
 	userRepoMock := mocks.NewMockUserRepo(ctrl)
+	refreshTokenRepoMock := mocks.NewMockRefreshTokenRepo(ctrl)
+	sessionRepoMock := mocks.NewMockAuthSessionRepo(ctrl)
 	hasherMock := mocks.NewMockAuthHasher(ctrl)
+	notifierMock := mocks.NewMockAuthNotifier(ctrl)
 
-	service := New(userRepoMock, hasherMock)
+	service := New(userRepoMock, refreshTokenRepoMock, sessionRepoMock, hasherMock, notifierMock, time.Hour)
 
 	type inputArgs = entity.User
 	type outputArg = *entity.User
@@ -126,3 +132,146 @@ func TestAuthService_Login(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthService_RefreshTokens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	userRepoMock := mocks.NewMockUserRepo(ctrl)
+	refreshTokenRepoMock := mocks.NewMockRefreshTokenRepo(ctrl)
+	sessionRepoMock := mocks.NewMockAuthSessionRepo(ctrl)
+	hasherMock := mocks.NewMockAuthHasher(ctrl)
+	notifierMock := mocks.NewMockAuthNotifier(ctrl)
+
+	service := New(userRepoMock, refreshTokenRepoMock, sessionRepoMock, hasherMock, notifierMock, time.Hour)
+
+	hash := hashToken("token")
+	user := &entity.User{ID: 1, Username: "username"}
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		wantErr       error
+	}{
+		{
+			name: "ok, token is rotated within its family",
+			mockBehaviour: func() {
+				refreshTokenRepoMock.EXPECT().GetRefreshToken(ctx, hash).
+					Return(&entity.RefreshToken{ID: 1, FamilyID: "family", UserID: 1, TokenHash: hash, ExpiresAt: now.Add(time.Hour)}, nil)
+				refreshTokenRepoMock.EXPECT().UseRefreshToken(ctx, hash, gomock.Any()).Return(nil)
+				userRepoMock.EXPECT().GetUserByID(ctx, 1).Return(user, nil)
+				sessionRepoMock.EXPECT().ExtendSession(ctx, "family", gomock.Any(), gomock.Any()).Return(nil)
+				refreshTokenRepoMock.EXPECT().AddRefreshToken(ctx, gomock.Any()).DoAndReturn(
+					func(_ context.Context, token entity.RefreshToken) (*entity.RefreshToken, error) {
+						assert.Equal(t, "family", token.FamilyID)
+						assert.NotEqual(t, hash, token.TokenHash)
+
+						return &token, nil
+					})
+			},
+		},
+		{
+			name: "err, unknown token",
+			mockBehaviour: func() {
+				refreshTokenRepoMock.EXPECT().GetRefreshToken(ctx, hash).Return(nil, repoerrors.ErrNoSuchRefreshToken)
+			},
+			wantErr: ErrInvalidRefreshToken,
+		},
+		{
+			name: "err, reused token revokes family",
+			mockBehaviour: func() {
+				refreshTokenRepoMock.EXPECT().GetRefreshToken(ctx, hash).
+					Return(&entity.RefreshToken{ID: 1, FamilyID: "family", TokenHash: hash, ExpiresAt: now.Add(time.Hour), UsedAt: &now}, nil)
+				refreshTokenRepoMock.EXPECT().RevokeTokenFamily(ctx, "family", gomock.Any()).Return(nil)
+				sessionRepoMock.EXPECT().RevokeSession(ctx, "family", gomock.Any()).Return(nil)
+				notifierMock.EXPECT().NotifySessionRevoked(ctx, "family")
+			},
+			wantErr: ErrRefreshTokenReused,
+		},
+		{
+			name: "err, token used by concurrent request revokes family",
+			mockBehaviour: func() {
+				refreshTokenRepoMock.EXPECT().GetRefreshToken(ctx, hash).
+					Return(&entity.RefreshToken{ID: 1, FamilyID: "family", TokenHash: hash, ExpiresAt: now.Add(time.Hour)}, nil)
+				refreshTokenRepoMock.EXPECT().UseRefreshToken(ctx, hash, gomock.Any()).Return(repoerrors.ErrRefreshTokenUsed)
+				refreshTokenRepoMock.EXPECT().RevokeTokenFamily(ctx, "family", gomock.Any()).Return(nil)
+				sessionRepoMock.EXPECT().RevokeSession(ctx, "family", gomock.Any()).Return(nil)
+				notifierMock.EXPECT().NotifySessionRevoked(ctx, "family")
+			},
+			wantErr: ErrRefreshTokenReused,
+		},
+		{
+			name: "err, revoked session",
+			mockBehaviour: func() {
+				refreshTokenRepoMock.EXPECT().GetRefreshToken(ctx, hash).
+					Return(&entity.RefreshToken{ID: 1, FamilyID: "family", TokenHash: hash, ExpiresAt: now.Add(time.Hour), UsedAt: &now, RevokedAt: &now}, nil)
+			},
+			wantErr: ErrSessionRevoked,
+		},
+		{
+			name: "err, expired token",
+			mockBehaviour: func() {
+				refreshTokenRepoMock.EXPECT().GetRefreshToken(ctx, hash).
+					Return(&entity.RefreshToken{ID: 1, FamilyID: "family", TokenHash: hash, ExpiresAt: now.Add(-time.Minute)}, nil)
+			},
+			wantErr: ErrRefreshTokenExpired,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			got, err := service.RefreshTokens(ctx, "token")
+
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, "family", got.FamilyID)
+				assert.Equal(t, user, got.User)
+				assert.NotEmpty(t, got.RefreshToken)
+				assert.NotEqual(t, "token", got.RefreshToken)
+			}
+		})
+	}
+}
+
+func TestAuthService_RefreshTokens_ReplayRotatedToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	db, _ := inmemory.NewInMemDB(ctx, "")
+
+	userRepoMock := mocks.NewMockUserRepo(ctrl)
+	sessionRepoMock := mocks.NewMockAuthSessionRepo(ctrl)
+	notifierMock := mocks.NewMockAuthNotifier(ctrl)
+
+	service := New(userRepoMock, inmemoryrepository.NewRefreshTokenRepo(db), sessionRepoMock, mocks.NewMockAuthHasher(ctrl),
+		notifierMock, time.Hour)
+
+	user := &entity.User{ID: 1, Username: "username"}
+
+	userRepoMock.EXPECT().GetUserByID(ctx, 1).Return(user, nil).Times(2)
+	sessionRepoMock.EXPECT().ExtendSession(ctx, "family", gomock.Any(), gomock.Any()).Return(nil).Times(2)
+
+	first, err := service.issueRefreshToken(ctx, user, "family", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+
+	second, err := service.RefreshTokens(ctx, first.RefreshToken)
+	require.NoError(t, err)
+
+	third, err := service.RefreshTokens(ctx, second.RefreshToken)
+	require.NoError(t, err)
+
+	// token from two rotations back is still recognized as reused
+	sessionRepoMock.EXPECT().RevokeSession(ctx, "family", gomock.Any()).Return(nil)
+	notifierMock.EXPECT().NotifySessionRevoked(ctx, "family")
+
+	_, err = service.RefreshTokens(ctx, first.RefreshToken)
+	assert.ErrorIs(t, err, ErrRefreshTokenReused)
+
+	_, err = service.RefreshTokens(ctx, third.RefreshToken)
+	assert.ErrorIs(t, err, ErrSessionRevoked)
+}
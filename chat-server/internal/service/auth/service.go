@@ -2,29 +2,83 @@ package auth
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"time"
 
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+)
+
+//go:generate mockgen -destination=../../mocks/refresh_token_repository.go -package=mocks github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/auth RefreshTokenRepo
+
+const (
+	refreshTokenBytes = 32
+	familyIDBytes     = 16
+	maxUserAgentLen   = 512
 )
 
 type UserRepo interface {
+	GetUserByID(ctx context.Context, id int) (*entity.User, error)
 	GetUserByUsername(ctx context.Context, username string) (*entity.User, error)
 }
 
-//go:generate mockgen -destination=../../mocks/hasher_auth.go -package=mocks github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/auth Hasher
+type RefreshTokenRepo interface {
+	AddRefreshToken(ctx context.Context, token entity.RefreshToken) (*entity.RefreshToken, error)
+	GetRefreshToken(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+	UseRefreshToken(ctx context.Context, tokenHash string, usedAt time.Time) error
+	RevokeTokenFamily(ctx context.Context, familyID string, revokedAt time.Time) error
+}
+
+//go:generate mockgen -destination=../../mocks/auth_session_repository.go -package=mocks -mock_names=SessionRepo=MockAuthSessionRepo github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/auth SessionRepo
+
+type SessionRepo interface {
+	AddSession(ctx context.Context, session entity.Session) (*entity.Session, error)
+	ExtendSession(ctx context.Context, id string, expiresAt, lastSeenAt time.Time) error
+	RevokeSession(ctx context.Context, id string, revokedAt time.Time) error
+}
+
+//go:generate mockgen -destination=../../mocks/hasher_auth.go -package=mocks -mock_names=Hasher=MockAuthHasher github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/auth Hasher
 
 type Hasher interface {
 	CompareHashAndPassword(hashedPassword []byte, password []byte) error
 }
 
+//go:generate mockgen -destination=../../mocks/auth_notifier.go -package=mocks -mock_names=Notifier=MockAuthNotifier github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/auth Notifier
+
+// Notifier closes live connections opened within revoked session.
+type Notifier interface {
+	NotifySessionRevoked(ctx context.Context, sessionID string)
+}
+
 type Service struct {
-	UserRepo UserRepo
-	Hasher   Hasher
+	UserRepo         UserRepo
+	RefreshTokenRepo RefreshTokenRepo
+	SessionRepo      SessionRepo
+	Hasher           Hasher
+	Notifier         Notifier
+
+	refreshTTL time.Duration
 }
 
-func New(ur UserRepo, hasher Hasher) *Service {
+func New(
+	ur UserRepo,
+	refreshTokenRepo RefreshTokenRepo,
+	sessionRepo SessionRepo,
+	hasher Hasher,
+	notifier Notifier,
+	refreshTTL time.Duration,
+) *Service {
 	return &Service{
-		UserRepo: ur,
-		Hasher:   hasher,
+		UserRepo:         ur,
+		RefreshTokenRepo: refreshTokenRepo,
+		SessionRepo:      sessionRepo,
+		Hasher:           hasher,
+		Notifier:         notifier,
+		refreshTTL:       refreshTTL,
 	}
 }
 
@@ -41,3 +95,170 @@ func (as *Service) Login(ctx context.Context, username, password string) (*entit
 
 	return user, nil
 }
+
+func randomString(n int, encode func([]byte) string) (string, error) {
+	b := make([]byte, n)
+
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return encode(b), nil
+}
+
+func truncate(s string, maxLen int) string {
+	if runes := []rune(s); len(runes) > maxLen {
+		return string(runes[:maxLen])
+	}
+
+	return s
+}
+
+// hashToken returns hash under which token is stored, so leaked storage does not reveal usable tokens.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func (as *Service) issueRefreshToken(ctx context.Context, user *entity.User, familyID string, expiresAt time.Time) (*entity.AuthTokens, error) {
+	plain, err := randomString(refreshTokenBytes, base64.RawURLEncoding.EncodeToString)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := as.RefreshTokenRepo.AddRefreshToken(ctx, entity.RefreshToken{
+		FamilyID:  familyID,
+		UserID:    user.ID,
+		TokenHash: hashToken(plain),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.AuthTokens{
+		User:             user,
+		FamilyID:         familyID,
+		RefreshToken:     plain,
+		RefreshExpiresAt: token.ExpiresAt,
+	}, nil
+}
+
+// IssueTokens starts new session of logged-in user on device with its first refresh token.
+func (as *Service) IssueTokens(ctx context.Context, user *entity.User, device entity.SessionDevice) (*entity.AuthTokens, error) {
+	familyID, err := randomString(familyIDBytes, hex.EncodeToString)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := time.Now().Add(as.refreshTTL)
+
+	_, err = as.SessionRepo.AddSession(ctx, entity.Session{
+		ID:         familyID,
+		UserID:     user.ID,
+		DeviceName: device.Name,
+		UserAgent:  truncate(device.UserAgent, maxUserAgentLen),
+		IP:         device.IP,
+		ExpiresAt:  expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return as.issueRefreshToken(ctx, user, familyID, expiresAt)
+}
+
+// revokeSession revokes session with all its refresh tokens.
+func (as *Service) revokeSession(ctx context.Context, familyID string) error {
+	now := time.Now()
+
+	if err := as.RefreshTokenRepo.RevokeTokenFamily(ctx, familyID, now); err != nil {
+		return err
+	}
+
+	if err := as.SessionRepo.RevokeSession(ctx, familyID, now); err != nil {
+		return err
+	}
+
+	as.Notifier.NotifySessionRevoked(ctx, familyID)
+
+	return nil
+}
+
+func (as *Service) getRefreshToken(ctx context.Context, refreshToken string) (*entity.RefreshToken, error) {
+	token, err := as.RefreshTokenRepo.GetRefreshToken(ctx, hashToken(refreshToken))
+	if errors.Is(err, repository.ErrNoSuchRefreshToken) {
+		return nil, ErrInvalidRefreshToken
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+// revokeOnReuse revokes whole session when already rotated token is presented again:
+// either client or attacker holds stolen token, and there is no way to tell which one.
+func (as *Service) revokeOnReuse(ctx context.Context, familyID string) error {
+	if err := as.revokeSession(ctx, familyID); err != nil {
+		return err
+	}
+
+	return ErrRefreshTokenReused
+}
+
+// RefreshTokens rotates refresh token: presented one is used up and new one of the same session is issued.
+func (as *Service) RefreshTokens(ctx context.Context, refreshToken string) (*entity.AuthTokens, error) {
+	token, err := as.getRefreshToken(ctx, refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	switch {
+	case token.IsRevoked():
+		return nil, ErrSessionRevoked
+
+	case token.IsUsed():
+		return nil, as.revokeOnReuse(ctx, token.FamilyID)
+
+	case !token.ExpiresAt.After(now):
+		return nil, ErrRefreshTokenExpired
+	}
+
+	err = as.RefreshTokenRepo.UseRefreshToken(ctx, token.TokenHash, now)
+	if errors.Is(err, repository.ErrRefreshTokenUsed) {
+		// token was rotated by concurrent request
+		return nil, as.revokeOnReuse(ctx, token.FamilyID)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := as.UserRepo.GetUserByID(ctx, token.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	expiresAt := now.Add(as.refreshTTL)
+
+	if err = as.SessionRepo.ExtendSession(ctx, token.FamilyID, expiresAt, now); err != nil {
+		return nil, err
+	}
+
+	return as.issueRefreshToken(ctx, user, token.FamilyID, expiresAt)
+}
+
+// Logout revokes session of refresh token, access tokens of session are rejected from now on.
+func (as *Service) Logout(ctx context.Context, refreshToken string) error {
+	token, err := as.getRefreshToken(ctx, refreshToken)
+	if err != nil {
+		return err
+	}
+
+	return as.revokeSession(ctx, token.FamilyID)
+}
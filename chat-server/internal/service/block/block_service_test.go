@@ -0,0 +1,76 @@
+package block
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/mocks"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+)
+
+func TestBlockService_BlockUser(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	blockRepoMock := mocks.NewMockBlockRepo(ctrl)
+	userRepoMock := mocks.NewMockBlockUserRepo(ctrl)
+
+	service := New(blockRepoMock, userRepoMock)
+
+	tests := []struct {
+		name            string
+		blockedUsername string
+		mockBehaviour   func()
+		want            *entity.Block
+		wantErr         error
+	}{
+		{
+			name:            "ok",
+			blockedUsername: "bob",
+			mockBehaviour: func() {
+				userRepoMock.EXPECT().GetUserByUsername(ctx, "bob").Return(&entity.User{Username: "bob"}, nil)
+				blockRepoMock.EXPECT().AddBlock(ctx, entity.Block{Username: "alice", BlockedUsername: "bob"}).
+					Return(&entity.Block{Username: "alice", BlockedUsername: "bob"}, nil)
+			},
+			want: &entity.Block{Username: "alice", BlockedUsername: "bob"},
+		},
+		{
+			name:            "self",
+			blockedUsername: "alice",
+			mockBehaviour:   func() {},
+			wantErr:         ErrBlockSelf,
+		},
+		{
+			name:            "no such user",
+			blockedUsername: "ghost",
+			mockBehaviour: func() {
+				userRepoMock.EXPECT().GetUserByUsername(ctx, "ghost").Return(nil, repository.ErrNoSuchUser)
+			},
+			wantErr: ErrNoSuchUser,
+		},
+		{
+			name:            "already blocked",
+			blockedUsername: "bob",
+			mockBehaviour: func() {
+				userRepoMock.EXPECT().GetUserByUsername(ctx, "bob").Return(&entity.User{Username: "bob"}, nil)
+				blockRepoMock.EXPECT().AddBlock(ctx, entity.Block{Username: "alice", BlockedUsername: "bob"}).
+					Return(nil, repository.ErrBlockExists)
+			},
+			wantErr: repository.ErrBlockExists,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehaviour()
+
+			got, err := service.BlockUser(ctx, "alice", tt.blockedUsername)
+			assert.Equal(t, tt.wantErr, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,8 @@
+package block
+
+import "errors"
+
+var (
+	ErrBlockSelf  = errors.New("user can not block themselves")
+	ErrNoSuchUser = errors.New("no such user")
+)
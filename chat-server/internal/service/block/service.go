@@ -0,0 +1,57 @@
+package block
+
+import (
+	"context"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+)
+
+//go:generate mockgen -destination=../../mocks/block_repository.go -package=mocks github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/block BlockRepo
+//go:generate mockgen -destination=../../mocks/block_user_repository.go -package=mocks -mock_names=UserRepo=MockBlockUserRepo github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/block UserRepo
+
+type BlockRepo interface {
+	AddBlock(ctx context.Context, block entity.Block) (*entity.Block, error)
+	RemoveBlock(ctx context.Context, username, blockedUsername string) error
+	GetBlocks(ctx context.Context, username string) ([]*entity.Block, error)
+}
+
+type UserRepo interface {
+	GetUserByUsername(ctx context.Context, username string) (*entity.User, error)
+}
+
+type Service struct {
+	BlockRepo BlockRepo
+	UserRepo  UserRepo
+}
+
+func New(blockRepo BlockRepo, userRepo UserRepo) *Service {
+	return &Service{
+		BlockRepo: blockRepo,
+		UserRepo:  userRepo,
+	}
+}
+
+// BlockUser stops user from receiving private messages of blocked user.
+func (s *Service) BlockUser(ctx context.Context, username, blockedUsername string) (*entity.Block, error) {
+	if username == blockedUsername {
+		return nil, ErrBlockSelf
+	}
+
+	if _, err := s.UserRepo.GetUserByUsername(ctx, blockedUsername); err != nil {
+		return nil, ErrNoSuchUser
+	}
+
+	return s.BlockRepo.AddBlock(ctx, entity.Block{
+		Username:        username,
+		BlockedUsername: blockedUsername,
+	})
+}
+
+func (s *Service) UnblockUser(ctx context.Context, username, blockedUsername string) error {
+	return s.BlockRepo.RemoveBlock(ctx, username, blockedUsername)
+}
+
+// GetBlockedUsers returns users blocked by user, most recently blocked first.
+func (s *Service) GetBlockedUsers(ctx context.Context, username string) ([]*entity.Block, error) {
+	return s.BlockRepo.GetBlocks(ctx, username)
+}
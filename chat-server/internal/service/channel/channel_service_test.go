@@ -0,0 +1,363 @@
+package channel
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/mocks"
+	testingutils "github.com/ew0s/ewos-to-go-hw/chat-server/internal/pkg/utils/testing"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message"
+
+	repoerrors "github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+)
+
+func TestChannelService_SendMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	channelRepoMock := mocks.NewMockChannelRepo(ctrl)
+	msgRepoMock := mocks.NewMockChannelMessageRepo(ctrl)
+	reactionRepoMock := mocks.NewMockReactionRepo(ctrl)
+	attachmentRepoMock := mocks.NewMockMessageAttachmentRepo(ctrl)
+	profileRepoMock := mocks.NewMockMessageProfileRepo(ctrl)
+	mentionRepoMock := mocks.NewMockMessageMentionRepo(ctrl)
+	blockRepoMock := mocks.NewMockMessageBlockRepo(ctrl)
+	userRepoMock := mocks.NewMockUserRepo(ctrl)
+	notifierMock := mocks.NewMockChannelNotifier(ctrl)
+
+	service := New(channelRepoMock, msgRepoMock, reactionRepoMock, attachmentRepoMock, profileRepoMock, mentionRepoMock, blockRepoMock,
+		userRepoMock, testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
+
+	// authors are checked in public message service tests
+	profileRepoMock.
+		EXPECT().
+		GetProfilesByUsernames(ctx, gomock.Any()).
+		Return(nil, nil).
+		AnyTimes()
+
+	type inputArgs struct {
+		channelID int
+		msg       entity.PublicMessage
+	}
+
+	type outputArg = *entity.PublicMessage
+
+	user := &entity.User{ID: 1, Username: "username"}
+	channel := &entity.Channel{ID: 2, Name: "random"}
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		input         inputArgs
+		want          outputArg
+		wantErr       error
+	}{
+		{
+			name: "ok, member sends message",
+			mockBehaviour: func() {
+				userRepoMock.EXPECT().GetUserByUsername(ctx, "username").Return(user, nil)
+				channelRepoMock.EXPECT().GetChannel(ctx, 2).Return(channel, nil)
+				channelRepoMock.EXPECT().IsChannelMember(ctx, 2, "username").Return(true, nil)
+
+				msgRepoMock.
+					EXPECT().
+					AddPublicMessage(ctx, entity.PublicMessage{ChannelID: 2, FromUsername: "username", Content: "content"}).
+					Return(&entity.PublicMessage{ID: 1, ChannelID: 2, FromUsername: "username", Content: "content", SentAt: now, EditedAt: now}, nil)
+
+				channelRepoMock.
+					EXPECT().
+					GetChannelMembers(ctx, 2).
+					Return([]*entity.ChannelMember{{ChannelID: 2, Username: "username"}, {ChannelID: 2, Username: "other"}}, nil)
+
+				notifierMock.EXPECT().NotifyChannelMessage(ctx, gomock.Any(), []string{"username", "other"})
+			},
+			input: inputArgs{
+				channelID: 2,
+				msg:       entity.PublicMessage{FromUsername: "username", Content: "content"},
+			},
+			want: &entity.PublicMessage{ID: 1, ChannelID: 2, FromUsername: "username", Content: "content", SentAt: now, EditedAt: now},
+		},
+		{
+			name: "ok, only members are mentioned",
+			mockBehaviour: func() {
+				userRepoMock.EXPECT().GetUserByUsername(ctx, "username").Return(user, nil)
+				channelRepoMock.EXPECT().GetChannel(ctx, 2).Return(channel, nil)
+				channelRepoMock.EXPECT().IsChannelMember(ctx, 2, "username").Return(true, nil)
+
+				msgRepoMock.
+					EXPECT().
+					AddPublicMessage(ctx, entity.PublicMessage{ChannelID: 2, FromUsername: "username", Content: "hi @other and @outsider"}).
+					Return(&entity.PublicMessage{ID: 1, ChannelID: 2, FromUsername: "username", Content: "hi @other and @outsider", SentAt: now}, nil)
+
+				userRepoMock.EXPECT().GetUserByUsername(ctx, "other").Return(&entity.User{ID: 2, Username: "other"}, nil)
+				channelRepoMock.EXPECT().IsChannelMember(ctx, 2, "other").Return(true, nil)
+				blockRepoMock.EXPECT().IsBlocked(ctx, "other", "username").Return(false, nil)
+
+				userRepoMock.EXPECT().GetUserByUsername(ctx, "outsider").Return(&entity.User{ID: 3, Username: "outsider"}, nil)
+				channelRepoMock.EXPECT().IsChannelMember(ctx, 2, "outsider").Return(false, nil)
+
+				mention := &entity.Mention{ID: 1, MessageKind: entity.MessageKindPublic, MessageID: 1, Username: "other", FromUsername: "username"}
+
+				mentionRepoMock.
+					EXPECT().
+					AddMentions(ctx, []entity.Mention{{MessageKind: entity.MessageKindPublic, MessageID: 1, Username: "other", FromUsername: "username"}}).
+					Return([]*entity.Mention{mention}, nil)
+
+				channelRepoMock.
+					EXPECT().
+					GetChannelMembers(ctx, 2).
+					Return([]*entity.ChannelMember{{ChannelID: 2, Username: "username"}, {ChannelID: 2, Username: "other"}}, nil)
+
+				notifierMock.EXPECT().NotifyChannelMessage(ctx, gomock.Any(), []string{"username", "other"})
+				notifierMock.EXPECT().NotifyMention(ctx, mention)
+			},
+			input: inputArgs{
+				channelID: 2,
+				msg:       entity.PublicMessage{FromUsername: "username", Content: "hi @other and @outsider"},
+			},
+			want: &entity.PublicMessage{
+				ID:           1,
+				ChannelID:    2,
+				FromUsername: "username",
+				Content:      "hi @other and @outsider",
+				SentAt:       now,
+				Mentions:     []entity.MentionSpan{{Username: "other", Offset: 3, Length: 6}},
+			},
+		},
+		{
+			name: "ok, general channel is open for everyone",
+			mockBehaviour: func() {
+				userRepoMock.EXPECT().GetUserByUsername(ctx, "username").Return(user, nil)
+				channelRepoMock.EXPECT().GetChannel(ctx, entity.GeneralChannelID).Return(&entity.Channel{ID: entity.GeneralChannelID}, nil)
+
+				msgRepoMock.
+					EXPECT().
+					AddPublicMessage(ctx, entity.PublicMessage{ChannelID: entity.GeneralChannelID, FromUsername: "username", Content: "content"}).
+					Return(&entity.PublicMessage{ID: 1, ChannelID: entity.GeneralChannelID, FromUsername: "username", Content: "content", SentAt: now, EditedAt: now}, nil)
+
+				notifierMock.EXPECT().NotifyPublicMessage(ctx, gomock.Any())
+			},
+			input: inputArgs{
+				channelID: entity.GeneralChannelID,
+				msg:       entity.PublicMessage{FromUsername: "username", Content: "content"},
+			},
+			want: &entity.PublicMessage{ID: 1, ChannelID: entity.GeneralChannelID, FromUsername: "username", Content: "content", SentAt: now, EditedAt: now},
+		},
+		{
+			name: "err, not a member",
+			mockBehaviour: func() {
+				userRepoMock.EXPECT().GetUserByUsername(ctx, "username").Return(user, nil)
+				channelRepoMock.EXPECT().GetChannel(ctx, 2).Return(channel, nil)
+				channelRepoMock.EXPECT().IsChannelMember(ctx, 2, "username").Return(false, nil)
+			},
+			input: inputArgs{
+				channelID: 2,
+				msg:       entity.PublicMessage{FromUsername: "username", Content: "content"},
+			},
+			wantErr: ErrNotChannelMember,
+		},
+		{
+			name: "err, no such channel",
+			mockBehaviour: func() {
+				userRepoMock.EXPECT().GetUserByUsername(ctx, "username").Return(user, nil)
+				channelRepoMock.EXPECT().GetChannel(ctx, 3).Return(nil, repoerrors.ErrNoSuchChannel)
+			},
+			input: inputArgs{
+				channelID: 3,
+				msg:       entity.PublicMessage{FromUsername: "username", Content: "content"},
+			},
+			wantErr: repoerrors.ErrNoSuchChannel,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			got, err := service.SendChannelMessage(ctx, test.input.channelID, test.input.msg)
+
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
+			}
+		})
+	}
+}
+
+func TestChannelService_Leave(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	channelRepoMock := mocks.NewMockChannelRepo(ctrl)
+	msgRepoMock := mocks.NewMockChannelMessageRepo(ctrl)
+	reactionRepoMock := mocks.NewMockReactionRepo(ctrl)
+	attachmentRepoMock := mocks.NewMockMessageAttachmentRepo(ctrl)
+	profileRepoMock := mocks.NewMockMessageProfileRepo(ctrl)
+	mentionRepoMock := mocks.NewMockMessageMentionRepo(ctrl)
+	blockRepoMock := mocks.NewMockMessageBlockRepo(ctrl)
+	userRepoMock := mocks.NewMockUserRepo(ctrl)
+	notifierMock := mocks.NewMockChannelNotifier(ctrl)
+
+	service := New(channelRepoMock, msgRepoMock, reactionRepoMock, attachmentRepoMock, profileRepoMock, mentionRepoMock, blockRepoMock,
+		userRepoMock, testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
+
+	// authors are checked in public message service tests
+	profileRepoMock.
+		EXPECT().
+		GetProfilesByUsernames(ctx, gomock.Any()).
+		Return(nil, nil).
+		AnyTimes()
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		channelID     int
+		wantErr       error
+	}{
+		{
+			name: "ok",
+			mockBehaviour: func() {
+				channelRepoMock.EXPECT().GetChannel(ctx, 2).Return(&entity.Channel{ID: 2}, nil)
+				channelRepoMock.EXPECT().IsChannelMember(ctx, 2, "username").Return(true, nil)
+				channelRepoMock.EXPECT().RemoveChannelMember(ctx, 2, "username").Return(nil)
+			},
+			channelID: 2,
+		},
+		{
+			name:          "err, general channel",
+			mockBehaviour: func() {},
+			channelID:     entity.GeneralChannelID,
+			wantErr:       ErrLeaveGeneralChannel,
+		},
+		{
+			name: "err, not a member",
+			mockBehaviour: func() {
+				channelRepoMock.EXPECT().GetChannel(ctx, 2).Return(&entity.Channel{ID: 2}, nil)
+				channelRepoMock.EXPECT().IsChannelMember(ctx, 2, "username").Return(false, nil)
+			},
+			channelID: 2,
+			wantErr:   ErrNotChannelMember,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			err := service.LeaveChannel(ctx, test.channelID, "username")
+
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestChannelService_EditMessage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	channelRepoMock := mocks.NewMockChannelRepo(ctrl)
+	msgRepoMock := mocks.NewMockChannelMessageRepo(ctrl)
+	reactionRepoMock := mocks.NewMockReactionRepo(ctrl)
+	attachmentRepoMock := mocks.NewMockMessageAttachmentRepo(ctrl)
+	profileRepoMock := mocks.NewMockMessageProfileRepo(ctrl)
+	mentionRepoMock := mocks.NewMockMessageMentionRepo(ctrl)
+	blockRepoMock := mocks.NewMockMessageBlockRepo(ctrl)
+	userRepoMock := mocks.NewMockUserRepo(ctrl)
+	notifierMock := mocks.NewMockChannelNotifier(ctrl)
+
+	service := New(channelRepoMock, msgRepoMock, reactionRepoMock, attachmentRepoMock, profileRepoMock, mentionRepoMock, blockRepoMock,
+		userRepoMock, testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
+
+	type inputArgs struct {
+		channelID int
+		messageID int
+		username  string
+	}
+
+	channel := &entity.Channel{ID: 2, Name: "random"}
+	existing := &entity.PublicMessage{ID: 1, ChannelID: 2, FromUsername: "username", Content: "content", SentAt: now, EditedAt: now}
+	edited := &entity.PublicMessage{ID: 1, ChannelID: 2, FromUsername: "username", Content: "edited", SentAt: now, EditedAt: now}
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		input         inputArgs
+		want          *entity.PublicMessage
+		wantErr       error
+	}{
+		{
+			name: "ok, author edits message, only members are notified",
+			mockBehaviour: func() {
+				channelRepoMock.EXPECT().GetChannel(ctx, 2).Return(channel, nil)
+				channelRepoMock.EXPECT().IsChannelMember(ctx, 2, "username").Return(true, nil)
+				msgRepoMock.EXPECT().GetPublicMessage(ctx, 1).Return(existing, nil)
+				msgRepoMock.EXPECT().UpdatePublicMessage(ctx, 1, entity.PublicMessage{Content: "edited"}).Return(edited, nil)
+
+				channelRepoMock.
+					EXPECT().
+					GetChannelMembers(ctx, 2).
+					Return([]*entity.ChannelMember{{ChannelID: 2, Username: "username"}, {ChannelID: 2, Username: "other"}}, nil)
+
+				notifierMock.EXPECT().NotifyChannelMessageEdited(ctx, edited, []string{"username", "other"})
+			},
+			input: inputArgs{channelID: 2, messageID: 1, username: "username"},
+			want:  edited,
+		},
+		{
+			name: "err, author is not a member anymore",
+			mockBehaviour: func() {
+				channelRepoMock.EXPECT().GetChannel(ctx, 2).Return(channel, nil)
+				channelRepoMock.EXPECT().IsChannelMember(ctx, 2, "username").Return(false, nil)
+			},
+			input:   inputArgs{channelID: 2, messageID: 1, username: "username"},
+			wantErr: ErrNotChannelMember,
+		},
+		{
+			name: "err, not an author",
+			mockBehaviour: func() {
+				channelRepoMock.EXPECT().GetChannel(ctx, 2).Return(channel, nil)
+				channelRepoMock.EXPECT().IsChannelMember(ctx, 2, "other").Return(true, nil)
+				msgRepoMock.EXPECT().GetPublicMessage(ctx, 1).Return(existing, nil)
+			},
+			input:   inputArgs{channelID: 2, messageID: 1, username: "other"},
+			wantErr: message.ErrNotMessageAuthor,
+		},
+		{
+			name: "err, message of another channel",
+			mockBehaviour: func() {
+				channelRepoMock.EXPECT().GetChannel(ctx, entity.GeneralChannelID).Return(&entity.Channel{ID: entity.GeneralChannelID}, nil)
+				msgRepoMock.EXPECT().GetPublicMessage(ctx, 1).Return(existing, nil)
+			},
+			input:   inputArgs{channelID: entity.GeneralChannelID, messageID: 1, username: "username"},
+			wantErr: repoerrors.ErrNoSuchPublicMessage,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			got, err := service.EditChannelMessage(ctx, test.input.channelID, test.input.messageID, test.input.username, "edited")
+
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
+			}
+		})
+	}
+}
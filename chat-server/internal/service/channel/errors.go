@@ -0,0 +1,9 @@
+package channel
+
+import "errors"
+
+var (
+	ErrNoSuchUser          = errors.New("no such user")
+	ErrNotChannelMember    = errors.New("user is not a member of channel")
+	ErrLeaveGeneralChannel = errors.New("general channel can not be left")
+)
@@ -0,0 +1,509 @@
+package channel
+
+import (
+	"context"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message"
+)
+
+//go:generate mockgen -destination=../../mocks/channel_repository.go -package=mocks github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/channel ChannelRepo
+//go:generate mockgen -destination=../../mocks/channel_message_repository.go -package=mocks -mock_names=PublicMessageRepo=MockChannelMessageRepo github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/channel PublicMessageRepo
+//go:generate mockgen -destination=../../mocks/channel_notifier.go -package=mocks -mock_names=Notifier=MockChannelNotifier github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/channel Notifier
+
+type ChannelRepo interface {
+	AddChannel(ctx context.Context, channel entity.Channel) (*entity.Channel, error)
+	GetChannel(ctx context.Context, id int) (*entity.Channel, error)
+	GetAllChannels(ctx context.Context, offset, limit int) []*entity.Channel
+	AddChannelMember(ctx context.Context, channelID int, username string) error
+	RemoveChannelMember(ctx context.Context, channelID int, username string) error
+	GetChannelMembers(ctx context.Context, channelID int) ([]*entity.ChannelMember, error)
+	IsChannelMember(ctx context.Context, channelID int, username string) (bool, error)
+}
+
+type PublicMessageRepo interface {
+	AddPublicMessage(ctx context.Context, msg entity.PublicMessage) (*entity.PublicMessage, error)
+	GetChannelMessages(ctx context.Context, channelID, offset, limit int) []*entity.PublicMessage
+	GetPublicMessage(ctx context.Context, id int) (*entity.PublicMessage, error)
+	UpdatePublicMessage(ctx context.Context, id int, updated entity.PublicMessage) (*entity.PublicMessage, error)
+	GetPublicMessageRevisions(ctx context.Context, messageID int) ([]*entity.MessageRevision, error)
+	DeletePublicMessage(ctx context.Context, id int) (*entity.PublicMessage, error)
+	GetPublicMessageReplies(ctx context.Context, parentID, offset, limit int) []*entity.PublicMessage
+	CountPublicMessageReplies(ctx context.Context, parentIDs []int) (map[int]int, error)
+}
+
+type UserRepo interface {
+	GetUserByUsername(ctx context.Context, username string) (*entity.User, error)
+}
+
+type Notifier interface {
+	NotifyPublicMessage(ctx context.Context, msg *entity.PublicMessage)
+	NotifyPublicMessageEdited(ctx context.Context, msg *entity.PublicMessage)
+	NotifyPublicMessageDeleted(ctx context.Context, msg *entity.PublicMessage)
+	NotifyChannelMessage(ctx context.Context, msg *entity.PublicMessage, members []string)
+	NotifyChannelMessageEdited(ctx context.Context, msg *entity.PublicMessage, members []string)
+	NotifyChannelMessageDeleted(ctx context.Context, msg *entity.PublicMessage, members []string)
+	NotifyMention(ctx context.Context, mention *entity.Mention)
+}
+
+type Service struct {
+	ChannelRepo       ChannelRepo
+	PublicMessageRepo PublicMessageRepo
+	ReactionRepo      message.ReactionRepo
+	AttachmentRepo    message.AttachmentRepo
+	ProfileRepo       message.ProfileRepo
+	MentionRepo       message.MentionRepo
+	BlockRepo         message.BlockRepo
+	UserRepo          UserRepo
+	UnitOfWork        message.UnitOfWork
+	Notifier          Notifier
+}
+
+func New(
+	channelRepo ChannelRepo,
+	publicMessageRepo PublicMessageRepo,
+	reactionRepo message.ReactionRepo,
+	attachmentRepo message.AttachmentRepo,
+	profileRepo message.ProfileRepo,
+	mentionRepo message.MentionRepo,
+	blockRepo message.BlockRepo,
+	userRepo UserRepo,
+	unitOfWork message.UnitOfWork,
+	notifier Notifier,
+) *Service {
+	return &Service{
+		ChannelRepo:       channelRepo,
+		PublicMessageRepo: publicMessageRepo,
+		ReactionRepo:      reactionRepo,
+		AttachmentRepo:    attachmentRepo,
+		ProfileRepo:       profileRepo,
+		MentionRepo:       mentionRepo,
+		BlockRepo:         blockRepo,
+		UserRepo:          userRepo,
+		UnitOfWork:        unitOfWork,
+		Notifier:          notifier,
+	}
+}
+
+// checkMembership returns error if user can not read or post into channel. General channel is open for everyone.
+func (s *Service) checkMembership(ctx context.Context, channelID int, username string) error {
+	if _, err := s.ChannelRepo.GetChannel(ctx, channelID); err != nil {
+		return err
+	}
+
+	if channelID == entity.GeneralChannelID {
+		return nil
+	}
+
+	isMember, err := s.ChannelRepo.IsChannelMember(ctx, channelID, username)
+	if err != nil {
+		return err
+	}
+
+	if !isMember {
+		return ErrNotChannelMember
+	}
+
+	return nil
+}
+
+// CreateChannel creates new channel, creator joins it right away.
+func (s *Service) CreateChannel(ctx context.Context, channel entity.Channel, creatorUsername string) (*entity.Channel, error) {
+	if _, err := s.UserRepo.GetUserByUsername(ctx, creatorUsername); err != nil {
+		return nil, ErrNoSuchUser
+	}
+
+	channel.CreatedBy = &creatorUsername
+
+	created, err := s.ChannelRepo.AddChannel(ctx, channel)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.ChannelRepo.AddChannelMember(ctx, created.ID, creatorUsername); err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+func (s *Service) GetChannel(ctx context.Context, id int) (*entity.Channel, error) {
+	return s.ChannelRepo.GetChannel(ctx, id)
+}
+
+func (s *Service) GetAllChannels(ctx context.Context, offset, limit int) []*entity.Channel {
+	return s.ChannelRepo.GetAllChannels(ctx, offset, limit)
+}
+
+func (s *Service) JoinChannel(ctx context.Context, id int, username string) error {
+	return s.ChannelRepo.AddChannelMember(ctx, id, username)
+}
+
+func (s *Service) LeaveChannel(ctx context.Context, id int, username string) error {
+	if id == entity.GeneralChannelID {
+		return ErrLeaveGeneralChannel
+	}
+
+	if err := s.checkMembership(ctx, id, username); err != nil {
+		return err
+	}
+
+	return s.ChannelRepo.RemoveChannelMember(ctx, id, username)
+}
+
+func (s *Service) GetChannelMembers(ctx context.Context, id int) ([]*entity.ChannelMember, error) {
+	return s.ChannelRepo.GetChannelMembers(ctx, id)
+}
+
+func (s *Service) SendChannelMessage(ctx context.Context, channelID int, msg entity.PublicMessage) (*entity.PublicMessage, error) {
+	if _, err := s.UserRepo.GetUserByUsername(ctx, msg.FromUsername); err != nil {
+		return nil, ErrNoSuchUser
+	}
+
+	if err := s.checkMembership(ctx, channelID, msg.FromUsername); err != nil {
+		return nil, err
+	}
+
+	msg.ChannelID = channelID
+
+	if err := message.ResolvePublicParent(ctx, s.PublicMessageRepo, &msg); err != nil {
+		return nil, err
+	}
+
+	attachmentIDs := message.TakeAttachmentIDs(&msg.Attachments)
+
+	if err := message.CheckAttachments(ctx, s.AttachmentRepo, attachmentIDs, msg.FromUsername); err != nil {
+		return nil, err
+	}
+
+	var (
+		created  *entity.PublicMessage
+		mentions []*entity.Mention
+	)
+
+	// message is stored together with its attachments and mentions or not at all
+	err := s.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+
+		if created, err = s.PublicMessageRepo.AddPublicMessage(ctx, msg); err != nil {
+			return err
+		}
+
+		created.Attachments, err = message.LinkAttachments(ctx, s.AttachmentRepo, entity.MessageKindPublic, created.ID, attachmentIDs)
+		if err != nil {
+			return err
+		}
+
+		mentions, created.Mentions, err = message.RecordMentions(ctx, s.MentionRepo, s.UserRepo, entity.MessageKindPublic,
+			created.ID, created.FromUsername, created.Content, func(ctx context.Context, username string) (bool, error) {
+				return s.canMention(ctx, created, username)
+			})
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// message is already sent, it is delivered without author profile if it can not be loaded
+	_ = message.FillPublicAuthors(ctx, s.ProfileRepo, []*entity.PublicMessage{created})
+
+	s.notify(ctx, created, s.Notifier.NotifyPublicMessage, s.Notifier.NotifyChannelMessage)
+
+	for _, mention := range mentions {
+		s.Notifier.NotifyMention(ctx, mention)
+	}
+
+	return created, nil
+}
+
+// canMention reports whether user can be mentioned in channel message. Channel other than general is read
+// only by its members, so only they can be mentioned there, and nobody can be mentioned by user they have blocked.
+func (s *Service) canMention(ctx context.Context, msg *entity.PublicMessage, username string) (bool, error) {
+	if msg.ChannelID != entity.GeneralChannelID {
+		isMember, err := s.ChannelRepo.IsChannelMember(ctx, msg.ChannelID, username)
+		if err != nil || !isMember {
+			return false, err
+		}
+	}
+
+	blocked, err := s.BlockRepo.IsBlocked(ctx, username, msg.FromUsername)
+
+	return !blocked, err
+}
+
+// notify delivers event of general channel to everyone and event of other channel only to its members.
+// Message is already changed, so members are not notified if they can not be loaded.
+func (s *Service) notify(
+	ctx context.Context,
+	msg *entity.PublicMessage,
+	toEveryone func(ctx context.Context, msg *entity.PublicMessage),
+	toMembers func(ctx context.Context, msg *entity.PublicMessage, members []string),
+) {
+	if msg.ChannelID == entity.GeneralChannelID {
+		toEveryone(ctx, msg)
+		return
+	}
+
+	members, err := message.ChannelMemberUsernames(ctx, s.ChannelRepo, msg.ChannelID)
+	if err != nil {
+		return
+	}
+
+	toMembers(ctx, msg, members)
+}
+
+func (s *Service) GetChannelMessages(ctx context.Context, channelID int, username string, offset, limit int) ([]*entity.PublicMessage, error) {
+	if err := s.checkMembership(ctx, channelID, username); err != nil {
+		return nil, err
+	}
+
+	messages := s.PublicMessageRepo.GetChannelMessages(ctx, channelID, offset, limit)
+
+	if err := message.FillPublicReplyCounts(ctx, s.PublicMessageRepo, messages); err != nil {
+		return nil, err
+	}
+
+	if err := message.FillPublicReactions(ctx, s.ReactionRepo, messages, username); err != nil {
+		return nil, err
+	}
+
+	if err := message.FillPublicAttachments(ctx, s.AttachmentRepo, messages); err != nil {
+		return nil, err
+	}
+
+	if err := message.FillPublicMentions(ctx, s.MentionRepo, messages); err != nil {
+		return nil, err
+	}
+
+	if err := message.FillPublicAuthors(ctx, s.ProfileRepo, messages); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// GetChannelThread returns root of the thread that message belongs to and paginated replies of it.
+func (s *Service) GetChannelThread(ctx context.Context, channelID, messageID int, username string, offset, limit int) (*entity.PublicMessage, []*entity.PublicMessage, error) {
+	if err := s.checkMembership(ctx, channelID, username); err != nil {
+		return nil, nil, err
+	}
+
+	root, err := s.PublicMessageRepo.GetPublicMessage(ctx, messageID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if root.IsReply() {
+		if root, err = s.PublicMessageRepo.GetPublicMessage(ctx, *root.ParentID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// message of another channel is not visible through this one
+	if root.ChannelID != channelID {
+		return nil, nil, repository.ErrNoSuchPublicMessage
+	}
+
+	if err = message.FillPublicReplyCounts(ctx, s.PublicMessageRepo, []*entity.PublicMessage{root}); err != nil {
+		return nil, nil, err
+	}
+
+	replies := s.PublicMessageRepo.GetPublicMessageReplies(ctx, root.ID, offset, limit)
+
+	thread := append([]*entity.PublicMessage{root}, replies...)
+
+	if err = message.FillPublicReactions(ctx, s.ReactionRepo, thread, username); err != nil {
+		return nil, nil, err
+	}
+
+	if err = message.FillPublicAttachments(ctx, s.AttachmentRepo, thread); err != nil {
+		return nil, nil, err
+	}
+
+	if err = message.FillPublicMentions(ctx, s.MentionRepo, thread); err != nil {
+		return nil, nil, err
+	}
+
+	if err = message.FillPublicAuthors(ctx, s.ProfileRepo, thread); err != nil {
+		return nil, nil, err
+	}
+
+	return root, replies, nil
+}
+
+// getReactableChannelMessage returns message of channel that user can react to, only channel members can do it.
+func (s *Service) getReactableChannelMessage(ctx context.Context, channelID, messageID int, username string) (*entity.PublicMessage, error) {
+	if err := s.checkMembership(ctx, channelID, username); err != nil {
+		return nil, err
+	}
+
+	msg, err := s.PublicMessageRepo.GetPublicMessage(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	// message of another channel is not visible through this one
+	if msg.ChannelID != channelID {
+		return nil, repository.ErrNoSuchPublicMessage
+	}
+
+	if msg.IsDeleted() {
+		return nil, message.ErrMessageDeleted
+	}
+
+	return msg, nil
+}
+
+// AddChannelMessageReaction adds emoji reaction of user to channel message and returns message with updated reactions.
+func (s *Service) AddChannelMessageReaction(ctx context.Context, channelID, messageID int, username, emoji string) (*entity.PublicMessage, error) {
+	msg, err := s.getReactableChannelMessage(ctx, channelID, messageID, username)
+	if err != nil {
+		return nil, err
+	}
+
+	reaction := entity.Reaction{
+		MessageID: msg.ID,
+		Username:  username,
+		Emoji:     emoji,
+	}
+
+	if _, err = s.ReactionRepo.AddReaction(ctx, entity.MessageKindPublic, reaction); err != nil {
+		return nil, err
+	}
+
+	if err = message.FillPublicReactions(ctx, s.ReactionRepo, []*entity.PublicMessage{msg}, username); err != nil {
+		return nil, err
+	}
+
+	if err = message.FillPublicAttachments(ctx, s.AttachmentRepo, []*entity.PublicMessage{msg}); err != nil {
+		return nil, err
+	}
+
+	if err = message.FillPublicAuthors(ctx, s.ProfileRepo, []*entity.PublicMessage{msg}); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// RemoveChannelMessageReaction removes emoji reaction of user from channel message and returns message with updated reactions.
+func (s *Service) RemoveChannelMessageReaction(ctx context.Context, channelID, messageID int, username, emoji string) (*entity.PublicMessage, error) {
+	msg, err := s.getReactableChannelMessage(ctx, channelID, messageID, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.ReactionRepo.RemoveReaction(ctx, entity.MessageKindPublic, msg.ID, username, emoji); err != nil {
+		return nil, err
+	}
+
+	if err = message.FillPublicReactions(ctx, s.ReactionRepo, []*entity.PublicMessage{msg}, username); err != nil {
+		return nil, err
+	}
+
+	if err = message.FillPublicAttachments(ctx, s.AttachmentRepo, []*entity.PublicMessage{msg}); err != nil {
+		return nil, err
+	}
+
+	if err = message.FillPublicAuthors(ctx, s.ProfileRepo, []*entity.PublicMessage{msg}); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// getChannelMessage returns message of channel to its member.
+func (s *Service) getChannelMessage(ctx context.Context, channelID, messageID int, username string) (*entity.PublicMessage, error) {
+	if err := s.checkMembership(ctx, channelID, username); err != nil {
+		return nil, err
+	}
+
+	msg, err := s.PublicMessageRepo.GetPublicMessage(ctx, messageID)
+	if err != nil {
+		return nil, err
+	}
+
+	// message of another channel is not visible through this one
+	if msg.ChannelID != channelID {
+		return nil, repository.ErrNoSuchPublicMessage
+	}
+
+	return msg, nil
+}
+
+// EditChannelMessage replaces content of channel message and keeps previous version in revision history.
+// Only author who is still a member of channel can edit message.
+func (s *Service) EditChannelMessage(ctx context.Context, channelID, messageID int, editorUsername, content string) (*entity.PublicMessage, error) {
+	msg, err := s.getChannelMessage(ctx, channelID, messageID, editorUsername)
+	if err != nil {
+		return nil, err
+	}
+
+	// only author can edit their message
+	if msg.FromUsername != editorUsername {
+		return nil, message.ErrNotMessageAuthor
+	}
+
+	if msg.IsDeleted() {
+		return nil, message.ErrMessageDeleted
+	}
+
+	// content not changed, thus no need for new revision
+	if msg.Content == content {
+		return msg, nil
+	}
+
+	updated, err := s.PublicMessageRepo.UpdatePublicMessage(ctx, msg.ID, entity.PublicMessage{Content: content})
+	if err != nil {
+		return nil, err
+	}
+
+	s.notify(ctx, updated, s.Notifier.NotifyPublicMessageEdited, s.Notifier.NotifyChannelMessageEdited)
+
+	return updated, nil
+}
+
+// GetChannelMessageRevisions returns edit history of channel message to channel members.
+func (s *Service) GetChannelMessageRevisions(ctx context.Context, channelID, messageID int, username string) ([]*entity.MessageRevision, error) {
+	msg, err := s.getChannelMessage(ctx, channelID, messageID, username)
+	if err != nil {
+		return nil, err
+	}
+
+	// history of deleted message is not exposed
+	if msg.IsDeleted() {
+		return nil, message.ErrMessageDeleted
+	}
+
+	return s.PublicMessageRepo.GetPublicMessageRevisions(ctx, msg.ID)
+}
+
+// DeleteChannelMessage leaves tombstone in place of channel message. Only author who is still a member
+// of channel can delete message.
+func (s *Service) DeleteChannelMessage(ctx context.Context, channelID, messageID int, username string) (*entity.PublicMessage, error) {
+	msg, err := s.getChannelMessage(ctx, channelID, messageID, username)
+	if err != nil {
+		return nil, err
+	}
+
+	// only author can delete their message
+	if msg.FromUsername != username {
+		return nil, message.ErrNotMessageAuthor
+	}
+
+	// already deleted, nothing to do
+	if msg.IsDeleted() {
+		return msg, nil
+	}
+
+	deleted, err := s.PublicMessageRepo.DeletePublicMessage(ctx, msg.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.notify(ctx, deleted, s.Notifier.NotifyPublicMessageDeleted, s.Notifier.NotifyChannelMessageDeleted)
+
+	return deleted, nil
+}
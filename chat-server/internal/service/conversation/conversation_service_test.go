@@ -0,0 +1,229 @@
+package conversation
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/mocks"
+)
+
+func TestConversationService_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	conversationRepoMock := mocks.NewMockConversationRepo(ctrl)
+	privateMessageRepoMock := mocks.NewMockConversationPrivateMessageRepo(ctrl)
+	userRepoMock := mocks.NewMockUserRepo(ctrl)
+	notifierMock := mocks.NewMockConversationNotifier(ctrl)
+
+	service := New(conversationRepoMock, privateMessageRepoMock, userRepoMock, notifierMock)
+
+	creator := "creator"
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		participants  []string
+		want          *entity.Conversation
+		wantErr       error
+	}{
+		{
+			name: "ok",
+			mockBehaviour: func() {
+				for _, username := range []string{"creator", "first", "second"} {
+					userRepoMock.EXPECT().GetUserByUsername(ctx, username).Return(&entity.User{Username: username}, nil)
+				}
+
+				conversationRepoMock.
+					EXPECT().
+					AddConversation(ctx, entity.Conversation{Title: "title", CreatedBy: &creator}).
+					Return(&entity.Conversation{ID: 1, Title: "title", CreatedBy: &creator, CreatedAt: now}, nil)
+
+				for _, username := range []string{"creator", "first", "second"} {
+					conversationRepoMock.EXPECT().AddConversationParticipant(ctx, 1, username).Return(nil)
+				}
+			},
+			participants: []string{"first", "second", "first", "creator"},
+			want:         &entity.Conversation{ID: 1, Title: "title", CreatedBy: &creator, CreatedAt: now},
+		},
+		{
+			name:          "err, not enough participants",
+			mockBehaviour: func() {},
+			participants:  []string{"first", "creator"},
+			wantErr:       ErrNotEnoughParticipants,
+		},
+		{
+			name: "err, no such participant",
+			mockBehaviour: func() {
+				userRepoMock.EXPECT().GetUserByUsername(ctx, "creator").Return(&entity.User{Username: "creator"}, nil)
+				userRepoMock.EXPECT().GetUserByUsername(ctx, "first").Return(nil, ErrNoSuchUser)
+			},
+			participants: []string{"first", "second"},
+			wantErr:      ErrNoSuchUser,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			got, err := service.CreateConversation(ctx, entity.Conversation{Title: "title"}, creator, test.participants)
+
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
+			}
+		})
+	}
+}
+
+func TestConversationService_RemoveParticipant(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	conversationRepoMock := mocks.NewMockConversationRepo(ctrl)
+	privateMessageRepoMock := mocks.NewMockConversationPrivateMessageRepo(ctrl)
+	userRepoMock := mocks.NewMockUserRepo(ctrl)
+	notifierMock := mocks.NewMockConversationNotifier(ctrl)
+
+	service := New(conversationRepoMock, privateMessageRepoMock, userRepoMock, notifierMock)
+
+	creator := "creator"
+	conversation := &entity.Conversation{ID: 1, CreatedBy: &creator}
+
+	type inputArgs struct {
+		actor    string
+		username string
+	}
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		input         inputArgs
+		wantErr       error
+	}{
+		{
+			name: "ok, participant leaves",
+			mockBehaviour: func() {
+				conversationRepoMock.EXPECT().GetConversation(ctx, 1).Return(conversation, nil)
+				conversationRepoMock.EXPECT().IsConversationParticipant(ctx, 1, "first").Return(true, nil)
+				conversationRepoMock.EXPECT().RemoveConversationParticipant(ctx, 1, "first").Return(nil)
+			},
+			input: inputArgs{actor: "first", username: "first"},
+		},
+		{
+			name: "ok, creator removes participant",
+			mockBehaviour: func() {
+				conversationRepoMock.EXPECT().GetConversation(ctx, 1).Return(conversation, nil).Times(3)
+				conversationRepoMock.EXPECT().IsConversationParticipant(ctx, 1, "creator").Return(true, nil)
+				conversationRepoMock.EXPECT().IsConversationParticipant(ctx, 1, "first").Return(true, nil)
+				conversationRepoMock.EXPECT().RemoveConversationParticipant(ctx, 1, "first").Return(nil)
+			},
+			input: inputArgs{actor: "creator", username: "first"},
+		},
+		{
+			name: "err, not creator removes participant",
+			mockBehaviour: func() {
+				conversationRepoMock.EXPECT().GetConversation(ctx, 1).Return(conversation, nil).Times(2)
+				conversationRepoMock.EXPECT().IsConversationParticipant(ctx, 1, "second").Return(true, nil)
+			},
+			input:   inputArgs{actor: "second", username: "first"},
+			wantErr: ErrNotConversationCreator,
+		},
+		{
+			name: "err, not a participant",
+			mockBehaviour: func() {
+				conversationRepoMock.EXPECT().GetConversation(ctx, 1).Return(conversation, nil)
+				conversationRepoMock.EXPECT().IsConversationParticipant(ctx, 1, "stranger").Return(false, nil)
+			},
+			input:   inputArgs{actor: "stranger", username: "stranger"},
+			wantErr: ErrNotConversationParticipant,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			err := service.RemoveParticipant(ctx, 1, test.input.actor, test.input.username)
+
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConversationService_GetConversationList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	conversationRepoMock := mocks.NewMockConversationRepo(ctrl)
+	privateMessageRepoMock := mocks.NewMockConversationPrivateMessageRepo(ctrl)
+	userRepoMock := mocks.NewMockUserRepo(ctrl)
+	notifierMock := mocks.NewMockConversationNotifier(ctrl)
+
+	service := New(conversationRepoMock, privateMessageRepoMock, userRepoMock, notifierMock)
+
+	privateMessageRepoMock.
+		EXPECT().
+		GetAllPrivateMessages(ctx, 0, math.MaxInt64).
+		Return([]*entity.PrivateMessage{
+			{ID: 1, FromUsername: "first", ToUsername: "username", SentAt: now.Add(-3 * time.Minute)},
+			{ID: 2, FromUsername: "username", ToUsername: "first", SentAt: now.Add(-time.Minute)},
+			{ID: 3, FromUsername: "second", ToUsername: "third", SentAt: now},
+			{ID: 4, FromUsername: "username", ToUsername: "second", SentAt: now.Add(-4 * time.Minute)},
+		})
+
+	conversationRepoMock.
+		EXPECT().
+		GetUserConversations(ctx, "username").
+		Return([]*entity.Conversation{{ID: 1, Title: "group", CreatedAt: now.Add(-time.Hour)}}, nil)
+
+	conversationRepoMock.
+		EXPECT().
+		GetConversationParticipants(ctx, 1).
+		Return([]*entity.ConversationParticipant{{ConversationID: 1, Username: "username"}, {ConversationID: 1, Username: "first"}, {ConversationID: 1, Username: "second"}}, nil)
+
+	conversationRepoMock.
+		EXPECT().
+		GetLastConversationMessage(ctx, 1).
+		Return(&entity.ConversationMessage{ID: 1, ConversationID: 1, SentAt: now.Add(-2 * time.Minute)}, nil)
+
+	got, err := service.GetConversationList(ctx, "username", 0, math.MaxInt64)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.ConversationSummary{
+		{
+			Kind:          entity.ConversationKindDirect,
+			Counterpart:   "first",
+			Participants:  []string{"username", "first"},
+			LastMessageAt: now.Add(-time.Minute),
+		},
+		{
+			Kind:           entity.ConversationKindGroup,
+			ConversationID: 1,
+			Title:          "group",
+			Participants:   []string{"username", "first", "second"},
+			LastMessageAt:  now.Add(-2 * time.Minute),
+		},
+		{
+			Kind:          entity.ConversationKindDirect,
+			Counterpart:   "second",
+			Participants:  []string{"username", "second"},
+			LastMessageAt: now.Add(-4 * time.Minute),
+		},
+	}, got)
+}
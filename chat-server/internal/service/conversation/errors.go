@@ -0,0 +1,10 @@
+package conversation
+
+import "errors"
+
+var (
+	ErrNoSuchUser                 = errors.New("no such user")
+	ErrNotConversationParticipant = errors.New("user is not a participant of conversation")
+	ErrNotConversationCreator     = errors.New("only creator of conversation can remove other participants")
+	ErrNotEnoughParticipants      = errors.New("group conversation needs at least two participants besides creator")
+)
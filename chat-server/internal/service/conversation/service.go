@@ -0,0 +1,280 @@
+package conversation
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+
+	sliceutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/slice"
+)
+
+//go:generate mockgen -destination=../../mocks/conversation_repository.go -package=mocks github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/conversation ConversationRepo
+//go:generate mockgen -destination=../../mocks/conversation_private_message_repository.go -package=mocks -mock_names=PrivateMessageRepo=MockConversationPrivateMessageRepo github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/conversation PrivateMessageRepo
+//go:generate mockgen -destination=../../mocks/conversation_notifier.go -package=mocks -mock_names=Notifier=MockConversationNotifier github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/conversation Notifier
+
+type ConversationRepo interface {
+	AddConversation(ctx context.Context, conversation entity.Conversation) (*entity.Conversation, error)
+	GetConversation(ctx context.Context, id int) (*entity.Conversation, error)
+	GetUserConversations(ctx context.Context, username string) ([]*entity.Conversation, error)
+	AddConversationParticipant(ctx context.Context, conversationID int, username string) error
+	RemoveConversationParticipant(ctx context.Context, conversationID int, username string) error
+	GetConversationParticipants(ctx context.Context, conversationID int) ([]*entity.ConversationParticipant, error)
+	IsConversationParticipant(ctx context.Context, conversationID int, username string) (bool, error)
+	AddConversationMessage(ctx context.Context, msg entity.ConversationMessage) (*entity.ConversationMessage, error)
+	GetConversationMessages(ctx context.Context, conversationID, offset, limit int) []*entity.ConversationMessage
+	GetLastConversationMessage(ctx context.Context, conversationID int) (*entity.ConversationMessage, error)
+}
+
+type PrivateMessageRepo interface {
+	GetAllPrivateMessages(ctx context.Context, offset, limit int) []*entity.PrivateMessage
+}
+
+type UserRepo interface {
+	GetUserByUsername(ctx context.Context, username string) (*entity.User, error)
+}
+
+type Notifier interface {
+	NotifyConversationMessage(ctx context.Context, msg *entity.ConversationMessage, participants []string)
+}
+
+type Service struct {
+	ConversationRepo   ConversationRepo
+	PrivateMessageRepo PrivateMessageRepo
+	UserRepo           UserRepo
+	Notifier           Notifier
+}
+
+func New(conversationRepo ConversationRepo, privateMessageRepo PrivateMessageRepo, userRepo UserRepo, notifier Notifier) *Service {
+	return &Service{
+		ConversationRepo:   conversationRepo,
+		PrivateMessageRepo: privateMessageRepo,
+		UserRepo:           userRepo,
+		Notifier:           notifier,
+	}
+}
+
+func (s *Service) checkParticipant(ctx context.Context, conversationID int, username string) error {
+	if _, err := s.ConversationRepo.GetConversation(ctx, conversationID); err != nil {
+		return err
+	}
+
+	isParticipant, err := s.ConversationRepo.IsConversationParticipant(ctx, conversationID, username)
+	if err != nil {
+		return err
+	}
+
+	if !isParticipant {
+		return ErrNotConversationParticipant
+	}
+
+	return nil
+}
+
+func (s *Service) participantUsernames(ctx context.Context, conversationID int) ([]string, error) {
+	participants, err := s.ConversationRepo.GetConversationParticipants(ctx, conversationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return sliceutils.Map(participants, func(p *entity.ConversationParticipant) string { return p.Username }), nil
+}
+
+// CreateConversation creates group conversation of creator and provided participants.
+func (s *Service) CreateConversation(ctx context.Context, conversation entity.Conversation, creatorUsername string, participants []string) (*entity.Conversation, error) {
+	participants = sliceutils.Unique(sliceutils.Filter(participants, func(p string) bool { return p != creatorUsername }))
+
+	// conversation of two users is a plain private chat
+	if len(participants) < 2 {
+		return nil, ErrNotEnoughParticipants
+	}
+
+	for _, username := range append([]string{creatorUsername}, participants...) {
+		if _, err := s.UserRepo.GetUserByUsername(ctx, username); err != nil {
+			return nil, ErrNoSuchUser
+		}
+	}
+
+	conversation.CreatedBy = &creatorUsername
+
+	created, err := s.ConversationRepo.AddConversation(ctx, conversation)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, username := range append([]string{creatorUsername}, participants...) {
+		if err = s.ConversationRepo.AddConversationParticipant(ctx, created.ID, username); err != nil {
+			return nil, err
+		}
+	}
+
+	return created, nil
+}
+
+func (s *Service) GetConversation(ctx context.Context, id int, username string) (*entity.Conversation, []string, error) {
+	if err := s.checkParticipant(ctx, id, username); err != nil {
+		return nil, nil, err
+	}
+
+	conversation, err := s.ConversationRepo.GetConversation(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	participants, err := s.participantUsernames(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return conversation, participants, nil
+}
+
+// AddParticipant adds user to conversation. Any participant can invite new ones.
+func (s *Service) AddParticipant(ctx context.Context, id int, actorUsername, username string) error {
+	if err := s.checkParticipant(ctx, id, actorUsername); err != nil {
+		return err
+	}
+
+	if _, err := s.UserRepo.GetUserByUsername(ctx, username); err != nil {
+		return ErrNoSuchUser
+	}
+
+	return s.ConversationRepo.AddConversationParticipant(ctx, id, username)
+}
+
+// RemoveParticipant removes user from conversation. Participants can leave by removing themselves,
+// other participants can be removed only by creator of conversation.
+func (s *Service) RemoveParticipant(ctx context.Context, id int, actorUsername, username string) error {
+	if err := s.checkParticipant(ctx, id, actorUsername); err != nil {
+		return err
+	}
+
+	if actorUsername != username {
+		conversation, err := s.ConversationRepo.GetConversation(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if conversation.CreatedBy == nil || *conversation.CreatedBy != actorUsername {
+			return ErrNotConversationCreator
+		}
+
+		if err = s.checkParticipant(ctx, id, username); err != nil {
+			return err
+		}
+	}
+
+	return s.ConversationRepo.RemoveConversationParticipant(ctx, id, username)
+}
+
+func (s *Service) LeaveConversation(ctx context.Context, id int, username string) error {
+	return s.RemoveParticipant(ctx, id, username, username)
+}
+
+func (s *Service) SendConversationMessage(ctx context.Context, msg entity.ConversationMessage) (*entity.ConversationMessage, error) {
+	if err := s.checkParticipant(ctx, msg.ConversationID, msg.FromUsername); err != nil {
+		return nil, err
+	}
+
+	created, err := s.ConversationRepo.AddConversationMessage(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	participants, err := s.participantUsernames(ctx, msg.ConversationID)
+	if err != nil {
+		return created, nil
+	}
+
+	s.Notifier.NotifyConversationMessage(ctx, created, participants)
+
+	return created, nil
+}
+
+func (s *Service) GetConversationMessages(ctx context.Context, id int, username string, offset, limit int) ([]*entity.ConversationMessage, error) {
+	if err := s.checkParticipant(ctx, id, username); err != nil {
+		return nil, err
+	}
+
+	return s.ConversationRepo.GetConversationMessages(ctx, id, offset, limit), nil
+}
+
+// GetConversationList returns direct and group conversations of user, most recently active first.
+func (s *Service) GetConversationList(ctx context.Context, username string, offset, limit int) ([]*entity.ConversationSummary, error) {
+	summaries := s.directConversations(ctx, username)
+
+	conversations, err := s.ConversationRepo.GetUserConversations(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, conversation := range conversations {
+		participants, err := s.participantUsernames(ctx, conversation.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		lastMessage, err := s.ConversationRepo.GetLastConversationMessage(ctx, conversation.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		// conversation without messages is ordered by its creation time
+		lastActivity := conversation.CreatedAt
+		if lastMessage != nil {
+			lastActivity = lastMessage.SentAt
+		}
+
+		summaries = append(summaries, &entity.ConversationSummary{
+			Kind:           entity.ConversationKindGroup,
+			ConversationID: conversation.ID,
+			Title:          conversation.Title,
+			Participants:   participants,
+			LastMessageAt:  lastActivity,
+		})
+	}
+
+	sort.SliceStable(summaries, func(i, j int) bool { return summaries[i].LastMessageAt.After(summaries[j].LastMessageAt) })
+
+	return sliceutils.Slice(summaries, offset, limit), nil
+}
+
+// directConversations groups private messages of user by counterpart.
+func (s *Service) directConversations(ctx context.Context, username string) []*entity.ConversationSummary {
+	messages := s.PrivateMessageRepo.GetAllPrivateMessages(ctx, 0, math.MaxInt64)
+
+	byCounterpart := make(map[string]*entity.ConversationSummary)
+	summaries := make([]*entity.ConversationSummary, 0)
+
+	for _, msg := range messages {
+		var counterpart string
+
+		switch username {
+		case msg.ToUsername:
+			counterpart = msg.FromUsername
+		case msg.FromUsername:
+			counterpart = msg.ToUsername
+		default:
+			continue
+		}
+
+		summary, ok := byCounterpart[counterpart]
+		if !ok {
+			summary = &entity.ConversationSummary{
+				Kind:         entity.ConversationKindDirect,
+				Counterpart:  counterpart,
+				Participants: sliceutils.Unique([]string{username, counterpart}),
+			}
+
+			byCounterpart[counterpart] = summary
+			summaries = append(summaries, summary)
+		}
+
+		if msg.SentAt.After(summary.LastMessageAt) {
+			summary.LastMessageAt = msg.SentAt
+		}
+	}
+
+	return summaries
+}
@@ -0,0 +1,64 @@
+package mention
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/mocks"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+)
+
+func TestMentionService_GetMentions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	mentionRepoMock := mocks.NewMockMentionRepo(ctrl)
+	publicRepoMock := mocks.NewMockMentionPublicMessageRepo(ctrl)
+	privateRepoMock := mocks.NewMockMentionPrivateMessageRepo(ctrl)
+
+	service := New(mentionRepoMock, publicRepoMock, privateRepoMock)
+
+	deletedAt := time.Now()
+
+	mentionRepoMock.EXPECT().GetUserMentions(ctx, "bob", true, 0, 10).Return([]*entity.Mention{
+		{ID: 4, MessageKind: entity.MessageKindPrivate, MessageID: 2, Username: "bob"},
+		{ID: 3, MessageKind: entity.MessageKindPublic, MessageID: 7, Username: "bob"},
+		{ID: 2, MessageKind: entity.MessageKindPublic, MessageID: 5, Username: "bob"},
+		{ID: 1, MessageKind: entity.MessageKindPublic, MessageID: 1, Username: "bob"},
+	}, nil)
+	privateRepoMock.EXPECT().GetPrivateMessage(ctx, 2).Return(&entity.PrivateMessage{ID: 2, Content: "hey @bob"}, nil)
+	publicRepoMock.EXPECT().GetPublicMessage(ctx, 7).Return(&entity.PublicMessage{ID: 7, Content: "@bob look"}, nil)
+	publicRepoMock.EXPECT().GetPublicMessage(ctx, 5).Return(nil, repository.ErrNoSuchPublicMessage)
+	publicRepoMock.EXPECT().GetPublicMessage(ctx, 1).Return(&entity.PublicMessage{ID: 1, Content: "@bob", DeletedAt: &deletedAt}, nil)
+
+	mentions, err := service.GetMentions(ctx, "bob", true, 0, 10)
+	require.NoError(t, err)
+
+	// mention of purged message is left out
+	require.Len(t, mentions, 3)
+	assert.Equal(t, "hey @bob", mentions[0].Content)
+	assert.Equal(t, "@bob look", mentions[1].Content)
+	assert.False(t, mentions[1].MessageDeleted)
+	assert.True(t, mentions[2].MessageDeleted)
+}
+
+func TestMentionService_MarkMentionsRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	mentionRepoMock := mocks.NewMockMentionRepo(ctrl)
+
+	service := New(mentionRepoMock, mocks.NewMockMentionPublicMessageRepo(ctrl), mocks.NewMockMentionPrivateMessageRepo(ctrl))
+
+	mentionRepoMock.EXPECT().MarkMentionsRead(ctx, "bob", 3, gomock.Any()).Return(2, nil)
+
+	marked, err := service.MarkMentionsRead(ctx, "bob", 3)
+	require.NoError(t, err)
+	assert.Equal(t, 2, marked)
+}
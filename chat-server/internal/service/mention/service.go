@@ -0,0 +1,93 @@
+package mention
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+)
+
+//go:generate mockgen -destination=../../mocks/mention_repository.go -package=mocks github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/mention MentionRepo
+//go:generate mockgen -destination=../../mocks/mention_public_message_repository.go -package=mocks -mock_names=PublicMessageRepo=MockMentionPublicMessageRepo github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/mention PublicMessageRepo
+//go:generate mockgen -destination=../../mocks/mention_private_message_repository.go -package=mocks -mock_names=PrivateMessageRepo=MockMentionPrivateMessageRepo github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/mention PrivateMessageRepo
+
+type MentionRepo interface {
+	GetUserMentions(ctx context.Context, username string, unreadOnly bool, offset, limit int) ([]*entity.Mention, error)
+	MarkMentionsRead(ctx context.Context, username string, upToID int, readAt time.Time) (int, error)
+}
+
+type PublicMessageRepo interface {
+	GetPublicMessage(ctx context.Context, id int) (*entity.PublicMessage, error)
+}
+
+type PrivateMessageRepo interface {
+	GetPrivateMessage(ctx context.Context, id int) (*entity.PrivateMessage, error)
+}
+
+type Service struct {
+	MentionRepo        MentionRepo
+	PublicMessageRepo  PublicMessageRepo
+	PrivateMessageRepo PrivateMessageRepo
+}
+
+func New(mentionRepo MentionRepo, publicMessageRepo PublicMessageRepo, privateMessageRepo PrivateMessageRepo) *Service {
+	return &Service{
+		MentionRepo:        mentionRepo,
+		PublicMessageRepo:  publicMessageRepo,
+		PrivateMessageRepo: privateMessageRepo,
+	}
+}
+
+// GetMentions returns mentions of user, newest first, with content of mentioning messages.
+// Mentions of purged messages are left out.
+func (s *Service) GetMentions(ctx context.Context, username string, unreadOnly bool, offset, limit int) ([]*entity.Mention, error) {
+	mentions, err := s.MentionRepo.GetUserMentions(ctx, username, unreadOnly, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	filled := make([]*entity.Mention, 0, len(mentions))
+
+	for _, mention := range mentions {
+		err = s.fillContent(ctx, mention)
+		if errors.Is(err, repository.ErrNoSuchPublicMessage) || errors.Is(err, repository.ErrNoSuchPrivateMessage) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		filled = append(filled, mention)
+	}
+
+	return filled, nil
+}
+
+func (s *Service) fillContent(ctx context.Context, mention *entity.Mention) error {
+	switch mention.MessageKind {
+	case entity.MessageKindPublic:
+		msg, err := s.PublicMessageRepo.GetPublicMessage(ctx, mention.MessageID)
+		if err != nil {
+			return err
+		}
+
+		mention.Content, mention.MessageDeleted = msg.Content, msg.IsDeleted()
+	case entity.MessageKindPrivate:
+		msg, err := s.PrivateMessageRepo.GetPrivateMessage(ctx, mention.MessageID)
+		if err != nil {
+			return err
+		}
+
+		mention.Content, mention.MessageDeleted = msg.Content, msg.IsDeleted()
+	}
+
+	return nil
+}
+
+// MarkMentionsRead marks mentions of user up to provided id as read and returns count of newly read ones.
+func (s *Service) MarkMentionsRead(ctx context.Context, username string, upToID int) (int, error) {
+	return s.MentionRepo.MarkMentionsRead(ctx, username, upToID, time.Now())
+}
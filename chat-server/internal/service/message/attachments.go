@@ -0,0 +1,115 @@
+package message
+
+import (
+	"context"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+
+	sliceutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/slice"
+)
+
+//go:generate mockgen -destination=../../mocks/message_attachment_repository.go -package=mocks -mock_names=AttachmentRepo=MockMessageAttachmentRepo github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message AttachmentRepo
+
+type AttachmentRepo interface {
+	GetAttachments(ctx context.Context, ids []int) ([]*entity.Attachment, error)
+	LinkAttachments(ctx context.Context, kind entity.MessageKind, messageID int, ids []int) error
+	GetMessageAttachments(ctx context.Context, kind entity.MessageKind, messageIDs []int) ([]*entity.Attachment, error)
+}
+
+// TakeAttachmentIDs returns ids of attachments set on message being sent and clears them,
+// attachments are not stored with message but linked to it after it is created.
+func TakeAttachmentIDs(attachments *[]entity.Attachment) []int {
+	ids := sliceutils.Map(*attachments, func(a entity.Attachment) int { return a.ID })
+
+	*attachments = nil
+
+	return sliceutils.Unique(ids)
+}
+
+// CheckAttachments validates that user can send attachments: they exist, were uploaded by user and were not sent yet.
+func CheckAttachments(ctx context.Context, repo AttachmentRepo, ids []int, username string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	attachments, err := repo.GetAttachments(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	if len(attachments) != len(ids) {
+		return ErrInvalidAttachment
+	}
+
+	for _, attachment := range attachments {
+		if attachment.UploadedBy != username || attachment.IsLinked() {
+			return ErrInvalidAttachment
+		}
+	}
+
+	return nil
+}
+
+// LinkAttachments links checked attachments to created message and returns them.
+func LinkAttachments(ctx context.Context, repo AttachmentRepo, kind entity.MessageKind, messageID int, ids []int) ([]entity.Attachment, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	if err := repo.LinkAttachments(ctx, kind, messageID, ids); err != nil {
+		return nil, err
+	}
+
+	attachments, err := repo.GetMessageAttachments(ctx, kind, []int{messageID})
+	if err != nil {
+		return nil, err
+	}
+
+	return sliceutils.Map(attachments, func(a *entity.Attachment) entity.Attachment { return *a }), nil
+}
+
+func groupAttachments(attachments []*entity.Attachment) map[int][]entity.Attachment {
+	grouped := make(map[int][]entity.Attachment)
+
+	for _, attachment := range attachments {
+		grouped[*attachment.MessageID] = append(grouped[*attachment.MessageID], *attachment)
+	}
+
+	return grouped
+}
+
+// FillPublicAttachments sets Attachments of provided messages, attachments of deleted messages are hidden.
+func FillPublicAttachments(ctx context.Context, repo AttachmentRepo, messages []*entity.PublicMessage) error {
+	attachments, err := repo.GetMessageAttachments(ctx, entity.MessageKindPublic, sliceutils.Map(messages, func(m *entity.PublicMessage) int { return m.ID }))
+	if err != nil {
+		return err
+	}
+
+	grouped := groupAttachments(attachments)
+
+	for _, msg := range messages {
+		if !msg.IsDeleted() {
+			msg.Attachments = grouped[msg.ID]
+		}
+	}
+
+	return nil
+}
+
+// FillPrivateAttachments sets Attachments of provided messages, attachments of deleted messages are hidden.
+func FillPrivateAttachments(ctx context.Context, repo AttachmentRepo, messages []*entity.PrivateMessage) error {
+	attachments, err := repo.GetMessageAttachments(ctx, entity.MessageKindPrivate, sliceutils.Map(messages, func(m *entity.PrivateMessage) int { return m.ID }))
+	if err != nil {
+		return err
+	}
+
+	grouped := groupAttachments(attachments)
+
+	for _, msg := range messages {
+		if !msg.IsDeleted() {
+			msg.Attachments = grouped[msg.ID]
+		}
+	}
+
+	return nil
+}
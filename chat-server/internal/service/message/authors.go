@@ -0,0 +1,37 @@
+package message
+
+import (
+	"context"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+
+	sliceutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/slice"
+)
+
+//go:generate mockgen -destination=../../mocks/message_profile_repository.go -package=mocks -mock_names=ProfileRepo=MockMessageProfileRepo github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message ProfileRepo
+
+type ProfileRepo interface {
+	GetProfilesByUsernames(ctx context.Context, usernames []string) ([]*entity.Profile, error)
+}
+
+// FillPublicAuthors sets AuthorProfile of provided messages, it stays nil for authors who have not filled profile.
+func FillPublicAuthors(ctx context.Context, repo ProfileRepo, messages []*entity.PublicMessage) error {
+	usernames := sliceutils.Unique(sliceutils.Map(messages, func(m *entity.PublicMessage) string { return m.FromUsername }))
+
+	profiles, err := repo.GetProfilesByUsernames(ctx, usernames)
+	if err != nil {
+		return err
+	}
+
+	byUsername := make(map[string]*entity.Profile, len(profiles))
+
+	for _, profile := range profiles {
+		byUsername[profile.Username] = profile
+	}
+
+	for _, msg := range messages {
+		msg.AuthorProfile = byUsername[msg.FromUsername]
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package message
+
+import (
+	"context"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+
+	sliceutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/slice"
+)
+
+//go:generate mockgen -destination=../../mocks/message_block_repository.go -package=mocks -mock_names=BlockRepo=MockMessageBlockRepo github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message BlockRepo
+
+type BlockRepo interface {
+	GetBlocks(ctx context.Context, username string) ([]*entity.Block, error)
+	IsBlocked(ctx context.Context, username, blockedUsername string) (bool, error)
+}
+
+// CheckNotBlocked returns ErrSenderBlocked if receiver has blocked sender.
+func CheckNotBlocked(ctx context.Context, repo BlockRepo, senderUsername, receiverUsername string) error {
+	blocked, err := repo.IsBlocked(ctx, receiverUsername, senderUsername)
+	if err != nil {
+		return err
+	}
+
+	if blocked {
+		return ErrSenderBlocked
+	}
+
+	return nil
+}
+
+// BlockedUsernames returns usernames of users blocked by user.
+func BlockedUsernames(ctx context.Context, repo BlockRepo, username string) ([]string, error) {
+	blocks, err := repo.GetBlocks(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	return sliceutils.Map(blocks, func(b *entity.Block) string { return b.BlockedUsername }), nil
+}
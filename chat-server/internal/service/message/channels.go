@@ -0,0 +1,26 @@
+package message
+
+import (
+	"context"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+
+	sliceutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/slice"
+)
+
+//go:generate mockgen -destination=../../mocks/message_channel_repository.go -package=mocks -mock_names=ChannelRepo=MockMessageChannelRepo github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message ChannelRepo
+
+// ChannelRepo gives members of channel, events of channels other than general are delivered only to them.
+type ChannelRepo interface {
+	GetChannelMembers(ctx context.Context, channelID int) ([]*entity.ChannelMember, error)
+}
+
+// ChannelMemberUsernames returns usernames of members of channel.
+func ChannelMemberUsernames(ctx context.Context, repo ChannelRepo, channelID int) ([]string, error) {
+	members, err := repo.GetChannelMembers(ctx, channelID)
+	if err != nil {
+		return nil, err
+	}
+
+	return sliceutils.Map(members, func(m *entity.ChannelMember) string { return m.Username }), nil
+}
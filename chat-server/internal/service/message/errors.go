@@ -5,4 +5,13 @@ import "errors"
 var (
 	ErrNoSuchReceiver = errors.New("no such receiver")
 	ErrNoSuchSender   = errors.New("no such sender")
+	ErrSenderBlocked  = errors.New("receiver has blocked sender")
+
+	ErrNotMessageAuthor      = errors.New("user is not an author of message")
+	ErrNotMessageParticipant = errors.New("user is not a participant of message")
+	ErrMessageDeleted        = errors.New("message was deleted")
+
+	ErrInvalidParentMessage = errors.New("parent message belongs to another conversation")
+
+	ErrInvalidAttachment = errors.New("attachment does not exist, was uploaded by another user or was already sent")
 )
@@ -0,0 +1,211 @@
+package message
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+
+	sliceutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/slice"
+)
+
+//go:generate mockgen -destination=../../mocks/message_mention_repository.go -package=mocks -mock_names=MentionRepo=MockMessageMentionRepo github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message MentionRepo
+
+type MentionRepo interface {
+	AddMentions(ctx context.Context, mentions []entity.Mention) ([]*entity.Mention, error)
+	GetMessageMentions(ctx context.Context, kind entity.MessageKind, messageIDs []int) ([]*entity.Mention, error)
+}
+
+// MentionUserRepo resolves mentioned usernames, only mentions of existing users are recorded.
+type MentionUserRepo interface {
+	GetUserByUsername(ctx context.Context, username string) (*entity.User, error)
+}
+
+func isUsernameRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '-' || r == '.'
+}
+
+// ParseMentions returns spans of @username in content. Mention must not follow username character,
+// so emails are not taken for mentions, and trailing dots are left out as end of sentence.
+func ParseMentions(content string) []entity.MentionSpan {
+	runes := []rune(content)
+	spans := make([]entity.MentionSpan, 0)
+
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '@' || (i > 0 && isUsernameRune(runes[i-1])) {
+			continue
+		}
+
+		end := i + 1
+		for end < len(runes) && isUsernameRune(runes[end]) {
+			end++
+		}
+
+		for end > i+1 && runes[end-1] == '.' {
+			end--
+		}
+
+		if end == i+1 {
+			continue
+		}
+
+		spans = append(spans, entity.MentionSpan{
+			Username: string(runes[i+1 : end]),
+			Offset:   i,
+			Length:   end - i,
+		})
+
+		i = end - 1
+	}
+
+	return spans
+}
+
+// RecordMentions stores mentions of existing users in content of sent message, mentions of its author
+// and of users rejected by canMention are skipped. It returns recorded mentions and spans of them in content.
+func RecordMentions(
+	ctx context.Context,
+	mentionRepo MentionRepo,
+	userRepo MentionUserRepo,
+	kind entity.MessageKind,
+	messageID int,
+	fromUsername, content string,
+	canMention func(ctx context.Context, username string) (bool, error),
+) ([]*entity.Mention, []entity.MentionSpan, error) {
+	spans := ParseMentions(content)
+	if len(spans) == 0 {
+		return nil, nil, nil
+	}
+
+	mentions := make([]entity.Mention, 0)
+
+	for _, username := range sliceutils.Unique(sliceutils.Map(spans, func(s entity.MentionSpan) string { return s.Username })) {
+		if username == fromUsername {
+			continue
+		}
+
+		_, err := userRepo.GetUserByUsername(ctx, username)
+		if errors.Is(err, repository.ErrNoSuchUser) {
+			continue
+		}
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		allowed, err := canMention(ctx, username)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if !allowed {
+			continue
+		}
+
+		mentions = append(mentions, entity.Mention{
+			MessageKind:  kind,
+			MessageID:    messageID,
+			Username:     username,
+			FromUsername: fromUsername,
+		})
+	}
+
+	if len(mentions) == 0 {
+		return nil, nil, nil
+	}
+
+	recorded, err := mentionRepo.AddMentions(ctx, mentions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, mention := range recorded {
+		mention.Content = content
+	}
+
+	return recorded, mentionedSpans(content, recorded), nil
+}
+
+// mentionedSpans returns spans of content that refer to recorded mentions.
+func mentionedSpans(content string, mentions []*entity.Mention) []entity.MentionSpan {
+	mentioned := make(map[string]struct{}, len(mentions))
+
+	for _, mention := range mentions {
+		mentioned[mention.Username] = struct{}{}
+	}
+
+	return sliceutils.Filter(ParseMentions(content), func(s entity.MentionSpan) bool {
+		_, ok := mentioned[s.Username]
+		return ok
+	})
+}
+
+// mentionCandidates returns ids of messages that may contain mentions, others are not looked up.
+func mentionCandidates[T any](messages []T, content func(T) string, id func(T) int) []int {
+	return sliceutils.Map(
+		sliceutils.Filter(messages, func(m T) bool { return strings.ContainsRune(content(m), '@') }),
+		id)
+}
+
+func groupMentions(mentions []*entity.Mention) map[int][]*entity.Mention {
+	grouped := make(map[int][]*entity.Mention)
+
+	for _, mention := range mentions {
+		grouped[mention.MessageID] = append(grouped[mention.MessageID], mention)
+	}
+
+	return grouped
+}
+
+// FillPublicMentions sets Mentions of provided messages, mentions of deleted messages are hidden.
+func FillPublicMentions(ctx context.Context, repo MentionRepo, messages []*entity.PublicMessage) error {
+	ids := mentionCandidates(messages,
+		func(m *entity.PublicMessage) string { return m.Content },
+		func(m *entity.PublicMessage) int { return m.ID })
+	if len(ids) == 0 {
+		return nil
+	}
+
+	mentions, err := repo.GetMessageMentions(ctx, entity.MessageKindPublic, ids)
+	if err != nil {
+		return err
+	}
+
+	grouped := groupMentions(mentions)
+
+	for _, msg := range messages {
+		if !msg.IsDeleted() && len(grouped[msg.ID]) > 0 {
+			msg.Mentions = mentionedSpans(msg.Content, grouped[msg.ID])
+		}
+	}
+
+	return nil
+}
+
+// FillPrivateMentions sets Mentions of provided messages, mentions of deleted messages are hidden.
+func FillPrivateMentions(ctx context.Context, repo MentionRepo, messages []*entity.PrivateMessage) error {
+	ids := mentionCandidates(messages,
+		func(m *entity.PrivateMessage) string { return m.Content },
+		func(m *entity.PrivateMessage) int { return m.ID })
+	if len(ids) == 0 {
+		return nil
+	}
+
+	mentions, err := repo.GetMessageMentions(ctx, entity.MessageKindPrivate, ids)
+	if err != nil {
+		return err
+	}
+
+	grouped := groupMentions(mentions)
+
+	for _, msg := range messages {
+		if !msg.IsDeleted() && len(grouped[msg.ID]) > 0 {
+			msg.Mentions = mentionedSpans(msg.Content, grouped[msg.ID])
+		}
+	}
+
+	return nil
+}
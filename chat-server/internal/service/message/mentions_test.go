@@ -0,0 +1,60 @@
+package message
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+)
+
+func TestParseMentions(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []entity.MentionSpan
+	}{
+		{
+			name:    "no mentions",
+			content: "hello there",
+			want:    []entity.MentionSpan{},
+		},
+		{
+			name:    "mentions at start and in the middle",
+			content: "@alice ping @bob_1",
+			want: []entity.MentionSpan{
+				{Username: "alice", Offset: 0, Length: 6},
+				{Username: "bob_1", Offset: 12, Length: 6},
+			},
+		},
+		{
+			name:    "email is not a mention",
+			content: "mail me at bob@mail.com",
+			want:    []entity.MentionSpan{},
+		},
+		{
+			name:    "trailing dot and punctuation are left out",
+			content: "thanks @j.doe. (@bob)",
+			want: []entity.MentionSpan{
+				{Username: "j.doe", Offset: 7, Length: 6},
+				{Username: "bob", Offset: 16, Length: 4},
+			},
+		},
+		{
+			name:    "offsets are counted in characters",
+			content: "привет @bob",
+			want:    []entity.MentionSpan{{Username: "bob", Offset: 7, Length: 4}},
+		},
+		{
+			name:    "lone at sign",
+			content: "@ @@",
+			want:    []entity.MentionSpan{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, ParseMentions(test.content))
+		})
+	}
+}
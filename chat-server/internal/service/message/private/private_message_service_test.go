@@ -11,6 +11,7 @@ import (
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/mocks"
 	repoerrors "github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message"
 	sliceutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/slice"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -23,9 +24,14 @@ func TestPrivateMessageService_Send(t *testing.T) {
 	ctx := context.Background()
 
 	msgRepoMock := mocks.NewMockPrivateMessageRepo(ctrl)
+	reactionRepoMock := mocks.NewMockReactionRepo(ctrl)
+	attachmentRepoMock := mocks.NewMockMessageAttachmentRepo(ctrl)
+	blockRepoMock := mocks.NewMockMessageBlockRepo(ctrl)
+	mentionRepoMock := mocks.NewMockMessageMentionRepo(ctrl)
 	userRepoMock := mocks.NewMockUserRepo(ctrl)
+	notifierMock := mocks.NewMockPrivateMessageNotifier(ctrl)
 
-	service := New(msgRepoMock, userRepoMock)
+	service := New(msgRepoMock, reactionRepoMock, attachmentRepoMock, blockRepoMock, mentionRepoMock, userRepoMock, testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
 
 	type inputArgs = entity.PrivateMessage
 	type outputArg = *entity.PrivateMessage
@@ -64,6 +70,11 @@ func TestPrivateMessageService_Send(t *testing.T) {
 						UpdatedAt:      time.Time{},
 					}, nil)
 
+				blockRepoMock.
+					EXPECT().
+					IsBlocked(ctx, "to_username", "from_username").
+					Return(false, nil)
+
 				msgRepoMock.
 					EXPECT().
 					AddPrivateMessage(ctx, entity.PrivateMessage{
@@ -80,6 +91,16 @@ func TestPrivateMessageService_Send(t *testing.T) {
 						EditedAt:     now,
 					}, nil)
 
+				notifierMock.
+					EXPECT().
+					NotifyPrivateMessage(ctx, &entity.PrivateMessage{
+						ID:           1,
+						FromUsername: "from_username",
+						ToUsername:   "to_username",
+						Content:      "content",
+						SentAt:       now,
+						EditedAt:     now,
+					})
 			},
 
 			input: inputArgs{
@@ -140,6 +161,32 @@ func TestPrivateMessageService_Send(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "err, receiver blocked sender",
+			mockBehaviour: func() {
+				userRepoMock.
+					EXPECT().
+					GetUserByUsername(ctx, "from_username").
+					Return(&entity.User{Username: "from_username"}, nil)
+
+				userRepoMock.
+					EXPECT().
+					GetUserByUsername(ctx, "to_username").
+					Return(&entity.User{Username: "to_username"}, nil)
+
+				blockRepoMock.
+					EXPECT().
+					IsBlocked(ctx, "to_username", "from_username").
+					Return(true, nil)
+			},
+
+			input: inputArgs{
+				FromUsername: "from_username",
+				ToUsername:   "to_username",
+				Content:      "content",
+			},
+			wantErr: true,
+		},
 		{
 			name: "err, empty content",
 			mockBehaviour: func() {
@@ -167,6 +214,11 @@ func TestPrivateMessageService_Send(t *testing.T) {
 						UpdatedAt:      time.Time{},
 					}, nil)
 
+				blockRepoMock.
+					EXPECT().
+					IsBlocked(ctx, "to_username", "from_username").
+					Return(false, nil)
+
 				msgRepoMock.
 					EXPECT().
 					AddPrivateMessage(ctx, entity.PrivateMessage{
@@ -208,9 +260,14 @@ func TestPrivateMessageService_Get(t *testing.T) {
 	ctx := context.Background()
 
 	msgRepoMock := mocks.NewMockPrivateMessageRepo(ctrl)
+	reactionRepoMock := mocks.NewMockReactionRepo(ctrl)
+	attachmentRepoMock := mocks.NewMockMessageAttachmentRepo(ctrl)
+	blockRepoMock := mocks.NewMockMessageBlockRepo(ctrl)
+	mentionRepoMock := mocks.NewMockMessageMentionRepo(ctrl)
 	userRepoMock := mocks.NewMockUserRepo(ctrl)
+	notifierMock := mocks.NewMockPrivateMessageNotifier(ctrl)
 
-	service := New(msgRepoMock, userRepoMock)
+	service := New(msgRepoMock, reactionRepoMock, attachmentRepoMock, blockRepoMock, mentionRepoMock, userRepoMock, testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
 
 	type inputArgs = int
 	type outputArg = *entity.PrivateMessage
@@ -295,9 +352,14 @@ func TestPrivateMessageService_GetAll(t *testing.T) {
 	ctx := context.Background()
 
 	msgRepoMock := mocks.NewMockPrivateMessageRepo(ctrl)
+	reactionRepoMock := mocks.NewMockReactionRepo(ctrl)
+	attachmentRepoMock := mocks.NewMockMessageAttachmentRepo(ctrl)
+	blockRepoMock := mocks.NewMockMessageBlockRepo(ctrl)
+	mentionRepoMock := mocks.NewMockMessageMentionRepo(ctrl)
 	userRepoMock := mocks.NewMockUserRepo(ctrl)
+	notifierMock := mocks.NewMockPrivateMessageNotifier(ctrl)
 
-	service := New(msgRepoMock, userRepoMock)
+	service := New(msgRepoMock, reactionRepoMock, attachmentRepoMock, blockRepoMock, mentionRepoMock, userRepoMock, testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
 
 	messages := []*entity.PrivateMessage{
 		{
@@ -360,6 +422,25 @@ func TestPrivateMessageService_GetAll(t *testing.T) {
 
 	type outputArg = []entity.PrivateMessage
 
+	// reply counts and reactions are checked in thread and reaction tests
+	msgRepoMock.
+		EXPECT().
+		CountPrivateMessageReplies(ctx, gomock.Any()).
+		Return(map[int]int{}, nil).
+		AnyTimes()
+
+	reactionRepoMock.
+		EXPECT().
+		GetReactions(ctx, entity.MessageKindPrivate, gomock.Any()).
+		Return(nil, nil).
+		AnyTimes()
+
+	attachmentRepoMock.
+		EXPECT().
+		GetMessageAttachments(ctx, entity.MessageKindPrivate, gomock.Any()).
+		Return(nil, nil).
+		AnyTimes()
+
 	tests := []struct {
 		name          string
 		mockBehaviour func()
@@ -539,9 +620,14 @@ func TestPrivateMessageService_GetAllFromUser(t *testing.T) {
 	ctx := context.Background()
 
 	msgRepoMock := mocks.NewMockPrivateMessageRepo(ctrl)
+	reactionRepoMock := mocks.NewMockReactionRepo(ctrl)
+	attachmentRepoMock := mocks.NewMockMessageAttachmentRepo(ctrl)
+	blockRepoMock := mocks.NewMockMessageBlockRepo(ctrl)
+	mentionRepoMock := mocks.NewMockMessageMentionRepo(ctrl)
 	userRepoMock := mocks.NewMockUserRepo(ctrl)
+	notifierMock := mocks.NewMockPrivateMessageNotifier(ctrl)
 
-	service := New(msgRepoMock, userRepoMock)
+	service := New(msgRepoMock, reactionRepoMock, attachmentRepoMock, blockRepoMock, mentionRepoMock, userRepoMock, testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
 
 	messages := []*entity.PrivateMessage{
 		{
@@ -604,6 +690,25 @@ func TestPrivateMessageService_GetAllFromUser(t *testing.T) {
 
 	type outputArg = []entity.PrivateMessage
 
+	// reply counts and reactions are checked in thread and reaction tests
+	msgRepoMock.
+		EXPECT().
+		CountPrivateMessageReplies(ctx, gomock.Any()).
+		Return(map[int]int{}, nil).
+		AnyTimes()
+
+	reactionRepoMock.
+		EXPECT().
+		GetReactions(ctx, entity.MessageKindPrivate, gomock.Any()).
+		Return(nil, nil).
+		AnyTimes()
+
+	attachmentRepoMock.
+		EXPECT().
+		GetMessageAttachments(ctx, entity.MessageKindPrivate, gomock.Any()).
+		Return(nil, nil).
+		AnyTimes()
+
 	tests := []struct {
 		name          string
 		mockBehaviour func()
@@ -924,9 +1029,14 @@ func TestPrivateMessageService_GetAllUsersThatSentMessage(t *testing.T) {
 	ctx := context.Background()
 
 	msgRepoMock := mocks.NewMockPrivateMessageRepo(ctrl)
+	reactionRepoMock := mocks.NewMockReactionRepo(ctrl)
+	attachmentRepoMock := mocks.NewMockMessageAttachmentRepo(ctrl)
+	blockRepoMock := mocks.NewMockMessageBlockRepo(ctrl)
+	mentionRepoMock := mocks.NewMockMessageMentionRepo(ctrl)
 	userRepoMock := mocks.NewMockUserRepo(ctrl)
+	notifierMock := mocks.NewMockPrivateMessageNotifier(ctrl)
 
-	service := New(msgRepoMock, userRepoMock)
+	service := New(msgRepoMock, reactionRepoMock, attachmentRepoMock, blockRepoMock, mentionRepoMock, userRepoMock, testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
 
 	messages := []*entity.PrivateMessage{
 		{
@@ -1202,3 +1312,354 @@ func TestPrivateMessageService_GetAllUsersThatSentMessage(t *testing.T) {
 		})
 	}
 }
+
+func TestPrivateMessageService_GetRevisions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	msgRepoMock := mocks.NewMockPrivateMessageRepo(ctrl)
+	reactionRepoMock := mocks.NewMockReactionRepo(ctrl)
+	attachmentRepoMock := mocks.NewMockMessageAttachmentRepo(ctrl)
+	blockRepoMock := mocks.NewMockMessageBlockRepo(ctrl)
+	mentionRepoMock := mocks.NewMockMessageMentionRepo(ctrl)
+	userRepoMock := mocks.NewMockUserRepo(ctrl)
+	notifierMock := mocks.NewMockPrivateMessageNotifier(ctrl)
+
+	service := New(msgRepoMock, reactionRepoMock, attachmentRepoMock, blockRepoMock, mentionRepoMock, userRepoMock, testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
+
+	type inputArgs struct {
+		id       int
+		username string
+		role     entity.Role
+	}
+
+	type outputArg = []*entity.MessageRevision
+
+	msg := &entity.PrivateMessage{
+		ID:           1,
+		FromUsername: "from_username",
+		ToUsername:   "to_username",
+		Content:      "edited",
+		SentAt:       now,
+		EditedAt:     now,
+	}
+
+	deletedAt := now
+	deleted := &entity.PrivateMessage{
+		ID:           1,
+		FromUsername: "from_username",
+		ToUsername:   "to_username",
+		SentAt:       now,
+		EditedAt:     now,
+		DeletedAt:    &deletedAt,
+	}
+
+	revisions := []*entity.MessageRevision{
+		{
+			ID:        1,
+			MessageID: 1,
+			Content:   "content",
+			EditedAt:  now,
+		},
+	}
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		input         inputArgs
+		want          outputArg
+		wantErr       bool
+	}{
+		{
+			name: "ok, receiver requests revisions",
+			mockBehaviour: func() {
+				msgRepoMock.
+					EXPECT().
+					GetPrivateMessage(ctx, 1).
+					Return(msg, nil)
+
+				msgRepoMock.
+					EXPECT().
+					GetPrivateMessageRevisions(ctx, 1).
+					Return(revisions, nil)
+			},
+			input: inputArgs{
+				id:       1,
+				username: "to_username",
+				role:     entity.RoleUser,
+			},
+			want: revisions,
+		},
+		{
+			name: "err, not a participant",
+			mockBehaviour: func() {
+				msgRepoMock.
+					EXPECT().
+					GetPrivateMessage(ctx, 1).
+					Return(msg, nil)
+			},
+			input: inputArgs{
+				id:       1,
+				username: "other",
+				role:     entity.RoleUser,
+			},
+			wantErr: true,
+		},
+		{
+			name: "err, moderator requests revisions of message of other users",
+			mockBehaviour: func() {
+				msgRepoMock.
+					EXPECT().
+					GetPrivateMessage(ctx, 1).
+					Return(msg, nil)
+			},
+			input: inputArgs{
+				id:       1,
+				username: "other",
+				role:     entity.RoleModerator,
+			},
+			wantErr: true,
+		},
+		{
+			name: "err, receiver requests revisions of deleted message",
+			mockBehaviour: func() {
+				msgRepoMock.
+					EXPECT().
+					GetPrivateMessage(ctx, 1).
+					Return(deleted, nil)
+			},
+			input: inputArgs{
+				id:       1,
+				username: "to_username",
+				role:     entity.RoleUser,
+			},
+			wantErr: true,
+		},
+		{
+			name: "ok, admin requests revisions of deleted message",
+			mockBehaviour: func() {
+				msgRepoMock.
+					EXPECT().
+					GetPrivateMessage(ctx, 1).
+					Return(deleted, nil)
+
+				msgRepoMock.
+					EXPECT().
+					GetPrivateMessageRevisions(ctx, 1).
+					Return(revisions, nil)
+			},
+			input: inputArgs{
+				id:       1,
+				username: "admin",
+				role:     entity.RoleAdmin,
+			},
+			want: revisions,
+		},
+		{
+			name: "err, no such message",
+			mockBehaviour: func() {
+				msgRepoMock.
+					EXPECT().
+					GetPrivateMessage(ctx, 2).
+					Return(nil, repoerrors.ErrNoSuchPrivateMessage)
+			},
+			input: inputArgs{
+				id:       2,
+				username: "to_username",
+				role:     entity.RoleUser,
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			got, err := service.GetPrivateMessageRevisions(ctx, test.input.id, test.input.username, test.input.role)
+
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
+			}
+		})
+	}
+}
+
+func TestPrivateMessageService_GetThread(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	msgRepoMock := mocks.NewMockPrivateMessageRepo(ctrl)
+	reactionRepoMock := mocks.NewMockReactionRepo(ctrl)
+	attachmentRepoMock := mocks.NewMockMessageAttachmentRepo(ctrl)
+	blockRepoMock := mocks.NewMockMessageBlockRepo(ctrl)
+	mentionRepoMock := mocks.NewMockMessageMentionRepo(ctrl)
+	userRepoMock := mocks.NewMockUserRepo(ctrl)
+	notifierMock := mocks.NewMockPrivateMessageNotifier(ctrl)
+
+	service := New(msgRepoMock, reactionRepoMock, attachmentRepoMock, blockRepoMock, mentionRepoMock, userRepoMock, testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
+
+	rootID := 1
+	kind := entity.MessageKindPrivate
+
+	root := &entity.PrivateMessage{ID: 1, FromUsername: "first", ToUsername: "second", Content: "root", SentAt: now, EditedAt: now}
+	reply := &entity.PrivateMessage{ID: 2, FromUsername: "second", ToUsername: "first", Content: "reply", SentAt: now, EditedAt: now, ParentID: &rootID}
+
+	type inputArgs struct {
+		id       int
+		username string
+	}
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		input         inputArgs
+		wantRoot      *entity.PrivateMessage
+		wantReplies   []*entity.PrivateMessage
+		wantErr       error
+	}{
+		{
+			name: "ok, thread of reply is thread of its root",
+			mockBehaviour: func() {
+				msgRepoMock.EXPECT().GetPrivateMessage(ctx, 2).Return(reply, nil)
+				msgRepoMock.EXPECT().GetPrivateMessage(ctx, 1).Return(root, nil)
+				msgRepoMock.EXPECT().CountPrivateMessageReplies(ctx, []int{1}).Return(map[int]int{1: 1}, nil)
+				msgRepoMock.EXPECT().GetPrivateMessageReplies(ctx, 1, 0, 10).Return([]*entity.PrivateMessage{reply})
+				reactionRepoMock.EXPECT().GetReactions(ctx, entity.MessageKindPrivate, []int{1, 2}).Return([]*entity.Reaction{
+					{ID: 1, MessageID: 1, Username: "second", Emoji: "👍"},
+					{ID: 2, MessageID: 2, Username: "second", Emoji: "🔥"},
+					{ID: 3, MessageID: 1, Username: "first", Emoji: "👍"},
+				}, nil)
+				attachmentRepoMock.EXPECT().GetMessageAttachments(ctx, entity.MessageKindPrivate, []int{1, 2}).Return([]*entity.Attachment{
+					{ID: 1, UploadedBy: "second", MessageKind: &kind, MessageID: &reply.ID, FileName: "cat.png", ContentType: "image/png", Size: 10},
+				}, nil)
+			},
+			input: inputArgs{id: 2, username: "first"},
+			wantRoot: &entity.PrivateMessage{
+				ID: 1, FromUsername: "first", ToUsername: "second", Content: "root", SentAt: now, EditedAt: now, ReplyCount: 1,
+				Reactions: []entity.ReactionSummary{{Emoji: "👍", Count: 2, Reacted: true}},
+			},
+			wantReplies: []*entity.PrivateMessage{{
+				ID: 2, FromUsername: "second", ToUsername: "first", Content: "reply", SentAt: now, EditedAt: now, ParentID: &rootID,
+				Reactions: []entity.ReactionSummary{{Emoji: "🔥", Count: 1, Reacted: false}},
+				Attachments: []entity.Attachment{
+					{ID: 1, UploadedBy: "second", MessageKind: &kind, MessageID: &reply.ID, FileName: "cat.png", ContentType: "image/png", Size: 10},
+				},
+			}},
+		},
+		{
+			name: "err, not a participant",
+			mockBehaviour: func() {
+				msgRepoMock.EXPECT().GetPrivateMessage(ctx, 1).Return(root, nil)
+			},
+			input:   inputArgs{id: 1, username: "third"},
+			wantErr: message.ErrNotMessageParticipant,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			gotRoot, gotReplies, err := service.GetPrivateMessageThread(ctx, test.input.id, test.input.username, 0, 10)
+
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.wantRoot, gotRoot)
+				assert.Equal(t, test.wantReplies, gotReplies)
+			}
+		})
+	}
+}
+
+func TestPrivateMessageService_MarkRead(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	msgRepoMock := mocks.NewMockPrivateMessageRepo(ctrl)
+	reactionRepoMock := mocks.NewMockReactionRepo(ctrl)
+	attachmentRepoMock := mocks.NewMockMessageAttachmentRepo(ctrl)
+	blockRepoMock := mocks.NewMockMessageBlockRepo(ctrl)
+	mentionRepoMock := mocks.NewMockMessageMentionRepo(ctrl)
+	userRepoMock := mocks.NewMockUserRepo(ctrl)
+	notifierMock := mocks.NewMockPrivateMessageNotifier(ctrl)
+
+	service := New(msgRepoMock, reactionRepoMock, attachmentRepoMock, blockRepoMock, mentionRepoMock, userRepoMock, testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
+
+	msg := &entity.PrivateMessage{ID: 3, FromUsername: "first", ToUsername: "second", Content: "content", SentAt: now, EditedAt: now}
+	selfMsg := &entity.PrivateMessage{ID: 4, FromUsername: "first", ToUsername: "first", Content: "note", SentAt: now, EditedAt: now}
+
+	marker := &entity.ReadMarker{Username: "second", Counterpart: "first", LastReadID: 3, ReadAt: now}
+	selfMarker := &entity.ReadMarker{Username: "first", Counterpart: "first", LastReadID: 4, ReadAt: now}
+
+	type inputArgs struct {
+		id       int
+		username string
+	}
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		input         inputArgs
+		want          *entity.ReadMarker
+		wantErr       error
+	}{
+		{
+			name: "ok, receiver marks messages read and sender is notified",
+			mockBehaviour: func() {
+				msgRepoMock.EXPECT().GetPrivateMessage(ctx, 3).Return(msg, nil)
+				msgRepoMock.EXPECT().MarkPrivateMessagesRead(ctx, "second", "first", 3).Return(marker, nil)
+				notifierMock.EXPECT().NotifyPrivateMessagesRead(ctx, marker)
+			},
+			input: inputArgs{id: 3, username: "second"},
+			want:  marker,
+		},
+		{
+			name: "ok, conversation with yourself is not notified",
+			mockBehaviour: func() {
+				msgRepoMock.EXPECT().GetPrivateMessage(ctx, 4).Return(selfMsg, nil)
+				msgRepoMock.EXPECT().MarkPrivateMessagesRead(ctx, "first", "first", 4).Return(selfMarker, nil)
+			},
+			input: inputArgs{id: 4, username: "first"},
+			want:  selfMarker,
+		},
+		{
+			name: "err, not a participant",
+			mockBehaviour: func() {
+				msgRepoMock.EXPECT().GetPrivateMessage(ctx, 3).Return(msg, nil)
+			},
+			input:   inputArgs{id: 3, username: "other"},
+			wantErr: message.ErrNotMessageParticipant,
+		},
+		{
+			name: "err, no such message",
+			mockBehaviour: func() {
+				msgRepoMock.EXPECT().GetPrivateMessage(ctx, 5).Return(nil, repoerrors.ErrNoSuchPrivateMessage)
+			},
+			input:   inputArgs{id: 5, username: "second"},
+			wantErr: repoerrors.ErrNoSuchPrivateMessage,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			got, err := service.MarkPrivateMessagesRead(ctx, test.input.id, test.input.username)
+
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
+			}
+		})
+	}
+}
@@ -13,11 +13,20 @@ import (
 )
 
 //go:generate mockgen -destination=../../../mocks/private_message_repository.go -package=mocks github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message/private PrivateMessageRepo
+//go:generate mockgen -destination=../../../mocks/private_message_notifier.go -package=mocks -mock_names=Notifier=MockPrivateMessageNotifier github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message/private Notifier
 
 type PrivateMessageRepo interface {
 	AddPrivateMessage(ctx context.Context, msg entity.PrivateMessage) (*entity.PrivateMessage, error)
 	GetAllPrivateMessages(ctx context.Context, offset, limit int) []*entity.PrivateMessage
 	GetPrivateMessage(ctx context.Context, id int) (*entity.PrivateMessage, error)
+	UpdatePrivateMessage(ctx context.Context, id int, updated entity.PrivateMessage) (*entity.PrivateMessage, error)
+	GetPrivateMessageRevisions(ctx context.Context, messageID int) ([]*entity.MessageRevision, error)
+	DeletePrivateMessage(ctx context.Context, id int) (*entity.PrivateMessage, error)
+	PurgePrivateMessage(ctx context.Context, id int) error
+	GetPrivateMessageReplies(ctx context.Context, parentID, offset, limit int) []*entity.PrivateMessage
+	CountPrivateMessageReplies(ctx context.Context, parentIDs []int) (map[int]int, error)
+	MarkPrivateMessagesRead(ctx context.Context, username, counterpart string, upToID int) (*entity.ReadMarker, error)
+	CountUnreadPrivateMessages(ctx context.Context, username string) (map[string]int, error)
 }
 
 type UserRepo interface {
@@ -26,15 +35,45 @@ type UserRepo interface {
 	GetUserByUsername(ctx context.Context, username string) (*entity.User, error)
 }
 
+type Notifier interface {
+	NotifyPrivateMessage(ctx context.Context, msg *entity.PrivateMessage)
+	NotifyPrivateMessageEdited(ctx context.Context, msg *entity.PrivateMessage)
+	NotifyPrivateMessageDeleted(ctx context.Context, msg *entity.PrivateMessage)
+	NotifyPrivateMessagePurged(ctx context.Context, msg *entity.PrivateMessage)
+	NotifyPrivateMessagesRead(ctx context.Context, marker *entity.ReadMarker)
+	NotifyMention(ctx context.Context, mention *entity.Mention)
+}
+
 type Service struct {
 	PrivateMessageRepo PrivateMessageRepo
+	ReactionRepo       message.ReactionRepo
+	AttachmentRepo     message.AttachmentRepo
+	BlockRepo          message.BlockRepo
+	MentionRepo        message.MentionRepo
 	UserRepo           UserRepo
+	UnitOfWork         message.UnitOfWork
+	Notifier           Notifier
 }
 
-func New(privateMessageRepo PrivateMessageRepo, userRepo UserRepo) *Service {
+func New(
+	privateMessageRepo PrivateMessageRepo,
+	reactionRepo message.ReactionRepo,
+	attachmentRepo message.AttachmentRepo,
+	blockRepo message.BlockRepo,
+	mentionRepo message.MentionRepo,
+	userRepo UserRepo,
+	unitOfWork message.UnitOfWork,
+	notifier Notifier,
+) *Service {
 	return &Service{
 		PrivateMessageRepo: privateMessageRepo,
+		ReactionRepo:       reactionRepo,
+		AttachmentRepo:     attachmentRepo,
+		BlockRepo:          blockRepo,
+		MentionRepo:        mentionRepo,
 		UserRepo:           userRepo,
+		UnitOfWork:         unitOfWork,
+		Notifier:           notifier,
 	}
 }
 
@@ -56,11 +95,57 @@ func (s *Service) SendPrivateMessage(ctx context.Context, msg entity.PrivateMess
 		return nil, err
 	}
 
-	created, err := s.PrivateMessageRepo.AddPrivateMessage(ctx, msg)
+	if err := message.CheckNotBlocked(ctx, s.BlockRepo, msg.FromUsername, msg.ToUsername); err != nil {
+		return nil, err
+	}
+
+	if err := message.ResolvePrivateParent(ctx, s.PrivateMessageRepo, &msg); err != nil {
+		return nil, err
+	}
+
+	attachmentIDs := message.TakeAttachmentIDs(&msg.Attachments)
+
+	if err := message.CheckAttachments(ctx, s.AttachmentRepo, attachmentIDs, msg.FromUsername); err != nil {
+		return nil, err
+	}
+
+	var (
+		created  *entity.PrivateMessage
+		mentions []*entity.Mention
+	)
+
+	// message is stored together with its attachments and mentions or not at all
+	err := s.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+
+		if created, err = s.PrivateMessageRepo.AddPrivateMessage(ctx, msg); err != nil {
+			return err
+		}
+
+		created.Attachments, err = message.LinkAttachments(ctx, s.AttachmentRepo, entity.MessageKindPrivate, created.ID, attachmentIDs)
+		if err != nil {
+			return err
+		}
+
+		// only receiver can read the message, thus mentions of other users are not recorded
+		mentions, created.Mentions, err = message.RecordMentions(ctx, s.MentionRepo, s.UserRepo, entity.MessageKindPrivate,
+			created.ID, created.FromUsername, created.Content, func(_ context.Context, username string) (bool, error) {
+				return username == created.ToUsername, nil
+			})
+
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	// push message to receiver and to all sender's devices
+	s.Notifier.NotifyPrivateMessage(ctx, created)
+
+	for _, mention := range mentions {
+		s.Notifier.NotifyMention(ctx, mention)
+	}
+
 	return created, nil
 }
 
@@ -74,7 +159,7 @@ func (s *Service) GetPrivateMessage(ctx context.Context, id int) (*entity.Privat
 	return msg, nil
 }
 
-func (s *Service) GetAllPrivateMessages(ctx context.Context, toUsername string, offset, limit int) []*entity.PrivateMessage {
+func (s *Service) getUserPrivateMessages(ctx context.Context, toUsername string, offset, limit int) []*entity.PrivateMessage {
 	messages := s.PrivateMessageRepo.GetAllPrivateMessages(ctx, 0, math.MaxInt64)
 
 	// return only messages that were sent to or from user
@@ -85,6 +170,18 @@ func (s *Service) GetAllPrivateMessages(ctx context.Context, toUsername string,
 	return sliceutils.Slice(messages, offset, limit)
 }
 
+func (s *Service) GetAllPrivateMessages(ctx context.Context, toUsername string, offset, limit int) []*entity.PrivateMessage {
+	messages := s.getUserPrivateMessages(ctx, toUsername, offset, limit)
+
+	// reply counts, reactions, attachments and mentions are informational, messages are returned even if filling them failed
+	_ = message.FillPrivateReplyCounts(ctx, s.PrivateMessageRepo, messages)
+	_ = message.FillPrivateReactions(ctx, s.ReactionRepo, messages, toUsername)
+	_ = message.FillPrivateAttachments(ctx, s.AttachmentRepo, messages)
+	_ = message.FillPrivateMentions(ctx, s.MentionRepo, messages)
+
+	return messages
+}
+
 func (s *Service) GetAllPrivateMessagesFromUser(ctx context.Context, toUsername, fromUsername string, offset, limit int) ([]*entity.PrivateMessage, error) {
 	if err := s.checkSenderAndReceiver(ctx, fromUsername, toUsername); err != nil {
 		return nil, err
@@ -95,7 +192,142 @@ func (s *Service) GetAllPrivateMessagesFromUser(ctx context.Context, toUsername,
 		return msg.FromUsername == fromUsername && msg.ToUsername == toUsername
 	})
 
-	return sliceutils.Slice(messages, offset, limit), nil
+	messages = sliceutils.Slice(messages, offset, limit)
+
+	if err := message.FillPrivateReplyCounts(ctx, s.PrivateMessageRepo, messages); err != nil {
+		return nil, err
+	}
+
+	if err := message.FillPrivateReactions(ctx, s.ReactionRepo, messages, toUsername); err != nil {
+		return nil, err
+	}
+
+	if err := message.FillPrivateAttachments(ctx, s.AttachmentRepo, messages); err != nil {
+		return nil, err
+	}
+
+	if err := message.FillPrivateMentions(ctx, s.MentionRepo, messages); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+// GetPrivateMessageThread returns root of the thread that message belongs to and paginated replies of it.
+// Only participants of the conversation can read the thread.
+func (s *Service) GetPrivateMessageThread(ctx context.Context, id int, username string, offset, limit int) (*entity.PrivateMessage, []*entity.PrivateMessage, error) {
+	root, err := s.PrivateMessageRepo.GetPrivateMessage(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if root.FromUsername != username && root.ToUsername != username {
+		return nil, nil, message.ErrNotMessageParticipant
+	}
+
+	if root.IsReply() {
+		if root, err = s.PrivateMessageRepo.GetPrivateMessage(ctx, *root.ParentID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if err = message.FillPrivateReplyCounts(ctx, s.PrivateMessageRepo, []*entity.PrivateMessage{root}); err != nil {
+		return nil, nil, err
+	}
+
+	replies := s.PrivateMessageRepo.GetPrivateMessageReplies(ctx, root.ID, offset, limit)
+
+	thread := append([]*entity.PrivateMessage{root}, replies...)
+
+	if err = message.FillPrivateReactions(ctx, s.ReactionRepo, thread, username); err != nil {
+		return nil, nil, err
+	}
+
+	if err = message.FillPrivateAttachments(ctx, s.AttachmentRepo, thread); err != nil {
+		return nil, nil, err
+	}
+
+	if err = message.FillPrivateMentions(ctx, s.MentionRepo, thread); err != nil {
+		return nil, nil, err
+	}
+
+	return root, replies, nil
+}
+
+// getReactablePrivateMessage returns message that user can react to, only participants of message can do it.
+func (s *Service) getReactablePrivateMessage(ctx context.Context, id int, username string) (*entity.PrivateMessage, error) {
+	msg, err := s.PrivateMessageRepo.GetPrivateMessage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if msg.FromUsername != username && msg.ToUsername != username {
+		return nil, message.ErrNotMessageParticipant
+	}
+
+	if msg.IsDeleted() {
+		return nil, message.ErrMessageDeleted
+	}
+
+	return msg, nil
+}
+
+// AddPrivateMessageReaction adds emoji reaction of user to message and returns message with updated reactions.
+func (s *Service) AddPrivateMessageReaction(ctx context.Context, id int, username, emoji string) (*entity.PrivateMessage, error) {
+	msg, err := s.getReactablePrivateMessage(ctx, id, username)
+	if err != nil {
+		return nil, err
+	}
+
+	reaction := entity.Reaction{
+		MessageID: msg.ID,
+		Username:  username,
+		Emoji:     emoji,
+	}
+
+	if _, err = s.ReactionRepo.AddReaction(ctx, entity.MessageKindPrivate, reaction); err != nil {
+		return nil, err
+	}
+
+	if err = message.FillPrivateReactions(ctx, s.ReactionRepo, []*entity.PrivateMessage{msg}, username); err != nil {
+		return nil, err
+	}
+
+	if err = message.FillPrivateAttachments(ctx, s.AttachmentRepo, []*entity.PrivateMessage{msg}); err != nil {
+		return nil, err
+	}
+
+	if err = message.FillPrivateMentions(ctx, s.MentionRepo, []*entity.PrivateMessage{msg}); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// RemovePrivateMessageReaction removes emoji reaction of user from message and returns message with updated reactions.
+func (s *Service) RemovePrivateMessageReaction(ctx context.Context, id int, username, emoji string) (*entity.PrivateMessage, error) {
+	msg, err := s.getReactablePrivateMessage(ctx, id, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.ReactionRepo.RemoveReaction(ctx, entity.MessageKindPrivate, msg.ID, username, emoji); err != nil {
+		return nil, err
+	}
+
+	if err = message.FillPrivateReactions(ctx, s.ReactionRepo, []*entity.PrivateMessage{msg}, username); err != nil {
+		return nil, err
+	}
+
+	if err = message.FillPrivateAttachments(ctx, s.AttachmentRepo, []*entity.PrivateMessage{msg}); err != nil {
+		return nil, err
+	}
+
+	if err = message.FillPrivateMentions(ctx, s.MentionRepo, []*entity.PrivateMessage{msg}); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
 }
 
 func (s *Service) GetAllUsersThatSentMessage(ctx context.Context, toUsername string, offset, limit int) []*entity.User {
@@ -103,7 +335,7 @@ func (s *Service) GetAllUsersThatSentMessage(ctx context.Context, toUsername str
 		return nil
 	}
 
-	messages := s.GetAllPrivateMessages(ctx, toUsername, 0, math.MaxInt64)
+	messages := s.getUserPrivateMessages(ctx, toUsername, 0, math.MaxInt64)
 
 	// interested only in messages that sent to user
 	messages = sliceutils.Filter(messages, func(msg *entity.PrivateMessage) bool { return msg.FromUsername != toUsername })
@@ -132,3 +364,134 @@ func (s *Service) GetAllUsersThatSentMessage(ctx context.Context, toUsername str
 
 	return res
 }
+
+// GetUnreadPrivateMessageCounts returns number of unread messages received by user, by sender.
+func (s *Service) GetUnreadPrivateMessageCounts(ctx context.Context, username string) (map[string]int, error) {
+	return s.PrivateMessageRepo.CountUnreadPrivateMessages(ctx, username)
+}
+
+// MarkPrivateMessagesRead marks messages of conversation that message belongs to as read by user up to this message.
+// Counterpart is notified, so sender can see when messages were seen.
+func (s *Service) MarkPrivateMessagesRead(ctx context.Context, id int, username string) (*entity.ReadMarker, error) {
+	msg, err := s.PrivateMessageRepo.GetPrivateMessage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if msg.FromUsername != username && msg.ToUsername != username {
+		return nil, message.ErrNotMessageParticipant
+	}
+
+	counterpart := msg.FromUsername
+	if counterpart == username {
+		counterpart = msg.ToUsername
+	}
+
+	marker, err := s.PrivateMessageRepo.MarkPrivateMessagesRead(ctx, username, counterpart, msg.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if counterpart != username {
+		s.Notifier.NotifyPrivateMessagesRead(ctx, marker)
+	}
+
+	return marker, nil
+}
+
+func (s *Service) EditPrivateMessage(ctx context.Context, id int, editorUsername, content string) (*entity.PrivateMessage, error) {
+	msg, err := s.PrivateMessageRepo.GetPrivateMessage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// only author can edit their message
+	if msg.FromUsername != editorUsername {
+		return nil, message.ErrNotMessageAuthor
+	}
+
+	if msg.IsDeleted() {
+		return nil, message.ErrMessageDeleted
+	}
+
+	// content not changed, thus no need for new revision
+	if msg.Content == content {
+		return msg, nil
+	}
+
+	updated, err := s.PrivateMessageRepo.UpdatePrivateMessage(ctx, id, entity.PrivateMessage{Content: content})
+	if err != nil {
+		return nil, err
+	}
+
+	s.Notifier.NotifyPrivateMessageEdited(ctx, updated)
+
+	return updated, nil
+}
+
+// GetPrivateMessageRevisions returns edit history of message to its participants. Roles allowed to read private
+// revisions, which moderators are not, read history of any message, even deleted one.
+func (s *Service) GetPrivateMessageRevisions(ctx context.Context, id int, username string, role entity.Role) ([]*entity.MessageRevision, error) {
+	msg, err := s.PrivateMessageRepo.GetPrivateMessage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if role.HasPermission(entity.PermissionReadPrivateRevisions) {
+		return s.PrivateMessageRepo.GetPrivateMessageRevisions(ctx, id)
+	}
+
+	// reading history of other users' private messages is forbidden
+	if msg.FromUsername != username && msg.ToUsername != username {
+		return nil, message.ErrNotMessageParticipant
+	}
+
+	// history of deleted message is not exposed to regular users
+	if msg.IsDeleted() {
+		return nil, message.ErrMessageDeleted
+	}
+
+	return s.PrivateMessageRepo.GetPrivateMessageRevisions(ctx, id)
+}
+
+func (s *Service) DeletePrivateMessage(ctx context.Context, id int, username string) (*entity.PrivateMessage, error) {
+	msg, err := s.PrivateMessageRepo.GetPrivateMessage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// only author can delete their message
+	if msg.FromUsername != username {
+		return nil, message.ErrNotMessageAuthor
+	}
+
+	// already deleted, nothing to do
+	if msg.IsDeleted() {
+		return msg, nil
+	}
+
+	deleted, err := s.PrivateMessageRepo.DeletePrivateMessage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Notifier.NotifyPrivateMessageDeleted(ctx, deleted)
+
+	return deleted, nil
+}
+
+// PurgePrivateMessage removes message with its history completely. Caller must ensure that user has admin rights.
+func (s *Service) PurgePrivateMessage(ctx context.Context, id int) error {
+	msg, err := s.PrivateMessageRepo.GetPrivateMessage(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err = s.PrivateMessageRepo.PurgePrivateMessage(ctx, id); err != nil {
+		return err
+	}
+
+	s.Notifier.NotifyPrivateMessagePurged(ctx, msg)
+
+	return nil
+}
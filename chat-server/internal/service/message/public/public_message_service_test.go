@@ -14,6 +14,7 @@ import (
 	"time"
 
 	repoerrors "github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message"
 )
 
 func TestPublicMessageService_Send(t *testing.T) {
@@ -22,9 +23,17 @@ func TestPublicMessageService_Send(t *testing.T) {
 	now := time.Now()
 
 	msgRepoMock := mocks.NewMockPublicMessageRepo(ctrl)
+	reactionRepoMock := mocks.NewMockReactionRepo(ctrl)
+	attachmentRepoMock := mocks.NewMockMessageAttachmentRepo(ctrl)
+	profileRepoMock := mocks.NewMockMessageProfileRepo(ctrl)
+	blockRepoMock := mocks.NewMockMessageBlockRepo(ctrl)
+	mentionRepoMock := mocks.NewMockMessageMentionRepo(ctrl)
 	userRepoMock := mocks.NewMockUserRepo(ctrl)
+	channelRepoMock := mocks.NewMockMessageChannelRepo(ctrl)
+	notifierMock := mocks.NewMockPublicMessageNotifier(ctrl)
 
-	service := New(msgRepoMock, userRepoMock)
+	service := New(msgRepoMock, reactionRepoMock, attachmentRepoMock, profileRepoMock, blockRepoMock, mentionRepoMock, userRepoMock, channelRepoMock,
+		testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
 
 	type inputArgs = entity.PublicMessage
 	type outputArg = *entity.PublicMessage
@@ -65,6 +74,21 @@ func TestPublicMessageService_Send(t *testing.T) {
 						EditedAt:     now,
 					}, nil)
 
+				profileRepoMock.
+					EXPECT().
+					GetProfilesByUsernames(ctx, []string{"username"}).
+					Return([]*entity.Profile{{UserID: 1, Username: "username", DisplayName: "User"}}, nil)
+
+				notifierMock.
+					EXPECT().
+					NotifyPublicMessage(ctx, &entity.PublicMessage{
+						ID:            1,
+						FromUsername:  "username",
+						Content:       "content",
+						SentAt:        now,
+						EditedAt:      now,
+						AuthorProfile: &entity.Profile{UserID: 1, Username: "username", DisplayName: "User"},
+					})
 			},
 
 			input: inputArgs{
@@ -125,6 +149,70 @@ func TestPublicMessageService_Send(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "ok, with attachment",
+			mockBehaviour: func() {
+				kind := entity.MessageKindPublic
+				msgID := 2
+
+				userRepoMock.EXPECT().GetUserByUsername(ctx, "username").Return(&entity.User{ID: 1, Username: "username"}, nil)
+
+				attachmentRepoMock.
+					EXPECT().
+					GetAttachments(ctx, []int{5}).
+					Return([]*entity.Attachment{{ID: 5, UploadedBy: "username"}}, nil)
+
+				msgRepoMock.
+					EXPECT().
+					AddPublicMessage(ctx, entity.PublicMessage{FromUsername: "username", Content: "look"}).
+					Return(&entity.PublicMessage{ID: 2, FromUsername: "username", Content: "look", SentAt: now, EditedAt: now}, nil)
+
+				attachmentRepoMock.EXPECT().LinkAttachments(ctx, entity.MessageKindPublic, 2, []int{5}).Return(nil)
+
+				attachmentRepoMock.
+					EXPECT().
+					GetMessageAttachments(ctx, entity.MessageKindPublic, []int{2}).
+					Return([]*entity.Attachment{{ID: 5, UploadedBy: "username", MessageKind: &kind, MessageID: &msgID}}, nil)
+
+				// message is sent even if author profile can not be loaded
+				profileRepoMock.
+					EXPECT().
+					GetProfilesByUsernames(ctx, []string{"username"}).
+					Return(nil, errors.New("connection refused"))
+
+				notifierMock.EXPECT().NotifyPublicMessage(ctx, gomock.Any())
+			},
+
+			input: inputArgs{
+				FromUsername: "username",
+				Content:      "look",
+				Attachments:  []entity.Attachment{{ID: 5}, {ID: 5}},
+			},
+			want: &inputArgs{
+				ID:           2,
+				FromUsername: "username",
+				Content:      "look",
+				SentAt:       now,
+				EditedAt:     now,
+			},
+		},
+		{
+			name: "err, attachment of another user",
+			mockBehaviour: func() {
+				userRepoMock.EXPECT().GetUserByUsername(ctx, "username").Return(&entity.User{ID: 1, Username: "username"}, nil)
+
+				attachmentRepoMock.
+					EXPECT().
+					GetAttachments(ctx, []int{5}).
+					Return([]*entity.Attachment{{ID: 5, UploadedBy: "other"}}, nil)
+			},
+
+			input: inputArgs{
+				FromUsername: "username",
+				Attachments:  []entity.Attachment{{ID: 5}},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -149,9 +237,24 @@ func TestPublicMessageService_Get(t *testing.T) {
 	now := time.Now()
 
 	msgRepoMock := mocks.NewMockPublicMessageRepo(ctrl)
+	reactionRepoMock := mocks.NewMockReactionRepo(ctrl)
+	attachmentRepoMock := mocks.NewMockMessageAttachmentRepo(ctrl)
+	profileRepoMock := mocks.NewMockMessageProfileRepo(ctrl)
+	blockRepoMock := mocks.NewMockMessageBlockRepo(ctrl)
+	mentionRepoMock := mocks.NewMockMessageMentionRepo(ctrl)
 	userRepoMock := mocks.NewMockUserRepo(ctrl)
+	channelRepoMock := mocks.NewMockMessageChannelRepo(ctrl)
+	notifierMock := mocks.NewMockPublicMessageNotifier(ctrl)
+
+	service := New(msgRepoMock, reactionRepoMock, attachmentRepoMock, profileRepoMock, blockRepoMock, mentionRepoMock, userRepoMock, channelRepoMock,
+		testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
 
-	service := New(msgRepoMock, userRepoMock)
+	// authors are checked in send tests
+	profileRepoMock.
+		EXPECT().
+		GetProfilesByUsernames(ctx, gomock.Any()).
+		Return(nil, nil).
+		AnyTimes()
 
 	type inputArgs = int
 	type outputArg = *entity.PublicMessage
@@ -235,12 +338,46 @@ func TestPublicMessageService_GetAll(t *testing.T) {
 	now := time.Now()
 
 	msgRepoMock := mocks.NewMockPublicMessageRepo(ctrl)
+	reactionRepoMock := mocks.NewMockReactionRepo(ctrl)
+	attachmentRepoMock := mocks.NewMockMessageAttachmentRepo(ctrl)
+	profileRepoMock := mocks.NewMockMessageProfileRepo(ctrl)
+	blockRepoMock := mocks.NewMockMessageBlockRepo(ctrl)
+	mentionRepoMock := mocks.NewMockMessageMentionRepo(ctrl)
 	userRepoMock := mocks.NewMockUserRepo(ctrl)
+	channelRepoMock := mocks.NewMockMessageChannelRepo(ctrl)
+	notifierMock := mocks.NewMockPublicMessageNotifier(ctrl)
+
+	service := New(msgRepoMock, reactionRepoMock, attachmentRepoMock, profileRepoMock, blockRepoMock, mentionRepoMock, userRepoMock, channelRepoMock,
+		testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
 
-	service := New(msgRepoMock, userRepoMock)
+	// authors are checked in send tests
+	profileRepoMock.
+		EXPECT().
+		GetProfilesByUsernames(ctx, gomock.Any()).
+		Return(nil, nil).
+		AnyTimes()
 
 	type outputArg = []entity.PublicMessage
 
+	// reply counts and reactions are checked in thread and reaction tests
+	msgRepoMock.
+		EXPECT().
+		CountPublicMessageReplies(ctx, gomock.Any()).
+		Return(map[int]int{}, nil).
+		AnyTimes()
+
+	reactionRepoMock.
+		EXPECT().
+		GetReactions(ctx, entity.MessageKindPublic, gomock.Any()).
+		Return(nil, nil).
+		AnyTimes()
+
+	attachmentRepoMock.
+		EXPECT().
+		GetMessageAttachments(ctx, entity.MessageKindPublic, gomock.Any()).
+		Return(nil, nil).
+		AnyTimes()
+
 	tests := []struct {
 		name          string
 		mockBehaviour func()
@@ -254,7 +391,7 @@ func TestPublicMessageService_GetAll(t *testing.T) {
 			mockBehaviour: func() {
 				msgRepoMock.
 					EXPECT().
-					GetAllPublicMessages(ctx, 0, math.MaxInt64).
+					GetChannelMessages(ctx, entity.GeneralChannelID, 0, math.MaxInt64).
 					Return([]*entity.PublicMessage{
 						{
 							ID:           1,
@@ -311,7 +448,7 @@ func TestPublicMessageService_GetAll(t *testing.T) {
 			mockBehaviour: func() {
 				msgRepoMock.
 					EXPECT().
-					GetAllPublicMessages(ctx, 1, math.MaxInt64).
+					GetChannelMessages(ctx, entity.GeneralChannelID, 1, math.MaxInt64).
 					Return([]*entity.PublicMessage{
 						{
 							ID:           2,
@@ -354,7 +491,7 @@ func TestPublicMessageService_GetAll(t *testing.T) {
 			mockBehaviour: func() {
 				msgRepoMock.
 					EXPECT().
-					GetAllPublicMessages(ctx, 1, 10).
+					GetChannelMessages(ctx, entity.GeneralChannelID, 1, 10).
 					Return([]*entity.PublicMessage{
 						{
 							ID:           2,
@@ -397,7 +534,7 @@ func TestPublicMessageService_GetAll(t *testing.T) {
 			mockBehaviour: func() {
 				msgRepoMock.
 					EXPECT().
-					GetAllPublicMessages(ctx, 1, 1).
+					GetChannelMessages(ctx, entity.GeneralChannelID, 1, 1).
 					Return([]*entity.PublicMessage{
 						{
 							ID:           2,
@@ -426,7 +563,7 @@ func TestPublicMessageService_GetAll(t *testing.T) {
 			mockBehaviour: func() {
 				msgRepoMock.
 					EXPECT().
-					GetAllPublicMessages(ctx, 10, math.MaxInt64).
+					GetChannelMessages(ctx, entity.GeneralChannelID, 10, math.MaxInt64).
 					Return(nil)
 
 			},
@@ -439,7 +576,7 @@ func TestPublicMessageService_GetAll(t *testing.T) {
 			mockBehaviour: func() {
 				msgRepoMock.
 					EXPECT().
-					GetAllPublicMessages(ctx, 0, 0).
+					GetChannelMessages(ctx, entity.GeneralChannelID, 0, 0).
 					Return(nil)
 
 			},
@@ -453,9 +590,722 @@ func TestPublicMessageService_GetAll(t *testing.T) {
 		t.Run(test.name, func(t *testing.T) {
 			test.mockBehaviour()
 
-			got := service.GetAllPublicMessages(ctx, test.offset, test.limit)
+			got := service.GetAllPublicMessages(ctx, "username", test.offset, test.limit)
 
 			assert.True(t, sliceutils.PointerAndValueSlicesEquals(got, test.want))
 		})
 	}
 }
+
+func TestPublicMessageService_GetAllHidingBlocked(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	msgRepoMock := mocks.NewMockPublicMessageRepo(ctrl)
+	reactionRepoMock := mocks.NewMockReactionRepo(ctrl)
+	attachmentRepoMock := mocks.NewMockMessageAttachmentRepo(ctrl)
+	profileRepoMock := mocks.NewMockMessageProfileRepo(ctrl)
+	blockRepoMock := mocks.NewMockMessageBlockRepo(ctrl)
+	mentionRepoMock := mocks.NewMockMessageMentionRepo(ctrl)
+	userRepoMock := mocks.NewMockUserRepo(ctrl)
+	channelRepoMock := mocks.NewMockMessageChannelRepo(ctrl)
+	notifierMock := mocks.NewMockPublicMessageNotifier(ctrl)
+
+	service := New(msgRepoMock, reactionRepoMock, attachmentRepoMock, profileRepoMock, blockRepoMock, mentionRepoMock, userRepoMock, channelRepoMock,
+		testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
+
+	msgRepoMock.EXPECT().CountPublicMessageReplies(ctx, gomock.Any()).Return(nil, nil).AnyTimes()
+	reactionRepoMock.EXPECT().GetReactions(ctx, entity.MessageKindPublic, gomock.Any()).Return(nil, nil).AnyTimes()
+	attachmentRepoMock.EXPECT().GetMessageAttachments(ctx, entity.MessageKindPublic, gomock.Any()).Return(nil, nil).AnyTimes()
+	profileRepoMock.EXPECT().GetProfilesByUsernames(ctx, gomock.Any()).Return(nil, nil).AnyTimes()
+
+	blockRepoMock.EXPECT().GetBlocks(ctx, "username").
+		Return([]*entity.Block{{Username: "username", BlockedUsername: "spammer"}}, nil)
+	msgRepoMock.EXPECT().GetChannelMessagesExcludingAuthors(ctx, entity.GeneralChannelID, []string{"spammer"}, 0, 10).
+		Return([]*entity.PublicMessage{{ID: 1, FromUsername: "friend", Content: "content"}})
+
+	got, err := service.GetAllPublicMessagesHidingBlocked(ctx, "username", 0, 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.PublicMessage{{ID: 1, FromUsername: "friend", Content: "content"}}, got)
+
+	blockRepoMock.EXPECT().GetBlocks(ctx, "username").Return(nil, errors.New("err"))
+
+	_, err = service.GetAllPublicMessagesHidingBlocked(ctx, "username", 0, 10)
+	assert.Error(t, err)
+}
+
+func TestPublicMessageService_Edit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	msgRepoMock := mocks.NewMockPublicMessageRepo(ctrl)
+	reactionRepoMock := mocks.NewMockReactionRepo(ctrl)
+	attachmentRepoMock := mocks.NewMockMessageAttachmentRepo(ctrl)
+	profileRepoMock := mocks.NewMockMessageProfileRepo(ctrl)
+	blockRepoMock := mocks.NewMockMessageBlockRepo(ctrl)
+	mentionRepoMock := mocks.NewMockMessageMentionRepo(ctrl)
+	userRepoMock := mocks.NewMockUserRepo(ctrl)
+	channelRepoMock := mocks.NewMockMessageChannelRepo(ctrl)
+	notifierMock := mocks.NewMockPublicMessageNotifier(ctrl)
+
+	service := New(msgRepoMock, reactionRepoMock, attachmentRepoMock, profileRepoMock, blockRepoMock, mentionRepoMock, userRepoMock, channelRepoMock,
+		testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
+
+	// authors are checked in send tests
+	profileRepoMock.
+		EXPECT().
+		GetProfilesByUsernames(ctx, gomock.Any()).
+		Return(nil, nil).
+		AnyTimes()
+
+	type inputArgs struct {
+		id       int
+		username string
+		content  string
+	}
+
+	type outputArg = *entity.PublicMessage
+
+	existing := &entity.PublicMessage{
+		ID:           1,
+		ChannelID:    entity.GeneralChannelID,
+		FromUsername: "username",
+		Content:      "content",
+		SentAt:       now,
+		EditedAt:     now,
+	}
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		input         inputArgs
+		want          outputArg
+		wantErr       bool
+	}{
+		{
+			name: "ok, author edits message",
+			mockBehaviour: func() {
+				msgRepoMock.
+					EXPECT().
+					GetPublicMessage(ctx, 1).
+					Return(existing, nil)
+
+				msgRepoMock.
+					EXPECT().
+					UpdatePublicMessage(ctx, 1, entity.PublicMessage{Content: "edited"}).
+					Return(&entity.PublicMessage{
+						ID:           1,
+						ChannelID:    entity.GeneralChannelID,
+						FromUsername: "username",
+						Content:      "edited",
+						SentAt:       now,
+						EditedAt:     now,
+					}, nil)
+
+				notifierMock.
+					EXPECT().
+					NotifyPublicMessageEdited(ctx, gomock.Any())
+			},
+			input: inputArgs{
+				id:       1,
+				username: "username",
+				content:  "edited",
+			},
+			want: &entity.PublicMessage{
+				ID:           1,
+				ChannelID:    entity.GeneralChannelID,
+				FromUsername: "username",
+				Content:      "edited",
+				SentAt:       now,
+				EditedAt:     now,
+			},
+		},
+		{
+			name: "ok, content not changed",
+			mockBehaviour: func() {
+				msgRepoMock.
+					EXPECT().
+					GetPublicMessage(ctx, 1).
+					Return(existing, nil)
+			},
+			input: inputArgs{
+				id:       1,
+				username: "username",
+				content:  "content",
+			},
+			want: existing,
+		},
+		{
+			name: "err, not an author",
+			mockBehaviour: func() {
+				msgRepoMock.
+					EXPECT().
+					GetPublicMessage(ctx, 1).
+					Return(existing, nil)
+			},
+			input: inputArgs{
+				id:       1,
+				username: "other",
+				content:  "edited",
+			},
+			wantErr: true,
+		},
+		{
+			name: "err, message of other channel",
+			mockBehaviour: func() {
+				msgRepoMock.
+					EXPECT().
+					GetPublicMessage(ctx, 3).
+					Return(&entity.PublicMessage{ID: 3, ChannelID: 2, FromUsername: "username", Content: "content"}, nil)
+			},
+			input: inputArgs{
+				id:       3,
+				username: "username",
+				content:  "edited",
+			},
+			wantErr: true,
+		},
+		{
+			name: "err, no such message",
+			mockBehaviour: func() {
+				msgRepoMock.
+					EXPECT().
+					GetPublicMessage(ctx, 2).
+					Return(nil, repoerrors.ErrNoSuchPublicMessage)
+			},
+			input: inputArgs{
+				id:       2,
+				username: "username",
+				content:  "edited",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			got, err := service.EditPublicMessage(ctx, test.input.id, test.input.username, test.input.content)
+
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, testingutils.PublicMessagesEquals(*test.want, *got))
+			}
+		})
+	}
+}
+
+func TestPublicMessageService_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	msgRepoMock := mocks.NewMockPublicMessageRepo(ctrl)
+	reactionRepoMock := mocks.NewMockReactionRepo(ctrl)
+	attachmentRepoMock := mocks.NewMockMessageAttachmentRepo(ctrl)
+	profileRepoMock := mocks.NewMockMessageProfileRepo(ctrl)
+	blockRepoMock := mocks.NewMockMessageBlockRepo(ctrl)
+	mentionRepoMock := mocks.NewMockMessageMentionRepo(ctrl)
+	userRepoMock := mocks.NewMockUserRepo(ctrl)
+	channelRepoMock := mocks.NewMockMessageChannelRepo(ctrl)
+	notifierMock := mocks.NewMockPublicMessageNotifier(ctrl)
+
+	service := New(msgRepoMock, reactionRepoMock, attachmentRepoMock, profileRepoMock, blockRepoMock, mentionRepoMock, userRepoMock, channelRepoMock,
+		testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
+
+	// authors are checked in send tests
+	profileRepoMock.
+		EXPECT().
+		GetProfilesByUsernames(ctx, gomock.Any()).
+		Return(nil, nil).
+		AnyTimes()
+
+	type inputArgs struct {
+		id       int
+		username string
+	}
+
+	type outputArg = *entity.PublicMessage
+
+	existing := &entity.PublicMessage{
+		ID:           1,
+		ChannelID:    entity.GeneralChannelID,
+		FromUsername: "username",
+		Content:      "content",
+		SentAt:       now,
+		EditedAt:     now,
+	}
+
+	deleted := &entity.PublicMessage{
+		ID:           1,
+		ChannelID:    entity.GeneralChannelID,
+		FromUsername: "username",
+		SentAt:       now,
+		EditedAt:     now,
+		DeletedAt:    &now,
+	}
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		input         inputArgs
+		want          outputArg
+		wantErr       bool
+	}{
+		{
+			name: "ok, author deletes message",
+			mockBehaviour: func() {
+				msgRepoMock.
+					EXPECT().
+					GetPublicMessage(ctx, 1).
+					Return(existing, nil)
+
+				msgRepoMock.
+					EXPECT().
+					DeletePublicMessage(ctx, 1).
+					Return(deleted, nil)
+
+				notifierMock.
+					EXPECT().
+					NotifyPublicMessageDeleted(ctx, deleted)
+			},
+			input: inputArgs{
+				id:       1,
+				username: "username",
+			},
+			want: deleted,
+		},
+		{
+			name: "ok, already deleted",
+			mockBehaviour: func() {
+				msgRepoMock.
+					EXPECT().
+					GetPublicMessage(ctx, 1).
+					Return(deleted, nil)
+			},
+			input: inputArgs{
+				id:       1,
+				username: "username",
+			},
+			want: deleted,
+		},
+		{
+			name: "err, not an author",
+			mockBehaviour: func() {
+				msgRepoMock.
+					EXPECT().
+					GetPublicMessage(ctx, 1).
+					Return(existing, nil)
+			},
+			input: inputArgs{
+				id:       1,
+				username: "other",
+			},
+			wantErr: true,
+		},
+		{
+			name: "err, message of other channel",
+			mockBehaviour: func() {
+				msgRepoMock.
+					EXPECT().
+					GetPublicMessage(ctx, 3).
+					Return(&entity.PublicMessage{ID: 3, ChannelID: 2, FromUsername: "username", Content: "content"}, nil)
+			},
+			input: inputArgs{
+				id:       3,
+				username: "username",
+			},
+			wantErr: true,
+		},
+		{
+			name: "err, no such message",
+			mockBehaviour: func() {
+				msgRepoMock.
+					EXPECT().
+					GetPublicMessage(ctx, 2).
+					Return(nil, repoerrors.ErrNoSuchPublicMessage)
+			},
+			input: inputArgs{
+				id:       2,
+				username: "username",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			got, err := service.DeletePublicMessage(ctx, test.input.id, test.input.username)
+
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
+			}
+		})
+	}
+}
+
+func TestPublicMessageService_SendReply(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	msgRepoMock := mocks.NewMockPublicMessageRepo(ctrl)
+	reactionRepoMock := mocks.NewMockReactionRepo(ctrl)
+	attachmentRepoMock := mocks.NewMockMessageAttachmentRepo(ctrl)
+	profileRepoMock := mocks.NewMockMessageProfileRepo(ctrl)
+	blockRepoMock := mocks.NewMockMessageBlockRepo(ctrl)
+	mentionRepoMock := mocks.NewMockMessageMentionRepo(ctrl)
+	userRepoMock := mocks.NewMockUserRepo(ctrl)
+	channelRepoMock := mocks.NewMockMessageChannelRepo(ctrl)
+	notifierMock := mocks.NewMockPublicMessageNotifier(ctrl)
+
+	service := New(msgRepoMock, reactionRepoMock, attachmentRepoMock, profileRepoMock, blockRepoMock, mentionRepoMock, userRepoMock, channelRepoMock,
+		testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
+
+	// authors are checked in send tests
+	profileRepoMock.
+		EXPECT().
+		GetProfilesByUsernames(ctx, gomock.Any()).
+		Return(nil, nil).
+		AnyTimes()
+
+	rootID, replyID, otherID := 1, 2, 3
+
+	root := &entity.PublicMessage{ID: rootID, ChannelID: entity.GeneralChannelID, FromUsername: "first", SentAt: now, EditedAt: now}
+	reply := &entity.PublicMessage{ID: replyID, ChannelID: entity.GeneralChannelID, FromUsername: "second", SentAt: now, EditedAt: now, ParentID: &rootID}
+	other := &entity.PublicMessage{ID: otherID, ChannelID: 2, FromUsername: "second", SentAt: now, EditedAt: now}
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		input         entity.PublicMessage
+		wantErr       bool
+	}{
+		{
+			name: "ok, reply to reply is attached to root",
+			mockBehaviour: func() {
+				userRepoMock.EXPECT().GetUserByUsername(ctx, "username").Return(&entity.User{Username: "username"}, nil)
+				msgRepoMock.EXPECT().GetPublicMessage(ctx, replyID).Return(reply, nil)
+
+				msgRepoMock.
+					EXPECT().
+					AddPublicMessage(ctx, entity.PublicMessage{FromUsername: "username", Content: "content", ParentID: &rootID}).
+					Return(&entity.PublicMessage{ID: 4, ChannelID: entity.GeneralChannelID, FromUsername: "username", Content: "content", ParentID: &rootID}, nil)
+
+				notifierMock.EXPECT().NotifyPublicMessage(ctx, gomock.Any())
+			},
+			input: entity.PublicMessage{FromUsername: "username", Content: "content", ParentID: &replyID},
+		},
+		{
+			name: "ok, reply to root",
+			mockBehaviour: func() {
+				userRepoMock.EXPECT().GetUserByUsername(ctx, "username").Return(&entity.User{Username: "username"}, nil)
+				msgRepoMock.EXPECT().GetPublicMessage(ctx, rootID).Return(root, nil)
+
+				msgRepoMock.
+					EXPECT().
+					AddPublicMessage(ctx, entity.PublicMessage{FromUsername: "username", Content: "content", ParentID: &rootID}).
+					Return(&entity.PublicMessage{ID: 4, ChannelID: entity.GeneralChannelID, FromUsername: "username", Content: "content", ParentID: &rootID}, nil)
+
+				notifierMock.EXPECT().NotifyPublicMessage(ctx, gomock.Any())
+			},
+			input: entity.PublicMessage{FromUsername: "username", Content: "content", ParentID: &rootID},
+		},
+		{
+			name: "err, parent from another channel",
+			mockBehaviour: func() {
+				userRepoMock.EXPECT().GetUserByUsername(ctx, "username").Return(&entity.User{Username: "username"}, nil)
+				msgRepoMock.EXPECT().GetPublicMessage(ctx, otherID).Return(other, nil)
+			},
+			input:   entity.PublicMessage{FromUsername: "username", Content: "content", ParentID: &otherID},
+			wantErr: true,
+		},
+		{
+			name: "err, no such parent",
+			mockBehaviour: func() {
+				userRepoMock.EXPECT().GetUserByUsername(ctx, "username").Return(&entity.User{Username: "username"}, nil)
+				msgRepoMock.EXPECT().GetPublicMessage(ctx, 10).Return(nil, repoerrors.ErrNoSuchPublicMessage)
+			},
+			input:   entity.PublicMessage{FromUsername: "username", Content: "content", ParentID: func() *int { id := 10; return &id }()},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			got, err := service.SendPublicMessage(ctx, test.input)
+
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, rootID, *got.ParentID)
+			}
+		})
+	}
+}
+
+func TestPublicMessageService_AddReaction(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	msgRepoMock := mocks.NewMockPublicMessageRepo(ctrl)
+	reactionRepoMock := mocks.NewMockReactionRepo(ctrl)
+	attachmentRepoMock := mocks.NewMockMessageAttachmentRepo(ctrl)
+	profileRepoMock := mocks.NewMockMessageProfileRepo(ctrl)
+	blockRepoMock := mocks.NewMockMessageBlockRepo(ctrl)
+	mentionRepoMock := mocks.NewMockMessageMentionRepo(ctrl)
+	userRepoMock := mocks.NewMockUserRepo(ctrl)
+	channelRepoMock := mocks.NewMockMessageChannelRepo(ctrl)
+	notifierMock := mocks.NewMockPublicMessageNotifier(ctrl)
+
+	service := New(msgRepoMock, reactionRepoMock, attachmentRepoMock, profileRepoMock, blockRepoMock, mentionRepoMock, userRepoMock, channelRepoMock,
+		testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
+
+	// authors are checked in send tests
+	profileRepoMock.
+		EXPECT().
+		GetProfilesByUsernames(ctx, gomock.Any()).
+		Return(nil, nil).
+		AnyTimes()
+
+	msg := entity.PublicMessage{ID: 1, ChannelID: entity.GeneralChannelID, FromUsername: "first", Content: "content", SentAt: now, EditedAt: now}
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		id            int
+		want          []entity.ReactionSummary
+		wantErr       error
+	}{
+		{
+			name: "ok",
+			mockBehaviour: func() {
+				msgCopy := msg
+				msgRepoMock.EXPECT().GetPublicMessage(ctx, 1).Return(&msgCopy, nil)
+
+				reactionRepoMock.
+					EXPECT().
+					AddReaction(ctx, entity.MessageKindPublic, entity.Reaction{MessageID: 1, Username: "username", Emoji: "👍"}).
+					Return(&entity.Reaction{ID: 2, MessageID: 1, Username: "username", Emoji: "👍"}, nil)
+
+				reactionRepoMock.
+					EXPECT().
+					GetReactions(ctx, entity.MessageKindPublic, []int{1}).
+					Return([]*entity.Reaction{
+						{ID: 1, MessageID: 1, Username: "first", Emoji: "👍"},
+						{ID: 2, MessageID: 1, Username: "username", Emoji: "👍"},
+					}, nil)
+
+				attachmentRepoMock.
+					EXPECT().
+					GetMessageAttachments(ctx, entity.MessageKindPublic, []int{1}).
+					Return(nil, nil)
+			},
+			id:   1,
+			want: []entity.ReactionSummary{{Emoji: "👍", Count: 2, Reacted: true}},
+		},
+		{
+			name: "err, reaction already exists",
+			mockBehaviour: func() {
+				msgCopy := msg
+				msgRepoMock.EXPECT().GetPublicMessage(ctx, 1).Return(&msgCopy, nil)
+
+				reactionRepoMock.
+					EXPECT().
+					AddReaction(ctx, entity.MessageKindPublic, entity.Reaction{MessageID: 1, Username: "username", Emoji: "👍"}).
+					Return(nil, repoerrors.ErrReactionExists)
+			},
+			id:      1,
+			wantErr: repoerrors.ErrReactionExists,
+		},
+		{
+			name: "err, message of another channel",
+			mockBehaviour: func() {
+				msgRepoMock.EXPECT().GetPublicMessage(ctx, 2).Return(&entity.PublicMessage{ID: 2, ChannelID: 2}, nil)
+			},
+			id:      2,
+			wantErr: repoerrors.ErrNoSuchPublicMessage,
+		},
+		{
+			name: "err, message deleted",
+			mockBehaviour: func() {
+				msgRepoMock.EXPECT().GetPublicMessage(ctx, 3).Return(&entity.PublicMessage{ID: 3, ChannelID: entity.GeneralChannelID, DeletedAt: &now}, nil)
+			},
+			id:      3,
+			wantErr: message.ErrMessageDeleted,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			got, err := service.AddPublicMessageReaction(ctx, test.id, "username", "👍")
+
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, got.Reactions)
+			}
+		})
+	}
+}
+
+func TestPublicMessageService_SendWithMentions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	msgRepoMock := mocks.NewMockPublicMessageRepo(ctrl)
+	reactionRepoMock := mocks.NewMockReactionRepo(ctrl)
+	attachmentRepoMock := mocks.NewMockMessageAttachmentRepo(ctrl)
+	profileRepoMock := mocks.NewMockMessageProfileRepo(ctrl)
+	blockRepoMock := mocks.NewMockMessageBlockRepo(ctrl)
+	mentionRepoMock := mocks.NewMockMessageMentionRepo(ctrl)
+	userRepoMock := mocks.NewMockUserRepo(ctrl)
+	channelRepoMock := mocks.NewMockMessageChannelRepo(ctrl)
+	notifierMock := mocks.NewMockPublicMessageNotifier(ctrl)
+
+	service := New(msgRepoMock, reactionRepoMock, attachmentRepoMock, profileRepoMock, blockRepoMock, mentionRepoMock, userRepoMock, channelRepoMock,
+		testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
+
+	content := "@bob @ghost @carol and @username, see @bob"
+
+	userRepoMock.EXPECT().GetUserByUsername(ctx, "username").Return(&entity.User{Username: "username"}, nil)
+	msgRepoMock.EXPECT().AddPublicMessage(ctx, entity.PublicMessage{FromUsername: "username", Content: content}).
+		Return(&entity.PublicMessage{ID: 1, FromUsername: "username", Content: content}, nil)
+	profileRepoMock.EXPECT().GetProfilesByUsernames(ctx, gomock.Any()).Return(nil, nil)
+
+	// ghost does not exist and carol has blocked author, so only bob is mentioned
+	userRepoMock.EXPECT().GetUserByUsername(ctx, "bob").Return(&entity.User{Username: "bob"}, nil)
+	userRepoMock.EXPECT().GetUserByUsername(ctx, "ghost").Return(nil, repoerrors.ErrNoSuchUser)
+	userRepoMock.EXPECT().GetUserByUsername(ctx, "carol").Return(&entity.User{Username: "carol"}, nil)
+	blockRepoMock.EXPECT().IsBlocked(ctx, "bob", "username").Return(false, nil)
+	blockRepoMock.EXPECT().IsBlocked(ctx, "carol", "username").Return(true, nil)
+
+	mention := &entity.Mention{ID: 1, MessageKind: entity.MessageKindPublic, MessageID: 1, Username: "bob", FromUsername: "username"}
+
+	mentionRepoMock.EXPECT().AddMentions(ctx, []entity.Mention{
+		{MessageKind: entity.MessageKindPublic, MessageID: 1, Username: "bob", FromUsername: "username"},
+	}).Return([]*entity.Mention{mention}, nil)
+	notifierMock.EXPECT().NotifyPublicMessage(ctx, gomock.Any())
+	notifierMock.EXPECT().NotifyMention(ctx, mention)
+
+	got, err := service.SendPublicMessage(ctx, entity.PublicMessage{FromUsername: "username", Content: content})
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.MentionSpan{
+		{Username: "bob", Offset: 0, Length: 4},
+		{Username: "bob", Offset: 38, Length: 4},
+	}, got.Mentions)
+	assert.Equal(t, content, mention.Content)
+}
+
+func TestPublicMessageService_Purge(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	msgRepoMock := mocks.NewMockPublicMessageRepo(ctrl)
+	reactionRepoMock := mocks.NewMockReactionRepo(ctrl)
+	attachmentRepoMock := mocks.NewMockMessageAttachmentRepo(ctrl)
+	profileRepoMock := mocks.NewMockMessageProfileRepo(ctrl)
+	blockRepoMock := mocks.NewMockMessageBlockRepo(ctrl)
+	mentionRepoMock := mocks.NewMockMessageMentionRepo(ctrl)
+	userRepoMock := mocks.NewMockUserRepo(ctrl)
+	channelRepoMock := mocks.NewMockMessageChannelRepo(ctrl)
+	notifierMock := mocks.NewMockPublicMessageNotifier(ctrl)
+
+	service := New(msgRepoMock, reactionRepoMock, attachmentRepoMock, profileRepoMock, blockRepoMock, mentionRepoMock, userRepoMock, channelRepoMock,
+		testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
+
+	general := &entity.PublicMessage{ID: 1, ChannelID: entity.GeneralChannelID, FromUsername: "username"}
+	private := &entity.PublicMessage{ID: 2, ChannelID: 2, FromUsername: "username"}
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		id            int
+		wantErr       bool
+	}{
+		{
+			name: "ok, message of general channel is purged for everyone",
+			mockBehaviour: func() {
+				msgRepoMock.
+					EXPECT().
+					GetPublicMessage(ctx, 1).
+					Return(general, nil)
+
+				msgRepoMock.
+					EXPECT().
+					PurgePublicMessage(ctx, 1).
+					Return(nil)
+
+				notifierMock.
+					EXPECT().
+					NotifyPublicMessagePurged(ctx, general)
+			},
+			id: 1,
+		},
+		{
+			name: "ok, message of other channel is purged only for its members",
+			mockBehaviour: func() {
+				msgRepoMock.
+					EXPECT().
+					GetPublicMessage(ctx, 2).
+					Return(private, nil)
+
+				msgRepoMock.
+					EXPECT().
+					PurgePublicMessage(ctx, 2).
+					Return(nil)
+
+				channelRepoMock.
+					EXPECT().
+					GetChannelMembers(ctx, 2).
+					Return([]*entity.ChannelMember{{ChannelID: 2, Username: "username"}, {ChannelID: 2, Username: "member"}}, nil)
+
+				notifierMock.
+					EXPECT().
+					NotifyChannelMessagePurged(ctx, private, []string{"username", "member"})
+			},
+			id: 2,
+		},
+		{
+			name: "err, no such message",
+			mockBehaviour: func() {
+				msgRepoMock.
+					EXPECT().
+					GetPublicMessage(ctx, 3).
+					Return(nil, repoerrors.ErrNoSuchPublicMessage)
+			},
+			id:      3,
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			err := service.PurgePublicMessage(ctx, test.id)
+
+			if test.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -4,14 +4,24 @@ import (
 	"context"
 
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message"
 )
 
 //go:generate mockgen -destination=../../../mocks/public_message_repository.go -package=mocks github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message/public PublicMessageRepo
+//go:generate mockgen -destination=../../../mocks/public_message_notifier.go -package=mocks -mock_names=Notifier=MockPublicMessageNotifier github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message/public Notifier
 
 type PublicMessageRepo interface {
 	AddPublicMessage(ctx context.Context, msg entity.PublicMessage) (*entity.PublicMessage, error)
-	GetAllPublicMessages(ctx context.Context, offset, limit int) []*entity.PublicMessage
+	GetChannelMessages(ctx context.Context, channelID, offset, limit int) []*entity.PublicMessage
+	GetChannelMessagesExcludingAuthors(ctx context.Context, channelID int, authors []string, offset, limit int) []*entity.PublicMessage
 	GetPublicMessage(ctx context.Context, id int) (*entity.PublicMessage, error)
+	UpdatePublicMessage(ctx context.Context, id int, updated entity.PublicMessage) (*entity.PublicMessage, error)
+	GetPublicMessageRevisions(ctx context.Context, messageID int) ([]*entity.MessageRevision, error)
+	DeletePublicMessage(ctx context.Context, id int) (*entity.PublicMessage, error)
+	PurgePublicMessage(ctx context.Context, id int) error
+	GetPublicMessageReplies(ctx context.Context, parentID, offset, limit int) []*entity.PublicMessage
+	CountPublicMessageReplies(ctx context.Context, parentIDs []int) (map[int]int, error)
 }
 
 type UserRepo interface {
@@ -20,15 +30,51 @@ type UserRepo interface {
 	GetUserByUsername(ctx context.Context, username string) (*entity.User, error)
 }
 
+type Notifier interface {
+	NotifyPublicMessage(ctx context.Context, msg *entity.PublicMessage)
+	NotifyPublicMessageEdited(ctx context.Context, msg *entity.PublicMessage)
+	NotifyPublicMessageDeleted(ctx context.Context, msg *entity.PublicMessage)
+	NotifyPublicMessagePurged(ctx context.Context, msg *entity.PublicMessage)
+	NotifyChannelMessagePurged(ctx context.Context, msg *entity.PublicMessage, members []string)
+	NotifyMention(ctx context.Context, mention *entity.Mention)
+}
+
 type Service struct {
 	PublicMessageRepo PublicMessageRepo
+	ReactionRepo      message.ReactionRepo
+	AttachmentRepo    message.AttachmentRepo
+	ProfileRepo       message.ProfileRepo
+	BlockRepo         message.BlockRepo
+	MentionRepo       message.MentionRepo
 	UserRepo          UserRepo
+	ChannelRepo       message.ChannelRepo
+	UnitOfWork        message.UnitOfWork
+	Notifier          Notifier
 }
 
-func New(publicMessageRepo PublicMessageRepo, userRepo UserRepo) *Service {
+func New(
+	publicMessageRepo PublicMessageRepo,
+	reactionRepo message.ReactionRepo,
+	attachmentRepo message.AttachmentRepo,
+	profileRepo message.ProfileRepo,
+	blockRepo message.BlockRepo,
+	mentionRepo message.MentionRepo,
+	userRepo UserRepo,
+	channelRepo message.ChannelRepo,
+	unitOfWork message.UnitOfWork,
+	notifier Notifier,
+) *Service {
 	return &Service{
 		PublicMessageRepo: publicMessageRepo,
+		ReactionRepo:      reactionRepo,
+		AttachmentRepo:    attachmentRepo,
+		ProfileRepo:       profileRepo,
+		BlockRepo:         blockRepo,
+		MentionRepo:       mentionRepo,
 		UserRepo:          userRepo,
+		ChannelRepo:       channelRepo,
+		UnitOfWork:        unitOfWork,
+		Notifier:          notifier,
 	}
 }
 
@@ -38,11 +84,57 @@ func (s *Service) SendPublicMessage(ctx context.Context, msg entity.PublicMessag
 		return nil, err
 	}
 
-	created, err := s.PublicMessageRepo.AddPublicMessage(ctx, msg)
+	if err := message.ResolvePublicParent(ctx, s.PublicMessageRepo, &msg); err != nil {
+		return nil, err
+	}
+
+	attachmentIDs := message.TakeAttachmentIDs(&msg.Attachments)
+
+	if err := message.CheckAttachments(ctx, s.AttachmentRepo, attachmentIDs, msg.FromUsername); err != nil {
+		return nil, err
+	}
+
+	var (
+		created  *entity.PublicMessage
+		mentions []*entity.Mention
+	)
+
+	// message is stored together with its attachments and mentions or not at all
+	err := s.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+
+		if created, err = s.PublicMessageRepo.AddPublicMessage(ctx, msg); err != nil {
+			return err
+		}
+
+		created.Attachments, err = message.LinkAttachments(ctx, s.AttachmentRepo, entity.MessageKindPublic, created.ID, attachmentIDs)
+		if err != nil {
+			return err
+		}
+
+		// anyone can read general channel, thus any user can be mentioned in it unless they have blocked the author
+		mentions, created.Mentions, err = message.RecordMentions(ctx, s.MentionRepo, s.UserRepo, entity.MessageKindPublic,
+			created.ID, created.FromUsername, created.Content, func(ctx context.Context, username string) (bool, error) {
+				blocked, err := s.BlockRepo.IsBlocked(ctx, username, created.FromUsername)
+				return !blocked, err
+			})
+
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	// message is already sent, it is delivered without author profile if it can not be loaded
+	_ = message.FillPublicAuthors(ctx, s.ProfileRepo, []*entity.PublicMessage{created})
+
+	// push message to live connections
+	s.Notifier.NotifyPublicMessage(ctx, created)
+
+	for _, mention := range mentions {
+		s.Notifier.NotifyMention(ctx, mention)
+	}
+
 	return created, nil
 }
 
@@ -55,6 +147,285 @@ func (s *Service) GetPublicMessage(ctx context.Context, id int) (*entity.PublicM
 	return msg, nil
 }
 
-func (s *Service) GetAllPublicMessages(ctx context.Context, offset, limit int) []*entity.PublicMessage {
-	return s.PublicMessageRepo.GetAllPublicMessages(ctx, offset, limit)
+// GetAllPublicMessages returns messages of general channel, which replaced former global public chat.
+// Reactions are filled in as they are seen by user.
+func (s *Service) GetAllPublicMessages(ctx context.Context, username string, offset, limit int) []*entity.PublicMessage {
+	messages := s.PublicMessageRepo.GetChannelMessages(ctx, entity.GeneralChannelID, offset, limit)
+
+	s.fillFeed(ctx, messages, username)
+
+	return messages
+}
+
+// GetAllPublicMessagesHidingBlocked returns messages of general channel except ones sent by users blocked by user.
+func (s *Service) GetAllPublicMessagesHidingBlocked(ctx context.Context, username string, offset, limit int) ([]*entity.PublicMessage, error) {
+	blocked, err := message.BlockedUsernames(ctx, s.BlockRepo, username)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := s.PublicMessageRepo.GetChannelMessagesExcludingAuthors(ctx, entity.GeneralChannelID, blocked, offset, limit)
+
+	s.fillFeed(ctx, messages, username)
+
+	return messages, nil
+}
+
+func (s *Service) fillFeed(ctx context.Context, messages []*entity.PublicMessage, username string) {
+	// reply counts, reactions, attachments, mentions and authors are informational,
+	// messages are returned even if filling them failed
+	_ = message.FillPublicReplyCounts(ctx, s.PublicMessageRepo, messages)
+	_ = message.FillPublicReactions(ctx, s.ReactionRepo, messages, username)
+	_ = message.FillPublicAttachments(ctx, s.AttachmentRepo, messages)
+	_ = message.FillPublicMentions(ctx, s.MentionRepo, messages)
+	_ = message.FillPublicAuthors(ctx, s.ProfileRepo, messages)
+}
+
+// GetPublicMessageThread returns root of the thread that message belongs to and paginated replies of it.
+// Threads of other channels are served by channel service, which checks membership.
+func (s *Service) GetPublicMessageThread(ctx context.Context, id int, username string, offset, limit int) (*entity.PublicMessage, []*entity.PublicMessage, error) {
+	root, err := s.PublicMessageRepo.GetPublicMessage(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if root.IsReply() {
+		if root, err = s.PublicMessageRepo.GetPublicMessage(ctx, *root.ParentID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if root.ChannelID != entity.GeneralChannelID {
+		return nil, nil, repository.ErrNoSuchPublicMessage
+	}
+
+	if err = message.FillPublicReplyCounts(ctx, s.PublicMessageRepo, []*entity.PublicMessage{root}); err != nil {
+		return nil, nil, err
+	}
+
+	replies := s.PublicMessageRepo.GetPublicMessageReplies(ctx, root.ID, offset, limit)
+
+	thread := append([]*entity.PublicMessage{root}, replies...)
+
+	if err = message.FillPublicReactions(ctx, s.ReactionRepo, thread, username); err != nil {
+		return nil, nil, err
+	}
+
+	if err = message.FillPublicAttachments(ctx, s.AttachmentRepo, thread); err != nil {
+		return nil, nil, err
+	}
+
+	if err = message.FillPublicMentions(ctx, s.MentionRepo, thread); err != nil {
+		return nil, nil, err
+	}
+
+	if err = message.FillPublicAuthors(ctx, s.ProfileRepo, thread); err != nil {
+		return nil, nil, err
+	}
+
+	return root, replies, nil
+}
+
+// getReactablePublicMessage returns message of general channel that user can react to.
+// Messages of other channels are reacted through channel service, which checks membership.
+func (s *Service) getReactablePublicMessage(ctx context.Context, id int) (*entity.PublicMessage, error) {
+	msg, err := s.PublicMessageRepo.GetPublicMessage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if msg.ChannelID != entity.GeneralChannelID {
+		return nil, repository.ErrNoSuchPublicMessage
+	}
+
+	if msg.IsDeleted() {
+		return nil, message.ErrMessageDeleted
+	}
+
+	return msg, nil
+}
+
+// AddPublicMessageReaction adds emoji reaction of user to message and returns message with updated reactions.
+func (s *Service) AddPublicMessageReaction(ctx context.Context, id int, username, emoji string) (*entity.PublicMessage, error) {
+	msg, err := s.getReactablePublicMessage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	reaction := entity.Reaction{
+		MessageID: msg.ID,
+		Username:  username,
+		Emoji:     emoji,
+	}
+
+	if _, err = s.ReactionRepo.AddReaction(ctx, entity.MessageKindPublic, reaction); err != nil {
+		return nil, err
+	}
+
+	if err = message.FillPublicReactions(ctx, s.ReactionRepo, []*entity.PublicMessage{msg}, username); err != nil {
+		return nil, err
+	}
+
+	if err = message.FillPublicAttachments(ctx, s.AttachmentRepo, []*entity.PublicMessage{msg}); err != nil {
+		return nil, err
+	}
+
+	if err = message.FillPublicMentions(ctx, s.MentionRepo, []*entity.PublicMessage{msg}); err != nil {
+		return nil, err
+	}
+
+	if err = message.FillPublicAuthors(ctx, s.ProfileRepo, []*entity.PublicMessage{msg}); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// RemovePublicMessageReaction removes emoji reaction of user from message and returns message with updated reactions.
+func (s *Service) RemovePublicMessageReaction(ctx context.Context, id int, username, emoji string) (*entity.PublicMessage, error) {
+	msg, err := s.getReactablePublicMessage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.ReactionRepo.RemoveReaction(ctx, entity.MessageKindPublic, msg.ID, username, emoji); err != nil {
+		return nil, err
+	}
+
+	if err = message.FillPublicReactions(ctx, s.ReactionRepo, []*entity.PublicMessage{msg}, username); err != nil {
+		return nil, err
+	}
+
+	if err = message.FillPublicAttachments(ctx, s.AttachmentRepo, []*entity.PublicMessage{msg}); err != nil {
+		return nil, err
+	}
+
+	if err = message.FillPublicMentions(ctx, s.MentionRepo, []*entity.PublicMessage{msg}); err != nil {
+		return nil, err
+	}
+
+	if err = message.FillPublicAuthors(ctx, s.ProfileRepo, []*entity.PublicMessage{msg}); err != nil {
+		return nil, err
+	}
+
+	return msg, nil
+}
+
+// EditPublicMessage replaces content of message of general channel.
+// Messages of other channels are edited through channel service, which checks membership.
+func (s *Service) EditPublicMessage(ctx context.Context, id int, editorUsername, content string) (*entity.PublicMessage, error) {
+	msg, err := s.PublicMessageRepo.GetPublicMessage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if msg.ChannelID != entity.GeneralChannelID {
+		return nil, repository.ErrNoSuchPublicMessage
+	}
+
+	// only author can edit their message
+	if msg.FromUsername != editorUsername {
+		return nil, message.ErrNotMessageAuthor
+	}
+
+	if msg.IsDeleted() {
+		return nil, message.ErrMessageDeleted
+	}
+
+	// content not changed, thus no need for new revision
+	if msg.Content == content {
+		return msg, nil
+	}
+
+	updated, err := s.PublicMessageRepo.UpdatePublicMessage(ctx, id, entity.PublicMessage{Content: content})
+	if err != nil {
+		return nil, err
+	}
+
+	s.Notifier.NotifyPublicMessageEdited(ctx, updated)
+
+	return updated, nil
+}
+
+// GetPublicMessageRevisions returns edit history of message. History is kept for moderation, so roles allowed
+// to read revisions read it in any channel, even after message is deleted.
+func (s *Service) GetPublicMessageRevisions(ctx context.Context, id int, role entity.Role) ([]*entity.MessageRevision, error) {
+	msg, err := s.PublicMessageRepo.GetPublicMessage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	isModerator := role.HasPermission(entity.PermissionReadRevisions)
+
+	// history of other channels is read by regular users through channel service, which checks membership
+	if msg.ChannelID != entity.GeneralChannelID && !isModerator {
+		return nil, repository.ErrNoSuchPublicMessage
+	}
+
+	// history of deleted message is not exposed to regular users
+	if msg.IsDeleted() && !isModerator {
+		return nil, message.ErrMessageDeleted
+	}
+
+	return s.PublicMessageRepo.GetPublicMessageRevisions(ctx, id)
+}
+
+// DeletePublicMessage leaves tombstone in place of message of general channel.
+// Messages of other channels are deleted through channel service, which checks membership.
+func (s *Service) DeletePublicMessage(ctx context.Context, id int, username string) (*entity.PublicMessage, error) {
+	msg, err := s.PublicMessageRepo.GetPublicMessage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if msg.ChannelID != entity.GeneralChannelID {
+		return nil, repository.ErrNoSuchPublicMessage
+	}
+
+	// only author can delete their message
+	if msg.FromUsername != username {
+		return nil, message.ErrNotMessageAuthor
+	}
+
+	// already deleted, nothing to do
+	if msg.IsDeleted() {
+		return msg, nil
+	}
+
+	deleted, err := s.PublicMessageRepo.DeletePublicMessage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	s.Notifier.NotifyPublicMessageDeleted(ctx, deleted)
+
+	return deleted, nil
+}
+
+// PurgePublicMessage removes message with its history completely. Caller must ensure that user has admin rights.
+func (s *Service) PurgePublicMessage(ctx context.Context, id int) error {
+	msg, err := s.PublicMessageRepo.GetPublicMessage(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if err = s.PublicMessageRepo.PurgePublicMessage(ctx, id); err != nil {
+		return err
+	}
+
+	if msg.ChannelID == entity.GeneralChannelID {
+		s.Notifier.NotifyPublicMessagePurged(ctx, msg)
+
+		return nil
+	}
+
+	// message is already purged, members are not notified if they can not be loaded
+	members, err := message.ChannelMemberUsernames(ctx, s.ChannelRepo, msg.ChannelID)
+	if err != nil {
+		return nil
+	}
+
+	s.Notifier.NotifyChannelMessagePurged(ctx, msg, members)
+
+	return nil
 }
@@ -0,0 +1,74 @@
+package message
+
+import (
+	"context"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+
+	sliceutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/slice"
+)
+
+//go:generate mockgen -destination=../../mocks/reaction_repository.go -package=mocks github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/message ReactionRepo
+
+type ReactionRepo interface {
+	AddReaction(ctx context.Context, kind entity.MessageKind, reaction entity.Reaction) (*entity.Reaction, error)
+	RemoveReaction(ctx context.Context, kind entity.MessageKind, messageID int, username, emoji string) error
+	GetReactions(ctx context.Context, kind entity.MessageKind, messageIDs []int) ([]*entity.Reaction, error)
+}
+
+// summarizeReactions groups reactions of each message by emoji, keeping order in which emojis were first added.
+func summarizeReactions(reactions []*entity.Reaction, username string) map[int][]entity.ReactionSummary {
+	summaries := make(map[int][]entity.ReactionSummary)
+
+	for _, reaction := range reactions {
+		msgSummaries := summaries[reaction.MessageID]
+
+		i := 0
+		for i < len(msgSummaries) && msgSummaries[i].Emoji != reaction.Emoji {
+			i++
+		}
+
+		if i == len(msgSummaries) {
+			msgSummaries = append(msgSummaries, entity.ReactionSummary{Emoji: reaction.Emoji})
+		}
+
+		msgSummaries[i].Count++
+		msgSummaries[i].Reacted = msgSummaries[i].Reacted || reaction.Username == username
+
+		summaries[reaction.MessageID] = msgSummaries
+	}
+
+	return summaries
+}
+
+// FillPublicReactions sets Reactions of provided messages as they are seen by user.
+func FillPublicReactions(ctx context.Context, repo ReactionRepo, messages []*entity.PublicMessage, username string) error {
+	reactions, err := repo.GetReactions(ctx, entity.MessageKindPublic, sliceutils.Map(messages, func(m *entity.PublicMessage) int { return m.ID }))
+	if err != nil {
+		return err
+	}
+
+	summaries := summarizeReactions(reactions, username)
+
+	for _, msg := range messages {
+		msg.Reactions = summaries[msg.ID]
+	}
+
+	return nil
+}
+
+// FillPrivateReactions sets Reactions of provided messages as they are seen by user.
+func FillPrivateReactions(ctx context.Context, repo ReactionRepo, messages []*entity.PrivateMessage, username string) error {
+	reactions, err := repo.GetReactions(ctx, entity.MessageKindPrivate, sliceutils.Map(messages, func(m *entity.PrivateMessage) int { return m.ID }))
+	if err != nil {
+		return err
+	}
+
+	summaries := summarizeReactions(reactions, username)
+
+	for _, msg := range messages {
+		msg.Reactions = summaries[msg.ID]
+	}
+
+	return nil
+}
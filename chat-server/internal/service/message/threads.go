@@ -0,0 +1,117 @@
+package message
+
+import (
+	"context"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+
+	sliceutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/slice"
+)
+
+type PublicMessageGetter interface {
+	GetPublicMessage(ctx context.Context, id int) (*entity.PublicMessage, error)
+}
+
+type PublicReplyCounter interface {
+	CountPublicMessageReplies(ctx context.Context, parentIDs []int) (map[int]int, error)
+}
+
+type PrivateMessageGetter interface {
+	GetPrivateMessage(ctx context.Context, id int) (*entity.PrivateMessage, error)
+}
+
+type PrivateReplyCounter interface {
+	CountPrivateMessageReplies(ctx context.Context, parentIDs []int) (map[int]int, error)
+}
+
+func channelOf(msg *entity.PublicMessage) int {
+	if msg.ChannelID == 0 {
+		return entity.GeneralChannelID
+	}
+
+	return msg.ChannelID
+}
+
+// ResolvePublicParent validates parent of the reply. Threads are one level deep,
+// so reply to a reply is attached to the root of the thread.
+func ResolvePublicParent(ctx context.Context, repo PublicMessageGetter, msg *entity.PublicMessage) error {
+	if msg.ParentID == nil {
+		return nil
+	}
+
+	parent, err := repo.GetPublicMessage(ctx, *msg.ParentID)
+	if err != nil {
+		return err
+	}
+
+	if parent.IsDeleted() {
+		return ErrMessageDeleted
+	}
+
+	if channelOf(parent) != channelOf(msg) {
+		return ErrInvalidParentMessage
+	}
+
+	if parent.IsReply() {
+		msg.ParentID = parent.ParentID
+	}
+
+	return nil
+}
+
+// ResolvePrivateParent validates parent of the reply, it must belong to the same pair of users.
+// Reply to a reply is attached to the root of the thread.
+func ResolvePrivateParent(ctx context.Context, repo PrivateMessageGetter, msg *entity.PrivateMessage) error {
+	if msg.ParentID == nil {
+		return nil
+	}
+
+	parent, err := repo.GetPrivateMessage(ctx, *msg.ParentID)
+	if err != nil {
+		return err
+	}
+
+	if parent.IsDeleted() {
+		return ErrMessageDeleted
+	}
+
+	samePair := (parent.FromUsername == msg.FromUsername && parent.ToUsername == msg.ToUsername) ||
+		(parent.FromUsername == msg.ToUsername && parent.ToUsername == msg.FromUsername)
+	if !samePair {
+		return ErrInvalidParentMessage
+	}
+
+	if parent.IsReply() {
+		msg.ParentID = parent.ParentID
+	}
+
+	return nil
+}
+
+// FillPublicReplyCounts sets ReplyCount of provided messages.
+func FillPublicReplyCounts(ctx context.Context, repo PublicReplyCounter, messages []*entity.PublicMessage) error {
+	counts, err := repo.CountPublicMessageReplies(ctx, sliceutils.Map(messages, func(m *entity.PublicMessage) int { return m.ID }))
+	if err != nil {
+		return err
+	}
+
+	for _, msg := range messages {
+		msg.ReplyCount = counts[msg.ID]
+	}
+
+	return nil
+}
+
+// FillPrivateReplyCounts sets ReplyCount of provided messages.
+func FillPrivateReplyCounts(ctx context.Context, repo PrivateReplyCounter, messages []*entity.PrivateMessage) error {
+	counts, err := repo.CountPrivateMessageReplies(ctx, sliceutils.Map(messages, func(m *entity.PrivateMessage) int { return m.ID }))
+	if err != nil {
+		return err
+	}
+
+	for _, msg := range messages {
+		msg.ReplyCount = counts[msg.ID]
+	}
+
+	return nil
+}
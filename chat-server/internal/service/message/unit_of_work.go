@@ -0,0 +1,9 @@
+package message
+
+import "context"
+
+// UnitOfWork runs fn in transaction, repositories called with context passed to fn take part in it.
+// Message is sent in it together with its attachments and mentions, so that it is stored whole or not at all.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,132 @@
+package presence
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/mocks"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+)
+
+func TestPresenceService_Touch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	userRepoMock := mocks.NewMockPresenceUserRepo(ctrl)
+	connectionsMock := mocks.NewMockConnectionCounter(ctrl)
+
+	service := New(userRepoMock, connectionsMock, time.Minute)
+
+	userRepoMock.EXPECT().UpdateLastSeen(ctx, "alice", gomock.Any()).Return(nil).Times(1)
+	userRepoMock.EXPECT().UpdateLastSeen(ctx, "bob", gomock.Any()).Return(errors.New("err")).Times(1)
+
+	assert.NoError(t, service.Touch(ctx, "alice"))
+	assert.NoError(t, service.Touch(ctx, "alice"), "last seen time is written once in resolution")
+	assert.Error(t, service.Touch(ctx, "bob"))
+}
+
+func TestPresenceService_GetUsersPresence(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	now := time.Now()
+	recently := now.Add(-time.Minute)
+	longAgo := now.Add(-time.Hour)
+
+	userRepoMock := mocks.NewMockPresenceUserRepo(ctrl)
+	connectionsMock := mocks.NewMockConnectionCounter(ctrl)
+
+	service := New(userRepoMock, connectionsMock, 5*time.Minute)
+
+	userRepoMock.EXPECT().UpdateLastSeen(ctx, "active", gomock.Any()).Return(nil)
+	assert.NoError(t, service.Touch(ctx, "active"))
+
+	connectionsMock.EXPECT().ConnectionsCount("idle").Return(1)
+	connectionsMock.EXPECT().ConnectionsCount("gone").Return(0)
+	connectionsMock.EXPECT().ConnectionsCount("never").Return(0)
+
+	got := service.GetUsersPresence(ctx, []*entity.User{
+		{Username: "active", LastSeenAt: &longAgo},
+		{Username: "recent", LastSeenAt: &recently},
+		{Username: "idle", LastSeenAt: &longAgo},
+		{Username: "gone", LastSeenAt: &longAgo},
+		{Username: "never"},
+	})
+
+	statuses := make([]entity.PresenceStatus, 0, len(got))
+	for _, presence := range got {
+		statuses = append(statuses, presence.Status)
+	}
+
+	assert.Equal(t, []entity.PresenceStatus{
+		entity.PresenceOnline,
+		entity.PresenceOnline,
+		entity.PresenceAway,
+		entity.PresenceOffline,
+		entity.PresenceOffline,
+	}, statuses)
+
+	assert.True(t, got[0].LastSeenAt.After(longAgo), "activity seen by server is newer than persisted one")
+	assert.Equal(t, &longAgo, got[3].LastSeenAt)
+	assert.Nil(t, got[4].LastSeenAt)
+}
+
+func TestPresenceService_GetPresenceByUsernames(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	userRepoMock := mocks.NewMockPresenceUserRepo(ctrl)
+	connectionsMock := mocks.NewMockConnectionCounter(ctrl)
+
+	service := New(userRepoMock, connectionsMock, 5*time.Minute)
+
+	tests := []struct {
+		name          string
+		usernames     []string
+		mockBehaviour func()
+		want          []*entity.Presence
+		wantErr       bool
+	}{
+		{
+			name:      "ok, unknown users are skipped",
+			usernames: []string{"alice", "ghost"},
+			mockBehaviour: func() {
+				userRepoMock.EXPECT().GetUserByUsername(ctx, "alice").
+					Return(&entity.User{Username: "alice", LastSeenAt: &now}, nil)
+				userRepoMock.EXPECT().GetUserByUsername(ctx, "ghost").
+					Return(nil, repository.ErrNoSuchUser)
+			},
+			want: []*entity.Presence{{Username: "alice", Status: entity.PresenceOnline, LastSeenAt: &now}},
+		},
+		{
+			name:      "repo error",
+			usernames: []string{"alice"},
+			mockBehaviour: func() {
+				userRepoMock.EXPECT().GetUserByUsername(ctx, "alice").
+					Return(nil, errors.New("err"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockBehaviour()
+
+			got, err := service.GetPresenceByUsernames(ctx, tt.usernames)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package presence
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+)
+
+//go:generate mockgen -destination=../../mocks/presence_user_repository.go -package=mocks -mock_names=UserRepo=MockPresenceUserRepo github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/presence UserRepo
+//go:generate mockgen -destination=../../mocks/connection_counter.go -package=mocks github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/presence ConnectionCounter
+
+// lastSeenResolution limits how often last seen time is written, activity itself is tracked in memory.
+const lastSeenResolution = time.Minute
+
+type UserRepo interface {
+	GetUserByUsername(ctx context.Context, username string) (*entity.User, error)
+	UpdateLastSeen(ctx context.Context, username string, lastSeenAt time.Time) error
+}
+
+// ConnectionCounter reports number of live connections of user.
+type ConnectionCounter interface {
+	ConnectionsCount(key string) int
+}
+
+type Service struct {
+	UserRepo    UserRepo
+	Connections ConnectionCounter
+
+	idleTimeout time.Duration
+
+	mutex sync.Mutex
+	// activity keeps last activity of users seen by this server, persisted keeps when it was last written.
+	activity  map[string]time.Time
+	persisted map[string]time.Time
+}
+
+func New(userRepo UserRepo, connections ConnectionCounter, idleTimeout time.Duration) *Service {
+	return &Service{
+		UserRepo:    userRepo,
+		Connections: connections,
+		idleTimeout: idleTimeout,
+		activity:    make(map[string]time.Time),
+		persisted:   make(map[string]time.Time),
+	}
+}
+
+// Touch records activity of user, last seen time is persisted once in lastSeenResolution.
+func (s *Service) Touch(ctx context.Context, username string) error {
+	now := time.Now()
+
+	s.mutex.Lock()
+
+	s.activity[username] = now
+
+	persist := now.Sub(s.persisted[username]) >= lastSeenResolution
+	if persist {
+		s.persisted[username] = now
+	}
+
+	s.mutex.Unlock()
+
+	if !persist {
+		return nil
+	}
+
+	return s.UserRepo.UpdateLastSeen(ctx, username, now)
+}
+
+// presenceOf resolves presence of user from activity seen by server and last seen time persisted earlier.
+func (s *Service) presenceOf(user *entity.User, now time.Time) *entity.Presence {
+	presence := entity.Presence{
+		Username:   user.Username,
+		Status:     entity.PresenceOffline,
+		LastSeenAt: user.LastSeenAt,
+	}
+
+	s.mutex.Lock()
+	lastActivity, seen := s.activity[user.Username]
+	s.mutex.Unlock()
+
+	if seen && (presence.LastSeenAt == nil || lastActivity.After(*presence.LastSeenAt)) {
+		presence.LastSeenAt = &lastActivity
+	}
+
+	switch {
+	case presence.LastSeenAt != nil && now.Sub(*presence.LastSeenAt) < s.idleTimeout:
+		presence.Status = entity.PresenceOnline
+
+	case s.Connections.ConnectionsCount(user.Username) > 0:
+		presence.Status = entity.PresenceAway
+	}
+
+	return &presence
+}
+
+// GetUsersPresence returns presence of provided users in the same order.
+func (s *Service) GetUsersPresence(_ context.Context, users []*entity.User) []*entity.Presence {
+	now := time.Now()
+	presences := make([]*entity.Presence, 0, len(users))
+
+	for _, user := range users {
+		presences = append(presences, s.presenceOf(user, now))
+	}
+
+	return presences
+}
+
+// GetPresenceByUsernames returns presence of users with provided usernames, unknown usernames are skipped.
+func (s *Service) GetPresenceByUsernames(ctx context.Context, usernames []string) ([]*entity.Presence, error) {
+	users := make([]*entity.User, 0, len(usernames))
+
+	for _, username := range usernames {
+		user, err := s.UserRepo.GetUserByUsername(ctx, username)
+		if errors.Is(err, repository.ErrNoSuchUser) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		users = append(users, user)
+	}
+
+	return s.GetUsersPresence(ctx, users), nil
+}
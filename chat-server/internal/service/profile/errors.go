@@ -0,0 +1,11 @@
+package profile
+
+import "errors"
+
+var (
+	ErrInvalidTimeZone      = errors.New("invalid time zone")
+	ErrStatusExpiryInPast   = errors.New("status expiry must be in the future")
+	ErrAvatarTooLarge       = errors.New("avatar is too large")
+	ErrAvatarTypeNotAllowed = errors.New("avatar type is not allowed")
+	ErrNoAvatar             = errors.New("user has no avatar")
+)
@@ -0,0 +1,259 @@
+package profile
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/mocks"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n")
+
+func drain(_ context.Context, _ string, content io.Reader) (int64, error) {
+	return io.Copy(io.Discard, content)
+}
+
+func saved(_ context.Context, profile entity.Profile) (*entity.Profile, error) {
+	return &profile, nil
+}
+
+func ptr[T any](v T) *T { return &v }
+
+func TestProfileService_GetProfileByUsername(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	profileRepoMock := mocks.NewMockProfileRepo(ctrl)
+	userRepoMock := mocks.NewMockProfileUserRepo(ctrl)
+
+	service := New(profileRepoMock, userRepoMock, nil, AvatarLimits{})
+
+	user := &entity.User{ID: 1, Username: "username"}
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		want          *entity.Profile
+		wantErr       error
+	}{
+		{
+			name: "ok, expired status is hidden",
+			mockBehaviour: func() {
+				userRepoMock.EXPECT().GetUserByUsername(ctx, "username").Return(user, nil)
+				profileRepoMock.EXPECT().GetProfile(ctx, 1).Return(&entity.Profile{
+					UserID:          1,
+					Username:        "username",
+					DisplayName:     "User",
+					StatusText:      "on vacation",
+					StatusExpiresAt: ptr(time.Now().Add(-time.Minute)),
+				}, nil)
+			},
+			want: &entity.Profile{UserID: 1, Username: "username", DisplayName: "User"},
+		},
+		{
+			name: "ok, zero profile of user who has not filled it",
+			mockBehaviour: func() {
+				userRepoMock.EXPECT().GetUserByUsername(ctx, "username").Return(user, nil)
+				profileRepoMock.EXPECT().GetProfile(ctx, 1).Return(nil, repository.ErrNoSuchProfile)
+			},
+			want: &entity.Profile{UserID: 1, Username: "username"},
+		},
+		{
+			name: "err, no such user",
+			mockBehaviour: func() {
+				userRepoMock.EXPECT().GetUserByUsername(ctx, "username").Return(nil, repository.ErrNoSuchUser)
+			},
+			wantErr: repository.ErrNoSuchUser,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			got, err := service.GetProfileByUsername(ctx, "username")
+
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
+			}
+		})
+	}
+}
+
+func TestProfileService_UpdateProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	profileRepoMock := mocks.NewMockProfileRepo(ctrl)
+	userRepoMock := mocks.NewMockProfileUserRepo(ctrl)
+
+	service := New(profileRepoMock, userRepoMock, nil, AvatarLimits{})
+
+	user := &entity.User{ID: 1, Username: "username"}
+	expiresAt := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		input         entity.ProfileUpdate
+		want          *entity.Profile
+		wantErr       error
+	}{
+		{
+			name: "ok, only provided fields are changed",
+			mockBehaviour: func() {
+				userRepoMock.EXPECT().GetUserByID(ctx, 1).Return(user, nil)
+				profileRepoMock.EXPECT().GetProfile(ctx, 1).Return(&entity.Profile{
+					UserID: 1, Username: "username", DisplayName: "User", Bio: "about me",
+				}, nil)
+				profileRepoMock.EXPECT().SaveProfile(ctx, gomock.Any()).DoAndReturn(saved)
+			},
+			input: entity.ProfileUpdate{
+				Bio:             ptr(""),
+				TimeZone:        ptr("Europe/Berlin"),
+				StatusText:      ptr("in a meeting"),
+				StatusExpiresAt: &expiresAt,
+			},
+			want: &entity.Profile{
+				UserID:          1,
+				Username:        "username",
+				DisplayName:     "User",
+				TimeZone:        "Europe/Berlin",
+				StatusText:      "in a meeting",
+				StatusExpiresAt: &expiresAt,
+			},
+		},
+		{
+			name: "ok, clearing status clears its expiry",
+			mockBehaviour: func() {
+				userRepoMock.EXPECT().GetUserByID(ctx, 1).Return(user, nil)
+				profileRepoMock.EXPECT().GetProfile(ctx, 1).Return(&entity.Profile{
+					UserID: 1, Username: "username", StatusText: "busy", StatusExpiresAt: &expiresAt,
+				}, nil)
+				profileRepoMock.EXPECT().SaveProfile(ctx, gomock.Any()).DoAndReturn(saved)
+			},
+			input: entity.ProfileUpdate{StatusText: ptr("")},
+			want:  &entity.Profile{UserID: 1, Username: "username"},
+		},
+		{
+			name:          "err, unknown time zone",
+			mockBehaviour: func() {},
+			input:         entity.ProfileUpdate{TimeZone: ptr("Mars/Olympus")},
+			wantErr:       ErrInvalidTimeZone,
+		},
+		{
+			name:          "err, time zone of server",
+			mockBehaviour: func() {},
+			input:         entity.ProfileUpdate{TimeZone: ptr("Local")},
+			wantErr:       ErrInvalidTimeZone,
+		},
+		{
+			name:          "err, status expiry in the past",
+			mockBehaviour: func() {},
+			input:         entity.ProfileUpdate{StatusText: ptr("busy"), StatusExpiresAt: ptr(time.Now().Add(-time.Hour))},
+			wantErr:       ErrStatusExpiryInPast,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			got, err := service.UpdateProfile(ctx, 1, test.input)
+
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
+			}
+		})
+	}
+}
+
+func TestProfileService_SetAvatar(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	profileRepoMock := mocks.NewMockProfileRepo(ctrl)
+	userRepoMock := mocks.NewMockProfileUserRepo(ctrl)
+	storageMock := mocks.NewMockStorage(ctrl)
+
+	service := New(profileRepoMock, userRepoMock, storageMock, AvatarLimits{
+		MaxSize:      16,
+		AllowedTypes: []string{"image/png"},
+	})
+
+	user := &entity.User{ID: 1, Username: "username"}
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		input         entity.AvatarUpload
+		wantErr       error
+	}{
+		{
+			name: "ok, previous avatar is removed",
+			mockBehaviour: func() {
+				userRepoMock.EXPECT().GetUserByID(ctx, 1).Return(user, nil)
+				profileRepoMock.EXPECT().GetProfile(ctx, 1).Return(&entity.Profile{
+					UserID: 1, Username: "username", AvatarKey: "old", AvatarContentType: "image/png",
+				}, nil)
+				storageMock.EXPECT().Put(ctx, gomock.Any(), gomock.Any()).DoAndReturn(drain)
+				profileRepoMock.EXPECT().SaveProfile(ctx, gomock.Any()).DoAndReturn(
+					func(_ context.Context, profile entity.Profile) (*entity.Profile, error) {
+						assert.NotEqual(t, "old", profile.AvatarKey)
+						assert.Equal(t, "image/png", profile.AvatarContentType)
+
+						return &profile, nil
+					})
+				storageMock.EXPECT().Delete(ctx, "old").Return(nil)
+			},
+			input: entity.AvatarUpload{Content: bytes.NewReader(pngHeader)},
+		},
+		{
+			name:          "err, type not allowed",
+			mockBehaviour: func() {},
+			input:         entity.AvatarUpload{Content: strings.NewReader("plain text")},
+			wantErr:       ErrAvatarTypeNotAllowed,
+		},
+		{
+			name: "err, actual size is too large",
+			mockBehaviour: func() {
+				userRepoMock.EXPECT().GetUserByID(ctx, 1).Return(user, nil)
+				profileRepoMock.EXPECT().GetProfile(ctx, 1).Return(nil, repository.ErrNoSuchProfile)
+				storageMock.EXPECT().Put(ctx, gomock.Any(), gomock.Any()).DoAndReturn(drain)
+				storageMock.EXPECT().Delete(ctx, gomock.Any()).Return(nil)
+			},
+			input:   entity.AvatarUpload{Content: bytes.NewReader(append(pngHeader, make([]byte, 100)...))},
+			wantErr: ErrAvatarTooLarge,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			got, err := service.SetAvatar(ctx, 1, test.input)
+
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.True(t, got.HasAvatar())
+			}
+		})
+	}
+}
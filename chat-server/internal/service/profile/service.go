@@ -0,0 +1,258 @@
+package profile
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"io"
+	"slices"
+	"time"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/pkg/blob"
+)
+
+//go:generate mockgen -destination=../../mocks/profile_repository.go -package=mocks github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/profile ProfileRepo
+//go:generate mockgen -destination=../../mocks/profile_user_repository.go -package=mocks -mock_names=UserRepo=MockProfileUserRepo github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/profile UserRepo
+
+type ProfileRepo interface {
+	GetProfile(ctx context.Context, userID int) (*entity.Profile, error)
+	SaveProfile(ctx context.Context, profile entity.Profile) (*entity.Profile, error)
+}
+
+type UserRepo interface {
+	GetUserByID(ctx context.Context, id int) (*entity.User, error)
+	GetUserByUsername(ctx context.Context, username string) (*entity.User, error)
+}
+
+// AvatarLimits restrict images that can be uploaded as avatar.
+type AvatarLimits struct {
+	MaxSize      int64
+	AllowedTypes []string
+}
+
+type Service struct {
+	ProfileRepo ProfileRepo
+	UserRepo    UserRepo
+	Storage     blob.Storage
+
+	limits AvatarLimits
+}
+
+func New(profileRepo ProfileRepo, userRepo UserRepo, storage blob.Storage, limits AvatarLimits) *Service {
+	return &Service{
+		ProfileRepo: profileRepo,
+		UserRepo:    userRepo,
+		Storage:     storage,
+		limits:      limits,
+	}
+}
+
+// getUserProfile returns stored profile of user or zero one if user has not filled it yet.
+func (s *Service) getUserProfile(ctx context.Context, user *entity.User) (*entity.Profile, error) {
+	profile, err := s.ProfileRepo.GetProfile(ctx, user.ID)
+	if errors.Is(err, repository.ErrNoSuchProfile) {
+		return &entity.Profile{UserID: user.ID, Username: user.Username}, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return profile, nil
+}
+
+func (s *Service) GetProfile(ctx context.Context, userID int) (*entity.Profile, error) {
+	user, err := s.UserRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := s.getUserProfile(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	profile.HideExpiredStatus(time.Now())
+
+	return profile, nil
+}
+
+func (s *Service) GetProfileByUsername(ctx context.Context, username string) (*entity.Profile, error) {
+	user, err := s.UserRepo.GetUserByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := s.getUserProfile(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	profile.HideExpiredStatus(time.Now())
+
+	return profile, nil
+}
+
+// validTimeZone reports whether zone is IANA time zone name, empty zone means it is not set.
+func validTimeZone(zone string) bool {
+	if zone == "" {
+		return true
+	}
+
+	// LoadLocation resolves "Local" to time zone of server, which means nothing to other users
+	if zone == "Local" {
+		return false
+	}
+
+	_, err := time.LoadLocation(zone)
+
+	return err == nil
+}
+
+// UpdateProfile changes provided fields of user profile, expired status is dropped on update.
+func (s *Service) UpdateProfile(ctx context.Context, userID int, update entity.ProfileUpdate) (*entity.Profile, error) {
+	if update.TimeZone != nil && !validTimeZone(*update.TimeZone) {
+		return nil, ErrInvalidTimeZone
+	}
+
+	now := time.Now()
+
+	if update.StatusExpiresAt != nil && !update.StatusExpiresAt.After(now) {
+		return nil, ErrStatusExpiryInPast
+	}
+
+	user, err := s.UserRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := s.getUserProfile(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	profile.HideExpiredStatus(now)
+	update.Apply(profile)
+
+	return s.ProfileRepo.SaveProfile(ctx, *profile)
+}
+
+// SetAvatar stores image as avatar of user, previous avatar is removed.
+func (s *Service) SetAvatar(ctx context.Context, userID int, upload entity.AvatarUpload) (*entity.Profile, error) {
+	if upload.Size > s.limits.MaxSize {
+		return nil, ErrAvatarTooLarge
+	}
+
+	content := bufio.NewReaderSize(upload.Content, blob.SniffLen)
+
+	contentType := blob.DetectContentType(content)
+	if !slices.Contains(s.limits.AllowedTypes, contentType) {
+		return nil, ErrAvatarTypeNotAllowed
+	}
+
+	user, err := s.UserRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := s.getUserProfile(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := blob.NewKey()
+	if err != nil {
+		return nil, err
+	}
+
+	// declared size may lie, so no more than one byte over the limit is read
+	written, err := s.Storage.Put(ctx, key, io.LimitReader(content, s.limits.MaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if written > s.limits.MaxSize {
+		_ = s.Storage.Delete(ctx, key)
+
+		return nil, ErrAvatarTooLarge
+	}
+
+	previousKey := profile.AvatarKey
+
+	profile.AvatarKey = key
+	profile.AvatarContentType = contentType
+
+	saved, err := s.ProfileRepo.SaveProfile(ctx, *profile)
+	if err != nil {
+		_ = s.Storage.Delete(ctx, key)
+
+		return nil, err
+	}
+
+	// profile already points to new avatar, stale image is only a waste of space
+	if previousKey != "" {
+		_ = s.Storage.Delete(ctx, previousKey)
+	}
+
+	saved.HideExpiredStatus(time.Now())
+
+	return saved, nil
+}
+
+// DeleteAvatar removes avatar of user, removing missing avatar is not an error.
+func (s *Service) DeleteAvatar(ctx context.Context, userID int) (*entity.Profile, error) {
+	user, err := s.UserRepo.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := s.getUserProfile(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+
+	if !profile.HasAvatar() {
+		profile.HideExpiredStatus(time.Now())
+
+		return profile, nil
+	}
+
+	key := profile.AvatarKey
+
+	profile.AvatarKey = ""
+	profile.AvatarContentType = ""
+
+	saved, err := s.ProfileRepo.SaveProfile(ctx, *profile)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.Storage.Delete(ctx, key); err != nil {
+		return nil, err
+	}
+
+	saved.HideExpiredStatus(time.Now())
+
+	return saved, nil
+}
+
+// GetAvatar returns profile with reader of avatar image of user, caller must close reader.
+func (s *Service) GetAvatar(ctx context.Context, username string) (*entity.Profile, io.ReadCloser, error) {
+	profile, err := s.GetProfileByUsername(ctx, username)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !profile.HasAvatar() {
+		return nil, nil, ErrNoAvatar
+	}
+
+	content, err := s.Storage.Get(ctx, profile.AvatarKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return profile, content, nil
+}
@@ -0,0 +1,8 @@
+package search
+
+import "errors"
+
+var (
+	ErrEmptySearchQuery = errors.New("search query is empty")
+	ErrInvalidDateRange = errors.New("start of date range is after its end")
+)
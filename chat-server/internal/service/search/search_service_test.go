@@ -0,0 +1,147 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/mocks"
+)
+
+func TestSearchService_SearchMessages(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	publicRepoMock := mocks.NewMockSearchPublicMessageRepo(ctrl)
+	privateRepoMock := mocks.NewMockSearchPrivateMessageRepo(ctrl)
+	channelRepoMock := mocks.NewMockSearchChannelRepo(ctrl)
+
+	service := New(publicRepoMock, privateRepoMock, channelRepoMock)
+
+	oldPublic := &entity.SearchHit{
+		Kind:    entity.MessageKindPublic,
+		Public:  &entity.PublicMessage{ID: 1, ChannelID: entity.GeneralChannelID, FromUsername: "other", Content: "hello", SentAt: now.Add(-2 * time.Hour)},
+		Snippet: "<mark>hello</mark>",
+	}
+	newPublic := &entity.SearchHit{
+		Kind:    entity.MessageKindPublic,
+		Public:  &entity.PublicMessage{ID: 2, ChannelID: 3, FromUsername: "other", Content: "hello again", SentAt: now},
+		Snippet: "<mark>hello</mark> again",
+	}
+	private := &entity.SearchHit{
+		Kind:    entity.MessageKindPrivate,
+		Private: &entity.PrivateMessage{ID: 1, FromUsername: "other", ToUsername: "username", Content: "hello there", SentAt: now.Add(-time.Hour)},
+		Snippet: "<mark>hello</mark> there",
+	}
+
+	before := now.Add(-time.Hour)
+
+	type inputArgs struct {
+		query  entity.MessageSearchQuery
+		offset int
+		limit  int
+	}
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		input         inputArgs
+		want          []*entity.SearchHit
+		wantErr       error
+	}{
+		{
+			name: "ok, public and private hits are merged newest first",
+			mockBehaviour: func() {
+				channelRepoMock.EXPECT().GetMemberChannelIDs(ctx, "username").Return([]int{3}, nil)
+				publicRepoMock.EXPECT().
+					SearchPublicMessages(ctx, entity.MessageSearchQuery{Text: "hello"}, []int{3, entity.GeneralChannelID}, 10).
+					Return([]*entity.SearchHit{newPublic, oldPublic}, nil)
+				privateRepoMock.EXPECT().
+					SearchPrivateMessages(ctx, "username", entity.MessageSearchQuery{Text: "hello"}, 10).
+					Return([]*entity.SearchHit{private}, nil)
+			},
+			input: inputArgs{query: entity.MessageSearchQuery{Text: "hello"}, limit: 10},
+			want:  []*entity.SearchHit{newPublic, private, oldPublic},
+		},
+		{
+			name: "ok, page of merged hits",
+			mockBehaviour: func() {
+				channelRepoMock.EXPECT().GetMemberChannelIDs(ctx, "username").Return([]int{entity.GeneralChannelID, 3}, nil)
+				publicRepoMock.EXPECT().
+					SearchPublicMessages(ctx, entity.MessageSearchQuery{Text: "hello"}, []int{entity.GeneralChannelID, 3}, 2).
+					Return([]*entity.SearchHit{newPublic, oldPublic}, nil)
+				privateRepoMock.EXPECT().
+					SearchPrivateMessages(ctx, "username", entity.MessageSearchQuery{Text: "hello"}, 2).
+					Return([]*entity.SearchHit{private}, nil)
+			},
+			input: inputArgs{query: entity.MessageSearchQuery{Text: "hello"}, offset: 1, limit: 1},
+			want:  []*entity.SearchHit{private},
+		},
+		{
+			name: "ok, counterpart searches private messages only",
+			mockBehaviour: func() {
+				privateRepoMock.EXPECT().
+					SearchPrivateMessages(ctx, "username", entity.MessageSearchQuery{Text: "hello", Counterpart: "other"}, 10).
+					Return([]*entity.SearchHit{private}, nil)
+			},
+			input: inputArgs{query: entity.MessageSearchQuery{Text: "hello", Counterpart: "other"}, limit: 10},
+			want:  []*entity.SearchHit{private},
+		},
+		{
+			name: "ok, public kind",
+			mockBehaviour: func() {
+				query := entity.MessageSearchQuery{Text: "hello", Kind: entity.MessageKindPublic}
+
+				channelRepoMock.EXPECT().GetMemberChannelIDs(ctx, "username").Return([]int{}, nil)
+				publicRepoMock.EXPECT().
+					SearchPublicMessages(ctx, query, []int{entity.GeneralChannelID}, 10).
+					Return([]*entity.SearchHit{oldPublic}, nil)
+			},
+			input: inputArgs{query: entity.MessageSearchQuery{Text: "hello", Kind: entity.MessageKindPublic}, limit: 10},
+			want:  []*entity.SearchHit{oldPublic},
+		},
+		{
+			name:          "err, empty query",
+			mockBehaviour: func() {},
+			input:         inputArgs{query: entity.MessageSearchQuery{Text: "  "}, limit: 10},
+			wantErr:       ErrEmptySearchQuery,
+		},
+		{
+			name:          "err, invalid date range",
+			mockBehaviour: func() {},
+			input:         inputArgs{query: entity.MessageSearchQuery{Text: "hello", SentAfter: &now, SentBefore: &before}, limit: 10},
+			wantErr:       ErrInvalidDateRange,
+		},
+		{
+			name: "err, repo error",
+			mockBehaviour: func() {
+				privateRepoMock.EXPECT().
+					SearchPrivateMessages(ctx, "username", entity.MessageSearchQuery{Text: "hello", Kind: entity.MessageKindPrivate}, 10).
+					Return(nil, errors.New("connection refused"))
+			},
+			input:   inputArgs{query: entity.MessageSearchQuery{Text: "hello", Kind: entity.MessageKindPrivate}, limit: 10},
+			wantErr: errors.New("connection refused"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			got, err := service.SearchMessages(ctx, "username", test.input.query, test.input.offset, test.input.limit)
+
+			if test.wantErr != nil {
+				assert.EqualError(t, err, test.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package search
+
+import (
+	"context"
+	"math"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+
+	sliceutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/slice"
+)
+
+//go:generate mockgen -destination=../../mocks/search_public_message_repository.go -package=mocks -mock_names=PublicMessageRepo=MockSearchPublicMessageRepo github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/search PublicMessageRepo
+//go:generate mockgen -destination=../../mocks/search_private_message_repository.go -package=mocks -mock_names=PrivateMessageRepo=MockSearchPrivateMessageRepo github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/search PrivateMessageRepo
+//go:generate mockgen -destination=../../mocks/search_channel_repository.go -package=mocks -mock_names=ChannelRepo=MockSearchChannelRepo github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/search ChannelRepo
+
+type PublicMessageRepo interface {
+	SearchPublicMessages(ctx context.Context, query entity.MessageSearchQuery, channelIDs []int, limit int) ([]*entity.SearchHit, error)
+}
+
+type PrivateMessageRepo interface {
+	SearchPrivateMessages(ctx context.Context, username string, query entity.MessageSearchQuery, limit int) ([]*entity.SearchHit, error)
+}
+
+type ChannelRepo interface {
+	GetMemberChannelIDs(ctx context.Context, username string) ([]int, error)
+}
+
+type Service struct {
+	PublicMessageRepo  PublicMessageRepo
+	PrivateMessageRepo PrivateMessageRepo
+	ChannelRepo        ChannelRepo
+}
+
+func New(publicMessageRepo PublicMessageRepo, privateMessageRepo PrivateMessageRepo, channelRepo ChannelRepo) *Service {
+	return &Service{
+		PublicMessageRepo:  publicMessageRepo,
+		PrivateMessageRepo: privateMessageRepo,
+		ChannelRepo:        channelRepo,
+	}
+}
+
+// visibleChannelIDs returns channels which messages user can read. General channel is open for everyone.
+func (s *Service) visibleChannelIDs(ctx context.Context, username string) ([]int, error) {
+	ids, err := s.ChannelRepo.GetMemberChannelIDs(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(ids, entity.GeneralChannelID) {
+		ids = append(ids, entity.GeneralChannelID)
+	}
+
+	return ids, nil
+}
+
+// SearchMessages searches public messages of channels that user can read and private messages of user, newest first.
+func (s *Service) SearchMessages(ctx context.Context, username string, query entity.MessageSearchQuery, offset, limit int) ([]*entity.SearchHit, error) {
+	if strings.TrimSpace(query.Text) == "" {
+		return nil, ErrEmptySearchQuery
+	}
+
+	if query.SentAfter != nil && query.SentBefore != nil && query.SentAfter.After(*query.SentBefore) {
+		return nil, ErrInvalidDateRange
+	}
+
+	// both kinds of messages are merged, so each of them is fetched up to the end of requested page
+	fetchLimit := offset + limit
+	if fetchLimit < 0 {
+		fetchLimit = math.MaxInt64
+	}
+
+	hits := make([]*entity.SearchHit, 0)
+
+	// public messages have no counterpart
+	if query.Kind != entity.MessageKindPrivate && query.Counterpart == "" {
+		channelIDs, err := s.visibleChannelIDs(ctx, username)
+		if err != nil {
+			return nil, err
+		}
+
+		publicHits, err := s.PublicMessageRepo.SearchPublicMessages(ctx, query, channelIDs, fetchLimit)
+		if err != nil {
+			return nil, err
+		}
+
+		hits = append(hits, publicHits...)
+	}
+
+	if query.Kind != entity.MessageKindPublic {
+		privateHits, err := s.PrivateMessageRepo.SearchPrivateMessages(ctx, username, query, fetchLimit)
+		if err != nil {
+			return nil, err
+		}
+
+		hits = append(hits, privateHits...)
+	}
+
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].IsNewerThan(hits[j]) })
+
+	return sliceutils.Slice(hits, offset, min(limit, len(hits))), nil
+}
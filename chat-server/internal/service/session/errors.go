@@ -0,0 +1,5 @@
+package session
+
+import "errors"
+
+var ErrSessionNotActive = errors.New("session is revoked or expired")
@@ -0,0 +1,149 @@
+package session
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+)
+
+//go:generate mockgen -destination=../../mocks/session_repository.go -package=mocks github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/session SessionRepo
+//go:generate mockgen -destination=../../mocks/session_refresh_token_repository.go -package=mocks -mock_names=RefreshTokenRepo=MockSessionRefreshTokenRepo github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/session RefreshTokenRepo
+//go:generate mockgen -destination=../../mocks/session_notifier.go -package=mocks -mock_names=Notifier=MockSessionNotifier github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/session Notifier
+
+// lastSeenResolution limits how often last seen time is written, it is not updated on every request.
+const lastSeenResolution = time.Minute
+
+type SessionRepo interface {
+	GetSession(ctx context.Context, id string) (*entity.Session, error)
+	GetUserSessions(ctx context.Context, userID int) ([]*entity.Session, error)
+	TouchSession(ctx context.Context, id string, lastSeenAt time.Time) error
+	RevokeSession(ctx context.Context, id string, revokedAt time.Time) error
+}
+
+type RefreshTokenRepo interface {
+	RevokeTokenFamily(ctx context.Context, familyID string, revokedAt time.Time) error
+}
+
+// Notifier closes live connections opened within revoked session.
+type Notifier interface {
+	NotifySessionRevoked(ctx context.Context, sessionID string)
+}
+
+type Service struct {
+	SessionRepo      SessionRepo
+	RefreshTokenRepo RefreshTokenRepo
+	Notifier         Notifier
+}
+
+func New(sessionRepo SessionRepo, refreshTokenRepo RefreshTokenRepo, notifier Notifier) *Service {
+	return &Service{
+		SessionRepo:      sessionRepo,
+		RefreshTokenRepo: refreshTokenRepo,
+		Notifier:         notifier,
+	}
+}
+
+// IsSessionActive reports whether session was neither revoked nor expired and records that it was seen.
+func (s *Service) IsSessionActive(ctx context.Context, sessionID string) (bool, error) {
+	session, err := s.SessionRepo.GetSession(ctx, sessionID)
+	if errors.Is(err, repository.ErrNoSuchSession) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+
+	if !session.IsActive(now) {
+		return false, nil
+	}
+
+	if now.Sub(session.LastSeenAt) >= lastSeenResolution {
+		if err = s.SessionRepo.TouchSession(ctx, sessionID, now); err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// GetUserSessions returns active sessions of user, recently seen first.
+func (s *Service) GetUserSessions(ctx context.Context, userID int) ([]*entity.Session, error) {
+	sessions, err := s.SessionRepo.GetUserSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	active := make([]*entity.Session, 0, len(sessions))
+
+	for _, session := range sessions {
+		if session.IsActive(now) {
+			active = append(active, session)
+		}
+	}
+
+	sort.SliceStable(active, func(i, j int) bool {
+		return active[i].LastSeenAt.After(active[j].LastSeenAt)
+	})
+
+	return active, nil
+}
+
+func (s *Service) revokeSession(ctx context.Context, id string, at time.Time) error {
+	if err := s.RefreshTokenRepo.RevokeTokenFamily(ctx, id, at); err != nil {
+		return err
+	}
+
+	if err := s.SessionRepo.RevokeSession(ctx, id, at); err != nil {
+		return err
+	}
+
+	s.Notifier.NotifySessionRevoked(ctx, id)
+
+	return nil
+}
+
+// RevokeSession revokes active session of user, sessions of other users are reported as not existing.
+func (s *Service) RevokeSession(ctx context.Context, userID int, sessionID string) error {
+	session, err := s.SessionRepo.GetSession(ctx, sessionID)
+	if err != nil {
+		return err
+	}
+
+	if session.UserID != userID {
+		return repository.ErrNoSuchSession
+	}
+
+	now := time.Now()
+
+	if !session.IsActive(now) {
+		return ErrSessionNotActive
+	}
+
+	return s.revokeSession(ctx, sessionID, now)
+}
+
+// RevokeAllSessions revokes all active sessions of user, including the one of request.
+func (s *Service) RevokeAllSessions(ctx context.Context, userID int) error {
+	sessions, err := s.GetUserSessions(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	for _, session := range sessions {
+		if err = s.revokeSession(ctx, session.ID, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
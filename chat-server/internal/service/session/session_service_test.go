@@ -0,0 +1,143 @@
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/mocks"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+)
+
+func TestSessionService_IsSessionActive(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	sessionRepoMock := mocks.NewMockSessionRepo(ctrl)
+	refreshTokenRepoMock := mocks.NewMockSessionRefreshTokenRepo(ctrl)
+	notifierMock := mocks.NewMockSessionNotifier(ctrl)
+
+	service := New(sessionRepoMock, refreshTokenRepoMock, notifierMock)
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		want          bool
+	}{
+		{
+			name: "ok, recently seen session is not touched",
+			mockBehaviour: func() {
+				sessionRepoMock.EXPECT().GetSession(ctx, "sid").
+					Return(&entity.Session{ID: "sid", LastSeenAt: now, ExpiresAt: now.Add(time.Hour)}, nil)
+			},
+			want: true,
+		},
+		{
+			name: "ok, last seen time is updated",
+			mockBehaviour: func() {
+				sessionRepoMock.EXPECT().GetSession(ctx, "sid").
+					Return(&entity.Session{ID: "sid", LastSeenAt: now.Add(-time.Hour), ExpiresAt: now.Add(time.Hour)}, nil)
+				sessionRepoMock.EXPECT().TouchSession(ctx, "sid", gomock.Any()).Return(nil)
+			},
+			want: true,
+		},
+		{
+			name: "revoked session",
+			mockBehaviour: func() {
+				sessionRepoMock.EXPECT().GetSession(ctx, "sid").
+					Return(&entity.Session{ID: "sid", LastSeenAt: now, ExpiresAt: now.Add(time.Hour), RevokedAt: &now}, nil)
+			},
+			want: false,
+		},
+		{
+			name: "expired session",
+			mockBehaviour: func() {
+				sessionRepoMock.EXPECT().GetSession(ctx, "sid").
+					Return(&entity.Session{ID: "sid", LastSeenAt: now, ExpiresAt: now.Add(-time.Minute)}, nil)
+			},
+			want: false,
+		},
+		{
+			name: "no such session",
+			mockBehaviour: func() {
+				sessionRepoMock.EXPECT().GetSession(ctx, "sid").Return(nil, repository.ErrNoSuchSession)
+			},
+			want: false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			got, err := service.IsSessionActive(ctx, "sid")
+
+			assert.NoError(t, err)
+			assert.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestSessionService_RevokeSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	sessionRepoMock := mocks.NewMockSessionRepo(ctrl)
+	refreshTokenRepoMock := mocks.NewMockSessionRefreshTokenRepo(ctrl)
+	notifierMock := mocks.NewMockSessionNotifier(ctrl)
+
+	service := New(sessionRepoMock, refreshTokenRepoMock, notifierMock)
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		wantErr       error
+	}{
+		{
+			name: "ok, session and its refresh tokens are revoked",
+			mockBehaviour: func() {
+				sessionRepoMock.EXPECT().GetSession(ctx, "sid").
+					Return(&entity.Session{ID: "sid", UserID: 1, ExpiresAt: now.Add(time.Hour)}, nil)
+				refreshTokenRepoMock.EXPECT().RevokeTokenFamily(ctx, "sid", gomock.Any()).Return(nil)
+				sessionRepoMock.EXPECT().RevokeSession(ctx, "sid", gomock.Any()).Return(nil)
+				notifierMock.EXPECT().NotifySessionRevoked(ctx, "sid")
+			},
+		},
+		{
+			name: "err, session of another user",
+			mockBehaviour: func() {
+				sessionRepoMock.EXPECT().GetSession(ctx, "sid").
+					Return(&entity.Session{ID: "sid", UserID: 2, ExpiresAt: now.Add(time.Hour)}, nil)
+			},
+			wantErr: repository.ErrNoSuchSession,
+		},
+		{
+			name: "err, already revoked",
+			mockBehaviour: func() {
+				sessionRepoMock.EXPECT().GetSession(ctx, "sid").
+					Return(&entity.Session{ID: "sid", UserID: 1, ExpiresAt: now.Add(time.Hour), RevokedAt: &now}, nil)
+			},
+			wantErr: ErrSessionNotActive,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			err := service.RevokeSession(ctx, 1, "sid")
+
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
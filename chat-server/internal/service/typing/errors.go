@@ -0,0 +1,9 @@
+package typing
+
+import "errors"
+
+var (
+	ErrNoSuchReceiver             = errors.New("no such receiver")
+	ErrNotChannelMember           = errors.New("user is not a member of channel")
+	ErrNotConversationParticipant = errors.New("user is not a participant of conversation")
+)
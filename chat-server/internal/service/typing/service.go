@@ -0,0 +1,231 @@
+package typing
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+
+	sliceutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/slice"
+)
+
+//go:generate mockgen -destination=../../mocks/typing_channel_repository.go -package=mocks -mock_names=ChannelRepo=MockTypingChannelRepo github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/typing ChannelRepo
+//go:generate mockgen -destination=../../mocks/typing_conversation_repository.go -package=mocks -mock_names=ConversationRepo=MockTypingConversationRepo github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/typing ConversationRepo
+//go:generate mockgen -destination=../../mocks/typing_user_repository.go -package=mocks -mock_names=UserRepo=MockTypingUserRepo github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/typing UserRepo
+//go:generate mockgen -destination=../../mocks/typing_block_repository.go -package=mocks -mock_names=BlockRepo=MockTypingBlockRepo github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/typing BlockRepo
+//go:generate mockgen -destination=../../mocks/typing_notifier.go -package=mocks -mock_names=Notifier=MockTypingNotifier github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/typing Notifier
+
+const (
+	// typingTTL is how long typing notice is shown if user does not renew it.
+	typingTTL = 5 * time.Second
+	// typingInterval is minimal interval between notices of user, more frequent ones are dropped.
+	typingInterval = 2 * time.Second
+)
+
+type ChannelRepo interface {
+	GetChannel(ctx context.Context, id int) (*entity.Channel, error)
+	GetChannelMembers(ctx context.Context, channelID int) ([]*entity.ChannelMember, error)
+	IsChannelMember(ctx context.Context, channelID int, username string) (bool, error)
+}
+
+type ConversationRepo interface {
+	GetConversationParticipants(ctx context.Context, conversationID int) ([]*entity.ConversationParticipant, error)
+	IsConversationParticipant(ctx context.Context, conversationID int, username string) (bool, error)
+}
+
+type UserRepo interface {
+	GetUserByUsername(ctx context.Context, username string) (*entity.User, error)
+}
+
+type BlockRepo interface {
+	IsBlocked(ctx context.Context, username, blockedUsername string) (bool, error)
+}
+
+type Notifier interface {
+	NotifyPublicTyping(ctx context.Context, typing *entity.Typing)
+	NotifyTyping(ctx context.Context, typing *entity.Typing, recipients []string)
+}
+
+type Service struct {
+	ChannelRepo      ChannelRepo
+	ConversationRepo ConversationRepo
+	UserRepo         UserRepo
+	BlockRepo        BlockRepo
+	Notifier         Notifier
+
+	mutex sync.Mutex
+	// lastSent keeps when user was last notified as typing, entries older than typingInterval are pruned by PruneLimits.
+	lastSent map[string]time.Time
+}
+
+func New(
+	channelRepo ChannelRepo,
+	conversationRepo ConversationRepo,
+	userRepo UserRepo,
+	blockRepo BlockRepo,
+	notifier Notifier,
+) *Service {
+	return &Service{
+		ChannelRepo:      channelRepo,
+		ConversationRepo: conversationRepo,
+		UserRepo:         userRepo,
+		BlockRepo:        blockRepo,
+		Notifier:         notifier,
+		lastSent:         make(map[string]time.Time),
+	}
+}
+
+// allow reports whether user may be notified as typing now and remembers it if so. User is limited
+// regardless of place, so notices can not be multiplied by switching between places.
+func (s *Service) allow(username string, now time.Time) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if sentAt, ok := s.lastSent[username]; ok && now.Sub(sentAt) < typingInterval {
+		return false
+	}
+
+	s.lastSent[username] = now
+
+	return true
+}
+
+func (s *Service) prune(now time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	for username, sentAt := range s.lastSent {
+		if now.Sub(sentAt) >= typingInterval {
+			delete(s.lastSent, username)
+		}
+	}
+}
+
+// PruneLimits forgets users who may be notified as typing again once in typingInterval, until ctx is done.
+func (s *Service) PruneLimits(ctx context.Context) {
+	ticker := time.NewTicker(typingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case now := <-ticker.C:
+			s.prune(now)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// othersOnly excludes typing user, their own devices do not need the notice.
+func othersOnly(usernames []string, username string) []string {
+	return sliceutils.Filter(usernames, func(u string) bool { return u != username })
+}
+
+// recipients returns users who can see place where user is typing, general channel is seen by everyone (nil recipients).
+// Private receiver who has blocked typing user is not notified.
+func (s *Service) recipients(ctx context.Context, typing entity.Typing) ([]string, error) {
+	switch typing.Target {
+	case entity.TypingInPrivate:
+		if _, err := s.UserRepo.GetUserByUsername(ctx, typing.ToUsername); err != nil {
+			if errors.Is(err, repository.ErrNoSuchUser) {
+				return nil, ErrNoSuchReceiver
+			}
+
+			return nil, err
+		}
+
+		blocked, err := s.BlockRepo.IsBlocked(ctx, typing.ToUsername, typing.Username)
+		if err != nil {
+			return nil, err
+		}
+
+		if blocked {
+			return []string{}, nil
+		}
+
+		return othersOnly([]string{typing.ToUsername}, typing.Username), nil
+
+	case entity.TypingInConversation:
+		isParticipant, err := s.ConversationRepo.IsConversationParticipant(ctx, typing.ConversationID, typing.Username)
+		if err != nil {
+			return nil, err
+		}
+
+		if !isParticipant {
+			return nil, ErrNotConversationParticipant
+		}
+
+		participants, err := s.ConversationRepo.GetConversationParticipants(ctx, typing.ConversationID)
+		if err != nil {
+			return nil, err
+		}
+
+		usernames := sliceutils.Map(participants, func(p *entity.ConversationParticipant) string { return p.Username })
+
+		return othersOnly(usernames, typing.Username), nil
+
+	default:
+		if _, err := s.ChannelRepo.GetChannel(ctx, typing.ChannelID); err != nil {
+			return nil, err
+		}
+
+		if typing.ChannelID == entity.GeneralChannelID {
+			return nil, nil
+		}
+
+		isMember, err := s.ChannelRepo.IsChannelMember(ctx, typing.ChannelID, typing.Username)
+		if err != nil {
+			return nil, err
+		}
+
+		if !isMember {
+			return nil, ErrNotChannelMember
+		}
+
+		members, err := s.ChannelRepo.GetChannelMembers(ctx, typing.ChannelID)
+		if err != nil {
+			return nil, err
+		}
+
+		usernames := sliceutils.Map(members, func(m *entity.ChannelMember) string { return m.Username })
+
+		return othersOnly(usernames, typing.Username), nil
+	}
+}
+
+// StartTyping notifies users who can see the place that user is writing there. Nothing is stored,
+// notices sent more often than typingInterval are silently dropped before any lookups.
+func (s *Service) StartTyping(ctx context.Context, typing entity.Typing) error {
+	if typing.Target == entity.TypingInPublic && typing.ChannelID == 0 {
+		typing.ChannelID = entity.GeneralChannelID
+	}
+
+	now := time.Now()
+
+	if !s.allow(typing.Username, now) {
+		return nil
+	}
+
+	recipients, err := s.recipients(ctx, typing)
+	if err != nil {
+		return err
+	}
+
+	typing.ExpiresAt = now.Add(typingTTL)
+
+	if typing.Target == entity.TypingInPublic && typing.ChannelID == entity.GeneralChannelID {
+		s.Notifier.NotifyPublicTyping(ctx, &typing)
+		return nil
+	}
+
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	s.Notifier.NotifyTyping(ctx, &typing, recipients)
+
+	return nil
+}
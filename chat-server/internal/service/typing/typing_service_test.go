@@ -0,0 +1,169 @@
+package typing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/mocks"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
+)
+
+func TestTypingService_StartTyping(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	channelRepoMock := mocks.NewMockTypingChannelRepo(ctrl)
+	conversationRepoMock := mocks.NewMockTypingConversationRepo(ctrl)
+	userRepoMock := mocks.NewMockTypingUserRepo(ctrl)
+	blockRepoMock := mocks.NewMockTypingBlockRepo(ctrl)
+	notifierMock := mocks.NewMockTypingNotifier(ctrl)
+
+	tests := []struct {
+		name          string
+		typing        entity.Typing
+		mockBehaviour func()
+		wantErr       error
+	}{
+		{
+			name:   "ok, public chat is seen by everyone",
+			typing: entity.Typing{Username: "alice", Target: entity.TypingInPublic},
+			mockBehaviour: func() {
+				channelRepoMock.EXPECT().GetChannel(ctx, entity.GeneralChannelID).
+					Return(&entity.Channel{ID: entity.GeneralChannelID}, nil)
+				notifierMock.EXPECT().NotifyPublicTyping(ctx, gomock.Any()).
+					Do(func(_ context.Context, typing *entity.Typing) {
+						assert.Equal(t, entity.GeneralChannelID, typing.ChannelID)
+						assert.False(t, typing.ExpiresAt.IsZero())
+					})
+			},
+		},
+		{
+			name:   "ok, channel members besides typing user",
+			typing: entity.Typing{Username: "alice", Target: entity.TypingInPublic, ChannelID: 2},
+			mockBehaviour: func() {
+				channelRepoMock.EXPECT().GetChannel(ctx, 2).Return(&entity.Channel{ID: 2}, nil)
+				channelRepoMock.EXPECT().IsChannelMember(ctx, 2, "alice").Return(true, nil)
+				channelRepoMock.EXPECT().GetChannelMembers(ctx, 2).
+					Return([]*entity.ChannelMember{{Username: "alice"}, {Username: "bob"}}, nil)
+				notifierMock.EXPECT().NotifyTyping(ctx, gomock.Any(), []string{"bob"})
+			},
+		},
+		{
+			name:   "not a channel member",
+			typing: entity.Typing{Username: "alice", Target: entity.TypingInPublic, ChannelID: 3},
+			mockBehaviour: func() {
+				channelRepoMock.EXPECT().GetChannel(ctx, 3).Return(&entity.Channel{ID: 3}, nil)
+				channelRepoMock.EXPECT().IsChannelMember(ctx, 3, "alice").Return(false, nil)
+			},
+			wantErr: ErrNotChannelMember,
+		},
+		{
+			name:   "no such channel",
+			typing: entity.Typing{Username: "alice", Target: entity.TypingInPublic, ChannelID: 4},
+			mockBehaviour: func() {
+				channelRepoMock.EXPECT().GetChannel(ctx, 4).Return(nil, repository.ErrNoSuchChannel)
+			},
+			wantErr: repository.ErrNoSuchChannel,
+		},
+		{
+			name:   "ok, private",
+			typing: entity.Typing{Username: "alice", Target: entity.TypingInPrivate, ToUsername: "bob"},
+			mockBehaviour: func() {
+				userRepoMock.EXPECT().GetUserByUsername(ctx, "bob").Return(&entity.User{Username: "bob"}, nil)
+				blockRepoMock.EXPECT().IsBlocked(ctx, "bob", "alice").Return(false, nil)
+				notifierMock.EXPECT().NotifyTyping(ctx, gomock.Any(), []string{"bob"})
+			},
+		},
+		{
+			name:   "ok, private receiver who blocked typing user is not notified",
+			typing: entity.Typing{Username: "alice", Target: entity.TypingInPrivate, ToUsername: "bob"},
+			mockBehaviour: func() {
+				userRepoMock.EXPECT().GetUserByUsername(ctx, "bob").Return(&entity.User{Username: "bob"}, nil)
+				blockRepoMock.EXPECT().IsBlocked(ctx, "bob", "alice").Return(true, nil)
+			},
+		},
+		{
+			name:   "no such receiver",
+			typing: entity.Typing{Username: "alice", Target: entity.TypingInPrivate, ToUsername: "ghost"},
+			mockBehaviour: func() {
+				userRepoMock.EXPECT().GetUserByUsername(ctx, "ghost").Return(nil, repository.ErrNoSuchUser)
+			},
+			wantErr: ErrNoSuchReceiver,
+		},
+		{
+			name:   "ok, conversation",
+			typing: entity.Typing{Username: "alice", Target: entity.TypingInConversation, ConversationID: 1},
+			mockBehaviour: func() {
+				conversationRepoMock.EXPECT().IsConversationParticipant(ctx, 1, "alice").Return(true, nil)
+				conversationRepoMock.EXPECT().GetConversationParticipants(ctx, 1).
+					Return([]*entity.ConversationParticipant{{Username: "alice"}, {Username: "bob"}, {Username: "carol"}}, nil)
+				notifierMock.EXPECT().NotifyTyping(ctx, gomock.Any(), []string{"bob", "carol"})
+			},
+		},
+		{
+			name:   "not a conversation participant",
+			typing: entity.Typing{Username: "alice", Target: entity.TypingInConversation, ConversationID: 2},
+			mockBehaviour: func() {
+				conversationRepoMock.EXPECT().IsConversationParticipant(ctx, 2, "alice").Return(false, nil)
+			},
+			wantErr: ErrNotConversationParticipant,
+		},
+		{
+			name:   "repo error",
+			typing: entity.Typing{Username: "alice", Target: entity.TypingInConversation, ConversationID: 3},
+			mockBehaviour: func() {
+				conversationRepoMock.EXPECT().IsConversationParticipant(ctx, 3, "alice").Return(false, errors.New("err"))
+			},
+			wantErr: errors.New("err"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := New(channelRepoMock, conversationRepoMock, userRepoMock, blockRepoMock, notifierMock)
+
+			tt.mockBehaviour()
+
+			err := service.StartTyping(ctx, tt.typing)
+			assert.Equal(t, tt.wantErr, err)
+		})
+	}
+}
+
+func TestTypingService_StartTyping_RateLimited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	channelRepoMock := mocks.NewMockTypingChannelRepo(ctrl)
+	conversationRepoMock := mocks.NewMockTypingConversationRepo(ctrl)
+	userRepoMock := mocks.NewMockTypingUserRepo(ctrl)
+	blockRepoMock := mocks.NewMockTypingBlockRepo(ctrl)
+	notifierMock := mocks.NewMockTypingNotifier(ctrl)
+
+	service := New(channelRepoMock, conversationRepoMock, userRepoMock, blockRepoMock, notifierMock)
+
+	userRepoMock.EXPECT().GetUserByUsername(ctx, gomock.Any()).Return(&entity.User{}, nil).Times(3)
+	blockRepoMock.EXPECT().IsBlocked(ctx, gomock.Any(), gomock.Any()).Return(false, nil).Times(3)
+	notifierMock.EXPECT().NotifyTyping(ctx, gomock.Any(), []string{"bob"}).Times(1)
+	notifierMock.EXPECT().NotifyTyping(ctx, gomock.Any(), []string{"alice"}).Times(1)
+	notifierMock.EXPECT().NotifyTyping(ctx, gomock.Any(), []string{"carol"}).Times(1)
+
+	assert.NoError(t, service.StartTyping(ctx, entity.Typing{Username: "alice", Target: entity.TypingInPrivate, ToUsername: "bob"}))
+	assert.NoError(t, service.StartTyping(ctx, entity.Typing{Username: "alice", Target: entity.TypingInPrivate, ToUsername: "bob"}),
+		"repeated notice is dropped")
+	assert.NoError(t, service.StartTyping(ctx, entity.Typing{Username: "alice", Target: entity.TypingInPrivate, ToUsername: "carol"}),
+		"notice in other place is dropped too")
+	assert.NoError(t, service.StartTyping(ctx, entity.Typing{Username: "bob", Target: entity.TypingInPrivate, ToUsername: "alice"}),
+		"other user is not limited")
+
+	// limit is over once typing interval passes
+	service.prune(time.Now().Add(typingInterval))
+
+	assert.NoError(t, service.StartTyping(ctx, entity.Typing{Username: "alice", Target: entity.TypingInPrivate, ToUsername: "carol"}))
+}
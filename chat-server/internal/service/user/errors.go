@@ -0,0 +1,9 @@
+package user
+
+import "errors"
+
+var (
+	ErrInvalidRole   = errors.New("invalid role")
+	ErrLastAdmin     = errors.New("last admin cannot be removed or demoted")
+	ErrWrongPassword = errors.New("wrong password")
+)
@@ -2,13 +2,22 @@ package user
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
+	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/repository"
 )
 
-//go:generate mockgen -destination=../../mocks/hasher.go -package=mocks github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/user Hasher
+//go:generate mockgen -destination=../../mocks/user_repository.go -package=mocks github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/user UserRepo
+//go:generate mockgen -destination=../../mocks/hasher.go -package=mocks -mock_names=Hasher=MockUserHasher github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/user Hasher
+//go:generate mockgen -destination=../../mocks/username_renamer.go -package=mocks github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/user UsernameRenamer
+//go:generate mockgen -destination=../../mocks/unit_of_work.go -package=mocks github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/user UnitOfWork
+//go:generate mockgen -destination=../../mocks/user_session_repository.go -package=mocks -mock_names=SessionRepo=MockUserSessionRepo github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/user SessionRepo
+//go:generate mockgen -destination=../../mocks/user_refresh_token_repository.go -package=mocks -mock_names=RefreshTokenRepo=MockUserRefreshTokenRepo github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/user RefreshTokenRepo
+//go:generate mockgen -destination=../../mocks/user_notifier.go -package=mocks -mock_names=Notifier=MockUserNotifier github.com/ew0s/ewos-to-go-hw/chat-server/internal/service/user Notifier
 
 type UserRepo interface {
 	AddUser(ctx context.Context, user entity.User) (*entity.User, error)
@@ -19,21 +28,71 @@ type UserRepo interface {
 	DeleteUser(ctx context.Context, id int) (*entity.User, error)
 	UpdateUser(ctx context.Context, id int, updateModel entity.User) (*entity.User, error)
 	CheckUniqueConstraints(ctx context.Context, email, username string) error
+	UpdateUserRole(ctx context.Context, id int, role entity.Role) (*entity.User, error)
+	// LockUsersWithRole counts users with role, concurrent transactions locking the same role can not both commit
+	LockUsersWithRole(ctx context.Context, role entity.Role) (int, error)
+}
+
+// SessionRepo and RefreshTokenRepo revoke sessions of user in the same unit of work as the change
+// that signs them out, e.g. password change.
+type SessionRepo interface {
+	GetUserSessions(ctx context.Context, userID int) ([]*entity.Session, error)
+	RevokeSession(ctx context.Context, id string, revokedAt time.Time) error
+}
+
+type RefreshTokenRepo interface {
+	RevokeTokenFamily(ctx context.Context, familyID string, revokedAt time.Time) error
 }
 
 type Hasher interface {
 	GenerateFromPassword(password []byte, cost int) ([]byte, error)
+	CompareHashAndPassword(hashedPassword []byte, password []byte) error
+}
+
+// UsernameRenamer updates username in data of user that is stored denormalized. It is not needed
+// for storages which keep such references consistent themselves, like postgres with `on update cascade`.
+type UsernameRenamer interface {
+	RenameUsername(ctx context.Context, oldUsername, newUsername string) error
+}
+
+// UnitOfWork runs fn in transaction, repositories called with context passed to fn take part in it.
+// Changes made by fn are applied all at once if it succeeds and are discarded otherwise.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// Notifier closes live connections of user who has to sign in again, e.g. after password change.
+type Notifier interface {
+	NotifyUserSignedOut(ctx context.Context, username string)
 }
 
 type Service struct {
-	UserRepo UserRepo
-	Hasher   Hasher
+	UserRepo         UserRepo
+	SessionRepo      SessionRepo
+	RefreshTokenRepo RefreshTokenRepo
+	Hasher           Hasher
+	UnitOfWork       UnitOfWork
+	Notifier         Notifier
+	UsernameRenamers []UsernameRenamer
 }
 
-func New(userRepo UserRepo, hasher Hasher) *Service {
+func New(
+	userRepo UserRepo,
+	sessionRepo SessionRepo,
+	refreshTokenRepo RefreshTokenRepo,
+	hasher Hasher,
+	unitOfWork UnitOfWork,
+	notifier Notifier,
+	usernameRenamers ...UsernameRenamer,
+) *Service {
 	return &Service{
-		UserRepo: userRepo,
-		Hasher:   hasher,
+		UserRepo:         userRepo,
+		SessionRepo:      sessionRepo,
+		RefreshTokenRepo: refreshTokenRepo,
+		Hasher:           hasher,
+		UnitOfWork:       unitOfWork,
+		Notifier:         notifier,
+		UsernameRenamers: usernameRenamers,
 	}
 }
 
@@ -111,22 +170,67 @@ func usersEquals(usr1, usr2 *entity.User) bool {
 		usr1.HashedPassword == usr2.HashedPassword
 }
 
+// checkChangedUniqueFields ensures that new email and username are not taken, user's own ones sent again are not checked.
+func (us *Service) checkChangedUniqueFields(ctx context.Context, usr *entity.User, updateModel entity.User) error {
+	email, username := updateModel.Email, updateModel.Username
+
+	if email == usr.Email {
+		email = ""
+	}
+
+	if username == usr.Username {
+		username = ""
+	}
+
+	if email == "" && username == "" {
+		return nil
+	}
+
+	return us.UserRepo.CheckUniqueConstraints(ctx, email, username)
+}
+
+// UpdateUser changes user and renames them everywhere username is kept, all in one unit of work.
+// Renamed user is signed out, as access tokens carry old username.
 func (us *Service) UpdateUser(ctx context.Context, id int, updateModel entity.User) (*entity.User, error) {
-	err := us.UserRepo.CheckUniqueConstraints(ctx, updateModel.Email, updateModel.Username)
+	var (
+		updated     *entity.User
+		oldUsername string
+	)
+
+	err := us.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+
+		updated, oldUsername, err = us.updateUser(ctx, id, updateModel)
+
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
+	if updated.Username != oldUsername {
+		us.Notifier.NotifyUserSignedOut(ctx, oldUsername)
+	}
+
+	return updated, nil
+}
+
+// updateUser returns updated user along with username they had before.
+func (us *Service) updateUser(ctx context.Context, id int, updateModel entity.User) (*entity.User, string, error) {
 	usr, err := us.UserRepo.GetUserByID(ctx, id)
 	if err != nil {
-		return nil, err
+		return nil, "", err
+	}
+
+	if err = us.checkChangedUniqueFields(ctx, usr, updateModel); err != nil {
+		return nil, "", err
 	}
 
 	// if password changed => hash
 	if updateModel.HashedPassword != "" {
 		hash, err := us.Hasher.GenerateFromPassword([]byte(updateModel.HashedPassword), bcrypt.DefaultCost)
 		if err != nil {
-			return nil, err
+			return nil, "", err
 		}
 
 		updateModel.HashedPassword = string(hash)
@@ -136,18 +240,176 @@ func (us *Service) UpdateUser(ctx context.Context, id int, updateModel entity.Us
 
 	// if update model updates nothing, thus no need for UserRepo.UpdateUser() call
 	if usersEquals(&updateModel, usr) {
-		return usr, nil
+		return usr, usr.Username, nil
 	}
 
 	updated, err := us.UserRepo.UpdateUser(ctx, id, updateModel)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if updated.Username != usr.Username {
+		for _, renamer := range us.UsernameRenamers {
+			if err = renamer.RenameUsername(ctx, usr.Username, updated.Username); err != nil {
+				return nil, "", err
+			}
+		}
+
+		if err = us.revokeAllSessions(ctx, id); err != nil {
+			return nil, "", err
+		}
+	}
+
+	return updated, usr.Username, nil
+}
+
+// checkPassword returns user if password is the current one, it guards changes of account that cannot be undone.
+func (us *Service) checkPassword(ctx context.Context, id int, password string) (*entity.User, error) {
+	user, err := us.UserRepo.GetUserByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	return updated, nil
+	if err = us.Hasher.CompareHashAndPassword([]byte(user.HashedPassword), []byte(password)); err != nil {
+		return nil, ErrWrongPassword
+	}
+
+	return user, nil
+}
+
+// revokeAllSessions revokes active sessions of user with their refresh tokens.
+func (us *Service) revokeAllSessions(ctx context.Context, id int) error {
+	sessions, err := us.SessionRepo.GetUserSessions(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	for _, session := range sessions {
+		if !session.IsActive(now) {
+			continue
+		}
+
+		if err = us.RefreshTokenRepo.RevokeTokenFamily(ctx, session.ID, now); err != nil {
+			return err
+		}
+
+		if err = us.SessionRepo.RevokeSession(ctx, session.ID, now); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ChangePassword changes password and signs user out of all sessions, all in one unit of work.
+func (us *Service) ChangePassword(ctx context.Context, id int, currentPassword, newPassword string) error {
+	var user *entity.User
+
+	err := us.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+
+		user, err = us.changePassword(ctx, id, currentPassword, newPassword)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	us.Notifier.NotifyUserSignedOut(ctx, user.Username)
+
+	return nil
 }
 
-func (us *Service) DeleteUser(ctx context.Context, id int) (*entity.User, error) { // todo: authorize admin rights
+func (us *Service) changePassword(ctx context.Context, id int, currentPassword, newPassword string) (*entity.User, error) {
+	user, err := us.checkPassword(ctx, id, currentPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := us.Hasher.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	user.HashedPassword = string(hash)
+
+	if _, err = us.UserRepo.UpdateUser(ctx, id, *user); err != nil {
+		return nil, err
+	}
+
+	if err = us.revokeAllSessions(ctx, id); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (us *Service) DeleteAccount(ctx context.Context, id int, password string) (*entity.User, error) {
+	if _, err := us.checkPassword(ctx, id, password); err != nil {
+		return nil, err
+	}
+
+	return us.DeleteUser(ctx, id)
+}
+
+// ensureNotLastAdmin forbids leaving chat without admins, as nobody could manage roles then.
+func (us *Service) ensureNotLastAdmin(ctx context.Context, user *entity.User) error {
+	if user.Role != entity.RoleAdmin {
+		return nil
+	}
+
+	// admins are counted and changed in different rows, so without the lock concurrent demotions
+	// of the last two admins would both pass the check
+	admins, err := us.UserRepo.LockUsersWithRole(ctx, entity.RoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	if admins <= 1 {
+		return ErrLastAdmin
+	}
+
+	return nil
+}
+
+// DeleteUser deletes user and signs them out of all sessions, all in one unit of work.
+func (us *Service) DeleteUser(ctx context.Context, id int) (*entity.User, error) {
+	var deleted *entity.User
+
+	err := us.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+
+		deleted, err = us.deleteUser(ctx, id)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	us.Notifier.NotifyUserSignedOut(ctx, deleted.Username)
+
+	return deleted, nil
+}
+
+func (us *Service) deleteUser(ctx context.Context, id int) (*entity.User, error) {
+	user, err := us.UserRepo.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = us.ensureNotLastAdmin(ctx, user); err != nil {
+		return nil, err
+	}
+
+	// access tokens of deleted user must not outlive them
+	if err = us.revokeAllSessions(ctx, id); err != nil {
+		return nil, err
+	}
+
 	deleted, err := us.UserRepo.DeleteUser(ctx, id)
 	if err != nil {
 		return nil, err
@@ -155,3 +417,69 @@ func (us *Service) DeleteUser(ctx context.Context, id int) (*entity.User, error)
 
 	return deleted, nil
 }
+
+func (us *Service) ChangeUserRole(ctx context.Context, id int, role entity.Role) (*entity.User, error) {
+	if !role.IsValid() {
+		return nil, ErrInvalidRole
+	}
+
+	var changed *entity.User
+
+	err := us.UnitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+
+		changed, err = us.changeUserRole(ctx, id, role)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return changed, nil
+}
+
+func (us *Service) changeUserRole(ctx context.Context, id int, role entity.Role) (*entity.User, error) {
+	user, err := us.UserRepo.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Role == role {
+		return user, nil
+	}
+
+	if err = us.ensureNotLastAdmin(ctx, user); err != nil {
+		return nil, err
+	}
+
+	return us.UserRepo.UpdateUserRole(ctx, id, role)
+}
+
+// BootstrapAdmins grants admin role to users with given usernames, so chat has admins from the very start.
+// Usernames that are not registered yet are returned as missing.
+func (us *Service) BootstrapAdmins(ctx context.Context, usernames []string) ([]string, error) {
+	missing := make([]string, 0)
+
+	for _, username := range usernames {
+		user, err := us.UserRepo.GetUserByUsername(ctx, username)
+		if errors.Is(err, repository.ErrNoSuchUser) {
+			missing = append(missing, username)
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if user.Role == entity.RoleAdmin {
+			continue
+		}
+
+		if _, err = us.UserRepo.UpdateUserRole(ctx, user.ID, entity.RoleAdmin); err != nil {
+			return nil, err
+		}
+	}
+
+	return missing, nil
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
 
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/domain/entity"
 	"github.com/ew0s/ewos-to-go-hw/chat-server/internal/mocks"
@@ -24,9 +25,12 @@ func TestUserService_Register(t *testing.T) {
 	now := time.Now()
 
 	repoMock := mocks.NewMockUserRepo(ctrl)
+	sessionRepoMock := mocks.NewMockUserSessionRepo(ctrl)
+	refreshTokenRepoMock := mocks.NewMockUserRefreshTokenRepo(ctrl)
 	hasherMock := mocks.NewMockUserHasher(ctrl)
+	notifierMock := mocks.NewMockUserNotifier(ctrl)
 
-	service := New(repoMock, hasherMock)
+	service := New(repoMock, sessionRepoMock, refreshTokenRepoMock, hasherMock, testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
 
 	type inputArgs = entity.User
 	type outputArg = *entity.User
@@ -114,9 +118,12 @@ func TestUserService_GetByID(t *testing.T) {
 	now := time.Now()
 
 	repoMock := mocks.NewMockUserRepo(ctrl)
+	sessionRepoMock := mocks.NewMockUserSessionRepo(ctrl)
+	refreshTokenRepoMock := mocks.NewMockUserRefreshTokenRepo(ctrl)
 	hasherMock := mocks.NewMockUserHasher(ctrl)
+	notifierMock := mocks.NewMockUserNotifier(ctrl)
 
-	service := New(repoMock, hasherMock)
+	service := New(repoMock, sessionRepoMock, refreshTokenRepoMock, hasherMock, testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
 
 	type inputArgs = int
 	type outputArg = *entity.User
@@ -204,9 +211,12 @@ func TestUserService_GetByEmail(t *testing.T) {
 	now := time.Now()
 
 	repoMock := mocks.NewMockUserRepo(ctrl)
+	sessionRepoMock := mocks.NewMockUserSessionRepo(ctrl)
+	refreshTokenRepoMock := mocks.NewMockUserRefreshTokenRepo(ctrl)
 	hasherMock := mocks.NewMockUserHasher(ctrl)
+	notifierMock := mocks.NewMockUserNotifier(ctrl)
 
-	service := New(repoMock, hasherMock)
+	service := New(repoMock, sessionRepoMock, refreshTokenRepoMock, hasherMock, testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
 
 	type inputArgs = string
 	type outputArg = *entity.User
@@ -282,9 +292,12 @@ func TestUserService_GetByUsername(t *testing.T) {
 	now := time.Now()
 
 	repoMock := mocks.NewMockUserRepo(ctrl)
+	sessionRepoMock := mocks.NewMockUserSessionRepo(ctrl)
+	refreshTokenRepoMock := mocks.NewMockUserRefreshTokenRepo(ctrl)
 	hasherMock := mocks.NewMockUserHasher(ctrl)
+	notifierMock := mocks.NewMockUserNotifier(ctrl)
 
-	service := New(repoMock, hasherMock)
+	service := New(repoMock, sessionRepoMock, refreshTokenRepoMock, hasherMock, testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
 
 	type inputArgs = string
 	type outputArg = *entity.User
@@ -360,9 +373,12 @@ func TestUserService_GetAll(t *testing.T) {
 	now := time.Now()
 
 	repoMock := mocks.NewMockUserRepo(ctrl)
+	sessionRepoMock := mocks.NewMockUserSessionRepo(ctrl)
+	refreshTokenRepoMock := mocks.NewMockUserRefreshTokenRepo(ctrl)
 	hasherMock := mocks.NewMockUserHasher(ctrl)
+	notifierMock := mocks.NewMockUserNotifier(ctrl)
 
-	service := New(repoMock, hasherMock)
+	service := New(repoMock, sessionRepoMock, refreshTokenRepoMock, hasherMock, testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
 
 	type outputArg = []entity.User
 
@@ -615,9 +631,12 @@ func TestUserService_Update(t *testing.T) { // TODO: TEST THAT UPDATED_AT FIELD
 	now := time.Now()
 
 	repoMock := mocks.NewMockUserRepo(ctrl)
+	sessionRepoMock := mocks.NewMockUserSessionRepo(ctrl)
+	refreshTokenRepoMock := mocks.NewMockUserRefreshTokenRepo(ctrl)
 	hasherMock := mocks.NewMockUserHasher(ctrl)
+	notifierMock := mocks.NewMockUserNotifier(ctrl)
 
-	service := New(repoMock, hasherMock)
+	service := New(repoMock, sessionRepoMock, refreshTokenRepoMock, hasherMock, testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
 
 	type outputArg = *entity.User
 
@@ -678,6 +697,25 @@ func TestUserService_Update(t *testing.T) { // TODO: TEST THAT UPDATED_AT FIELD
 							UpdatedAt:      now,
 						},
 						nil)
+
+				sessionRepoMock.
+					EXPECT().
+					GetUserSessions(ctx, 1).
+					Return([]*entity.Session{{ID: "sid", UserID: 1, ExpiresAt: now.Add(time.Hour)}}, nil)
+
+				refreshTokenRepoMock.
+					EXPECT().
+					RevokeTokenFamily(ctx, "sid", gomock.Any()).
+					Return(nil)
+
+				sessionRepoMock.
+					EXPECT().
+					RevokeSession(ctx, "sid", gomock.Any()).
+					Return(nil)
+
+				notifierMock.
+					EXPECT().
+					NotifyUserSignedOut(ctx, "username")
 			},
 			id: 1,
 			updateModel: entity.User{
@@ -697,11 +735,6 @@ func TestUserService_Update(t *testing.T) { // TODO: TEST THAT UPDATED_AT FIELD
 		{
 			name: "err, invalid id",
 			mockBehaviour: func() {
-				repoMock.
-					EXPECT().
-					CheckUniqueConstraints(ctx, "newemail@mail.com", "newusername").
-					Return(nil)
-
 				repoMock.
 					EXPECT().
 					GetUserByID(ctx, 1).
@@ -715,9 +748,54 @@ func TestUserService_Update(t *testing.T) { // TODO: TEST THAT UPDATED_AT FIELD
 			},
 			wantErr: true,
 		},
+		{
+			name: "ok, own email and username are sent again",
+			mockBehaviour: func() {
+				repoMock.
+					EXPECT().
+					GetUserByID(ctx, 1).
+					Return(&entity.User{ID: 1, Email: "email@mail.com", Username: "username"}, nil)
+			},
+			id: 1,
+			updateModel: entity.User{
+				Email:    "email@mail.com",
+				Username: "username",
+			},
+			want: &entity.User{ID: 1, Email: "email@mail.com", Username: "username"},
+		},
+		{
+			name: "ok, own username is sent again with new email",
+			mockBehaviour: func() {
+				repoMock.
+					EXPECT().
+					GetUserByID(ctx, 1).
+					Return(&entity.User{ID: 1, Email: "email@mail.com", Username: "username"}, nil)
+
+				repoMock.
+					EXPECT().
+					CheckUniqueConstraints(ctx, "newemail@mail.com", "").
+					Return(nil)
+
+				repoMock.
+					EXPECT().
+					UpdateUser(ctx, 1, entity.User{Email: "newemail@mail.com", Username: "username"}).
+					Return(&entity.User{ID: 1, Email: "newemail@mail.com", Username: "username"}, nil)
+			},
+			id: 1,
+			updateModel: entity.User{
+				Email:    "newemail@mail.com",
+				Username: "username",
+			},
+			want: &entity.User{ID: 1, Email: "newemail@mail.com", Username: "username"},
+		},
 		{
 			name: "err, invalid update model (existing email)",
 			mockBehaviour: func() {
+				repoMock.
+					EXPECT().
+					GetUserByID(ctx, 1).
+					Return(&entity.User{ID: 1, Email: "email@mail.com", Username: "username"}, nil)
+
 				repoMock.
 					EXPECT().
 					CheckUniqueConstraints(ctx, "existingemail@mail.com", "").
@@ -732,6 +810,11 @@ func TestUserService_Update(t *testing.T) { // TODO: TEST THAT UPDATED_AT FIELD
 		{
 			name: "err, invalid update model (existing username)",
 			mockBehaviour: func() {
+				repoMock.
+					EXPECT().
+					GetUserByID(ctx, 1).
+					Return(&entity.User{ID: 1, Email: "email@mail.com", Username: "username"}, nil)
+
 				repoMock.
 					EXPECT().
 					CheckUniqueConstraints(ctx, "", "existingusername").
@@ -767,9 +850,12 @@ func TestUserService_Delete(t *testing.T) {
 	now := time.Now()
 
 	repoMock := mocks.NewMockUserRepo(ctrl)
+	sessionRepoMock := mocks.NewMockUserSessionRepo(ctrl)
+	refreshTokenRepoMock := mocks.NewMockUserRefreshTokenRepo(ctrl)
 	hasherMock := mocks.NewMockUserHasher(ctrl)
+	notifierMock := mocks.NewMockUserNotifier(ctrl)
 
-	service := New(repoMock, hasherMock)
+	service := New(repoMock, sessionRepoMock, refreshTokenRepoMock, hasherMock, testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
 
 	type inputArg = int
 	type outputArg = *entity.User
@@ -784,6 +870,16 @@ func TestUserService_Delete(t *testing.T) {
 		{
 			name: "ok, valid id",
 			mockBehaviour: func() {
+				repoMock.
+					EXPECT().
+					GetUserByID(ctx, 1).
+					Return(&entity.User{ID: 1, Role: entity.RoleUser}, nil)
+
+				sessionRepoMock.
+					EXPECT().
+					GetUserSessions(ctx, 1).
+					Return(nil, nil)
+
 				repoMock.
 					EXPECT().
 					DeleteUser(ctx, 1).
@@ -797,6 +893,10 @@ func TestUserService_Delete(t *testing.T) {
 							UpdatedAt:      now,
 						},
 						nil)
+
+				notifierMock.
+					EXPECT().
+					NotifyUserSignedOut(ctx, "username")
 			},
 			input: 1,
 			want: &entity.User{
@@ -813,7 +913,7 @@ func TestUserService_Delete(t *testing.T) {
 			mockBehaviour: func() {
 				repoMock.
 					EXPECT().
-					DeleteUser(ctx, 1).
+					GetUserByID(ctx, 1).
 					Return(nil, repoerrors.ErrNoSuchUser)
 			},
 			input:   1,
@@ -824,12 +924,58 @@ func TestUserService_Delete(t *testing.T) {
 			mockBehaviour: func() {
 				repoMock.
 					EXPECT().
-					DeleteUser(ctx, -1).
+					GetUserByID(ctx, -1).
 					Return(nil, repoerrors.ErrNoSuchUser)
 			},
 			input:   -1,
 			wantErr: true,
 		},
+		{
+			name: "ok, one of admins",
+			mockBehaviour: func() {
+				repoMock.
+					EXPECT().
+					GetUserByID(ctx, 1).
+					Return(&entity.User{ID: 1, Username: "username", Role: entity.RoleAdmin}, nil)
+
+				repoMock.
+					EXPECT().
+					LockUsersWithRole(ctx, entity.RoleAdmin).
+					Return(2, nil)
+
+				sessionRepoMock.
+					EXPECT().
+					GetUserSessions(ctx, 1).
+					Return(nil, nil)
+
+				repoMock.
+					EXPECT().
+					DeleteUser(ctx, 1).
+					Return(&entity.User{ID: 1, Username: "username", Role: entity.RoleAdmin}, nil)
+
+				notifierMock.
+					EXPECT().
+					NotifyUserSignedOut(ctx, "username")
+			},
+			input: 1,
+			want:  &entity.User{ID: 1, Username: "username", Role: entity.RoleAdmin},
+		},
+		{
+			name: "err, last admin",
+			mockBehaviour: func() {
+				repoMock.
+					EXPECT().
+					GetUserByID(ctx, 1).
+					Return(&entity.User{ID: 1, Role: entity.RoleAdmin}, nil)
+
+				repoMock.
+					EXPECT().
+					LockUsersWithRole(ctx, entity.RoleAdmin).
+					Return(1, nil)
+			},
+			input:   1,
+			wantErr: true,
+		},
 	}
 
 	for _, test := range tests {
@@ -847,3 +993,272 @@ func TestUserService_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestUserService_ChangeUserRole(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repoMock := mocks.NewMockUserRepo(ctrl)
+	sessionRepoMock := mocks.NewMockUserSessionRepo(ctrl)
+	refreshTokenRepoMock := mocks.NewMockUserRefreshTokenRepo(ctrl)
+	hasherMock := mocks.NewMockUserHasher(ctrl)
+	notifierMock := mocks.NewMockUserNotifier(ctrl)
+
+	service := New(repoMock, sessionRepoMock, refreshTokenRepoMock, hasherMock, testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		role          entity.Role
+		want          *entity.User
+		wantErr       error
+	}{
+		{
+			name: "ok, user promoted to moderator",
+			mockBehaviour: func() {
+				repoMock.EXPECT().GetUserByID(ctx, 1).Return(&entity.User{ID: 1, Role: entity.RoleUser}, nil)
+				repoMock.EXPECT().UpdateUserRole(ctx, 1, entity.RoleModerator).
+					Return(&entity.User{ID: 1, Role: entity.RoleModerator}, nil)
+			},
+			role: entity.RoleModerator,
+			want: &entity.User{ID: 1, Role: entity.RoleModerator},
+		},
+		{
+			name: "ok, same role is not updated",
+			mockBehaviour: func() {
+				repoMock.EXPECT().GetUserByID(ctx, 1).Return(&entity.User{ID: 1, Role: entity.RoleAdmin}, nil)
+			},
+			role: entity.RoleAdmin,
+			want: &entity.User{ID: 1, Role: entity.RoleAdmin},
+		},
+		{
+			name:          "err, invalid role",
+			mockBehaviour: func() {},
+			role:          entity.Role("owner"),
+			wantErr:       ErrInvalidRole,
+		},
+		{
+			name: "err, no such user",
+			mockBehaviour: func() {
+				repoMock.EXPECT().GetUserByID(ctx, 1).Return(nil, repoerrors.ErrNoSuchUser)
+			},
+			role:    entity.RoleModerator,
+			wantErr: repoerrors.ErrNoSuchUser,
+		},
+		{
+			name: "err, last admin demoted",
+			mockBehaviour: func() {
+				repoMock.EXPECT().GetUserByID(ctx, 1).Return(&entity.User{ID: 1, Role: entity.RoleAdmin}, nil)
+				repoMock.EXPECT().LockUsersWithRole(ctx, entity.RoleAdmin).Return(1, nil)
+			},
+			role:    entity.RoleUser,
+			wantErr: ErrLastAdmin,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			got, err := service.ChangeUserRole(ctx, 1, test.role)
+
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, test.want, got)
+			}
+		})
+	}
+}
+
+func TestUserService_BootstrapAdmins(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repoMock := mocks.NewMockUserRepo(ctrl)
+	sessionRepoMock := mocks.NewMockUserSessionRepo(ctrl)
+	refreshTokenRepoMock := mocks.NewMockUserRefreshTokenRepo(ctrl)
+	hasherMock := mocks.NewMockUserHasher(ctrl)
+	notifierMock := mocks.NewMockUserNotifier(ctrl)
+
+	service := New(repoMock, sessionRepoMock, refreshTokenRepoMock, hasherMock, testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
+
+	repoMock.EXPECT().GetUserByUsername(ctx, "alice").Return(&entity.User{ID: 1, Role: entity.RoleUser}, nil)
+	repoMock.EXPECT().UpdateUserRole(ctx, 1, entity.RoleAdmin).Return(&entity.User{ID: 1, Role: entity.RoleAdmin}, nil)
+	repoMock.EXPECT().GetUserByUsername(ctx, "bob").Return(&entity.User{ID: 2, Role: entity.RoleAdmin}, nil)
+	repoMock.EXPECT().GetUserByUsername(ctx, "carol").Return(nil, repoerrors.ErrNoSuchUser)
+
+	missing, err := service.BootstrapAdmins(ctx, []string{"alice", "bob", "carol"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"carol"}, missing)
+}
+
+func TestUserService_UpdateUser_RenamesUsername(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repoMock := mocks.NewMockUserRepo(ctrl)
+	sessionRepoMock := mocks.NewMockUserSessionRepo(ctrl)
+	refreshTokenRepoMock := mocks.NewMockUserRefreshTokenRepo(ctrl)
+	hasherMock := mocks.NewMockUserHasher(ctrl)
+	notifierMock := mocks.NewMockUserNotifier(ctrl)
+	renamerMock := mocks.NewMockUsernameRenamer(ctrl)
+
+	service := New(repoMock, sessionRepoMock, refreshTokenRepoMock, hasherMock, testingutils.NewUnitOfWorkMock(ctrl), notifierMock, renamerMock)
+
+	current := &entity.User{ID: 1, Email: "email@mail.com", Username: "old", HashedPassword: "hash"}
+
+	repoMock.EXPECT().CheckUniqueConstraints(ctx, "", "new").Return(nil)
+	repoMock.EXPECT().GetUserByID(ctx, 1).Return(current, nil)
+	repoMock.EXPECT().
+		UpdateUser(ctx, 1, entity.User{Email: "email@mail.com", Username: "new", HashedPassword: "hash"}).
+		Return(&entity.User{ID: 1, Email: "email@mail.com", Username: "new", HashedPassword: "hash"}, nil)
+	renamerMock.EXPECT().RenameUsername(ctx, "old", "new").Return(nil)
+	sessionRepoMock.EXPECT().GetUserSessions(ctx, 1).Return(nil, nil)
+	notifierMock.EXPECT().NotifyUserSignedOut(ctx, "old")
+
+	got, err := service.UpdateUser(ctx, 1, entity.User{Username: "new"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "new", got.Username)
+}
+
+func TestUserService_UpdateUser_FailedUnitOfWork(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repoMock := mocks.NewMockUserRepo(ctrl)
+	sessionRepoMock := mocks.NewMockUserSessionRepo(ctrl)
+	refreshTokenRepoMock := mocks.NewMockUserRefreshTokenRepo(ctrl)
+	hasherMock := mocks.NewMockUserHasher(ctrl)
+	notifierMock := mocks.NewMockUserNotifier(ctrl)
+	uowMock := mocks.NewMockUnitOfWork(ctrl)
+
+	service := New(repoMock, sessionRepoMock, refreshTokenRepoMock, hasherMock, uowMock, notifierMock)
+
+	// user is updated, but unit of work is not committed
+	uowMock.EXPECT().Do(ctx, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+			assert.NoError(t, fn(ctx))
+			return repoerrors.ErrConcurrentUpdate
+		})
+
+	repoMock.EXPECT().CheckUniqueConstraints(ctx, "", "new").Return(nil)
+	repoMock.EXPECT().GetUserByID(ctx, 1).Return(&entity.User{ID: 1, Username: "old"}, nil)
+	repoMock.EXPECT().UpdateUser(ctx, 1, entity.User{Username: "new"}).Return(&entity.User{ID: 1, Username: "new"}, nil)
+	sessionRepoMock.EXPECT().GetUserSessions(ctx, 1).Return(nil, nil)
+
+	got, err := service.UpdateUser(ctx, 1, entity.User{Username: "new"})
+
+	assert.ErrorIs(t, err, repoerrors.ErrConcurrentUpdate)
+	assert.Nil(t, got)
+}
+
+func TestUserService_ChangePassword(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+	now := time.Now()
+
+	repoMock := mocks.NewMockUserRepo(ctrl)
+	sessionRepoMock := mocks.NewMockUserSessionRepo(ctrl)
+	refreshTokenRepoMock := mocks.NewMockUserRefreshTokenRepo(ctrl)
+	hasherMock := mocks.NewMockUserHasher(ctrl)
+	notifierMock := mocks.NewMockUserNotifier(ctrl)
+
+	service := New(repoMock, sessionRepoMock, refreshTokenRepoMock, hasherMock, testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
+
+	tests := []struct {
+		name          string
+		mockBehaviour func()
+		wantErr       error
+	}{
+		{
+			name: "ok",
+			mockBehaviour: func() {
+				repoMock.EXPECT().GetUserByID(ctx, 1).Return(&entity.User{ID: 1, Username: "username", HashedPassword: "hash"}, nil)
+				hasherMock.EXPECT().CompareHashAndPassword([]byte("hash"), []byte("current")).Return(nil)
+				hasherMock.EXPECT().GenerateFromPassword([]byte("new_password"), 10).Return([]byte("new_hash"), nil)
+				repoMock.EXPECT().UpdateUser(ctx, 1, entity.User{ID: 1, Username: "username", HashedPassword: "new_hash"}).
+					Return(&entity.User{ID: 1, Username: "username", HashedPassword: "new_hash"}, nil)
+				sessionRepoMock.EXPECT().GetUserSessions(ctx, 1).Return([]*entity.Session{
+					{ID: "active", UserID: 1, ExpiresAt: now.Add(time.Hour)},
+					{ID: "revoked", UserID: 1, ExpiresAt: now.Add(time.Hour), RevokedAt: &now},
+				}, nil)
+				refreshTokenRepoMock.EXPECT().RevokeTokenFamily(ctx, "active", gomock.Any()).Return(nil)
+				sessionRepoMock.EXPECT().RevokeSession(ctx, "active", gomock.Any()).Return(nil)
+				notifierMock.EXPECT().NotifyUserSignedOut(ctx, "username")
+			},
+		},
+		{
+			name: "err, password is not changed if sessions are not revoked",
+			mockBehaviour: func() {
+				repoMock.EXPECT().GetUserByID(ctx, 1).Return(&entity.User{ID: 1, Username: "username", HashedPassword: "hash"}, nil)
+				hasherMock.EXPECT().CompareHashAndPassword([]byte("hash"), []byte("current")).Return(nil)
+				hasherMock.EXPECT().GenerateFromPassword([]byte("new_password"), 10).Return([]byte("new_hash"), nil)
+				repoMock.EXPECT().UpdateUser(ctx, 1, entity.User{ID: 1, Username: "username", HashedPassword: "new_hash"}).
+					Return(&entity.User{ID: 1, Username: "username", HashedPassword: "new_hash"}, nil)
+				sessionRepoMock.EXPECT().GetUserSessions(ctx, 1).Return(nil, repoerrors.ErrConcurrentUpdate)
+			},
+			wantErr: repoerrors.ErrConcurrentUpdate,
+		},
+		{
+			name: "err, wrong current password",
+			mockBehaviour: func() {
+				repoMock.EXPECT().GetUserByID(ctx, 1).Return(&entity.User{ID: 1, HashedPassword: "hash"}, nil)
+				hasherMock.EXPECT().CompareHashAndPassword([]byte("hash"), []byte("current")).
+					Return(bcrypt.ErrMismatchedHashAndPassword)
+			},
+			wantErr: ErrWrongPassword,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.mockBehaviour()
+
+			err := service.ChangePassword(ctx, 1, "current", "new_password")
+
+			if test.wantErr != nil {
+				assert.ErrorIs(t, err, test.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestUserService_DeleteAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	ctx := context.Background()
+
+	repoMock := mocks.NewMockUserRepo(ctrl)
+	sessionRepoMock := mocks.NewMockUserSessionRepo(ctrl)
+	refreshTokenRepoMock := mocks.NewMockUserRefreshTokenRepo(ctrl)
+	hasherMock := mocks.NewMockUserHasher(ctrl)
+	notifierMock := mocks.NewMockUserNotifier(ctrl)
+
+	service := New(repoMock, sessionRepoMock, refreshTokenRepoMock, hasherMock, testingutils.NewUnitOfWorkMock(ctrl), notifierMock)
+
+	user := &entity.User{ID: 1, Username: "username", HashedPassword: "hash", Role: entity.RoleUser}
+
+	repoMock.EXPECT().GetUserByID(ctx, 1).Return(user, nil).Times(2)
+	hasherMock.EXPECT().CompareHashAndPassword([]byte("hash"), []byte("password")).Return(nil)
+	sessionRepoMock.EXPECT().GetUserSessions(ctx, 1).Return(nil, nil)
+	repoMock.EXPECT().DeleteUser(ctx, 1).Return(user, nil)
+	notifierMock.EXPECT().NotifyUserSignedOut(ctx, "username")
+
+	got, err := service.DeleteAccount(ctx, 1, "password")
+
+	assert.NoError(t, err)
+	assert.Equal(t, user, got)
+
+	repoMock.EXPECT().GetUserByID(ctx, 1).Return(user, nil)
+	hasherMock.EXPECT().CompareHashAndPassword([]byte("hash"), []byte("wrong")).
+		Return(bcrypt.ErrMismatchedHashAndPassword)
+
+	_, err = service.DeleteAccount(ctx, 1, "wrong")
+
+	assert.ErrorIs(t, err, ErrWrongPassword)
+}
@@ -0,0 +1,16 @@
+package blob
+
+import (
+	"context"
+	"io"
+)
+
+// Storage keeps binary objects by key. Keys are generated by caller and must not contain path separators.
+type Storage interface {
+	// Put stores content under the key and returns number of written bytes.
+	Put(ctx context.Context, key string, content io.Reader) (int64, error)
+	// Get returns reader of stored content, caller must close it.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes content, deleting missing key is not an error.
+	Delete(ctx context.Context, key string) error
+}
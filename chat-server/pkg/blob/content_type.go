@@ -0,0 +1,22 @@
+package blob
+
+import (
+	"bufio"
+	"mime"
+	"net/http"
+)
+
+// SniffLen is number of bytes http.DetectContentType looks at, content reader should buffer at least that many.
+const SniffLen = 512
+
+// DetectContentType sniffs media type of content without consuming it, declared type of file is not trusted.
+func DetectContentType(content *bufio.Reader) string {
+	head, _ := content.Peek(SniffLen)
+
+	mediaType, _, err := mime.ParseMediaType(http.DetectContentType(head))
+	if err != nil {
+		return "application/octet-stream"
+	}
+
+	return mediaType
+}
@@ -0,0 +1,8 @@
+package blob
+
+import "errors"
+
+var (
+	ErrNotFound   = errors.New("no such blob")
+	ErrInvalidKey = errors.New("invalid blob key")
+)
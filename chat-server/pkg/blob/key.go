@@ -0,0 +1,19 @@
+package blob
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+)
+
+const keyBytes = 16
+
+// NewKey returns random key that is safe to use with any Storage.
+func NewKey() (string, error) {
+	key := make([]byte, keyBytes)
+
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(key), nil
+}
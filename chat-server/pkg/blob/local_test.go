@@ -0,0 +1,49 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestLocalStorage(t *testing.T) {
+	ctx := context.Background()
+
+	storage, err := NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	written, err := storage.Put(ctx, "key", strings.NewReader("content"))
+	if err != nil || written != int64(len("content")) {
+		t.Fatalf("unexpected put result %v, err: %v", written, err)
+	}
+
+	reader, err := storage.Get(ctx, "key")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	content, _ := io.ReadAll(reader)
+	reader.Close()
+
+	if string(content) != "content" {
+		t.Fatalf("unexpected content %q", content)
+	}
+
+	if err = storage.Delete(ctx, "key"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = storage.Get(ctx, "key"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("deleted blob must not be found, err: %v", err)
+	}
+
+	for _, key := range []string{"", "..", "../key", `dir\key`} {
+		if _, err = storage.Put(ctx, key, strings.NewReader("content")); !errors.Is(err, ErrInvalidKey) {
+			t.Fatalf("key %q must be rejected, err: %v", key, err)
+		}
+	}
+}
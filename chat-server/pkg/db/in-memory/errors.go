@@ -6,4 +6,20 @@ var (
 	ErrNotExistedRow   = errors.New("no such row")
 	ErrNotExistedTable = errors.New("no such table")
 	ErrExistingKey     = errors.New("key already exists")
+	ErrNotExistedIndex = errors.New("no such index")
+	ErrUniqueViolation = errors.New("unique index violation")
+
+	ErrUnknownRowType             = errors.New("row type is not registered")
+	ErrTypeRegistered             = errors.New("row type is already registered")
+	ErrMixedRowTypes              = errors.New("table has rows of different types")
+	ErrUnsupportedSnapshotVersion = errors.New("unsupported snapshot version")
+
+	ErrCorruptedWAL = errors.New("write-ahead log is corrupted")
+	ErrWALFailed    = errors.New("write-ahead log write failed")
+
+	ErrTxDone     = errors.New("transaction is already committed or rolled back")
+	ErrTxConflict = errors.New("transaction conflicts with concurrent change")
+
+	ErrUnexpectedRowType = errors.New("row has unexpected type")
+	ErrNoAutoIncrement   = errors.New("table does not generate keys")
 )
@@ -2,8 +2,6 @@ package in_memory
 
 import (
 	"context"
-	"encoding/json"
-	"os"
 	"sync"
 
 	orderedmap "github.com/wk8/go-ordered-map/v2"
@@ -21,10 +19,20 @@ type InMemoryDB interface {
 	DropRow(table string, identifier string) error
 	GetRow(table string, identifier string) (any, error)
 	GetAllRows(table string, offset, limit int) ([]any, error)
+	EachRow(table string, fn func(row any) bool) error
 
 	GetRowsCount(table string) (int, error)
 	GetTableCounter(table string) (int, error)
 
+	CreateTextIndex(table string, extract TextExtractor) error
+	SearchText(table string, query string) ([]string, error)
+
+	CreateIndex(table string, spec IndexSpec) error
+	GetRowByIndex(table string, index string, key string) (any, error)
+	GetRowsByIndex(table string, index string, key string) ([]any, error)
+
+	Begin() *Tx
+
 	Clear()
 }
 
@@ -33,15 +41,26 @@ type Table = *orderedmap.OrderedMap[string, any]
 type InMemDB struct {
 	Tables   map[string]Table
 	counters map[string]int
+	indexes  map[string]*textIndex
+	// secondary holds secondary indexes of tables by their names
+	secondary map[string]map[string]*secondaryIndex
+	types     *TypeRegistry
+	// wal is set for db opened with OpenInMemDB, changes are logged to it before they are applied
+	wal *wal
+	// txState tracks open transactions, see tx.go
+	txState
 
 	m *sync.RWMutex
 }
 
 func NewInMemDB(ctx context.Context, savePath string) (*InMemDB, <-chan any) {
 	db := InMemDB{
-		Tables:   make(map[string]Table),
-		counters: make(map[string]int),
-		m:        &sync.RWMutex{},
+		Tables:    make(map[string]Table),
+		counters:  make(map[string]int),
+		indexes:   make(map[string]*textIndex),
+		secondary: make(map[string]map[string]*secondaryIndex),
+		types:     Types,
+		m:         &sync.RWMutex{},
 	}
 
 	savedChan := make(chan any, 1)
@@ -54,27 +73,26 @@ func NewInMemDB(ctx context.Context, savePath string) (*InMemDB, <-chan any) {
 	return &db, savedChan
 }
 
+// NewInMemDBFromJSON restores db from snapshot made by Save. Rows get back types they were saved with,
+// thus types of all saved rows must be registered in Types.
 func NewInMemDBFromJSON(ctx context.Context, jsonState string, savePath string) (*InMemDB, <-chan any, error) {
-	tables := make(map[string]Table)
-
-	err := json.Unmarshal([]byte(jsonState), &tables) // todo: not unmarshalls embedded map
+	snapshot, err := ParseSnapshot([]byte(jsonState))
 	if err != nil {
 		return nil, nil, err
 	}
 
-	counters := make(map[string]int)
-
-	for name, table := range tables {
-		counters[name] = table.Len()
+	db := InMemDB{
+		indexes:   make(map[string]*textIndex),
+		secondary: make(map[string]map[string]*secondaryIndex),
+		types:     Types,
+		m:         &sync.RWMutex{},
 	}
 
-	db := InMemDB{
-		Tables:   tables,
-		counters: counters,
-		m:        &sync.RWMutex{},
+	if err = db.restore(snapshot); err != nil {
+		return nil, nil, err
 	}
 
-	savedChan := make(chan any)
+	savedChan := make(chan any, 1)
 
 	go func() {
 		<-ctx.Done()
@@ -85,13 +103,13 @@ func NewInMemDBFromJSON(ctx context.Context, jsonState string, savePath string)
 }
 
 func (db *InMemDB) Save(path string, doneChan chan any) {
-	bytes, err := json.Marshal(db.Tables)
+	bytes, err := db.MarshalSnapshot()
 	if err != nil {
 		doneChan <- err
 		return // todo: log?
 	}
 
-	err = os.WriteFile(path, []byte(jsonutils.PrettifyJSON(string(bytes))), writePerm)
+	err = writeFileAtomic(path, []byte(jsonutils.PrettifyJSON(string(bytes))), writePerm)
 	if err != nil {
 		doneChan <- err
 		return
@@ -100,12 +118,33 @@ func (db *InMemDB) Save(path string, doneChan chan any) {
 	doneChan <- "ok"
 }
 
+// CreateTable creates empty table, existing table with the same name is replaced.
+// If change can not be logged, it is not applied and the following writes fail with ErrWALFailed.
 func (db *InMemDB) CreateTable(name string) {
 	db.m.Lock()
 	defer db.m.Unlock()
 
+	if db.log(walRecord{Op: walOpCreateTable, Table: name}) != nil {
+		return
+	}
+
+	db.createTableNotLocking(name)
+}
+
+func (db *InMemDB) createTableNotLocking(name string) {
+	db.touchTableNotLocking(name)
+
 	db.Tables[name] = orderedmap.New[string, any]()
 	db.counters[name] = 0
+
+	if index, ok := db.indexes[name]; ok {
+		index.clear()
+	}
+
+	// old indexes may still be read by transactions
+	for indexName, index := range db.secondary[name] {
+		db.secondary[name][indexName] = newSecondaryIndex(index.spec)
+	}
 }
 
 func (db *InMemDB) getTableNotLocking(name string) (Table, error) {
@@ -128,14 +167,40 @@ func (db *InMemDB) DropTable(name string) {
 	db.m.Lock()
 	defer db.m.Unlock()
 
+	if db.log(walRecord{Op: walOpDropTable, Table: name}) != nil {
+		return
+	}
+
+	db.dropTableNotLocking(name)
+}
+
+func (db *InMemDB) dropTableNotLocking(name string) {
+	db.touchTableNotLocking(name)
+
 	delete(db.Tables, name)
+	delete(db.indexes, name)
+	delete(db.secondary, name)
 }
 
 func (db *InMemDB) Clear() {
 	db.m.Lock()
 	defer db.m.Unlock()
 
+	if db.log(walRecord{Op: walOpClear}) != nil {
+		return
+	}
+
+	db.clearNotLocking()
+}
+
+func (db *InMemDB) clearNotLocking() {
+	for name := range db.Tables {
+		db.touchTableNotLocking(name)
+	}
+
 	db.Tables = make(map[string]Table)
+	db.indexes = make(map[string]*textIndex)
+	db.secondary = make(map[string]map[string]*secondaryIndex)
 }
 
 func (db *InMemDB) AddRow(table string, identifier string, row any) error {
@@ -151,18 +216,41 @@ func (db *InMemDB) AddRow(table string, identifier string, row any) error {
 		return ErrExistingKey
 	}
 
+	if err = db.checkUniqueNotLocking(table, identifier, row); err != nil {
+		return err
+	}
+
+	if err = db.logRow(walOpAddRow, table, identifier, row); err != nil {
+		return err
+	}
+
+	db.addRowNotLocking(t, table, identifier, row)
+
+	return nil
+}
+
+func (db *InMemDB) addRowNotLocking(t Table, table string, identifier string, row any) {
+	t = db.ownTableNotLocking(table, t)
+	db.touchRowNotLocking(table, identifier)
+
 	t.Set(identifier, row)
 
 	db.counters[table]++
 
-	return nil
+	if index, ok := db.indexes[table]; ok {
+		index.add(identifier, row)
+	}
+
+	for _, index := range db.secondary[table] {
+		index.add(identifier, row)
+	}
 }
 
 func (db *InMemDB) AlterRow(table string, identifier string, newRow any) error {
 	db.m.Lock()
 	defer db.m.Unlock()
 
-	t, err := db.GetTable(table)
+	t, err := db.getTableNotLocking(table)
 	if err != nil {
 		return err
 	}
@@ -172,12 +260,38 @@ func (db *InMemDB) AlterRow(table string, identifier string, newRow any) error {
 		return ErrNotExistedRow
 	}
 
-	t.Set(identifier, newRow) // todo: test if it's replaces existing value
+	if err = db.checkUniqueNotLocking(table, identifier, newRow); err != nil {
+		return err
+	}
+
+	if err = db.logRow(walOpAlterRow, table, identifier, newRow); err != nil {
+		return err
+	}
+
+	db.alterRowNotLocking(t, table, identifier, newRow)
 
 	return nil
 }
 
+func (db *InMemDB) alterRowNotLocking(t Table, table string, identifier string, newRow any) {
+	t = db.ownTableNotLocking(table, t)
+	db.touchRowNotLocking(table, identifier)
+
+	t.Set(identifier, newRow) // todo: test if it's replaces existing value
+
+	if index, ok := db.indexes[table]; ok {
+		index.add(identifier, newRow)
+	}
+
+	for _, index := range db.secondary[table] {
+		index.add(identifier, newRow)
+	}
+}
+
 func (db *InMemDB) GetTableCounter(table string) (int, error) {
+	db.m.RLock()
+	defer db.m.RUnlock()
+
 	counter, exists := db.counters[table]
 	if !exists {
 		return -1, ErrNotExistedTable
@@ -204,32 +318,45 @@ func (db *InMemDB) GetRow(table string, identifier string) (any, error) {
 }
 
 func (db *InMemDB) GetAllRows(table string, offset, limit int) ([]any, error) {
-	db.m.RLock()
-	defer db.m.RUnlock()
+	res := make([]any, 0)
 
-	t, err := db.getTableNotLocking(table)
+	count := 0
+
+	err := db.EachRow(table, func(row any) bool {
+		if count >= offset {
+			res = append(res, row)
+		}
+
+		count++
+
+		return len(res) != limit
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	res := make([]any, 0, t.Len())
+	return res, nil
+}
+
+// EachRow calls fn for rows in order they were added until fn returns false. Table is locked while
+// iterating, thus fn must not use db.
+func (db *InMemDB) EachRow(table string, fn func(row any) bool) error {
+	db.m.RLock()
+	defer db.m.RUnlock()
 
-	count := 0
+	t, err := db.getTableNotLocking(table)
+	if err != nil {
+		return err
+	}
 
 	// iterating pairs from oldest to newest:
 	for pair := t.Oldest(); pair != nil; pair = pair.Next() {
-		if count >= offset {
-			res = append(res, pair.Value)
-		}
-
-		if len(res) == limit {
-			break
+		if !fn(pair.Value) {
+			return nil
 		}
-
-		count++
 	}
 
-	return res, nil
+	return nil
 }
 
 func (db *InMemDB) GetRowsCount(table string) (int, error) {
@@ -253,7 +380,191 @@ func (db *InMemDB) DropRow(table string, identifier string) error {
 		return err
 	}
 
+	// dropping missing row changes nothing, thus it is not logged
+	if _, exists := t.Get(identifier); !exists {
+		return nil
+	}
+
+	if err = db.log(walRecord{Op: walOpDropRow, Table: table, Key: identifier}); err != nil {
+		return err
+	}
+
+	db.dropRowNotLocking(t, table, identifier)
+
+	return nil
+}
+
+func (db *InMemDB) dropRowNotLocking(t Table, table string, identifier string) {
+	t = db.ownTableNotLocking(table, t)
+	db.touchRowNotLocking(table, identifier)
+
 	t.Delete(identifier)
 
+	if index, ok := db.indexes[table]; ok {
+		index.remove(identifier)
+	}
+
+	for _, index := range db.secondary[table] {
+		index.remove(identifier)
+	}
+}
+
+// CreateTextIndex builds inverted index over text extracted from rows of the table, index is kept up to date
+// on every row change. Index is not saved with db state, it is rebuilt when created again.
+func (db *InMemDB) CreateTextIndex(table string, extract TextExtractor) error {
+	db.m.Lock()
+	defer db.m.Unlock()
+
+	t, err := db.getTableNotLocking(table)
+	if err != nil {
+		return err
+	}
+
+	index := newTextIndex(extract)
+
+	for pair := t.Oldest(); pair != nil; pair = pair.Next() {
+		index.add(pair.Key, pair.Value)
+	}
+
+	db.indexes[table] = index
+
 	return nil
 }
+
+// SearchText returns identifiers of rows containing all words of the query in order of insertion.
+func (db *InMemDB) SearchText(table string, query string) ([]string, error) {
+	db.m.RLock()
+	defer db.m.RUnlock()
+
+	t, err := db.getTableNotLocking(table)
+	if err != nil {
+		return nil, err
+	}
+
+	index, ok := db.indexes[table]
+	if !ok {
+		return nil, ErrNotExistedIndex
+	}
+
+	terms := uniqueTerms(Tokenize(query))
+	if len(terms) == 0 {
+		return []string{}, nil
+	}
+
+	// rarest term gives the smallest set of candidates
+	candidates := index.postings[terms[0]]
+	for _, term := range terms[1:] {
+		if len(index.postings[term]) < len(candidates) {
+			candidates = index.postings[term]
+		}
+	}
+
+	res := make([]string, 0, len(candidates))
+
+	if len(candidates) == 0 {
+		return res, nil
+	}
+
+	for pair := t.Oldest(); pair != nil; pair = pair.Next() {
+		if _, ok := candidates[pair.Key]; ok && index.matches(pair.Key, terms) {
+			res = append(res, pair.Key)
+		}
+	}
+
+	return res, nil
+}
+
+// CreateIndex builds secondary index over values extracted from rows of the table, index with the same name
+// is replaced. Unique index can not be created over rows that already break it. Like text index, it is kept
+// up to date on every row change and is not saved with db state.
+func (db *InMemDB) CreateIndex(table string, spec IndexSpec) error {
+	db.m.Lock()
+	defer db.m.Unlock()
+
+	t, err := db.getTableNotLocking(table)
+	if err != nil {
+		return err
+	}
+
+	index := newSecondaryIndex(spec)
+
+	for pair := t.Oldest(); pair != nil; pair = pair.Next() {
+		if key, taken := index.conflict(pair.Key, pair.Value); taken {
+			return &UniqueViolationError{Table: table, Index: spec.Name, Key: key}
+		}
+
+		index.add(pair.Key, pair.Value)
+	}
+
+	if _, ok := db.secondary[table]; !ok {
+		db.secondary[table] = make(map[string]*secondaryIndex)
+	}
+
+	db.secondary[table][spec.Name] = index
+
+	return nil
+}
+
+func (db *InMemDB) checkUniqueNotLocking(table string, identifier string, row any) error {
+	for name, index := range db.secondary[table] {
+		if key, taken := index.conflict(identifier, row); taken {
+			return &UniqueViolationError{Table: table, Index: name, Key: key}
+		}
+	}
+
+	return nil
+}
+
+// GetRowByIndex returns the first row having the key in the index, it is the only one for unique index.
+func (db *InMemDB) GetRowByIndex(table string, index string, key string) (any, error) {
+	db.m.RLock()
+	defer db.m.RUnlock()
+
+	t, identifiers, err := db.lookupNotLocking(table, index, key)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, identifier := range identifiers {
+		if row, ok := t.Get(identifier); ok {
+			return row, nil
+		}
+	}
+
+	return nil, ErrNotExistedRow
+}
+
+// GetRowsByIndex returns rows having the key in the index in order they got the key.
+func (db *InMemDB) GetRowsByIndex(table string, index string, key string) ([]any, error) {
+	db.m.RLock()
+	defer db.m.RUnlock()
+
+	t, identifiers, err := db.lookupNotLocking(table, index, key)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]any, 0, len(identifiers))
+
+	for _, identifier := range identifiers {
+		if row, ok := t.Get(identifier); ok {
+			res = append(res, row)
+		}
+	}
+
+	return res, nil
+}
+
+func (db *InMemDB) lookupNotLocking(table string, index string, key string) (Table, []string, error) {
+	t, err := db.getTableNotLocking(table)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	si, ok := db.secondary[table][index]
+	if !ok {
+		return nil, nil, ErrNotExistedIndex
+	}
+
+	return t, si.entries[key], nil
+}
@@ -0,0 +1,119 @@
+package in_memory
+
+import (
+	"fmt"
+	"slices"
+)
+
+// IndexExtractor returns value of the row the index is built over, ok is false if row must not be indexed.
+type IndexExtractor func(row any) (key string, ok bool)
+
+// IndexSpec declares secondary index of the table. Unique index allows at most one row per key.
+type IndexSpec struct {
+	Name    string
+	Unique  bool
+	Extract IndexExtractor
+}
+
+// UniqueViolationError is returned when row change breaks unique index, it matches ErrUniqueViolation.
+type UniqueViolationError struct {
+	Table string
+	Index string
+	Key   string
+}
+
+func (e *UniqueViolationError) Error() string {
+	return fmt.Sprintf("%s: index %q of table %q already has key %q", ErrUniqueViolation, e.Index, e.Table, e.Key)
+}
+
+func (e *UniqueViolationError) Is(target error) bool {
+	return target == ErrUniqueViolation
+}
+
+// secondaryIndex maps extracted keys to identifiers of rows in order they got the key.
+type secondaryIndex struct {
+	spec    IndexSpec
+	entries map[string][]string
+	rowKeys map[string]string
+}
+
+func newSecondaryIndex(spec IndexSpec) *secondaryIndex {
+	return &secondaryIndex{
+		spec:    spec,
+		entries: make(map[string][]string),
+		rowKeys: make(map[string]string),
+	}
+}
+
+func (si *secondaryIndex) add(identifier string, row any) {
+	key, ok := si.spec.Extract(row)
+
+	if oldKey, indexed := si.rowKeys[identifier]; indexed {
+		if ok && oldKey == key {
+			return
+		}
+
+		si.remove(identifier)
+	}
+
+	if !ok {
+		return
+	}
+
+	si.entries[key] = append(si.entries[key], identifier)
+	si.rowKeys[identifier] = key
+}
+
+func (si *secondaryIndex) remove(identifier string) {
+	key, ok := si.rowKeys[identifier]
+	if !ok {
+		return
+	}
+
+	identifiers := slices.DeleteFunc(si.entries[key], func(id string) bool { return id == identifier })
+	if len(identifiers) == 0 {
+		delete(si.entries, key)
+	} else {
+		si.entries[key] = identifiers
+	}
+
+	delete(si.rowKeys, identifier)
+}
+
+func (si *secondaryIndex) clone() *secondaryIndex {
+	res := &secondaryIndex{
+		spec:    si.spec,
+		entries: make(map[string][]string, len(si.entries)),
+		rowKeys: make(map[string]string, len(si.rowKeys)),
+	}
+
+	for key, identifiers := range si.entries {
+		res.entries[key] = slices.Clone(identifiers)
+	}
+
+	for identifier, key := range si.rowKeys {
+		res.rowKeys[identifier] = key
+	}
+
+	return res
+}
+
+// conflict returns key of the row that is already taken by another row in unique index.
+func (si *secondaryIndex) conflict(identifier string, row any) (string, bool) {
+	if !si.spec.Unique {
+		return "", false
+	}
+
+	key, ok := si.spec.Extract(row)
+	if !ok {
+		return "", false
+	}
+
+	for _, id := range si.entries[key] {
+		if id != identifier {
+			return key, true
+		}
+	}
+
+	return "", false
+}
@@ -0,0 +1,176 @@
+package in_memory
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+type indexedRow struct {
+	Email string
+	Group string
+}
+
+func emailOf(row any) (string, bool) {
+	r, ok := row.(indexedRow)
+	return r.Email, ok && r.Email != ""
+}
+
+func groupOf(row any) (string, bool) {
+	r, ok := row.(indexedRow)
+	return r.Group, ok
+}
+
+func TestCreateIndex(t *testing.T) {
+	inMemDB := initDB()
+
+	tableName := "users"
+
+	inMemDB.CreateTable(tableName)
+
+	_ = inMemDB.AddRow(tableName, "1", indexedRow{Email: "a@mail.ru", Group: "admins"})
+	_ = inMemDB.AddRow(tableName, "2", indexedRow{Email: "a@mail.ru", Group: "users"})
+
+	err := inMemDB.CreateIndex(tableName, IndexSpec{Name: "email", Unique: true, Extract: emailOf})
+	if !errors.Is(err, ErrUniqueViolation) {
+		t.Fatalf("unique index over duplicates must fail, got: %v", err)
+	}
+
+	if _, err = inMemDB.GetRowByIndex(tableName, "email", "a@mail.ru"); !errors.Is(err, ErrNotExistedIndex) {
+		t.Fatalf("failed index must not be created, got: %v", err)
+	}
+
+	if err = inMemDB.CreateIndex(tableName, IndexSpec{Name: "email", Extract: emailOf}); err != nil {
+		t.Fatal(err)
+	}
+
+	rows, err := inMemDB.GetRowsByIndex(tableName, "email", "a@mail.ru")
+	if err != nil || len(rows) != 2 {
+		t.Fatalf("non-unique index must keep all rows, got %v, err: %v", rows, err)
+	}
+
+	if err = inMemDB.CreateIndex("missing", IndexSpec{Name: "email", Extract: emailOf}); !errors.Is(err, ErrNotExistedTable) {
+		t.Fatalf("index of missing table must not be created, got: %v", err)
+	}
+}
+
+func TestIndexLookup(t *testing.T) {
+	inMemDB := initDB()
+
+	tableName := "users"
+
+	inMemDB.CreateTable(tableName)
+
+	_ = inMemDB.CreateIndex(tableName, IndexSpec{Name: "email", Unique: true, Extract: emailOf})
+	_ = inMemDB.CreateIndex(tableName, IndexSpec{Name: "group", Extract: groupOf})
+
+	_ = inMemDB.AddRow(tableName, "1", indexedRow{Email: "a@mail.ru", Group: "admins"})
+	_ = inMemDB.AddRow(tableName, "2", indexedRow{Email: "b@mail.ru", Group: "users"})
+	_ = inMemDB.AddRow(tableName, "3", indexedRow{Email: "c@mail.ru", Group: "admins"})
+	_ = inMemDB.AddRow(tableName, "4", indexedRow{Group: "users"})
+
+	row, err := inMemDB.GetRowByIndex(tableName, "email", "b@mail.ru")
+	if err != nil || row != (indexedRow{Email: "b@mail.ru", Group: "users"}) {
+		t.Fatalf("unexpected row %v, err: %v", row, err)
+	}
+
+	if _, err = inMemDB.GetRowByIndex(tableName, "email", ""); !errors.Is(err, ErrNotExistedRow) {
+		t.Fatalf("rows without key must not be indexed, got: %v", err)
+	}
+
+	emailsOf := func(group string) []string {
+		rows, err := inMemDB.GetRowsByIndex(tableName, "group", group)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		res := make([]string, 0, len(rows))
+		for _, row := range rows {
+			res = append(res, row.(indexedRow).Email)
+		}
+
+		return res
+	}
+
+	if got := emailsOf("admins"); !slices.Equal(got, []string{"a@mail.ru", "c@mail.ru"}) {
+		t.Fatalf("unexpected rows of group %v", got)
+	}
+
+	_ = inMemDB.AlterRow(tableName, "1", indexedRow{Email: "new@mail.ru", Group: "users"})
+	_ = inMemDB.DropRow(tableName, "3")
+
+	if got := emailsOf("admins"); len(got) != 0 {
+		t.Fatalf("changed rows must be reindexed, got %v", got)
+	}
+
+	if got := emailsOf("users"); !slices.Equal(got, []string{"b@mail.ru", "", "new@mail.ru"}) {
+		t.Fatalf("rows must be returned in order they got the key, got %v", got)
+	}
+
+	if _, err = inMemDB.GetRowByIndex(tableName, "email", "a@mail.ru"); !errors.Is(err, ErrNotExistedRow) {
+		t.Fatalf("old key must be removed from index, got: %v", err)
+	}
+
+	if _, err = inMemDB.GetRowsByIndex(tableName, "missing", "users"); !errors.Is(err, ErrNotExistedIndex) {
+		t.Fatalf("lookup by missing index must fail, got: %v", err)
+	}
+
+	inMemDB.CreateTable(tableName)
+
+	if got := emailsOf("users"); len(got) != 0 {
+		t.Fatalf("recreated table must have empty index, got %v", got)
+	}
+}
+
+func TestUniqueIndexViolation(t *testing.T) {
+	inMemDB := initDB()
+
+	tableName := "users"
+
+	inMemDB.CreateTable(tableName)
+
+	_ = inMemDB.CreateIndex(tableName, IndexSpec{Name: "email", Unique: true, Extract: emailOf})
+
+	_ = inMemDB.AddRow(tableName, "1", indexedRow{Email: "a@mail.ru"})
+	_ = inMemDB.AddRow(tableName, "2", indexedRow{Email: "b@mail.ru"})
+
+	err := inMemDB.AddRow(tableName, "3", indexedRow{Email: "a@mail.ru"})
+
+	var violation *UniqueViolationError
+	if !errors.As(err, &violation) || violation.Index != "email" || violation.Key != "a@mail.ru" {
+		t.Fatalf("adding duplicate must fail with unique violation, got: %v", err)
+	}
+
+	if !strings.Contains(err.Error(), `"a@mail.ru"`) {
+		t.Fatalf("error must name the key, got: %v", err)
+	}
+
+	if _, err = inMemDB.GetRow(tableName, "3"); !errors.Is(err, ErrNotExistedRow) {
+		t.Fatal("row breaking unique index must not be added")
+	}
+
+	if counter, _ := inMemDB.GetTableCounter(tableName); counter != 2 {
+		t.Fatalf("failed insert must not change counter, got %d", counter)
+	}
+
+	if err = inMemDB.AlterRow(tableName, "2", indexedRow{Email: "a@mail.ru"}); !errors.Is(err, ErrUniqueViolation) {
+		t.Fatalf("altering row to duplicate must fail, got: %v", err)
+	}
+
+	row, _ := inMemDB.GetRow(tableName, "2")
+	if row != (indexedRow{Email: "b@mail.ru"}) {
+		t.Fatalf("row breaking unique index must not be altered, got %v", row)
+	}
+
+	// row keeps its own key
+	if err = inMemDB.AlterRow(tableName, "1", indexedRow{Email: "a@mail.ru", Group: "admins"}); err != nil {
+		t.Fatal(err)
+	}
+
+	_ = inMemDB.DropRow(tableName, "1")
+
+	if err = inMemDB.AddRow(tableName, "3", indexedRow{Email: "a@mail.ru"}); err != nil {
+		t.Fatalf("key of dropped row must be free, got: %v", err)
+	}
+}
@@ -0,0 +1,308 @@
+package in_memory
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	jsonutils "github.com/ew0s/ewos-to-go-hw/chat-server/pkg/utils/json"
+)
+
+// PersistenceOptions configure where and how often db opened by OpenInMemDB is persisted.
+type PersistenceOptions struct {
+	SnapshotPath string
+	WALPath      string
+	Fsync        FsyncPolicy
+	// FsyncInterval is used with FsyncInterval policy
+	FsyncInterval time.Duration
+	// SnapshotInterval is period of snapshots that truncate log, zero disables periodic snapshots
+	SnapshotInterval time.Duration
+}
+
+// OpenInMemDB restores db from snapshot and replays write-ahead log over it. Every change is logged
+// before it is applied, so it survives crash. Snapshot is saved periodically and when ctx is done,
+// the result of the final save is sent to returned channel.
+func OpenInMemDB(ctx context.Context, opts PersistenceOptions) (*InMemDB, <-chan any, error) {
+	if !opts.Fsync.Valid() {
+		return nil, nil, fmt.Errorf("invalid fsync policy %q", opts.Fsync)
+	}
+
+	db, snapshotSeq, err := loadSnapshot(opts.SnapshotPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	records, validSize, err := readWAL(opts.WALPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	lastSeq, err := db.replay(records, snapshotSeq)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if db.wal, err = openWAL(opts.WALPath, opts.Fsync, validSize, lastSeq); err != nil {
+		return nil, nil, err
+	}
+
+	savedChan := make(chan any, 1)
+
+	go db.persist(ctx, opts, savedChan)
+
+	return db, savedChan, nil
+}
+
+func loadSnapshot(path string) (*InMemDB, int64, error) {
+	db := &InMemDB{
+		Tables:    make(map[string]Table),
+		counters:  make(map[string]int),
+		indexes:   make(map[string]*textIndex),
+		secondary: make(map[string]map[string]*secondaryIndex),
+		types:     Types,
+		m:         &sync.RWMutex{},
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) || (err == nil && len(bytes.TrimSpace(data)) == 0) {
+		return db, 0, nil
+	}
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	snapshot, err := ParseSnapshot(data)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if err = db.restore(snapshot); err != nil {
+		return nil, 0, err
+	}
+
+	return db, snapshot.WALSeq, nil
+}
+
+// replay applies log records that are not in snapshot yet and returns sequence number of the last record.
+func (db *InMemDB) replay(records []walRecord, snapshotSeq int64) (int64, error) {
+	lastSeq := snapshotSeq
+
+	for _, record := range records {
+		// record was logged before snapshot was taken, but log was not truncated
+		if record.Seq <= snapshotSeq {
+			continue
+		}
+
+		if err := db.apply(record); err != nil {
+			return 0, fmt.Errorf("%w: replaying record %d: %v", ErrCorruptedWAL, record.Seq, err)
+		}
+
+		lastSeq = record.Seq
+	}
+
+	return lastSeq, nil
+}
+
+func (db *InMemDB) apply(record walRecord) error {
+	switch record.Op {
+	case walOpCreateTable:
+		db.createTableNotLocking(record.Table)
+		return nil
+	case walOpDropTable:
+		db.dropTableNotLocking(record.Table)
+		return nil
+	case walOpClear:
+		db.clearNotLocking()
+		return nil
+	case walOpTx:
+		for _, op := range record.Ops {
+			if err := db.apply(op); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	t, err := db.getTableNotLocking(record.Table)
+	if err != nil {
+		return err
+	}
+
+	switch record.Op {
+	case walOpAddRow, walOpAlterRow:
+		row, err := db.types.decode(record.RowType, record.Row)
+		if err != nil {
+			return err
+		}
+
+		if record.Op == walOpAddRow {
+			db.addRowNotLocking(t, record.Table, record.Key, row)
+		} else {
+			db.alterRowNotLocking(t, record.Table, record.Key, row)
+		}
+	case walOpDropRow:
+		db.dropRowNotLocking(t, record.Table, record.Key)
+	default:
+		return fmt.Errorf("unknown operation %q", record.Op)
+	}
+
+	return nil
+}
+
+// log appends record to write-ahead log, caller must hold write lock.
+func (db *InMemDB) log(record walRecord) error {
+	if db.wal == nil {
+		return nil
+	}
+
+	return db.wal.append(record)
+}
+
+func (db *InMemDB) logRow(op walOp, table string, identifier string, row any) error {
+	if db.wal == nil {
+		return nil
+	}
+
+	record, err := db.rowRecord(op, table, identifier, row)
+	if err != nil {
+		return err
+	}
+
+	return db.log(record)
+}
+
+func (db *InMemDB) rowRecord(op walOp, table string, identifier string, row any) (walRecord, error) {
+	rowType, err := db.types.nameOf(row)
+	if err != nil {
+		return walRecord{}, err
+	}
+
+	value, err := json.Marshal(row)
+	if err != nil {
+		return walRecord{}, err
+	}
+
+	return walRecord{Op: op, Table: table, Key: identifier, RowType: rowType, Row: value}, nil
+}
+
+// Checkpoint saves snapshot to path and truncates write-ahead log, as its records are in snapshot now.
+// Writes wait for checkpoint, so snapshot and log never miss a change between them.
+func (db *InMemDB) Checkpoint(path string) error {
+	db.m.Lock()
+	defer db.m.Unlock()
+
+	snapshot, err := db.snapshot()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	if err = writeFileAtomic(path, []byte(jsonutils.PrettifyJSON(string(data))), writePerm); err != nil {
+		return err
+	}
+
+	if db.wal == nil {
+		return nil
+	}
+
+	return db.wal.truncate()
+}
+
+func (db *InMemDB) persist(ctx context.Context, opts PersistenceOptions, savedChan chan<- any) {
+	var snapshotTick, fsyncTick <-chan time.Time
+
+	if opts.SnapshotInterval > 0 {
+		ticker := time.NewTicker(opts.SnapshotInterval)
+		defer ticker.Stop()
+
+		snapshotTick = ticker.C
+	}
+
+	if opts.Fsync == FsyncInterval && opts.FsyncInterval > 0 {
+		ticker := time.NewTicker(opts.FsyncInterval)
+		defer ticker.Stop()
+
+		fsyncTick = ticker.C
+	}
+
+	for {
+		select {
+		case <-snapshotTick:
+			// failed snapshot is retried on the next tick, changes are kept by log meanwhile
+			_ = db.Checkpoint(opts.SnapshotPath)
+		case <-fsyncTick:
+			_ = db.wal.sync()
+		case <-ctx.Done():
+			err := db.Checkpoint(opts.SnapshotPath)
+			if closeErr := db.wal.close(); err == nil {
+				err = closeErr
+			}
+
+			if err != nil {
+				savedChan <- err
+				return
+			}
+
+			savedChan <- "ok"
+
+			return
+		}
+	}
+}
+
+// writeFileAtomic replaces file with data, so the file is never left written in part.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	dir, name := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+
+	tmp, err := os.CreateTemp(dir, name+".tmp-*")
+	if err != nil {
+		return err
+	}
+
+	defer os.Remove(tmp.Name()) // nolint
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err = tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	if err = os.Chmod(tmp.Name(), perm); err != nil {
+		return err
+	}
+
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+
+	// rename is durable only when directory is synced
+	if d, err := os.Open(dir); err == nil {
+		_ = d.Sync()
+		d.Close()
+	}
+
+	return nil
+}
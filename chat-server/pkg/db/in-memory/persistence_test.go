@@ -0,0 +1,165 @@
+package in_memory
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func persistenceOpts(dir string) PersistenceOptions {
+	return PersistenceOptions{
+		SnapshotPath: filepath.Join(dir, "db_state.json"),
+		WALPath:      filepath.Join(dir, "db_state.wal"),
+		Fsync:        FsyncAlways,
+	}
+}
+
+func openTestDB(t *testing.T, opts PersistenceOptions) *InMemDB {
+	t.Helper()
+
+	db, _, err := OpenInMemDB(context.Background(), opts)
+	require.NoError(t, err)
+
+	return db
+}
+
+func getTestRows(t *testing.T, db *InMemDB, table string) []any {
+	t.Helper()
+
+	rows, err := db.GetAllRows(table, 0, 100)
+	require.NoError(t, err)
+
+	return rows
+}
+
+func TestOpenInMemDB_ReplaysLogAfterCrash(t *testing.T) {
+	opts := persistenceOpts(t.TempDir())
+
+	db := openTestDB(t, opts)
+	db.CreateTable("rows")
+	db.CreateTable("dropped")
+	require.NoError(t, db.AddRow("rows", "1", snapshotTestRow{ID: 1, Name: "first"}))
+	require.NoError(t, db.AddRow("rows", "2", snapshotTestRow{ID: 2, Name: "second"}))
+	require.NoError(t, db.AddRow("rows", "3", snapshotTestRow{ID: 3, Name: "third"}))
+	require.NoError(t, db.AlterRow("rows", "1", snapshotTestRow{ID: 1, Name: "edited"}))
+	require.NoError(t, db.DropRow("rows", "2"))
+	db.DropTable("dropped")
+
+	// db is not closed, as it would not be on kill -9
+	restored := openTestDB(t, opts)
+
+	assert.Equal(t, []any{
+		snapshotTestRow{ID: 1, Name: "edited"},
+		snapshotTestRow{ID: 3, Name: "third"},
+	}, getTestRows(t, restored, "rows"))
+
+	counter, err := restored.GetTableCounter("rows")
+	require.NoError(t, err)
+	assert.Equal(t, 3, counter)
+
+	_, err = restored.GetTable("dropped")
+	assert.ErrorIs(t, err, ErrNotExistedTable)
+}
+
+func TestOpenInMemDB_CheckpointTruncatesLog(t *testing.T) {
+	opts := persistenceOpts(t.TempDir())
+
+	db := openTestDB(t, opts)
+	db.CreateTable("rows")
+	require.NoError(t, db.AddRow("rows", "1", snapshotTestRow{ID: 1}))
+
+	require.NoError(t, db.Checkpoint(opts.SnapshotPath))
+
+	info, err := os.Stat(opts.WALPath)
+	require.NoError(t, err)
+	assert.Zero(t, info.Size())
+
+	require.NoError(t, db.AddRow("rows", "2", snapshotTestRow{ID: 2}))
+
+	restored := openTestDB(t, opts)
+	assert.Equal(t, []any{snapshotTestRow{ID: 1}, snapshotTestRow{ID: 2}}, getTestRows(t, restored, "rows"))
+
+	// no temporary files are left after snapshot is written
+	entries, err := os.ReadDir(filepath.Dir(opts.SnapshotPath))
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestOpenInMemDB_SkipsLoggedRecordsOfSnapshot(t *testing.T) {
+	opts := persistenceOpts(t.TempDir())
+
+	db := openTestDB(t, opts)
+	db.CreateTable("rows")
+	require.NoError(t, db.AddRow("rows", "1", snapshotTestRow{ID: 1}))
+
+	logged, err := os.ReadFile(opts.WALPath)
+	require.NoError(t, err)
+
+	require.NoError(t, db.Checkpoint(opts.SnapshotPath))
+
+	// crash after snapshot was renamed but before log was truncated
+	require.NoError(t, os.WriteFile(opts.WALPath, logged, writePerm))
+
+	restored := openTestDB(t, opts)
+	assert.Equal(t, []any{snapshotTestRow{ID: 1}}, getTestRows(t, restored, "rows"))
+}
+
+func TestOpenInMemDB_CutsUnfinishedRecord(t *testing.T) {
+	opts := persistenceOpts(t.TempDir())
+
+	db := openTestDB(t, opts)
+	db.CreateTable("rows")
+	require.NoError(t, db.AddRow("rows", "1", snapshotTestRow{ID: 1}))
+
+	file, err := os.OpenFile(opts.WALPath, os.O_APPEND|os.O_WRONLY, writePerm)
+	require.NoError(t, err)
+
+	_, err = file.WriteString(`{"seq":3,"op":"add_row","table":"rows","key":"2","row_type":"snapsh`)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	restored := openTestDB(t, opts)
+	assert.Equal(t, []any{snapshotTestRow{ID: 1}}, getTestRows(t, restored, "rows"))
+
+	// log continues after the last complete record
+	require.NoError(t, restored.AddRow("rows", "2", snapshotTestRow{ID: 2}))
+
+	reopened := openTestDB(t, opts)
+	assert.Equal(t, []any{snapshotTestRow{ID: 1}, snapshotTestRow{ID: 2}}, getTestRows(t, reopened, "rows"))
+}
+
+func TestOpenInMemDB_CorruptedLog(t *testing.T) {
+	opts := persistenceOpts(t.TempDir())
+
+	require.NoError(t, os.WriteFile(opts.WALPath, []byte("not a record\n"), writePerm))
+
+	_, _, err := OpenInMemDB(context.Background(), opts)
+	assert.ErrorIs(t, err, ErrCorruptedWAL)
+}
+
+func TestOpenInMemDB_SavesOnShutdown(t *testing.T) {
+	opts := persistenceOpts(t.TempDir())
+	opts.Fsync = FsyncInterval
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	db, savedChan, err := OpenInMemDB(ctx, opts)
+	require.NoError(t, err)
+
+	db.CreateTable("rows")
+	require.NoError(t, db.AddRow("rows", "1", snapshotTestRow{ID: 1}))
+
+	cancel()
+	assert.Equal(t, "ok", <-savedChan)
+
+	info, err := os.Stat(opts.WALPath)
+	require.NoError(t, err)
+	assert.Zero(t, info.Size())
+
+	restored := openTestDB(t, opts)
+	assert.Equal(t, []any{snapshotTestRow{ID: 1}}, getTestRows(t, restored, "rows"))
+}
@@ -0,0 +1,215 @@
+package in_memory
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	orderedmap "github.com/wk8/go-ordered-map/v2"
+)
+
+// SnapshotVersion is version of snapshot format written by Save. Snapshots of older versions
+// are brought up to date with migrations registered by RegisterSnapshotMigration.
+const SnapshotVersion = 1
+
+// Snapshot is saved state of InMemDB. Version 0 is legacy format, plain map of tables without row types.
+type Snapshot struct {
+	Version int                      `json:"version"`
+	Tables  map[string]SnapshotTable `json:"tables"`
+	// WALSeq is sequence number of the last write-ahead log record included in snapshot
+	WALSeq int64 `json:"wal_seq,omitempty"`
+}
+
+type SnapshotTable struct {
+	// RowType is name of rows type in Types, it is empty for empty table
+	RowType string        `json:"row_type"`
+	Counter int           `json:"counter"`
+	Rows    []SnapshotRow `json:"rows"`
+}
+
+type SnapshotRow struct {
+	Key   string          `json:"key"`
+	Value json.RawMessage `json:"value"`
+}
+
+// SnapshotMigration changes snapshot of one version to the next one.
+type SnapshotMigration func(snapshot *Snapshot) error
+
+var (
+	snapshotMigrations   = make(map[int]SnapshotMigration)
+	snapshotMigrationsMu sync.RWMutex
+)
+
+// RegisterSnapshotMigration registers migration of snapshots of fromVersion to fromVersion+1.
+func RegisterSnapshotMigration(fromVersion int, migration SnapshotMigration) {
+	snapshotMigrationsMu.Lock()
+	defer snapshotMigrationsMu.Unlock()
+
+	snapshotMigrations[fromVersion] = migration
+}
+
+// ParseSnapshot reads snapshot of any known version and migrates it to SnapshotVersion.
+func ParseSnapshot(data []byte) (*Snapshot, error) {
+	var probe map[string]json.RawMessage
+
+	if err := json.Unmarshal(data, &probe); err != nil {
+		return nil, err
+	}
+
+	var (
+		snapshot *Snapshot
+		err      error
+	)
+
+	if _, versioned := probe["version"]; versioned {
+		snapshot = &Snapshot{}
+		err = json.Unmarshal(data, snapshot)
+	} else {
+		snapshot, err = parseLegacySnapshot(data)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = migrateSnapshot(snapshot); err != nil {
+		return nil, err
+	}
+
+	return snapshot, nil
+}
+
+// parseLegacySnapshot reads tables saved as objects of rows, keeping order of rows.
+func parseLegacySnapshot(data []byte) (*Snapshot, error) {
+	var tables map[string]*orderedmap.OrderedMap[string, json.RawMessage]
+
+	if err := json.Unmarshal(data, &tables); err != nil {
+		return nil, err
+	}
+
+	snapshot := Snapshot{
+		Tables: make(map[string]SnapshotTable, len(tables)),
+	}
+
+	for name, table := range tables {
+		rows := make([]SnapshotRow, 0, table.Len())
+
+		for pair := table.Oldest(); pair != nil; pair = pair.Next() {
+			rows = append(rows, SnapshotRow{Key: pair.Key, Value: pair.Value})
+		}
+
+		snapshot.Tables[name] = SnapshotTable{
+			Counter: len(rows),
+			Rows:    rows,
+		}
+	}
+
+	return &snapshot, nil
+}
+
+func migrateSnapshot(snapshot *Snapshot) error {
+	snapshotMigrationsMu.RLock()
+	defer snapshotMigrationsMu.RUnlock()
+
+	if snapshot.Version > SnapshotVersion {
+		return fmt.Errorf("%w: %d", ErrUnsupportedSnapshotVersion, snapshot.Version)
+	}
+
+	for snapshot.Version < SnapshotVersion {
+		migration := snapshotMigrations[snapshot.Version]
+		if migration == nil {
+			return fmt.Errorf("%w: no migration from %d", ErrUnsupportedSnapshotVersion, snapshot.Version)
+		}
+
+		if err := migration(snapshot); err != nil {
+			return fmt.Errorf("migrating snapshot from version %d: %w", snapshot.Version, err)
+		}
+
+		snapshot.Version++
+	}
+
+	return nil
+}
+
+// snapshot takes state of all tables, caller must hold at least read lock.
+func (db *InMemDB) snapshot() (*Snapshot, error) {
+	snapshot := Snapshot{
+		Version: SnapshotVersion,
+		Tables:  make(map[string]SnapshotTable, len(db.Tables)),
+	}
+
+	if db.wal != nil {
+		snapshot.WALSeq = db.wal.lastSeq()
+	}
+
+	for name, table := range db.Tables {
+		snapshotTable := SnapshotTable{
+			Counter: db.counters[name],
+			Rows:    make([]SnapshotRow, 0, table.Len()),
+		}
+
+		for pair := table.Oldest(); pair != nil; pair = pair.Next() {
+			rowType, err := db.types.nameOf(pair.Value)
+			if err != nil {
+				return nil, fmt.Errorf("table %q: %w", name, err)
+			}
+
+			if snapshotTable.RowType == "" {
+				snapshotTable.RowType = rowType
+			} else if snapshotTable.RowType != rowType {
+				return nil, fmt.Errorf("table %q: %w: %q and %q", name, ErrMixedRowTypes, snapshotTable.RowType, rowType)
+			}
+
+			value, err := json.Marshal(pair.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			snapshotTable.Rows = append(snapshotTable.Rows, SnapshotRow{Key: pair.Key, Value: value})
+		}
+
+		snapshot.Tables[name] = snapshotTable
+	}
+
+	return &snapshot, nil
+}
+
+// restore replaces state of db with snapshot, rows are decoded into types registered for them.
+func (db *InMemDB) restore(snapshot *Snapshot) error {
+	tables := make(map[string]Table, len(snapshot.Tables))
+	counters := make(map[string]int, len(snapshot.Tables))
+
+	for name, snapshotTable := range snapshot.Tables {
+		table := orderedmap.New[string, any]()
+
+		for _, row := range snapshotTable.Rows {
+			value, err := db.types.decode(snapshotTable.RowType, row.Value)
+			if err != nil {
+				return fmt.Errorf("table %q, row %q: %w", name, row.Key, err)
+			}
+
+			table.Set(row.Key, value)
+		}
+
+		tables[name] = table
+		counters[name] = max(snapshotTable.Counter, table.Len())
+	}
+
+	db.Tables = tables
+	db.counters = counters
+
+	return nil
+}
+
+// MarshalSnapshot returns state of db in current snapshot format.
+func (db *InMemDB) MarshalSnapshot() ([]byte, error) {
+	db.m.RLock()
+	defer db.m.RUnlock()
+
+	snapshot, err := db.snapshot()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(snapshot)
+}
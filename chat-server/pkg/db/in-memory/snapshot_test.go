@@ -0,0 +1,133 @@
+package in_memory
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type snapshotTestRow struct {
+	ID        int
+	Name      string
+	Tags      map[string]int
+	DeletedAt *time.Time
+}
+
+type snapshotTestOtherRow struct {
+	Value string
+}
+
+func init() {
+	RegisterType[snapshotTestRow]("snapshot_test_row")
+	RegisterType[snapshotTestOtherRow]("snapshot_test_other_row")
+}
+
+func TestSnapshot_RoundTrip(t *testing.T) {
+	ctx := context.Background()
+	db, _ := NewInMemDB(ctx, "")
+
+	deletedAt := time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	db.CreateTable("rows")
+	db.CreateTable("empty")
+	require.NoError(t, db.AddRow("rows", "1", snapshotTestRow{ID: 1, Name: "first", Tags: map[string]int{"a": 1}}))
+	require.NoError(t, db.AddRow("rows", "2", snapshotTestRow{ID: 2, Name: "second"}))
+	require.NoError(t, db.AddRow("rows", "3", snapshotTestRow{ID: 3, Name: "third", DeletedAt: &deletedAt}))
+	require.NoError(t, db.DropRow("rows", "2"))
+
+	data, err := db.MarshalSnapshot()
+	require.NoError(t, err)
+
+	restored, _, err := NewInMemDBFromJSON(ctx, string(data), "")
+	require.NoError(t, err)
+
+	rows, err := restored.GetAllRows("rows", 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []any{
+		snapshotTestRow{ID: 1, Name: "first", Tags: map[string]int{"a": 1}},
+		snapshotTestRow{ID: 3, Name: "third", DeletedAt: &deletedAt},
+	}, rows)
+
+	// counter is kept, so ids of dropped rows are not reused
+	counter, err := restored.GetTableCounter("rows")
+	require.NoError(t, err)
+	assert.Equal(t, 3, counter)
+
+	count, err := restored.GetRowsCount("empty")
+	require.NoError(t, err)
+	assert.Equal(t, 0, count)
+}
+
+func TestSnapshot_Errors(t *testing.T) {
+	ctx := context.Background()
+	db, _ := NewInMemDB(ctx, "")
+
+	type unregistered struct{}
+
+	db.CreateTable("rows")
+	require.NoError(t, db.AddRow("rows", "1", unregistered{}))
+
+	_, err := db.MarshalSnapshot()
+	assert.ErrorIs(t, err, ErrUnknownRowType)
+
+	db.CreateTable("rows")
+	require.NoError(t, db.AddRow("rows", "1", snapshotTestRow{ID: 1}))
+	require.NoError(t, db.AddRow("rows", "2", snapshotTestOtherRow{Value: "v"}))
+
+	_, err = db.MarshalSnapshot()
+	assert.ErrorIs(t, err, ErrMixedRowTypes)
+
+	_, _, err = NewInMemDBFromJSON(ctx, `{"version": 1, "tables": {"rows": {"row_type": "missing", "rows": [{"key": "1", "value": {}}]}}}`, "")
+	assert.ErrorIs(t, err, ErrUnknownRowType)
+
+	_, _, err = NewInMemDBFromJSON(ctx, `{"version": 100, "tables": {}}`, "")
+	assert.ErrorIs(t, err, ErrUnsupportedSnapshotVersion)
+}
+
+func TestSnapshot_Migration(t *testing.T) {
+	RegisterSnapshotMigration(0, func(snapshot *Snapshot) error {
+		table := snapshot.Tables["rows"]
+		table.RowType = "snapshot_test_row"
+		snapshot.Tables["rows"] = table
+
+		return nil
+	})
+
+	defer RegisterSnapshotMigration(0, nil)
+
+	// legacy snapshot is plain map of tables with rows in order they were added
+	legacy := `{"rows": {"2": {"ID": 2, "Name": "second"}, "1": {"ID": 1, "Name": "first"}}}`
+
+	snapshot, err := ParseSnapshot([]byte(legacy))
+	require.NoError(t, err)
+	assert.Equal(t, SnapshotVersion, snapshot.Version)
+	assert.Equal(t, "snapshot_test_row", snapshot.Tables["rows"].RowType)
+
+	restored, _, err := NewInMemDBFromJSON(context.Background(), legacy, "")
+	require.NoError(t, err)
+
+	rows, err := restored.GetAllRows("rows", 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []any{snapshotTestRow{ID: 2, Name: "second"}, snapshotTestRow{ID: 1, Name: "first"}}, rows)
+
+	data, err := restored.MarshalSnapshot()
+	require.NoError(t, err)
+
+	var saved Snapshot
+
+	require.NoError(t, json.Unmarshal(data, &saved))
+	assert.Equal(t, SnapshotVersion, saved.Version)
+}
+
+func TestTypeRegistry_Register(t *testing.T) {
+	registry := NewTypeRegistry()
+
+	require.NoError(t, registry.Register("row", snapshotTestRow{}))
+	require.NoError(t, registry.Register("row", snapshotTestRow{}))
+	assert.ErrorIs(t, registry.Register("row", snapshotTestOtherRow{}), ErrTypeRegistered)
+	assert.ErrorIs(t, registry.Register("other", snapshotTestRow{}), ErrTypeRegistered)
+}
@@ -0,0 +1,226 @@
+package in_memory
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	highlightStart  = "<mark>"
+	highlightStop   = "</mark>"
+	snippetEllipsis = "..."
+)
+
+// TextExtractor returns text of the row that should be searchable, ok is false if row must not be indexed.
+type TextExtractor func(row any) (text string, ok bool)
+
+// textIndex is an inverted index from lowercased terms to identifiers of rows containing them.
+type textIndex struct {
+	extract  TextExtractor
+	postings map[string]map[string]struct{}
+	rowTerms map[string][]string
+}
+
+func newTextIndex(extract TextExtractor) *textIndex {
+	return &textIndex{
+		extract:  extract,
+		postings: make(map[string]map[string]struct{}),
+		rowTerms: make(map[string][]string),
+	}
+}
+
+func (ti *textIndex) add(identifier string, row any) {
+	ti.remove(identifier)
+
+	text, ok := ti.extract(row)
+	if !ok {
+		return
+	}
+
+	terms := uniqueTerms(Tokenize(text))
+
+	for _, term := range terms {
+		rows, exists := ti.postings[term]
+		if !exists {
+			rows = make(map[string]struct{})
+			ti.postings[term] = rows
+		}
+
+		rows[identifier] = struct{}{}
+	}
+
+	ti.rowTerms[identifier] = terms
+}
+
+func (ti *textIndex) remove(identifier string) {
+	for _, term := range ti.rowTerms[identifier] {
+		delete(ti.postings[term], identifier)
+
+		if len(ti.postings[term]) == 0 {
+			delete(ti.postings, term)
+		}
+	}
+
+	delete(ti.rowTerms, identifier)
+}
+
+func (ti *textIndex) clear() {
+	ti.postings = make(map[string]map[string]struct{})
+	ti.rowTerms = make(map[string][]string)
+}
+
+// matches reports whether row contains every term of the query.
+func (ti *textIndex) matches(identifier string, terms []string) bool {
+	for _, term := range terms {
+		if _, ok := ti.postings[term][identifier]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// matchesRow reports whether text of row contains every term of the query, row does not have to be in index.
+func (ti *textIndex) matchesRow(row any, terms []string) bool {
+	text, ok := ti.extract(row)
+	if !ok {
+		return false
+	}
+
+	words := make(map[string]struct{})
+	for _, word := range Tokenize(text) {
+		words[word] = struct{}{}
+	}
+
+	for _, term := range terms {
+		if _, ok := words[term]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Tokenize splits text into lowercased words, everything except letters and digits separates words.
+// It follows postgres 'simple' text search configuration, so both backends find the same messages.
+func Tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), isSeparator)
+}
+
+func isSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
+
+func uniqueTerms(terms []string) []string {
+	seen := make(map[string]struct{}, len(terms))
+	res := make([]string, 0, len(terms))
+
+	for _, term := range terms {
+		if _, ok := seen[term]; ok {
+			continue
+		}
+
+		seen[term] = struct{}{}
+		res = append(res, term)
+	}
+
+	return res
+}
+
+type wordSpan struct {
+	start, end int
+}
+
+func wordSpans(text string) []wordSpan {
+	var (
+		spans  []wordSpan
+		inWord bool
+		start  int
+	)
+
+	for i, r := range text {
+		switch {
+		case !isSeparator(r) && !inWord:
+			inWord = true
+			start = i
+		case isSeparator(r) && inWord:
+			inWord = false
+			spans = append(spans, wordSpan{start: start, end: i})
+		}
+	}
+
+	if inWord {
+		spans = append(spans, wordSpan{start: start, end: len(text)})
+	}
+
+	return spans
+}
+
+// Highlight returns fragment of text of at most maxWords words around the first match of the query,
+// matched words are wrapped into <mark></mark> as postgres ts_headline does.
+func Highlight(text, query string, maxWords int) string {
+	terms := make(map[string]struct{})
+	for _, term := range Tokenize(query) {
+		terms[term] = struct{}{}
+	}
+
+	spans := wordSpans(text)
+
+	isMatch := func(span wordSpan) bool {
+		_, ok := terms[strings.ToLower(text[span.start:span.end])]
+		return ok
+	}
+
+	first := 0
+
+	for i, span := range spans {
+		if isMatch(span) {
+			first = i
+			break
+		}
+	}
+
+	from, to := 0, len(spans)
+
+	if maxWords > 0 && len(spans) > maxWords {
+		// keep some context before the match
+		from = max(0, first-maxWords/4)
+		to = min(len(spans), from+maxWords)
+		from = max(0, to-maxWords)
+	}
+
+	if from == to {
+		return text
+	}
+
+	var sb strings.Builder
+
+	if from > 0 {
+		sb.WriteString(snippetEllipsis)
+	}
+
+	pos := spans[from].start
+	if from == 0 {
+		pos = 0
+	}
+
+	for _, span := range spans[from:to] {
+		sb.WriteString(text[pos:span.start])
+
+		if isMatch(span) {
+			sb.WriteString(highlightStart + text[span.start:span.end] + highlightStop)
+		} else {
+			sb.WriteString(text[span.start:span.end])
+		}
+
+		pos = span.end
+	}
+
+	if to == len(spans) {
+		sb.WriteString(text[pos:])
+	} else {
+		sb.WriteString(snippetEllipsis)
+	}
+
+	return sb.String()
+}
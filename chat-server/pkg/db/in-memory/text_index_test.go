@@ -0,0 +1,84 @@
+package in_memory
+
+import (
+	"slices"
+	"testing"
+)
+
+func textOf(row any) (string, bool) {
+	text, ok := row.(string)
+	return text, ok
+}
+
+func TestSearchText(t *testing.T) {
+	inMemDB := initDB()
+
+	tableName := "messages"
+
+	inMemDB.CreateTable(tableName)
+
+	_ = inMemDB.AddRow(tableName, "1", "Hello, World!")
+
+	if err := inMemDB.CreateTextIndex(tableName, textOf); err != nil {
+		t.Fatal(err)
+	}
+
+	_ = inMemDB.AddRow(tableName, "2", "hello there")
+	_ = inMemDB.AddRow(tableName, "3", "world peace")
+
+	got, err := inMemDB.SearchText(tableName, "HELLO")
+	if err != nil || !slices.Equal(got, []string{"1", "2"}) {
+		t.Fatalf("unexpected search result %v, err: %v", got, err)
+	}
+
+	got, _ = inMemDB.SearchText(tableName, "world hello")
+	if !slices.Equal(got, []string{"1"}) {
+		t.Fatalf("all words of query must match, got %v", got)
+	}
+
+	_ = inMemDB.AlterRow(tableName, "1", "bye")
+	_ = inMemDB.DropRow(tableName, "3")
+
+	got, _ = inMemDB.SearchText(tableName, "world")
+	if len(got) != 0 {
+		t.Fatalf("changed rows must be reindexed, got %v", got)
+	}
+
+	if _, err = inMemDB.SearchText("new_table", "hello"); err == nil {
+		t.Fatal("search in table without index must fail")
+	}
+}
+
+func TestHighlight(t *testing.T) {
+	tests := []struct {
+		text     string
+		query    string
+		maxWords int
+		want     string
+	}{
+		{
+			text:     "Hello, World!",
+			query:    "world",
+			maxWords: 10,
+			want:     "Hello, <mark>World</mark>!",
+		},
+		{
+			text:     "one two three four five six seven eight",
+			query:    "six",
+			maxWords: 4,
+			want:     "...five <mark>six</mark> seven eight",
+		},
+		{
+			text:     "one two three four five six",
+			query:    "one",
+			maxWords: 3,
+			want:     "<mark>one</mark> two three...",
+		},
+	}
+
+	for _, test := range tests {
+		if got := Highlight(test.text, test.query, test.maxWords); got != test.want {
+			t.Fatalf("Highlight(%q, %q) = %q, want %q", test.text, test.query, got, test.want)
+		}
+	}
+}
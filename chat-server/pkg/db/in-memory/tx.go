@@ -0,0 +1,673 @@
+package in_memory
+
+import (
+	"context"
+	"sync"
+
+	orderedmap "github.com/wk8/go-ordered-map/v2"
+)
+
+// Executor is the part of db that works the same way in and out of transaction.
+type Executor interface {
+	AddRow(table string, identifier string, row any) error
+	AlterRow(table string, identifier string, newRow any) error
+	DropRow(table string, identifier string) error
+	GetRow(table string, identifier string) (any, error)
+	GetAllRows(table string, offset, limit int) ([]any, error)
+	EachRow(table string, fn func(row any) bool) error
+	GetRowsCount(table string) (int, error)
+	GetTableCounter(table string) (int, error)
+	GetRowByIndex(table string, index string, key string) (any, error)
+	GetRowsByIndex(table string, index string, key string) ([]any, error)
+	SearchText(table string, query string) ([]string, error)
+}
+
+type txContextKey struct{}
+
+// ContextWithTx returns context carrying transaction, see ExecutorFromContext.
+func ContextWithTx(ctx context.Context, tx *Tx) context.Context {
+	return context.WithValue(ctx, txContextKey{}, tx)
+}
+
+func TxFromContext(ctx context.Context) (*Tx, bool) {
+	tx, ok := ctx.Value(txContextKey{}).(*Tx)
+	return tx, ok
+}
+
+// ExecutorFromContext returns transaction carried by context or db itself if there is none.
+func ExecutorFromContext(ctx context.Context, db InMemoryDB) Executor {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx
+	}
+
+	return db
+}
+
+// txState is kept by db for open transactions. Tables read by transactions are pinned and copied before
+// they are changed, and changes made since transactions began are remembered to detect conflicts.
+type txState struct {
+	open    int
+	version int64
+	pins    map[Table]int
+	// changedRows and changedTables keep version of the last change made while transactions are open
+	changedRows   map[string]map[string]int64
+	changedTables map[string]int64
+}
+
+func (db *InMemDB) touchRowNotLocking(table string, identifier string) {
+	db.version++
+
+	if db.open == 0 {
+		return
+	}
+
+	if db.changedRows == nil {
+		db.changedRows = make(map[string]map[string]int64)
+	}
+
+	if _, ok := db.changedRows[table]; !ok {
+		db.changedRows[table] = make(map[string]int64)
+	}
+
+	db.changedRows[table][identifier] = db.version
+}
+
+func (db *InMemDB) touchTableNotLocking(table string) {
+	db.version++
+
+	if db.open == 0 {
+		return
+	}
+
+	if db.changedTables == nil {
+		db.changedTables = make(map[string]int64)
+	}
+
+	db.changedTables[table] = db.version
+}
+
+// ownTableNotLocking returns table that can be changed in place. Table pinned by transactions is copied
+// together with its secondary indexes, so transactions keep reading the state they began with.
+func (db *InMemDB) ownTableNotLocking(name string, t Table) Table {
+	if db.pins[t] == 0 {
+		return t
+	}
+
+	own := orderedmap.New[string, any](orderedmap.WithCapacity[string, any](t.Len()))
+
+	for pair := t.Oldest(); pair != nil; pair = pair.Next() {
+		own.Set(pair.Key, pair.Value)
+	}
+
+	db.Tables[name] = own
+
+	for indexName, index := range db.secondary[name] {
+		db.secondary[name][indexName] = index.clone()
+	}
+
+	return own
+}
+
+// Begin starts transaction. Transaction reads the state db had when it began together with its own
+// changes, changes are applied to db on Commit all at once. Tables are not created in transaction.
+func (db *InMemDB) Begin() *Tx {
+	db.m.Lock()
+	defer db.m.Unlock()
+
+	if db.pins == nil {
+		db.pins = make(map[Table]int)
+	}
+
+	tx := &Tx{
+		db:      db,
+		version: db.version,
+		tables:  make(map[string]*txTable, len(db.Tables)),
+	}
+
+	for name, t := range db.Tables {
+		secondary := make(map[string]*secondaryIndex, len(db.secondary[name]))
+		for indexName, index := range db.secondary[name] {
+			secondary[indexName] = index
+		}
+
+		tx.tables[name] = &txTable{
+			rows:      t,
+			counter:   db.counters[name],
+			secondary: secondary,
+			changes:   orderedmap.New[string, txChange](),
+		}
+
+		db.pins[t]++
+	}
+
+	db.open++
+
+	return tx
+}
+
+func (db *InMemDB) releaseNotLocking(tx *Tx) {
+	for _, t := range tx.tables {
+		if db.pins[t.rows]--; db.pins[t.rows] <= 0 {
+			delete(db.pins, t.rows)
+		}
+	}
+
+	db.open--
+
+	// nobody can conflict with changes made so far
+	if db.open == 0 {
+		db.changedRows = nil
+		db.changedTables = nil
+	}
+}
+
+// Tx is transaction of InMemDB with snapshot isolation. Concurrent transactions changing the same row
+// do not overwrite each other, the one that commits later fails with ErrTxConflict.
+type Tx struct {
+	db      *InMemDB
+	version int64
+	tables  map[string]*txTable
+	ops     []txOp
+	done    bool
+
+	m sync.Mutex
+}
+
+// txTable is table as transaction sees it: rows of snapshot with changes made by transaction over them.
+type txTable struct {
+	rows      Table
+	counter   int
+	secondary map[string]*secondaryIndex
+	changes   *orderedmap.OrderedMap[string, txChange]
+}
+
+type txChange struct {
+	row     any
+	dropped bool
+	// added rows follow rows of snapshot, like rows added to table go after existing ones
+	added bool
+}
+
+type txOp struct {
+	op    walOp
+	table string
+	key   string
+	row   any
+}
+
+func (t *txTable) get(identifier string) (any, bool) {
+	if change, ok := t.changes.Get(identifier); ok {
+		return change.row, !change.dropped
+	}
+
+	return t.rows.Get(identifier)
+}
+
+// lookup returns identifiers of rows having the key in the index.
+func (t *txTable) lookup(index string, key string) ([]string, error) {
+	si, ok := t.secondary[index]
+	if !ok {
+		return nil, ErrNotExistedIndex
+	}
+
+	res := make([]string, 0, len(si.entries[key]))
+
+	for _, identifier := range si.entries[key] {
+		if _, changed := t.changes.Get(identifier); !changed {
+			res = append(res, identifier)
+		}
+	}
+
+	for pair := t.changes.Oldest(); pair != nil; pair = pair.Next() {
+		if pair.Value.dropped {
+			continue
+		}
+
+		if rowKey, ok := si.spec.Extract(pair.Value.row); ok && rowKey == key {
+			res = append(res, pair.Key)
+		}
+	}
+
+	return res, nil
+}
+
+func (t *txTable) checkUnique(table string, identifier string, row any) error {
+	for name, si := range t.secondary {
+		if !si.spec.Unique {
+			continue
+		}
+
+		key, ok := si.spec.Extract(row)
+		if !ok {
+			continue
+		}
+
+		identifiers, _ := t.lookup(name, key)
+
+		for _, id := range identifiers {
+			if id != identifier {
+				return &UniqueViolationError{Table: table, Index: name, Key: key}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (tx *Tx) table(name string) (*txTable, error) {
+	if tx.done {
+		return nil, ErrTxDone
+	}
+
+	t, ok := tx.tables[name]
+	if !ok {
+		return nil, ErrNotExistedTable
+	}
+
+	return t, nil
+}
+
+func (tx *Tx) AddRow(table string, identifier string, row any) error {
+	tx.m.Lock()
+	defer tx.m.Unlock()
+
+	t, err := tx.table(table)
+	if err != nil {
+		return err
+	}
+
+	if _, exists := t.get(identifier); exists {
+		return ErrExistingKey
+	}
+
+	if err = t.checkUnique(table, identifier, row); err != nil {
+		return err
+	}
+
+	t.changes.Delete(identifier)
+	t.changes.Set(identifier, txChange{row: row, added: true})
+	t.counter++
+
+	tx.ops = append(tx.ops, txOp{op: walOpAddRow, table: table, key: identifier, row: row})
+
+	return nil
+}
+
+func (tx *Tx) AlterRow(table string, identifier string, newRow any) error {
+	tx.m.Lock()
+	defer tx.m.Unlock()
+
+	t, err := tx.table(table)
+	if err != nil {
+		return err
+	}
+
+	if _, exists := t.get(identifier); !exists {
+		return ErrNotExistedRow
+	}
+
+	if err = t.checkUnique(table, identifier, newRow); err != nil {
+		return err
+	}
+
+	change, _ := t.changes.Get(identifier)
+	change.row = newRow
+	t.changes.Set(identifier, change)
+
+	tx.ops = append(tx.ops, txOp{op: walOpAlterRow, table: table, key: identifier, row: newRow})
+
+	return nil
+}
+
+func (tx *Tx) DropRow(table string, identifier string) error {
+	tx.m.Lock()
+	defer tx.m.Unlock()
+
+	t, err := tx.table(table)
+	if err != nil {
+		return err
+	}
+
+	if _, exists := t.get(identifier); !exists {
+		return nil
+	}
+
+	t.changes.Set(identifier, txChange{dropped: true})
+
+	tx.ops = append(tx.ops, txOp{op: walOpDropRow, table: table, key: identifier})
+
+	return nil
+}
+
+func (tx *Tx) GetRow(table string, identifier string) (any, error) {
+	tx.m.Lock()
+	defer tx.m.Unlock()
+
+	t, err := tx.table(table)
+	if err != nil {
+		return nil, err
+	}
+
+	row, exists := t.get(identifier)
+	if !exists {
+		return nil, ErrNotExistedRow
+	}
+
+	return row, nil
+}
+
+func (tx *Tx) GetAllRows(table string, offset, limit int) ([]any, error) {
+	res := make([]any, 0)
+
+	count := 0
+
+	// pages rows the same way as InMemDB.GetAllRows
+	err := tx.EachRow(table, func(row any) bool {
+		if count >= offset {
+			res = append(res, row)
+		}
+
+		count++
+
+		return len(res) != limit
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// EachRow calls fn for rows seen by transaction in order they were added, rows added by transaction go
+// last. Iteration stops when fn returns false. Transaction is locked while iterating, thus fn must not use it.
+func (tx *Tx) EachRow(table string, fn func(row any) bool) error {
+	tx.m.Lock()
+	defer tx.m.Unlock()
+
+	t, err := tx.table(table)
+	if err != nil {
+		return err
+	}
+
+	for pair := t.rows.Oldest(); pair != nil; pair = pair.Next() {
+		row := pair.Value
+
+		if change, ok := t.changes.Get(pair.Key); ok {
+			if change.dropped || change.added {
+				continue
+			}
+
+			row = change.row
+		}
+
+		if !fn(row) {
+			return nil
+		}
+	}
+
+	for pair := t.changes.Oldest(); pair != nil; pair = pair.Next() {
+		if pair.Value.added && !fn(pair.Value.row) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (tx *Tx) GetRowsCount(table string) (int, error) {
+	tx.m.Lock()
+	defer tx.m.Unlock()
+
+	t, err := tx.table(table)
+	if err != nil {
+		return 0, err
+	}
+
+	count := t.rows.Len()
+
+	for pair := t.changes.Oldest(); pair != nil; pair = pair.Next() {
+		_, inSnapshot := t.rows.Get(pair.Key)
+
+		switch {
+		case inSnapshot && pair.Value.dropped:
+			count--
+		case !inSnapshot && !pair.Value.dropped:
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (tx *Tx) GetTableCounter(table string) (int, error) {
+	tx.m.Lock()
+	defer tx.m.Unlock()
+
+	t, err := tx.table(table)
+	if err != nil {
+		return -1, err
+	}
+
+	return t.counter, nil
+}
+
+func (tx *Tx) GetRowByIndex(table string, index string, key string) (any, error) {
+	rows, err := tx.GetRowsByIndex(table, index, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) == 0 {
+		return nil, ErrNotExistedRow
+	}
+
+	return rows[0], nil
+}
+
+func (tx *Tx) GetRowsByIndex(table string, index string, key string) ([]any, error) {
+	tx.m.Lock()
+	defer tx.m.Unlock()
+
+	t, err := tx.table(table)
+	if err != nil {
+		return nil, err
+	}
+
+	identifiers, err := t.lookup(index, key)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]any, 0, len(identifiers))
+
+	for _, identifier := range identifiers {
+		if row, ok := t.get(identifier); ok {
+			res = append(res, row)
+		}
+	}
+
+	return res, nil
+}
+
+// SearchText returns identifiers of rows seen by transaction containing all words of the query, see
+// InMemDB.SearchText. Text index of db holds committed rows only, so rows are matched one by one.
+func (tx *Tx) SearchText(table string, query string) ([]string, error) {
+	tx.m.Lock()
+	defer tx.m.Unlock()
+
+	t, err := tx.table(table)
+	if err != nil {
+		return nil, err
+	}
+
+	tx.db.m.RLock()
+	index, ok := tx.db.indexes[table]
+	tx.db.m.RUnlock()
+
+	if !ok {
+		return nil, ErrNotExistedIndex
+	}
+
+	res := make([]string, 0)
+
+	terms := uniqueTerms(Tokenize(query))
+	if len(terms) == 0 {
+		return res, nil
+	}
+
+	for pair := t.rows.Oldest(); pair != nil; pair = pair.Next() {
+		row := pair.Value
+
+		if change, ok := t.changes.Get(pair.Key); ok {
+			if change.dropped || change.added {
+				continue
+			}
+
+			row = change.row
+		}
+
+		if index.matchesRow(row, terms) {
+			res = append(res, pair.Key)
+		}
+	}
+
+	for pair := t.changes.Oldest(); pair != nil; pair = pair.Next() {
+		if pair.Value.added && index.matchesRow(pair.Value.row, terms) {
+			res = append(res, pair.Key)
+		}
+	}
+
+	return res, nil
+}
+
+// Commit applies changes of transaction to db. Nothing is applied if rows changed by transaction were
+// changed by others since it began or if changes break unique indexes of db.
+func (tx *Tx) Commit() error {
+	tx.m.Lock()
+	defer tx.m.Unlock()
+
+	if tx.done {
+		return ErrTxDone
+	}
+
+	tx.done = true
+
+	db := tx.db
+
+	db.m.Lock()
+	defer db.m.Unlock()
+
+	err := tx.validateNotLocking()
+	if err == nil {
+		err = tx.logNotLocking()
+	}
+
+	// released before applying, so tables pinned only by this transaction are not copied
+	db.releaseNotLocking(tx)
+
+	if err != nil {
+		return err
+	}
+
+	for _, op := range tx.ops {
+		t, _ := db.getTableNotLocking(op.table)
+
+		switch op.op {
+		case walOpAddRow:
+			db.addRowNotLocking(t, op.table, op.key, op.row)
+		case walOpAlterRow:
+			db.alterRowNotLocking(t, op.table, op.key, op.row)
+		case walOpDropRow:
+			db.dropRowNotLocking(t, op.table, op.key)
+		}
+	}
+
+	return nil
+}
+
+func (tx *Tx) Rollback() error {
+	tx.m.Lock()
+	defer tx.m.Unlock()
+
+	if tx.done {
+		return ErrTxDone
+	}
+
+	tx.done = true
+
+	tx.db.m.Lock()
+	defer tx.db.m.Unlock()
+
+	tx.db.releaseNotLocking(tx)
+
+	return nil
+}
+
+func (tx *Tx) validateNotLocking() error {
+	db := tx.db
+
+	for name, t := range tx.tables {
+		if t.changes.Len() == 0 {
+			continue
+		}
+
+		if _, exists := db.Tables[name]; !exists || db.changedTables[name] > tx.version {
+			return ErrTxConflict
+		}
+
+		for pair := t.changes.Oldest(); pair != nil; pair = pair.Next() {
+			if db.changedRows[name][pair.Key] > tx.version {
+				return ErrTxConflict
+			}
+		}
+
+		// rows added by others since transaction began may take keys of unique indexes
+		for pair := t.changes.Oldest(); pair != nil; pair = pair.Next() {
+			if pair.Value.dropped {
+				continue
+			}
+
+			for indexName, index := range db.secondary[name] {
+				key, taken := index.conflict(pair.Key, pair.Value.row)
+				if !taken {
+					continue
+				}
+
+				for _, identifier := range index.entries[key] {
+					if _, changed := t.changes.Get(identifier); !changed && identifier != pair.Key {
+						return &UniqueViolationError{Table: name, Index: indexName, Key: key}
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// logNotLocking writes all changes of transaction as one record, so log never holds a part of them.
+func (tx *Tx) logNotLocking() error {
+	db := tx.db
+
+	if db.wal == nil || len(tx.ops) == 0 {
+		return nil
+	}
+
+	records := make([]walRecord, 0, len(tx.ops))
+
+	for _, op := range tx.ops {
+		if op.op == walOpDropRow {
+			records = append(records, walRecord{Op: op.op, Table: op.table, Key: op.key})
+			continue
+		}
+
+		record, err := db.rowRecord(op.op, op.table, op.key, op.row)
+		if err != nil {
+			return err
+		}
+
+		records = append(records, record)
+	}
+
+	return db.log(walRecord{Op: walOpTx, Ops: records})
+}
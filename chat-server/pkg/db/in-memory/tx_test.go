@@ -0,0 +1,264 @@
+package in_memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func initTxDB(t *testing.T) *InMemDB {
+	t.Helper()
+
+	db := initDB()
+	db.CreateTable("users")
+	db.CreateTable("messages")
+
+	require.NoError(t, db.CreateIndex("users", IndexSpec{Name: "email", Unique: true, Extract: emailOf}))
+	require.NoError(t, db.AddRow("users", "1", indexedRow{Email: "a@mail.ru", Group: "admins"}))
+	require.NoError(t, db.AddRow("users", "2", indexedRow{Email: "b@mail.ru", Group: "users"}))
+
+	return db
+}
+
+func TestTx_CommitAppliesAllChanges(t *testing.T) {
+	db := initTxDB(t)
+
+	tx := db.Begin()
+
+	require.NoError(t, tx.AlterRow("users", "1", indexedRow{Email: "new@mail.ru", Group: "admins"}))
+	require.NoError(t, tx.DropRow("users", "2"))
+	require.NoError(t, tx.AddRow("users", "3", indexedRow{Email: "b@mail.ru"}))
+	require.NoError(t, tx.AddRow("messages", "1", "hello"))
+
+	// changes are not seen outside before commit
+	row, err := db.GetRow("users", "1")
+	require.NoError(t, err)
+	assert.Equal(t, indexedRow{Email: "a@mail.ru", Group: "admins"}, row)
+
+	count, err := db.GetRowsCount("messages")
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	require.NoError(t, tx.Commit())
+
+	assert.Equal(t, []any{
+		indexedRow{Email: "new@mail.ru", Group: "admins"},
+		indexedRow{Email: "b@mail.ru"},
+	}, getTestRows(t, db, "users"))
+	assert.Equal(t, []any{"hello"}, getTestRows(t, db, "messages"))
+
+	row, err = db.GetRowByIndex("users", "email", "b@mail.ru")
+	require.NoError(t, err)
+	assert.Equal(t, indexedRow{Email: "b@mail.ru"}, row)
+
+	counter, err := db.GetTableCounter("users")
+	require.NoError(t, err)
+	assert.Equal(t, 3, counter)
+
+	assert.ErrorIs(t, tx.Commit(), ErrTxDone)
+	assert.ErrorIs(t, tx.AddRow("users", "4", indexedRow{}), ErrTxDone)
+}
+
+func TestTx_RollbackDiscardsChanges(t *testing.T) {
+	db := initTxDB(t)
+
+	tx := db.Begin()
+
+	require.NoError(t, tx.AddRow("messages", "1", "hello"))
+	require.NoError(t, tx.DropRow("users", "1"))
+	require.NoError(t, tx.Rollback())
+
+	assert.Empty(t, getTestRows(t, db, "messages"))
+	assert.Len(t, getTestRows(t, db, "users"), 2)
+
+	assert.ErrorIs(t, tx.Rollback(), ErrTxDone)
+
+	// table is not pinned by finished transaction, so it is changed in place
+	table := db.Tables["users"]
+	require.NoError(t, db.DropRow("users", "1"))
+	assert.Same(t, table, db.Tables["users"])
+}
+
+func TestTx_ReadsSnapshotWithOwnChanges(t *testing.T) {
+	db := initTxDB(t)
+
+	tx := db.Begin()
+
+	require.NoError(t, tx.DropRow("users", "1"))
+	require.NoError(t, tx.AddRow("users", "1", indexedRow{Email: "c@mail.ru", Group: "users"}))
+	require.NoError(t, tx.AddRow("users", "3", indexedRow{Email: "d@mail.ru", Group: "users"}))
+	require.NoError(t, tx.AlterRow("users", "2", indexedRow{Email: "b@mail.ru", Group: "admins"}))
+
+	// changes made outside after transaction began are not seen by it
+	require.NoError(t, db.AddRow("users", "10", indexedRow{Email: "e@mail.ru"}))
+	require.NoError(t, db.AlterRow("users", "2", indexedRow{Email: "f@mail.ru"}))
+
+	rows, err := tx.GetAllRows("users", 0, 100)
+	require.NoError(t, err)
+	assert.Equal(t, []any{
+		indexedRow{Email: "b@mail.ru", Group: "admins"},
+		indexedRow{Email: "c@mail.ru", Group: "users"},
+		indexedRow{Email: "d@mail.ru", Group: "users"},
+	}, rows)
+
+	rows, err = tx.GetAllRows("users", 1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, []any{indexedRow{Email: "c@mail.ru", Group: "users"}}, rows)
+
+	count, err := tx.GetRowsCount("users")
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	counter, err := tx.GetTableCounter("users")
+	require.NoError(t, err)
+	assert.Equal(t, 4, counter)
+
+	_, err = tx.GetRowByIndex("users", "email", "a@mail.ru")
+	assert.ErrorIs(t, err, ErrNotExistedRow)
+
+	row, err := tx.GetRowByIndex("users", "email", "d@mail.ru")
+	require.NoError(t, err)
+	assert.Equal(t, indexedRow{Email: "d@mail.ru", Group: "users"}, row)
+
+	_, err = tx.GetRow("users", "10")
+	assert.ErrorIs(t, err, ErrNotExistedRow)
+
+	assert.ErrorIs(t, tx.AddRow("users", "4", indexedRow{Email: "c@mail.ru"}), ErrUniqueViolation)
+	assert.ErrorIs(t, tx.AddRow("users", "3", indexedRow{}), ErrExistingKey)
+	assert.ErrorIs(t, tx.AlterRow("users", "5", indexedRow{}), ErrNotExistedRow)
+	assert.ErrorIs(t, tx.AddRow("missing", "1", indexedRow{}), ErrNotExistedTable)
+
+	// the row changed by both is changed by others first
+	assert.ErrorIs(t, tx.Commit(), ErrTxConflict)
+
+	_, err = db.GetRow("users", "3")
+	assert.ErrorIs(t, err, ErrNotExistedRow)
+}
+
+func TestTx_SearchText(t *testing.T) {
+	db := initTxDB(t)
+
+	require.NoError(t, db.CreateTextIndex("messages", textOf))
+	require.NoError(t, db.AddRow("messages", "1", "hello world"))
+	require.NoError(t, db.AddRow("messages", "2", "hello there"))
+
+	tx := db.Begin()
+
+	require.NoError(t, tx.AlterRow("messages", "1", "bye world"))
+	require.NoError(t, tx.AddRow("messages", "3", "Hello again"))
+
+	// changes made outside after transaction began are not seen by it
+	require.NoError(t, db.AddRow("messages", "4", "hello from outside"))
+
+	got, err := tx.SearchText("messages", "hello")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"2", "3"}, got)
+
+	got, err = db.SearchText("messages", "hello")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1", "2", "4"}, got)
+
+	_, err = tx.SearchText("users", "hello")
+	assert.ErrorIs(t, err, ErrNotExistedIndex)
+
+	require.NoError(t, tx.Rollback())
+}
+
+func TestTx_Conflicts(t *testing.T) {
+	db := initTxDB(t)
+
+	first, second := db.Begin(), db.Begin()
+
+	require.NoError(t, first.AlterRow("users", "1", indexedRow{Email: "first@mail.ru"}))
+	require.NoError(t, second.AlterRow("users", "1", indexedRow{Email: "second@mail.ru"}))
+	require.NoError(t, second.AddRow("messages", "1", "second"))
+
+	require.NoError(t, first.Commit())
+	assert.ErrorIs(t, second.Commit(), ErrTxConflict)
+
+	row, err := db.GetRow("users", "1")
+	require.NoError(t, err)
+	assert.Equal(t, indexedRow{Email: "first@mail.ru"}, row)
+	assert.Empty(t, getTestRows(t, db, "messages"), "failed transaction must not be applied in part")
+
+	// different rows with the same unique key
+	first, second = db.Begin(), db.Begin()
+
+	require.NoError(t, first.AddRow("users", "3", indexedRow{Email: "c@mail.ru"}))
+	require.NoError(t, second.AddRow("users", "4", indexedRow{Email: "c@mail.ru"}))
+
+	require.NoError(t, first.Commit())
+	assert.ErrorIs(t, second.Commit(), ErrUniqueViolation)
+
+	// different rows of the same table
+	first, second = db.Begin(), db.Begin()
+
+	require.NoError(t, first.AlterRow("users", "1", indexedRow{Email: "a@mail.ru"}))
+	require.NoError(t, second.AlterRow("users", "2", indexedRow{Email: "b2@mail.ru"}))
+
+	require.NoError(t, first.Commit())
+	require.NoError(t, second.Commit())
+
+	assert.Equal(t, []any{
+		indexedRow{Email: "a@mail.ru"},
+		indexedRow{Email: "b2@mail.ru"},
+		indexedRow{Email: "c@mail.ru"},
+	}, getTestRows(t, db, "users"))
+}
+
+func TestTx_RecreatedTableConflicts(t *testing.T) {
+	db := initTxDB(t)
+
+	tx := db.Begin()
+
+	require.NoError(t, tx.AddRow("messages", "1", "hello"))
+
+	db.CreateTable("messages")
+
+	assert.ErrorIs(t, tx.Commit(), ErrTxConflict)
+	assert.Empty(t, getTestRows(t, db, "messages"))
+}
+
+func TestTx_ExecutorFromContext(t *testing.T) {
+	db := initTxDB(t)
+	ctx := context.Background()
+
+	assert.Same(t, db, ExecutorFromContext(ctx, db))
+
+	tx := db.Begin()
+	defer func() { _ = tx.Rollback() }()
+
+	got, ok := TxFromContext(ContextWithTx(ctx, tx))
+	require.True(t, ok)
+	assert.Same(t, tx, got)
+	assert.Same(t, tx, ExecutorFromContext(ContextWithTx(ctx, tx), db))
+}
+
+func TestTx_CommitIsLoggedAsOneRecord(t *testing.T) {
+	opts := persistenceOpts(t.TempDir())
+
+	db := openTestDB(t, opts)
+	db.CreateTable("rows")
+	require.NoError(t, db.AddRow("rows", "1", snapshotTestRow{ID: 1, Name: "first"}))
+
+	tx := db.Begin()
+	require.NoError(t, tx.AlterRow("rows", "1", snapshotTestRow{ID: 1, Name: "edited"}))
+	require.NoError(t, tx.AddRow("rows", "2", snapshotTestRow{ID: 2, Name: "second"}))
+	require.NoError(t, tx.Commit())
+
+	records, _, err := readWAL(opts.WALPath)
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, walOpTx, records[2].Op)
+	assert.Len(t, records[2].Ops, 2)
+
+	// db is not closed, as it would not be on kill -9
+	restored := openTestDB(t, opts)
+
+	assert.Equal(t, []any{
+		snapshotTestRow{ID: 1, Name: "edited"},
+		snapshotTestRow{ID: 2, Name: "second"},
+	}, getTestRows(t, restored, "rows"))
+}
@@ -0,0 +1,322 @@
+package in_memory
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// KeyCodec tells how keys of typed table are stored in db and compared.
+type KeyCodec[K comparable] struct {
+	Encode  func(key K) string
+	Compare func(a, b K) int
+	// Next returns key following table counter, it is nil for keys that are not generated by table
+	Next func(counter int) K
+}
+
+// IntKeys are auto-incremented integer keys, the first row gets key 1.
+var IntKeys = KeyCodec[int]{
+	Encode:  strconv.Itoa,
+	Compare: cmp.Compare[int],
+	Next:    func(counter int) int { return counter + 1 },
+}
+
+// StringKeys are keys built by repository, like composite keys of rows without own id.
+var StringKeys = KeyCodec[string]{
+	Encode:  func(key string) string { return key },
+	Compare: strings.Compare,
+}
+
+// TableSchema describes rows of typed table. SetKey is only needed for tables with generated keys.
+type TableSchema[K comparable, V any] struct {
+	Name   string
+	Keys   KeyCodec[K]
+	KeyOf  func(row V) K
+	SetKey func(row *V, key K)
+}
+
+// TypedIndex is secondary index over rows of typed table, see IndexSpec.
+type TypedIndex[V any] struct {
+	Name    string
+	Unique  bool
+	Extract func(row V) (key string, ok bool)
+}
+
+// TypedTable is table of db holding rows of one type. Rows of other type found in table are reported
+// with ErrUnexpectedRowType instead of being skipped. All methods read and write through transaction
+// of the context if there is one.
+type TypedTable[K comparable, V any] struct {
+	db     InMemoryDB
+	schema TableSchema[K, V]
+}
+
+// NewTypedTable returns typed table of db, table is created if it does not exist yet.
+func NewTypedTable[K comparable, V any](db InMemoryDB, schema TableSchema[K, V]) *TypedTable[K, V] {
+	if _, err := db.GetTable(schema.Name); errors.Is(err, ErrNotExistedTable) {
+		db.CreateTable(schema.Name)
+	}
+
+	return &TypedTable[K, V]{
+		db:     db,
+		schema: schema,
+	}
+}
+
+func (t *TypedTable[K, V]) Name() string {
+	return t.schema.Name
+}
+
+func (t *TypedTable[K, V]) exec(ctx context.Context) Executor {
+	return ExecutorFromContext(ctx, t.db)
+}
+
+func (t *TypedTable[K, V]) cast(row any) (V, error) {
+	value, ok := row.(V)
+	if !ok {
+		return value, fmt.Errorf("%w: table %q holds %T instead of %T", ErrUnexpectedRowType, t.schema.Name, row, value)
+	}
+
+	return value, nil
+}
+
+func (t *TypedTable[K, V]) castAll(rows []any) ([]V, error) {
+	res := make([]V, 0, len(rows))
+
+	for _, row := range rows {
+		value, err := t.cast(row)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, value)
+	}
+
+	return res, nil
+}
+
+// CreateIndex builds secondary index over rows of the table, see InMemDB.CreateIndex.
+func (t *TypedTable[K, V]) CreateIndex(index TypedIndex[V]) error {
+	return t.db.CreateIndex(t.schema.Name, IndexSpec{
+		Name:   index.Name,
+		Unique: index.Unique,
+		Extract: func(row any) (string, bool) {
+			value, ok := row.(V)
+			if !ok {
+				return "", false
+			}
+
+			return index.Extract(value)
+		},
+	})
+}
+
+func (t *TypedTable[K, V]) Get(ctx context.Context, key K) (V, error) {
+	row, err := t.exec(ctx).GetRow(t.schema.Name, t.schema.Keys.Encode(key))
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+
+	return t.cast(row)
+}
+
+// Add saves row under the next key of table and returns row with the key set.
+func (t *TypedTable[K, V]) Add(ctx context.Context, row V) (V, error) {
+	if t.schema.Keys.Next == nil || t.schema.SetKey == nil {
+		return row, fmt.Errorf("%w: table %q", ErrNoAutoIncrement, t.schema.Name)
+	}
+
+	counter, err := t.exec(ctx).GetTableCounter(t.schema.Name)
+	if err != nil {
+		return row, err
+	}
+
+	t.schema.SetKey(&row, t.schema.Keys.Next(counter))
+
+	if err = t.Insert(ctx, row); err != nil {
+		return row, err
+	}
+
+	return row, nil
+}
+
+// Insert saves row under its own key, ErrExistingKey is returned if key is taken.
+func (t *TypedTable[K, V]) Insert(ctx context.Context, row V) error {
+	return t.exec(ctx).AddRow(t.schema.Name, t.schema.Keys.Encode(t.schema.KeyOf(row)), row)
+}
+
+// Update replaces row having the same key, ErrNotExistedRow is returned if there is none.
+func (t *TypedTable[K, V]) Update(ctx context.Context, row V) error {
+	return t.exec(ctx).AlterRow(t.schema.Name, t.schema.Keys.Encode(t.schema.KeyOf(row)), row)
+}
+
+// Save replaces row having the same key or inserts it if there is none.
+func (t *TypedTable[K, V]) Save(ctx context.Context, row V) error {
+	err := t.Update(ctx, row)
+	if errors.Is(err, ErrNotExistedRow) {
+		return t.Insert(ctx, row)
+	}
+
+	return err
+}
+
+func (t *TypedTable[K, V]) Delete(ctx context.Context, key K) error {
+	return t.exec(ctx).DropRow(t.schema.Name, t.schema.Keys.Encode(key))
+}
+
+// Rows returns page of rows in order they were added.
+func (t *TypedTable[K, V]) Rows(ctx context.Context, offset, limit int) ([]V, error) {
+	rows, err := t.exec(ctx).GetAllRows(t.schema.Name, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.castAll(rows)
+}
+
+// Each calls fn for rows in order they were added until fn returns false. Rows are read one by one
+// while table is locked, thus fn must not use the table.
+func (t *TypedTable[K, V]) Each(ctx context.Context, fn func(row V) bool) error {
+	var castErr error
+
+	err := t.exec(ctx).EachRow(t.schema.Name, func(row any) bool {
+		value, err := t.cast(row)
+		if err != nil {
+			castErr = err
+			return false
+		}
+
+		return fn(value)
+	})
+	if err != nil {
+		return err
+	}
+
+	return castErr
+}
+
+// Filter returns rows matching the condition in order they were added.
+func (t *TypedTable[K, V]) Filter(ctx context.Context, match func(row V) bool) ([]V, error) {
+	res := make([]V, 0)
+
+	err := t.Each(ctx, func(row V) bool {
+		if match(row) {
+			res = append(res, row)
+		}
+
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Range returns rows with keys from `from` to `to` inclusive ordered by key.
+func (t *TypedTable[K, V]) Range(ctx context.Context, from, to K) ([]V, error) {
+	rows, err := t.Filter(ctx, func(row V) bool {
+		key := t.schema.KeyOf(row)
+		return t.schema.Keys.Compare(key, from) >= 0 && t.schema.Keys.Compare(key, to) <= 0
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(rows, func(a, b V) int {
+		return t.schema.Keys.Compare(t.schema.KeyOf(a), t.schema.KeyOf(b))
+	})
+
+	return rows, nil
+}
+
+// FindBy returns the row having the key in the index.
+func (t *TypedTable[K, V]) FindBy(ctx context.Context, index string, key string) (V, error) {
+	row, err := t.exec(ctx).GetRowByIndex(t.schema.Name, index, key)
+	if err != nil {
+		var zero V
+		return zero, err
+	}
+
+	return t.cast(row)
+}
+
+// FindAllBy returns rows having the key in the index.
+func (t *TypedTable[K, V]) FindAllBy(ctx context.Context, index string, key string) ([]V, error) {
+	rows, err := t.exec(ctx).GetRowsByIndex(t.schema.Name, index, key)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.castAll(rows)
+}
+
+// Search returns rows matching query of text index of the table, see InMemDB.SearchText and Tx.SearchText.
+func (t *TypedTable[K, V]) Search(ctx context.Context, query string) ([]V, error) {
+	exec := t.exec(ctx)
+
+	identifiers, err := exec.SearchText(t.schema.Name, query)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]V, 0, len(identifiers))
+
+	for _, identifier := range identifiers {
+		row, err := exec.GetRow(t.schema.Name, identifier)
+		if errors.Is(err, ErrNotExistedRow) {
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := t.cast(row)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, value)
+	}
+
+	return res, nil
+}
+
+func (t *TypedTable[K, V]) Count(ctx context.Context) (int, error) {
+	return t.exec(ctx).GetRowsCount(t.schema.Name)
+}
+
+// UpdateAll applies change to every row and saves rows it reports as changed. Row whose key is changed
+// is moved under the new key.
+func (t *TypedTable[K, V]) UpdateAll(ctx context.Context, change func(row *V) bool) error {
+	rows, err := t.Rows(ctx, 0, math.MaxInt64)
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows {
+		oldKey := t.schema.KeyOf(row)
+
+		if !change(&row) {
+			continue
+		}
+
+		if newKey := t.schema.KeyOf(row); newKey == oldKey {
+			err = t.Update(ctx, row)
+		} else if err = t.Delete(ctx, oldKey); err == nil {
+			err = t.Insert(ctx, row)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+package in_memory
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type typedTestRow struct {
+	ID    int
+	Name  string
+	Group string
+}
+
+type typedTestMarker struct {
+	User  string
+	Value int
+}
+
+func initTypedTable(t *testing.T, db *InMemDB) *TypedTable[int, typedTestRow] {
+	t.Helper()
+
+	table := NewTypedTable(db, TableSchema[int, typedTestRow]{
+		Name:   "rows",
+		Keys:   IntKeys,
+		KeyOf:  func(row typedTestRow) int { return row.ID },
+		SetKey: func(row *typedTestRow, id int) { row.ID = id },
+	})
+
+	require.NoError(t, table.CreateIndex(TypedIndex[typedTestRow]{
+		Name:    "name",
+		Unique:  true,
+		Extract: func(row typedTestRow) (string, bool) { return row.Name, row.Name != "" },
+	}))
+	require.NoError(t, table.CreateIndex(TypedIndex[typedTestRow]{
+		Name:    "group",
+		Extract: func(row typedTestRow) (string, bool) { return row.Group, row.Group != "" },
+	}))
+
+	return table
+}
+
+func TestTypedTable_AddGetUpdateDelete(t *testing.T) {
+	ctx := context.Background()
+	table := initTypedTable(t, initDB())
+
+	first, err := table.Add(ctx, typedTestRow{Name: "first", Group: "a"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, first.ID)
+
+	second, err := table.Add(ctx, typedTestRow{Name: "second", Group: "a"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, second.ID)
+
+	_, err = table.Add(ctx, typedTestRow{Name: "first"})
+	assert.ErrorIs(t, err, ErrUniqueViolation)
+
+	got, err := table.Get(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, second, got)
+
+	second.Group = "b"
+	require.NoError(t, table.Update(ctx, second))
+
+	got, err = table.FindBy(ctx, "name", "second")
+	require.NoError(t, err)
+	assert.Equal(t, second, got)
+
+	rows, err := table.FindAllBy(ctx, "group", "a")
+	require.NoError(t, err)
+	assert.Equal(t, []typedTestRow{first}, rows)
+
+	assert.ErrorIs(t, table.Update(ctx, typedTestRow{ID: 10}), ErrNotExistedRow)
+	assert.ErrorIs(t, table.Insert(ctx, typedTestRow{ID: 1}), ErrExistingKey)
+
+	require.NoError(t, table.Save(ctx, typedTestRow{ID: 10, Name: "saved"}))
+	require.NoError(t, table.Save(ctx, typedTestRow{ID: 10, Name: "resaved"}))
+
+	require.NoError(t, table.Delete(ctx, 1))
+
+	_, err = table.Get(ctx, 1)
+	assert.ErrorIs(t, err, ErrNotExistedRow)
+
+	count, err := table.Count(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func TestTypedTable_Queries(t *testing.T) {
+	ctx := context.Background()
+	table := initTypedTable(t, initDB())
+
+	for _, name := range []string{"a", "b", "c", "d"} {
+		_, err := table.Add(ctx, typedTestRow{Name: name, Group: strings.Repeat("x", len(name))})
+		require.NoError(t, err)
+	}
+
+	rows, err := table.Rows(ctx, 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, []typedTestRow{{ID: 2, Name: "b", Group: "x"}, {ID: 3, Name: "c", Group: "x"}}, rows)
+
+	rows, err = table.Range(ctx, 2, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []typedTestRow{{ID: 2, Name: "b", Group: "x"}, {ID: 3, Name: "c", Group: "x"}}, rows)
+
+	rows, err = table.Filter(ctx, func(row typedTestRow) bool { return row.Name > "b" })
+	require.NoError(t, err)
+	assert.Equal(t, []typedTestRow{{ID: 3, Name: "c", Group: "x"}, {ID: 4, Name: "d", Group: "x"}}, rows)
+
+	names := make([]string, 0)
+
+	require.NoError(t, table.Each(ctx, func(row typedTestRow) bool {
+		names = append(names, row.Name)
+		return row.Name != "b"
+	}))
+	assert.Equal(t, []string{"a", "b"}, names)
+}
+
+func TestTypedTable_UnexpectedRowType(t *testing.T) {
+	ctx := context.Background()
+	db := initDB()
+	table := initTypedTable(t, db)
+
+	_, err := table.Add(ctx, typedTestRow{Name: "first"})
+	require.NoError(t, err)
+
+	// row written by untyped api is reported instead of being skipped
+	require.NoError(t, db.AddRow("rows", "2", "not a row"))
+
+	_, err = table.Get(ctx, 2)
+	assert.ErrorIs(t, err, ErrUnexpectedRowType)
+
+	_, err = table.Rows(ctx, 0, 10)
+	assert.ErrorIs(t, err, ErrUnexpectedRowType)
+
+	_, err = table.Filter(ctx, func(typedTestRow) bool { return true })
+	assert.ErrorIs(t, err, ErrUnexpectedRowType)
+}
+
+func TestTypedTable_StringKeys(t *testing.T) {
+	ctx := context.Background()
+
+	markers := NewTypedTable(initDB(), TableSchema[string, typedTestMarker]{
+		Name:  "markers",
+		Keys:  StringKeys,
+		KeyOf: func(marker typedTestMarker) string { return marker.User },
+	})
+
+	_, err := markers.Add(ctx, typedTestMarker{User: "ann"})
+	assert.ErrorIs(t, err, ErrNoAutoIncrement)
+
+	require.NoError(t, markers.Save(ctx, typedTestMarker{User: "ann", Value: 1}))
+	require.NoError(t, markers.Save(ctx, typedTestMarker{User: "bob", Value: 2}))
+	require.NoError(t, markers.Save(ctx, typedTestMarker{User: "ann", Value: 3}))
+
+	// rows whose key is changed are moved under the new key
+	require.NoError(t, markers.UpdateAll(ctx, func(marker *typedTestMarker) bool {
+		if marker.User != "ann" {
+			return false
+		}
+
+		marker.User = "anna"
+
+		return true
+	}))
+
+	_, err = markers.Get(ctx, "ann")
+	assert.ErrorIs(t, err, ErrNotExistedRow)
+
+	got, err := markers.Get(ctx, "anna")
+	require.NoError(t, err)
+	assert.Equal(t, typedTestMarker{User: "anna", Value: 3}, got)
+
+	rows, err := markers.Range(ctx, "b", "c")
+	require.NoError(t, err)
+	assert.Equal(t, []typedTestMarker{{User: "bob", Value: 2}}, rows)
+
+	// "anna" is added after "bob" but goes first by key
+	rows, err = markers.Range(ctx, "a", "z")
+	require.NoError(t, err)
+	assert.Equal(t, []typedTestMarker{{User: "anna", Value: 3}, {User: "bob", Value: 2}}, rows)
+}
+
+func TestTypedTable_Tx(t *testing.T) {
+	db := initDB()
+	table := initTypedTable(t, db)
+
+	tx := db.Begin()
+	ctx := ContextWithTx(context.Background(), tx)
+
+	added, err := table.Add(ctx, typedTestRow{Name: "first"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, added.ID)
+
+	_, err = table.Get(context.Background(), 1)
+	assert.ErrorIs(t, err, ErrNotExistedRow, "row is not seen outside before commit")
+
+	require.NoError(t, tx.Commit())
+
+	got, err := table.Get(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, added, got)
+}
@@ -0,0 +1,93 @@
+package in_memory
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// TypeRegistry maps names of row types to go types, so rows restored from snapshot get their type back.
+type TypeRegistry struct {
+	byName map[string]reflect.Type
+	names  map[reflect.Type]string
+
+	m sync.RWMutex
+}
+
+func NewTypeRegistry() *TypeRegistry {
+	return &TypeRegistry{
+		byName: make(map[string]reflect.Type),
+		names:  make(map[reflect.Type]string),
+	}
+}
+
+// Types is registry used for snapshots of InMemDB.
+var Types = NewTypeRegistry()
+
+// RegisterType registers row type T under name in Types. It panics if name or type is already registered
+// differently, as it is meant to be called on package initialization.
+func RegisterType[T any](name string) {
+	var sample T
+
+	if err := Types.Register(name, sample); err != nil {
+		panic(err)
+	}
+}
+
+// Register binds name to type of sample. Name is stored in snapshots, thus it must not change once data is saved.
+func (r *TypeRegistry) Register(name string, sample any) error {
+	r.m.Lock()
+	defer r.m.Unlock()
+
+	typ := reflect.TypeOf(sample)
+	if typ == nil {
+		return fmt.Errorf("%w: nil sample for %q", ErrUnknownRowType, name)
+	}
+
+	registered, nameTaken := r.byName[name]
+	registeredName, typeTaken := r.names[typ]
+
+	if nameTaken && typeTaken && registered == typ && registeredName == name {
+		return nil
+	}
+
+	if nameTaken || typeTaken {
+		return fmt.Errorf("%w: %q as %v", ErrTypeRegistered, name, typ)
+	}
+
+	r.byName[name] = typ
+	r.names[typ] = name
+
+	return nil
+}
+
+func (r *TypeRegistry) nameOf(row any) (string, error) {
+	r.m.RLock()
+	defer r.m.RUnlock()
+
+	name, ok := r.names[reflect.TypeOf(row)]
+	if !ok {
+		return "", fmt.Errorf("%w: %T", ErrUnknownRowType, row)
+	}
+
+	return name, nil
+}
+
+func (r *TypeRegistry) decode(name string, raw json.RawMessage) (any, error) {
+	r.m.RLock()
+	typ, ok := r.byName[name]
+	r.m.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownRowType, name)
+	}
+
+	value := reflect.New(typ)
+
+	if err := json.Unmarshal(raw, value.Interface()); err != nil {
+		return nil, err
+	}
+
+	return value.Elem().Interface(), nil
+}
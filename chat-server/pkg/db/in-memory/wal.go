@@ -0,0 +1,212 @@
+package in_memory
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+)
+
+// FsyncPolicy tells when write-ahead log is flushed to disk.
+type FsyncPolicy string
+
+const (
+	// FsyncAlways flushes log on every write, no acknowledged write is lost on crash.
+	FsyncAlways FsyncPolicy = "always"
+	// FsyncInterval flushes log periodically, writes of the last interval may be lost on power failure.
+	FsyncInterval FsyncPolicy = "interval"
+	// FsyncNever leaves flushing to operating system.
+	FsyncNever FsyncPolicy = "never"
+)
+
+func (p FsyncPolicy) Valid() bool {
+	return p == FsyncAlways || p == FsyncInterval || p == FsyncNever
+}
+
+type walOp string
+
+const (
+	walOpCreateTable walOp = "create_table"
+	walOpDropTable   walOp = "drop_table"
+	walOpClear       walOp = "clear"
+	walOpAddRow      walOp = "add_row"
+	walOpAlterRow    walOp = "alter_row"
+	walOpDropRow     walOp = "drop_row"
+	// walOpTx holds all changes of committed transaction, one record is written or lost as a whole
+	walOpTx walOp = "tx"
+)
+
+// walRecord is one change of db, log is stored as one json record per line.
+type walRecord struct {
+	Seq     int64           `json:"seq,omitempty"`
+	Op      walOp           `json:"op"`
+	Table   string          `json:"table,omitempty"`
+	Key     string          `json:"key,omitempty"`
+	RowType string          `json:"row_type,omitempty"`
+	Row     json.RawMessage `json:"row,omitempty"`
+	Ops     []walRecord     `json:"ops,omitempty"`
+}
+
+// wal is append-only log of changes made since the last snapshot.
+type wal struct {
+	file   *os.File
+	policy FsyncPolicy
+	seq    int64
+	// err is kept after failed write, as log with lost record can not be replayed correctly
+	err error
+
+	m sync.Mutex
+}
+
+// openWAL opens log for appending. Unfinished last record, left by crash in the middle of write, is cut off.
+func openWAL(path string, policy FsyncPolicy, validSize int64, seq int64) (*wal, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, writePerm)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = file.Truncate(validSize); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	if _, err = file.Seek(validSize, io.SeekStart); err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &wal{
+		file:   file,
+		policy: policy,
+		seq:    seq,
+	}, nil
+}
+
+// readWAL returns records of log and size of its part that holds complete records.
+func readWAL(path string) ([]walRecord, int64, error) {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, 0, nil
+	}
+
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	records := make([]walRecord, 0)
+
+	var validSize int64
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// record without line end was not written completely
+			return records, validSize, nil
+		}
+
+		if err != nil {
+			return nil, 0, err
+		}
+
+		if len(bytes.TrimSpace(line)) > 0 {
+			var record walRecord
+
+			if err = json.Unmarshal(line, &record); err != nil {
+				return nil, 0, fmt.Errorf("%w: record at offset %d: %v", ErrCorruptedWAL, validSize, err)
+			}
+
+			records = append(records, record)
+		}
+
+		validSize += int64(len(line))
+	}
+}
+
+func (w *wal) append(record walRecord) error {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	if w.err != nil {
+		return w.err
+	}
+
+	record.Seq = w.seq + 1
+
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	if _, err = w.file.Write(append(line, '\n')); err != nil {
+		w.err = fmt.Errorf("%w: %v", ErrWALFailed, err)
+		return w.err
+	}
+
+	if w.policy == FsyncAlways {
+		if err = w.file.Sync(); err != nil {
+			w.err = fmt.Errorf("%w: %v", ErrWALFailed, err)
+			return w.err
+		}
+	}
+
+	w.seq = record.Seq
+
+	return nil
+}
+
+func (w *wal) sync() error {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	if w.err != nil {
+		return w.err
+	}
+
+	return w.file.Sync()
+}
+
+// truncate empties log after its records were saved in snapshot.
+func (w *wal) truncate() error {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	if w.err != nil {
+		return w.err
+	}
+
+	if err := w.file.Truncate(0); err != nil {
+		return err
+	}
+
+	if _, err := w.file.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	return w.file.Sync()
+}
+
+func (w *wal) lastSeq() int64 {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	return w.seq
+}
+
+func (w *wal) close() error {
+	w.m.Lock()
+	defer w.m.Unlock()
+
+	if err := w.file.Sync(); err != nil {
+		w.file.Close()
+		return err
+	}
+
+	return w.file.Close()
+}
@@ -1,9 +1,14 @@
 package http
 
 import (
+	"bufio"
+	"errors"
+	"net"
 	"net/http"
 )
 
+var ErrHijackNotSupported = errors.New("underlying response writer does not support hijacking")
+
 type ResponseWriterWrapper interface {
 	http.ResponseWriter
 
@@ -68,3 +73,16 @@ func (b *BasicResponseWrapper) Write(buf []byte) (int, error) {
 
 	return n, err
 }
+
+// Hijack lets wrapped writer be used for protocol upgrades (e.g. websocket).
+func (b *BasicResponseWrapper) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := b.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, ErrHijackNotSupported
+	}
+
+	b.wroteHeader = true
+	b.statusCode = http.StatusSwitchingProtocols
+
+	return hijacker.Hijack()
+}
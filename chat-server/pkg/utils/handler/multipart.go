@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+)
+
+// NextFilePart skips form fields until the part of file field, so file is streamed without buffering.
+func NextFilePart(reader *multipart.Reader, field string) (*multipart.Part, error) {
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%w in '%s' form field", ErrNoFileProvided, field)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if part.FormName() == field {
+			return part, nil
+		}
+
+		_ = part.Close()
+	}
+}
@@ -1,9 +1,12 @@
 package handler
 
 import (
+	"net"
 	"net/http"
+	"net/url"
 	"strconv"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/sirupsen/logrus"
 )
 
@@ -58,3 +61,31 @@ func GetStringHeaderByKey(req *http.Request, key string) (string, error) {
 
 	return str, nil
 }
+
+func GetIntParamFromURL(req *http.Request, key string) (int, error) {
+	return strconv.Atoi(chi.URLParam(req, key))
+}
+
+// GetStringParamFromURL returns unescaped URL param, so it can carry characters like emoji.
+func GetStringParamFromURL(req *http.Request, key string) (string, error) {
+	str, err := url.PathUnescape(chi.URLParam(req, key))
+	if err != nil {
+		return "", err
+	}
+
+	if str == "" {
+		return str, ErrNoURLParamProvided
+	}
+
+	return str, nil
+}
+
+// GetClientIP returns IP of client connection, forwarding headers are not trusted as they are set by client.
+func GetClientIP(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+
+	return host
+}
@@ -13,11 +13,12 @@ func CreateJWT(payload jwt.MapClaims, method jwt.SigningMethod, secret string) (
 	return jwt.NewWithClaims(method, payload).SignedString([]byte(secret))
 }
 
+// ValidateToken checks signature and expiration of token, tokens without expiration are rejected.
 func ValidateToken(tokenString, secret string) (map[string]any, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) { // todo: see doc of parse
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
 		return []byte(secret), nil
-	})
-	if err != nil { // todo: jwt.WithValidMethods()
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithExpirationRequired())
+	if err != nil {
 		return nil, err
 	}
 
@@ -1,6 +1,9 @@
 package slice
 
-import "math"
+import (
+	"math"
+	"reflect"
+)
 
 func Filter[T any](ss []T, test func(T) bool) []T {
 	res := make([]T, 0, len(ss))
@@ -73,13 +76,13 @@ func ContainsValue[T comparable](ss []*T, v T) bool {
 	return false
 }
 
-func PointerAndValueSlicesEquals[T comparable](p []*T, v []T) bool {
+func PointerAndValueSlicesEquals[T any](p []*T, v []T) bool {
 	if len(p) != len(v) {
 		return false
 	}
 
 	for i := range p {
-		if *p[i] != v[i] {
+		if !reflect.DeepEqual(*p[i], v[i]) {
 			return false
 		}
 	}
@@ -0,0 +1,116 @@
+package ws
+
+import (
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+type Client struct {
+	Key string
+	// Session is set for clients opened within session, see Hub.CloseSession
+	Session string
+
+	hub  *Hub
+	conn *websocket.Conn
+	send chan []byte
+
+	closed bool
+	m      *sync.Mutex
+}
+
+func NewClient(hub *Hub, conn *websocket.Conn, key, session string) *Client {
+	return &Client{
+		Key:     key,
+		Session: session,
+		hub:     hub,
+		conn:    conn,
+		send:    make(chan []byte, sendBufferSize),
+		m:       &sync.Mutex{},
+	}
+}
+
+// Send queues message for writing. Slow clients whose buffer is full are dropped.
+func (c *Client) Send(msg []byte) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	if c.closed {
+		return
+	}
+
+	select {
+	case c.send <- msg:
+	default:
+		go c.hub.Unregister(c)
+	}
+}
+
+func (c *Client) close() {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	if !c.closed {
+		c.closed = true
+		close(c.send)
+	}
+}
+
+// ReadPump reads messages from connection until it is closed and passes them to onMessage.
+// Blocks, so it should be the last call of http handler serving the connection.
+func (c *Client) ReadPump(onMessage func(msg []byte)) {
+	defer func() {
+		c.hub.Unregister(c)
+		_ = c.conn.Close()
+	}()
+
+	c.conn.SetReadLimit(maxMessageSize)
+	_ = c.conn.SetReadDeadline(time.Now().Add(pongWait))
+
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		_, msg, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		onMessage(msg)
+	}
+}
+
+// WritePump writes queued messages to connection and keeps it alive with pings.
+func (c *Client) WritePump() {
+	ticker := time.NewTicker(pingPeriod)
+
+	defer func() {
+		ticker.Stop()
+		_ = c.conn.Close()
+	}()
+
+	for {
+		select {
+		case msg, ok := <-c.send:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+
+			if !ok {
+				_ = c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+
+			if err := c.conn.WriteMessage(websocket.TextMessage, msg); err != nil {
+				return
+			}
+
+		case <-ticker.C:
+			_ = c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
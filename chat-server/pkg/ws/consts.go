@@ -0,0 +1,11 @@
+package ws
+
+import "time"
+
+const (
+	writeWait      = 10 * time.Second
+	pongWait       = 60 * time.Second
+	pingPeriod     = (pongWait * 9) / 10
+	maxMessageSize = 8192
+	sendBufferSize = 256
+)
@@ -0,0 +1,124 @@
+package ws
+
+import "sync"
+
+type group = map[*Client]struct{}
+
+// Hub keeps track of live clients grouped by key (e.g. username)
+// and fans messages out to them. Clients opened within session are also
+// grouped by it, so they can be closed once session is over.
+type Hub struct {
+	clients  map[string]group
+	sessions map[string]group
+
+	m *sync.RWMutex
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		clients:  make(map[string]group),
+		sessions: make(map[string]group),
+		m:        &sync.RWMutex{},
+	}
+}
+
+func addToGroup(groups map[string]group, key string, client *Client) {
+	g, ok := groups[key]
+	if !ok {
+		g = make(group)
+		groups[key] = g
+	}
+
+	g[client] = struct{}{}
+}
+
+func removeFromGroup(groups map[string]group, key string, client *Client) {
+	delete(groups[key], client)
+
+	if len(groups[key]) == 0 {
+		delete(groups, key)
+	}
+}
+
+func (h *Hub) Register(client *Client) {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	addToGroup(h.clients, client.Key, client)
+
+	if client.Session != "" {
+		addToGroup(h.sessions, client.Session, client)
+	}
+}
+
+func (h *Hub) unregisterNotLocking(client *Client) {
+	if _, ok := h.clients[client.Key][client]; !ok {
+		return
+	}
+
+	removeFromGroup(h.clients, client.Key, client)
+
+	if client.Session != "" {
+		removeFromGroup(h.sessions, client.Session, client)
+	}
+
+	client.close()
+}
+
+func (h *Hub) Unregister(client *Client) {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	h.unregisterNotLocking(client)
+}
+
+// Close closes every connection of provided key.
+func (h *Hub) Close(key string) {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	for client := range h.clients[key] {
+		h.unregisterNotLocking(client)
+	}
+}
+
+// CloseSession closes every connection opened within provided session.
+func (h *Hub) CloseSession(session string) {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	for client := range h.sessions[session] {
+		h.unregisterNotLocking(client)
+	}
+}
+
+// Broadcast sends message to every connected client.
+func (h *Hub) Broadcast(msg []byte) {
+	h.m.RLock()
+	defer h.m.RUnlock()
+
+	for _, group := range h.clients {
+		for client := range group {
+			client.Send(msg)
+		}
+	}
+}
+
+// SendTo sends message to every connection of provided keys.
+func (h *Hub) SendTo(msg []byte, keys ...string) {
+	h.m.RLock()
+	defer h.m.RUnlock()
+
+	for _, key := range keys {
+		for client := range h.clients[key] {
+			client.Send(msg)
+		}
+	}
+}
+
+func (h *Hub) ConnectionsCount(key string) int {
+	h.m.RLock()
+	defer h.m.RUnlock()
+
+	return len(h.clients[key])
+}
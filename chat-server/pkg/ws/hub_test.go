@@ -0,0 +1,122 @@
+package ws
+
+import (
+	"testing"
+)
+
+func receive(client *Client) ([]byte, bool) {
+	select {
+	case msg, ok := <-client.send:
+		return msg, ok
+	default:
+		return nil, false
+	}
+}
+
+func TestBroadcastReachesAllClients(t *testing.T) {
+	hub := NewHub()
+
+	client1 := NewClient(hub, nil, "user1", "")
+	client2 := NewClient(hub, nil, "user2", "")
+
+	hub.Register(client1)
+	hub.Register(client2)
+
+	hub.Broadcast([]byte("hello"))
+
+	for _, client := range []*Client{client1, client2} {
+		msg, ok := receive(client)
+		if !ok || string(msg) != "hello" {
+			t.Fatalf("client %v did not receive broadcast", client.Key)
+		}
+	}
+}
+
+func TestSendToReachesOnlyProvidedKeys(t *testing.T) {
+	hub := NewHub()
+
+	device1 := NewClient(hub, nil, "user1", "")
+	device2 := NewClient(hub, nil, "user1", "")
+	other := NewClient(hub, nil, "user2", "")
+
+	hub.Register(device1)
+	hub.Register(device2)
+	hub.Register(other)
+
+	hub.SendTo([]byte("hello"), "user1")
+
+	if _, ok := receive(device1); !ok {
+		t.Fatal("first device did not receive message")
+	}
+
+	if _, ok := receive(device2); !ok {
+		t.Fatal("second device did not receive message")
+	}
+
+	if _, ok := receive(other); ok {
+		t.Fatal("message delivered to wrong user")
+	}
+}
+
+func TestUnregisteredClientNotReceiving(t *testing.T) {
+	hub := NewHub()
+
+	client := NewClient(hub, nil, "user1", "")
+
+	hub.Register(client)
+	hub.Unregister(client)
+
+	if hub.ConnectionsCount("user1") != 0 {
+		t.Fatal("client not unregistered")
+	}
+
+	hub.Broadcast([]byte("hello"))
+
+	if msg, ok := receive(client); ok {
+		t.Fatalf("unregistered client received message: %s", msg)
+	}
+}
+
+func TestCloseSessionClosesOnlyItsClients(t *testing.T) {
+	hub := NewHub()
+
+	revoked := NewClient(hub, nil, "user1", "session1")
+	other := NewClient(hub, nil, "user1", "session2")
+
+	hub.Register(revoked)
+	hub.Register(other)
+
+	hub.CloseSession("session1")
+
+	if hub.ConnectionsCount("user1") != 1 {
+		t.Fatal("client of revoked session not unregistered")
+	}
+
+	if _, ok := <-revoked.send; ok {
+		t.Fatal("client of revoked session not closed")
+	}
+
+	hub.SendTo([]byte("hello"), "user1")
+
+	if _, ok := receive(other); !ok {
+		t.Fatal("client of other session did not receive message")
+	}
+}
+
+func TestCloseClosesAllClientsOfKey(t *testing.T) {
+	hub := NewHub()
+
+	withSession := NewClient(hub, nil, "user1", "session1")
+	withoutSession := NewClient(hub, nil, "user1", "")
+
+	hub.Register(withSession)
+	hub.Register(withoutSession)
+
+	hub.Close("user1")
+
+	if hub.ConnectionsCount("user1") != 0 {
+		t.Fatal("clients not unregistered")
+	}
+
+	hub.CloseSession("session1")
+}